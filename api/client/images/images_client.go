@@ -134,7 +134,7 @@ PushImage pushes an image to the server
 
 Push an image to the server
 */
-func (a *Client) PushImage(params *PushImageParams) (*PushImageOK, error) {
+func (a *Client) PushImage(params *PushImageParams) (*PushImageAccepted, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
 		params = NewPushImageParams()
@@ -155,7 +155,7 @@ func (a *Client) PushImage(params *PushImageParams) (*PushImageOK, error) {
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*PushImageOK)
+	success, ok := result.(*PushImageAccepted)
 	if ok {
 		return success, nil
 	}
@@ -24,8 +24,8 @@ type PushImageReader struct {
 // ReadResponse reads a server response into the received o.
 func (o *PushImageReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
 	switch response.Code() {
-	case 200:
-		result := NewPushImageOK()
+	case 202:
+		result := NewPushImageAccepted()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -42,30 +42,30 @@ func (o *PushImageReader) ReadResponse(response runtime.ClientResponse, consumer
 	}
 }
 
-// NewPushImageOK creates a PushImageOK with default headers values
-func NewPushImageOK() *PushImageOK {
-	return &PushImageOK{}
+// NewPushImageAccepted creates a PushImageAccepted with default headers values
+func NewPushImageAccepted() *PushImageAccepted {
+	return &PushImageAccepted{}
 }
 
-/*PushImageOK handles this case with default header values.
+/*PushImageAccepted handles this case with default header values.
 
-successful operation
+The image is being imported
 */
-type PushImageOK struct {
-	Payload *models.VM
+type PushImageAccepted struct {
+	Payload *models.Operation
 }
 
-func (o *PushImageOK) Error() string {
-	return fmt.Sprintf("[POST /images/push][%d] pushImageOK  %+v", 200, o.Payload)
+func (o *PushImageAccepted) Error() string {
+	return fmt.Sprintf("[POST /images/push][%d] pushImageAccepted  %+v", 202, o.Payload)
 }
 
-func (o *PushImageOK) GetPayload() *models.VM {
+func (o *PushImageAccepted) GetPayload() *models.Operation {
 	return o.Payload
 }
 
-func (o *PushImageOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *PushImageAccepted) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
-	o.Payload = new(models.VM)
+	o.Payload = new(models.Operation)
 
 	// response payload
 	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
@@ -14,6 +14,7 @@ import (
 	"github.com/768bit/promethium/api/client/images"
 	"github.com/768bit/promethium/api/client/networking"
 	"github.com/768bit/promethium/api/client/storage"
+	"github.com/768bit/promethium/api/client/system"
 	"github.com/768bit/promethium/api/client/vms"
 )
 
@@ -66,6 +67,8 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Promethium
 
 	cli.Storage = storage.New(transport, formats)
 
+	cli.System = system.New(transport, formats)
+
 	cli.Vms = vms.New(transport, formats)
 
 	return cli
@@ -118,6 +121,8 @@ type Promethium struct {
 
 	Storage *storage.Client
 
+	System *system.Client
+
 	Vms *vms.Client
 
 	Transport runtime.ClientTransport
@@ -133,6 +138,8 @@ func (c *Promethium) SetTransport(transport runtime.ClientTransport) {
 
 	c.Storage.SetTransport(transport)
 
+	c.System.SetTransport(transport)
+
 	c.Vms.SetTransport(transport)
 
 }
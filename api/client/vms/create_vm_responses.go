@@ -24,8 +24,8 @@ type CreateVMReader struct {
 // ReadResponse reads a server response into the received o.
 func (o *CreateVMReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
 	switch response.Code() {
-	case 200:
-		result := NewCreateVMOK()
+	case 202:
+		result := NewCreateVMAccepted()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -42,36 +42,42 @@ func (o *CreateVMReader) ReadResponse(response runtime.ClientResponse, consumer
 			return nil, err
 		}
 		return nil, result
-
 	default:
-		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+		result := NewCreateVMDefault(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
 	}
 }
 
-// NewCreateVMOK creates a CreateVMOK with default headers values
-func NewCreateVMOK() *CreateVMOK {
-	return &CreateVMOK{}
+// NewCreateVMAccepted creates a CreateVMAccepted with default headers values
+func NewCreateVMAccepted() *CreateVMAccepted {
+	return &CreateVMAccepted{}
 }
 
-/*CreateVMOK handles this case with default header values.
+/*CreateVMAccepted handles this case with default header values.
 
-successful operation
+The VM is being created - the result of the operation is the VM
 */
-type CreateVMOK struct {
-	Payload *models.VM
+type CreateVMAccepted struct {
+	Payload *models.Operation
 }
 
-func (o *CreateVMOK) Error() string {
-	return fmt.Sprintf("[POST /vms][%d] createVmOK  %+v", 200, o.Payload)
+func (o *CreateVMAccepted) Error() string {
+	return fmt.Sprintf("[POST /vms][%d] createVmAccepted  %+v", 202, o.Payload)
 }
 
-func (o *CreateVMOK) GetPayload() *models.VM {
+func (o *CreateVMAccepted) GetPayload() *models.Operation {
 	return o.Payload
 }
 
-func (o *CreateVMOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *CreateVMAccepted) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
-	o.Payload = new(models.VM)
+	o.Payload = new(models.Operation)
 
 	// response payload
 	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
@@ -122,3 +128,45 @@ func (o *CreateVMNotFound) readResponse(response runtime.ClientResponse, consume
 
 	return nil
 }
+
+// NewCreateVMDefault creates a CreateVMDefault with default headers values
+func NewCreateVMDefault(code int) *CreateVMDefault {
+	return &CreateVMDefault{
+		_statusCode: code,
+	}
+}
+
+/*CreateVMDefault handles this case with default header values.
+
+unexpected error
+*/
+type CreateVMDefault struct {
+	_statusCode int
+
+	Payload *models.Error
+}
+
+// Code gets the status code for the create VM default response
+func (o *CreateVMDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *CreateVMDefault) Error() string {
+	return fmt.Sprintf("[POST /vms][%d] createVM default  %+v", o._statusCode, o.Payload)
+}
+
+func (o *CreateVMDefault) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *CreateVMDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -62,7 +62,7 @@ for the delete VM operation typically these are written to a http.Request
 type DeleteVMParams struct {
 
 	/*VMID
-	  ID of VM to return
+	  ID of VM to delete
 
 	*/
 	VMID string
@@ -42,6 +42,12 @@ func (o *DeleteVMReader) ReadResponse(response runtime.ClientResponse, consumer
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewDeleteVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -58,26 +64,14 @@ func NewDeleteVMOK() *DeleteVMOK {
 successful operation
 */
 type DeleteVMOK struct {
-	Payload *models.Item
 }
 
 func (o *DeleteVMOK) Error() string {
-	return fmt.Sprintf("[DELETE /vms/{vmID}][%d] deleteVmOK  %+v", 200, o.Payload)
-}
-
-func (o *DeleteVMOK) GetPayload() *models.Item {
-	return o.Payload
+	return fmt.Sprintf("[DELETE /vms/{vmID}][%d] deleteVmOK ", 200)
 }
 
 func (o *DeleteVMOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
-	o.Payload = new(models.Item)
-
-	// response payload
-	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
-		return err
-	}
-
 	return nil
 }
 
@@ -88,7 +82,7 @@ func NewDeleteVMBadRequest() *DeleteVMBadRequest {
 
 /*DeleteVMBadRequest handles this case with default header values.
 
-Invalid ID supplied
+The VM is still running or couldnt be deleted
 */
 type DeleteVMBadRequest struct {
 }
@@ -122,3 +116,36 @@ func (o *DeleteVMNotFound) readResponse(response runtime.ClientResponse, consume
 
 	return nil
 }
+
+// NewDeleteVMConflict creates a DeleteVMConflict with default headers values
+func NewDeleteVMConflict() *DeleteVMConflict {
+	return &DeleteVMConflict{}
+}
+
+/*DeleteVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type DeleteVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *DeleteVMConflict) Error() string {
+	return fmt.Sprintf("[DELETE /vms/{vmID}][%d] deleteVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *DeleteVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DeleteVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
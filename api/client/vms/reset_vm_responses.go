@@ -42,6 +42,12 @@ func (o *ResetVMReader) ReadResponse(response runtime.ClientResponse, consumer r
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewResetVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -122,3 +128,36 @@ func (o *ResetVMNotFound) readResponse(response runtime.ClientResponse, consumer
 
 	return nil
 }
+
+// NewResetVMConflict creates a ResetVMConflict with default headers values
+func NewResetVMConflict() *ResetVMConflict {
+	return &ResetVMConflict{}
+}
+
+/*ResetVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type ResetVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *ResetVMConflict) Error() string {
+	return fmt.Sprintf("[GET /vms/{vmID}/reset][%d] resetVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *ResetVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ResetVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -42,6 +42,12 @@ func (o *RestartVMReader) ReadResponse(response runtime.ClientResponse, consumer
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewRestartVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -122,3 +128,36 @@ func (o *RestartVMNotFound) readResponse(response runtime.ClientResponse, consum
 
 	return nil
 }
+
+// NewRestartVMConflict creates a RestartVMConflict with default headers values
+func NewRestartVMConflict() *RestartVMConflict {
+	return &RestartVMConflict{}
+}
+
+/*RestartVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type RestartVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *RestartVMConflict) Error() string {
+	return fmt.Sprintf("[GET /vms/{vmID}/restart][%d] restartVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *RestartVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *RestartVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
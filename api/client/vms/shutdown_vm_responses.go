@@ -42,6 +42,12 @@ func (o *ShutdownVMReader) ReadResponse(response runtime.ClientResponse, consume
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewShutdownVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -122,3 +128,36 @@ func (o *ShutdownVMNotFound) readResponse(response runtime.ClientResponse, consu
 
 	return nil
 }
+
+// NewShutdownVMConflict creates a ShutdownVMConflict with default headers values
+func NewShutdownVMConflict() *ShutdownVMConflict {
+	return &ShutdownVMConflict{}
+}
+
+/*ShutdownVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type ShutdownVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *ShutdownVMConflict) Error() string {
+	return fmt.Sprintf("[GET /vms/{vmID}/shutdown][%d] shutdownVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *ShutdownVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ShutdownVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -42,6 +42,12 @@ func (o *StartVMReader) ReadResponse(response runtime.ClientResponse, consumer r
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewStartVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -122,3 +128,36 @@ func (o *StartVMNotFound) readResponse(response runtime.ClientResponse, consumer
 
 	return nil
 }
+
+// NewStartVMConflict creates a StartVMConflict with default headers values
+func NewStartVMConflict() *StartVMConflict {
+	return &StartVMConflict{}
+}
+
+/*StartVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type StartVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *StartVMConflict) Error() string {
+	return fmt.Sprintf("[GET /vms/{vmID}/start][%d] startVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *StartVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *StartVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -42,6 +42,12 @@ func (o *StopVMReader) ReadResponse(response runtime.ClientResponse, consumer ru
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewStopVMConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("unknown error", response, response.Code())
@@ -122,3 +128,36 @@ func (o *StopVMNotFound) readResponse(response runtime.ClientResponse, consumer
 
 	return nil
 }
+
+// NewStopVMConflict creates a StopVMConflict with default headers values
+func NewStopVMConflict() *StopVMConflict {
+	return &StopVMConflict{}
+}
+
+/*StopVMConflict handles this case with default header values.
+
+Another operation is in progress on the VM
+*/
+type StopVMConflict struct {
+	Payload *models.Error
+}
+
+func (o *StopVMConflict) Error() string {
+	return fmt.Sprintf("[GET /vms/{vmID}/stop][%d] stopVmConflict  %+v", 409, o.Payload)
+}
+
+func (o *StopVMConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *StopVMConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -31,7 +31,7 @@ CreateVM creates a VM instance
 
 Create an instance of VM
 */
-func (a *Client) CreateVM(params *CreateVMParams) (*CreateVMOK, error) {
+func (a *Client) CreateVM(params *CreateVMParams) (*CreateVMAccepted, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
 		params = NewCreateVMParams()
@@ -52,14 +52,13 @@ func (a *Client) CreateVM(params *CreateVMParams) (*CreateVMOK, error) {
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*CreateVMOK)
+	success, ok := result.(*CreateVMAccepted)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
-	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for createVM: API contract not enforced by server. Client expected to get an error, but got: %T", result)
-	panic(msg)
+	unexpectedSuccess := result.(*CreateVMDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
 /*
@@ -132,6 +131,41 @@ func (a *Client) CreateVMInterface(params *CreateVMInterfaceParams) (*CreateVMIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+CreateVMSnapshot snapshots a VM
+
+Snapshots the memory, device state and disks of a running VM
+*/
+func (a *Client) CreateVMSnapshot(params *CreateVMSnapshotParams) (*CreateVMSnapshotAccepted, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateVMSnapshotParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createVMSnapshot",
+		Method:             "POST",
+		PathPattern:        "/vms/{vmID}/snapshots",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CreateVMSnapshotReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateVMSnapshotAccepted)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateVMSnapshotDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 CreateVMVolume creates or attach a VM volume
 
@@ -167,10 +201,45 @@ func (a *Client) CreateVMVolume(params *CreateVMVolumeParams) (*CreateVMVolumeOK
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+CreateVMVsockProxy proxies a host port to a VM vsock port
+
+Listens on a TCP port on the host and forwards connections to a vsock port in the VM
+*/
+func (a *Client) CreateVMVsockProxy(params *CreateVMVsockProxyParams) (*CreateVMVsockProxyOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateVMVsockProxyParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createVMVsockProxy",
+		Method:             "POST",
+		PathPattern:        "/vms/{vmID}/vsock/proxies",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CreateVMVsockProxyReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateVMVsockProxyOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateVMVsockProxyDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 DeleteVM destroys a VM instance
 
-Destroy an isntance of VM
+Destroys a stopped VM along with its disks, snapshots and logs
 */
 func (a *Client) DeleteVM(params *DeleteVMParams) (*DeleteVMOK, error) {
 	// TODO: Validate the params before sending
@@ -273,6 +342,41 @@ func (a *Client) DeleteVMInterface(params *DeleteVMInterfaceParams) (*DeleteVMIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+DeleteVMSnapshot deletes a VM snapshot
+
+Deletes a snapshot and the files it uses in the storage target
+*/
+func (a *Client) DeleteVMSnapshot(params *DeleteVMSnapshotParams) (*DeleteVMSnapshotOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteVMSnapshotParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "deleteVMSnapshot",
+		Method:             "DELETE",
+		PathPattern:        "/vms/{vmID}/snapshots/{snapshotID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteVMSnapshotReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteVMSnapshotOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DeleteVMSnapshotDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 DeleteVMVolume returns a VM instance
 
@@ -308,6 +412,112 @@ func (a *Client) DeleteVMVolume(params *DeleteVMVolumeParams) (*DeleteVMVolumeOK
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+DeleteVMVsockProxy deletes a VM vsock proxy
+
+Stops proxying a host port and drops its connections
+*/
+func (a *Client) DeleteVMVsockProxy(params *DeleteVMVsockProxyParams) (*DeleteVMVsockProxyOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteVMVsockProxyParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "deleteVMVsockProxy",
+		Method:             "DELETE",
+		PathPattern:        "/vms/{vmID}/vsock/proxies/{proxyID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteVMVsockProxyReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteVMVsockProxyOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DeleteVMVsockProxyDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+GetConsoleRecording gets a console recording
+
+Returns a console recording including the asciicast v2 recording itself
+*/
+func (a *Client) GetConsoleRecording(params *GetConsoleRecordingParams) (*GetConsoleRecordingOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetConsoleRecordingParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getConsoleRecording",
+		Method:             "GET",
+		PathPattern:        "/recordings/{recordingID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetConsoleRecordingReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetConsoleRecordingOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getConsoleRecording: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetConsoleRecordingList gets a list of console recordings
+
+Returns the recordings of interactive console sessions, newest first
+*/
+func (a *Client) GetConsoleRecordingList(params *GetConsoleRecordingListParams) (*GetConsoleRecordingListOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetConsoleRecordingListParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getConsoleRecordingList",
+		Method:             "GET",
+		PathPattern:        "/recordings",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetConsoleRecordingListReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetConsoleRecordingListOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetConsoleRecordingListDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetVM returns a VM instance
 
@@ -344,6 +554,76 @@ func (a *Client) GetVM(params *GetVMParams) (*GetVMOK, error) {
 	panic(msg)
 }
 
+/*
+GetVMAgentInfo gets information from the VM guest agent
+
+Pings the guest agent over vsock and returns its version and the network interfaces of the guest
+*/
+func (a *Client) GetVMAgentInfo(params *GetVMAgentInfoParams) (*GetVMAgentInfoOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMAgentInfoParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMAgentInfo",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/agent",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMAgentInfoReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMAgentInfoOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetVMAgentInfoDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+GetVMBalloon gets the memory balloon of a VM
+
+Returns the target and actual size of the balloon device of a VM
+*/
+func (a *Client) GetVMBalloon(params *GetVMBalloonParams) (*GetVMBalloonOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMBalloonParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMBalloon",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/balloon",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMBalloonReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMBalloonOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetVMBalloonDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetVMConsole gets a console for a VM instance
 
@@ -555,6 +835,149 @@ func (a *Client) GetVMList(params *GetVMListParams) (*GetVMListOK, error) {
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+GetVMLogs returns VM logs
+
+Returns the serial console output and firecracker log of a VM instance. When follow is set new lines are streamed as newline delimited JSON until the client disconnects
+*/
+func (a *Client) GetVMLogs(params *GetVMLogsParams) (*GetVMLogsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMLogsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMLogs",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/logs",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMLogsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMLogsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getVMLogs: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetVMMetrics returns VM metrics
+
+Returns the metrics collected from the VMM of a VM instance
+*/
+func (a *Client) GetVMMetrics(params *GetVMMetricsParams) (*GetVMMetricsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMMetricsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMMetrics",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/metrics",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMMetricsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMMetricsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getVMMetrics: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetVMResources gets the resource limits of a VM
+
+Returns the cgroup resource limits of a VM and, while it is running, the values in effect and its usage
+*/
+func (a *Client) GetVMResources(params *GetVMResourcesParams) (*GetVMResourcesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMResourcesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMResources",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/resources",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMResourcesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMResourcesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getVMResources: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetVMSnapshotList gets a list of VM snapshots
+
+Returns a list of snapshots taken of a VM
+*/
+func (a *Client) GetVMSnapshotList(params *GetVMSnapshotListParams) (*GetVMSnapshotListOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMSnapshotListParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMSnapshotList",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/snapshots",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMSnapshotListReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMSnapshotListOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetVMSnapshotListDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetVMVolume returns a VM instance
 
@@ -625,6 +1048,77 @@ func (a *Client) GetVMVolumeList(params *GetVMVolumeListParams) (*GetVMVolumeLis
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+GetVMVsockProxyList gets a list of VM vsock proxies
+
+Returns the host ports being proxied to vsock ports in the VM
+*/
+func (a *Client) GetVMVsockProxyList(params *GetVMVsockProxyListParams) (*GetVMVsockProxyListOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVMVsockProxyListParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMVsockProxyList",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/vsock/proxies",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetVMVsockProxyListReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVMVsockProxyListOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetVMVsockProxyListDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+PauseVM pauses a VM instance
+
+Pauses the vCPUs of a running instance of VM
+*/
+func (a *Client) PauseVM(params *PauseVMParams) (*PauseVMOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPauseVMParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "pauseVM",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/pause",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PauseVMReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PauseVMOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for pauseVM: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 ResetVM resets a VM instance
 
@@ -697,6 +1191,77 @@ func (a *Client) RestartVM(params *RestartVMParams) (*RestartVMOK, error) {
 	panic(msg)
 }
 
+/*
+RestoreVMSnapshot restores a VM snapshot
+
+Restores a VM from a snapshot, or restores the snapshot into a new clone of the VM
+*/
+func (a *Client) RestoreVMSnapshot(params *RestoreVMSnapshotParams) (*RestoreVMSnapshotAccepted, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRestoreVMSnapshotParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "restoreVMSnapshot",
+		Method:             "POST",
+		PathPattern:        "/vms/{vmID}/snapshots/{snapshotID}/restore",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &RestoreVMSnapshotReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RestoreVMSnapshotAccepted)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RestoreVMSnapshotDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+ResumeVM resumes a VM instance
+
+Resumes a paused instance of VM
+*/
+func (a *Client) ResumeVM(params *ResumeVMParams) (*ResumeVMOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewResumeVMParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "resumeVM",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/resume",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ResumeVMReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ResumeVMOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for resumeVM: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 ShutdownVM shutdowns a VM instance
 
@@ -841,6 +1406,41 @@ func (a *Client) UpdateVM(params *UpdateVMParams) (*UpdateVMOK, error) {
 	panic(msg)
 }
 
+/*
+UpdateVMBalloon resizes the memory balloon of a VM
+
+Sets the memory the balloon device takes from the guest - a running VM is resized straight away
+*/
+func (a *Client) UpdateVMBalloon(params *UpdateVMBalloonParams) (*UpdateVMBalloonOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateVMBalloonParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateVMBalloon",
+		Method:             "PUT",
+		PathPattern:        "/vms/{vmID}/balloon",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UpdateVMBalloonReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdateVMBalloonOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*UpdateVMBalloonDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 UpdateVMDisk updates a VM interface instance
 
@@ -911,6 +1511,42 @@ func (a *Client) UpdateVMInterface(params *UpdateVMInterfaceParams) (*UpdateVMIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+UpdateVMResources changes the resource limits of a VM
+
+Replaces the cgroup resource limits of a VM - a running VM has them applied straight away
+*/
+func (a *Client) UpdateVMResources(params *UpdateVMResourcesParams) (*UpdateVMResourcesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateVMResourcesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateVMResources",
+		Method:             "PUT",
+		PathPattern:        "/vms/{vmID}/resources",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UpdateVMResourcesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdateVMResourcesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for updateVMResources: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 UpdateVMVolume updates a VM interface instance
 
@@ -41,6 +41,9 @@ type NewVM struct {
 
 	// storage name
 	StorageName string `json:"storageName,omitempty"`
+
+	// Only needed for mock VMs which are created without an image - other VMs get their type from their image
+	Type string `json:"type,omitempty"`
 }
 
 // Validate validates this new VM
@@ -23,6 +23,12 @@ type VM struct {
 	// auto start
 	AutoStart bool `json:"autoStart,omitempty"`
 
+	// Memory currently held by the balloon device in MiB
+	BalloonActualMib int64 `json:"balloonActualMib,omitempty"`
+
+	// Memory the balloon device is asked to take from the guest in MiB
+	BalloonTargetMib int64 `json:"balloonTargetMib,omitempty"`
+
 	// boot cmd
 	BootCmd string `json:"bootCmd,omitempty"`
 
@@ -36,6 +42,9 @@ type VM struct {
 	// cpus
 	Cpus int64 `json:"cpus,omitempty"`
 
+	// Number of consecutive unexpected exits
+	CrashCount int64 `json:"crashCount,omitempty"`
+
 	// created at
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"createdAt,omitempty"`
@@ -46,6 +55,13 @@ type VM struct {
 	// entry point
 	EntryPoint string `json:"entryPoint,omitempty"`
 
+	// VM health check status
+	// Enum: [none starting healthy unhealthy]
+	Health string `json:"health,omitempty"`
+
+	// Last health check failure
+	HealthMessage string `json:"healthMessage,omitempty"`
+
 	// host node
 	HostNode string `json:"hostNode,omitempty"`
 
@@ -57,6 +73,9 @@ type VM struct {
 	// Format: uuid4
 	ID strfmt.UUID4 `json:"id,omitempty"`
 
+	// Reason the VM process last exited
+	LastExitReason string `json:"lastExitReason,omitempty"`
+
 	// memory
 	Memory int64 `json:"memory,omitempty"`
 
@@ -66,12 +85,16 @@ type VM struct {
 	// network
 	Network *MetaDataNetworkConfig `json:"network,omitempty"`
 
+	// What to do when the VM process exits unexpectedly
+	// Enum: [no on-failure always unless-stopped]
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+
 	// started at
 	// Format: date-time
 	StartedAt strfmt.DateTime `json:"startedAt,omitempty"`
 
 	// VM status
-	// Enum: [starting started restarting stopping stopped]
+	// Enum: [starting started restarting stopping stopped paused]
 	Status string `json:"status,omitempty"`
 
 	// stopped at
@@ -84,6 +107,9 @@ type VM struct {
 
 	// volumes
 	Volumes []*VMVolume `json:"volumes" xml:"volume"`
+
+	// Guest CID of the vsock device - 0 when the VM has no vsock device
+	VsockCID int64 `json:"vsockCID,omitempty"`
 }
 
 // Validate validates this VM
@@ -102,6 +128,10 @@ func (m *VM) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealth(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHostNodeID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -114,6 +144,10 @@ func (m *VM) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRestartPolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStartedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -191,6 +225,55 @@ func (m *VM) validateDisks(formats strfmt.Registry) error {
 	return nil
 }
 
+var vmTypeHealthPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["none","starting","healthy","unhealthy"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		vmTypeHealthPropEnum = append(vmTypeHealthPropEnum, v)
+	}
+}
+
+const (
+
+	// VMHealthNone captures enum value "none"
+	VMHealthNone string = "none"
+
+	// VMHealthStarting captures enum value "starting"
+	VMHealthStarting string = "starting"
+
+	// VMHealthHealthy captures enum value "healthy"
+	VMHealthHealthy string = "healthy"
+
+	// VMHealthUnhealthy captures enum value "unhealthy"
+	VMHealthUnhealthy string = "unhealthy"
+)
+
+// prop value enum
+func (m *VM) validateHealthEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, vmTypeHealthPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *VM) validateHealth(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Health) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateHealthEnum("health", "body", m.Health); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *VM) validateHostNodeID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.HostNodeID) { // not required
@@ -235,6 +318,55 @@ func (m *VM) validateNetwork(formats strfmt.Registry) error {
 	return nil
 }
 
+var vmTypeRestartPolicyPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["no","on-failure","always","unless-stopped"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		vmTypeRestartPolicyPropEnum = append(vmTypeRestartPolicyPropEnum, v)
+	}
+}
+
+const (
+
+	// VMRestartPolicyNo captures enum value "no"
+	VMRestartPolicyNo string = "no"
+
+	// VMRestartPolicyOnFailure captures enum value "on-failure"
+	VMRestartPolicyOnFailure string = "on-failure"
+
+	// VMRestartPolicyAlways captures enum value "always"
+	VMRestartPolicyAlways string = "always"
+
+	// VMRestartPolicyUnlessStopped captures enum value "unless-stopped"
+	VMRestartPolicyUnlessStopped string = "unless-stopped"
+)
+
+// prop value enum
+func (m *VM) validateRestartPolicyEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, vmTypeRestartPolicyPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *VM) validateRestartPolicy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RestartPolicy) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateRestartPolicyEnum("restartPolicy", "body", m.RestartPolicy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *VM) validateStartedAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.StartedAt) { // not required
@@ -252,7 +384,7 @@ var vmTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["starting","started","restarting","stopping","stopped"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["starting","started","restarting","stopping","stopped","paused"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -276,6 +408,9 @@ const (
 
 	// VMStatusStopped captures enum value "stopped"
 	VMStatusStopped string = "stopped"
+
+	// VMStatusPaused captures enum value "paused"
+	VMStatusPaused string = "paused"
 )
 
 // prop value enum
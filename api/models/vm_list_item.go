@@ -23,6 +23,10 @@ type VMListItem struct {
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"createdAt,omitempty"`
 
+	// VM health check status
+	// Enum: [none starting healthy unhealthy]
+	Health string `json:"health,omitempty"`
+
 	// id
 	// Format: uuid4
 	ID strfmt.UUID4 `json:"id,omitempty"`
@@ -35,7 +39,7 @@ type VMListItem struct {
 	StartedAt strfmt.DateTime `json:"startedAt,omitempty"`
 
 	// VM status
-	// Enum: [starting started restarting stopping stopped]
+	// Enum: [starting started restarting stopping stopped paused]
 	Status string `json:"status,omitempty"`
 
 	// stopped at
@@ -51,6 +55,10 @@ func (m *VMListItem) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealth(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -86,6 +94,55 @@ func (m *VMListItem) validateCreatedAt(formats strfmt.Registry) error {
 	return nil
 }
 
+var vmListItemTypeHealthPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["none","starting","healthy","unhealthy"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		vmListItemTypeHealthPropEnum = append(vmListItemTypeHealthPropEnum, v)
+	}
+}
+
+const (
+
+	// VMListItemHealthNone captures enum value "none"
+	VMListItemHealthNone string = "none"
+
+	// VMListItemHealthStarting captures enum value "starting"
+	VMListItemHealthStarting string = "starting"
+
+	// VMListItemHealthHealthy captures enum value "healthy"
+	VMListItemHealthHealthy string = "healthy"
+
+	// VMListItemHealthUnhealthy captures enum value "unhealthy"
+	VMListItemHealthUnhealthy string = "unhealthy"
+)
+
+// prop value enum
+func (m *VMListItem) validateHealthEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, vmListItemTypeHealthPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *VMListItem) validateHealth(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Health) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateHealthEnum("health", "body", m.Health); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *VMListItem) validateID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ID) { // not required
@@ -116,7 +173,7 @@ var vmListItemTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["starting","started","restarting","stopping","stopped"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["starting","started","restarting","stopping","stopped","paused"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -140,6 +197,9 @@ const (
 
 	// VMListItemStatusStopped captures enum value "stopped"
 	VMListItemStatusStopped string = "stopped"
+
+	// VMListItemStatusPaused captures enum value "paused"
+	VMListItemStatusPaused string = "paused"
 )
 
 // prop value enum
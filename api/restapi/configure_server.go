@@ -3,10 +3,14 @@
 package restapi
 
 import (
-	"bufio"
+	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"os"
 	"time"
 
 	errors "github.com/go-openapi/errors"
@@ -18,8 +22,14 @@ import (
 	"github.com/768bit/promethium/api/restapi/operations/images"
 	"github.com/768bit/promethium/api/restapi/operations/networking"
 	"github.com/768bit/promethium/api/restapi/operations/storage"
+	"github.com/768bit/promethium/api/restapi/operations/system"
 	"github.com/768bit/promethium/api/restapi/operations/vms"
+	"github.com/768bit/promethium/lib/agent"
 	img "github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/metrics"
+	ops "github.com/768bit/promethium/lib/operations"
+	"github.com/768bit/promethium/lib/peercred"
+	"github.com/768bit/promethium/lib/recording"
 	"github.com/768bit/promethium/lib/vmm"
 
 	"github.com/gorilla/websocket"
@@ -82,13 +92,14 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 		})
 	}
 	api.VmsCreateVMHandler = vms.CreateVMHandlerFunc(func(params vms.CreateVMParams) middleware.Responder {
-		newVm, err := vmmManager.Create(params.VMConfig)
-		if err != nil {
-			println(err.Error())
-			return &vms.CreateVMOK{}
-		}
-		println(newVm.ID())
-		return &vms.CreateVMOK{}
+		op := vmmManager.Operations().Start("create-vm", "", "Create VM "+params.VMConfig.Name, func(op *ops.Operation) (interface{}, error) {
+			newVm, err := vmmManager.Create(op, params.VMConfig)
+			if err != nil {
+				return nil, err
+			}
+			return newVm.GetModel(), nil
+		})
+		return vms.NewCreateVMAccepted().WithPayload(op.GetModel())
 	})
 
 	if api.VmsCreateVMDiskHandler == nil {
@@ -106,11 +117,20 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return middleware.NotImplemented("operation vms.CreateVMVolume has not yet been implemented")
 		})
 	}
-	if api.VmsDeleteVMHandler == nil {
-		api.VmsDeleteVMHandler = vms.DeleteVMHandlerFunc(func(params vms.DeleteVMParams) middleware.Responder {
-			return middleware.NotImplemented("operation vms.DeleteVM has not yet been implemented")
-		})
-	}
+	api.VmsDeleteVMHandler = vms.DeleteVMHandlerFunc(func(params vms.DeleteVMParams) middleware.Responder {
+		if _, err := vmmManager.Get(params.VMID); err != nil {
+			return &vms.DeleteVMNotFound{}
+		}
+		err := vmmManager.Delete(params.VMID)
+		if payload, ok := conflictError(err); ok {
+			return vms.NewDeleteVMConflict().WithPayload(payload)
+		} else if err != nil {
+			println(err.Error())
+			return &vms.DeleteVMBadRequest{}
+		}
+		return &vms.DeleteVMOK{}
+	})
+
 	if api.VmsDeleteVMDriveHandler == nil {
 		api.VmsDeleteVMDriveHandler = vms.DeleteVMDriveHandlerFunc(func(params vms.DeleteVMDriveParams) middleware.Responder {
 			return middleware.NotImplemented("operation vms.DeleteVMDrive has not yet been implemented")
@@ -153,7 +173,9 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return &vms.StartVMNotFound{}
 		}
 		err = vmm.Start()
-		if err != nil {
+		if payload, ok := conflictError(err); ok {
+			return vms.NewStartVMConflict().WithPayload(payload)
+		} else if err != nil {
 			println(err.Error())
 			return &vms.StartVMBadRequest{}
 		}
@@ -166,20 +188,361 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return &vms.StopVMNotFound{}
 		}
 		err = vmm.Stop()
-		if err != nil {
+		if payload, ok := conflictError(err); ok {
+			return vms.NewStopVMConflict().WithPayload(payload)
+		} else if err != nil {
 			println(err.Error())
 			return &vms.StopVMBadRequest{}
 		}
 		return &vms.StopVMOK{}
 	})
 
+	api.VmsPauseVMHandler = vms.PauseVMHandlerFunc(func(params vms.PauseVMParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.PauseVMNotFound{}
+		}
+		err = vmm.Pause()
+		if payload, ok := conflictError(err); ok {
+			return vms.NewPauseVMConflict().WithPayload(payload)
+		} else if err != nil {
+			println(err.Error())
+			return &vms.PauseVMBadRequest{}
+		}
+		return &vms.PauseVMOK{Payload: vmm.GetModel()}
+	})
+
+	api.VmsResumeVMHandler = vms.ResumeVMHandlerFunc(func(params vms.ResumeVMParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.ResumeVMNotFound{}
+		}
+		err = vmm.Resume()
+		if payload, ok := conflictError(err); ok {
+			return vms.NewResumeVMConflict().WithPayload(payload)
+		} else if err != nil {
+			println(err.Error())
+			return &vms.ResumeVMBadRequest{}
+		}
+		return &vms.ResumeVMOK{Payload: vmm.GetModel()}
+	})
+
+	api.VmsGetVMMetricsHandler = vms.GetVMMetricsHandlerFunc(func(params vms.GetVMMetricsParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMMetricsNotFound{}
+		}
+		snap, err := vmm.Metrics(*params.Flush)
+		if err != nil {
+			println(err.Error())
+			return &vms.GetVMMetricsBadRequest{}
+		}
+		return &vms.GetVMMetricsOK{Payload: vmm.GetMetricsModel(snap)}
+	})
+
+	api.VmsGetVMLogsHandler = vms.GetVMLogsHandlerFunc(func(params vms.GetVMLogsParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMLogsNotFound{}
+		}
+		since := time.Time{}
+		if params.Since != nil {
+			since = time.Time(*params.Since)
+		}
+		tail := 0
+		if params.Tail != nil {
+			tail = int(*params.Tail)
+		}
+		if *params.Follow {
+			return followLogs(vmm, *params.Source, since, tail, params.HTTPRequest)
+		}
+		entries, err := vmm.Logs(*params.Source, since, tail)
+		if err != nil {
+			println(err.Error())
+			return &vms.GetVMLogsBadRequest{}
+		}
+		payload := []*models.VMLogEntry{}
+		for _, entry := range entries {
+			payload = append(payload, vmm.GetLogEntryModel(entry))
+		}
+		return &vms.GetVMLogsOK{Payload: payload}
+	})
+
+	api.VmsGetConsoleRecordingListHandler = vms.GetConsoleRecordingListHandlerFunc(func(params vms.GetConsoleRecordingListParams) middleware.Responder {
+		vmID := ""
+		if params.VMID != nil {
+			vmID = *params.VMID
+		}
+		store := vmmManager.Recordings()
+		recordings, err := store.List(vmID)
+		if err != nil {
+			e := err.Error()
+			errPayload := vms.NewGetConsoleRecordingListDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		payload := []*models.ConsoleRecording{}
+		for _, rec := range recordings {
+			model, _ := vmm.GetRecordingModel(store, rec, false)
+			payload = append(payload, model)
+		}
+		return &vms.GetConsoleRecordingListOK{Payload: payload}
+	})
+
+	api.VmsGetConsoleRecordingHandler = vms.GetConsoleRecordingHandlerFunc(func(params vms.GetConsoleRecordingParams) middleware.Responder {
+		store := vmmManager.Recordings()
+		rec, err := store.Get(params.RecordingID)
+		if err == recording.ErrInvalidID {
+			return &vms.GetConsoleRecordingBadRequest{}
+		} else if err != nil {
+			return &vms.GetConsoleRecordingNotFound{}
+		}
+		model, err := vmm.GetRecordingModel(store, rec, true)
+		if err != nil {
+			println(err.Error())
+			return &vms.GetConsoleRecordingBadRequest{}
+		}
+		return &vms.GetConsoleRecordingOK{Payload: model}
+	})
+
+	api.VmsGetVMSnapshotListHandler = vms.GetVMSnapshotListHandlerFunc(func(params vms.GetVMSnapshotListParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMSnapshotListNotFound{}
+		}
+		snapshots, err := vmm.ListSnapshots()
+		if err != nil {
+			e := err.Error()
+			errPayload := vms.NewGetVMSnapshotListDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		resp := &vms.GetVMSnapshotListOK{
+			Payload: make([]*models.VMSnapshot, len(snapshots)),
+		}
+		for index, snap := range snapshots {
+			resp.Payload[index] = vmm.GetSnapshotModel(snap)
+		}
+		return resp
+	})
+
+	api.VmsCreateVMSnapshotHandler = vms.CreateVMSnapshotHandlerFunc(func(params vms.CreateVMSnapshotParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.CreateVMSnapshotNotFound{}
+		}
+		if payload, ok := conflictError(vmm.Busy("snapshot")); ok {
+			return vms.NewCreateVMSnapshotConflict().WithPayload(payload)
+		}
+		op := vmmManager.Operations().Start("snapshot-vm", vmm.ID(), "Snapshot VM "+vmm.Name(), func(op *ops.Operation) (interface{}, error) {
+			snap, err := vmm.CreateSnapshot(op, params.SnapshotConfig.Name)
+			if err != nil {
+				return nil, err
+			}
+			return vmm.GetSnapshotModel(snap), nil
+		})
+		return vms.NewCreateVMSnapshotAccepted().WithPayload(op.GetModel())
+	})
+
+	api.VmsDeleteVMSnapshotHandler = vms.DeleteVMSnapshotHandlerFunc(func(params vms.DeleteVMSnapshotParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.DeleteVMSnapshotNotFound{}
+		}
+		if _, err := vmm.GetSnapshot(params.SnapshotID); err != nil {
+			return &vms.DeleteVMSnapshotNotFound{}
+		}
+		if err := vmm.DeleteSnapshot(params.SnapshotID); err != nil {
+			if payload, ok := conflictError(err); ok {
+				return vms.NewDeleteVMSnapshotConflict().WithPayload(payload)
+			}
+			e := err.Error()
+			errPayload := vms.NewDeleteVMSnapshotDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		return &vms.DeleteVMSnapshotOK{}
+	})
+
+	api.VmsRestoreVMSnapshotHandler = vms.RestoreVMSnapshotHandlerFunc(func(params vms.RestoreVMSnapshotParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.RestoreVMSnapshotNotFound{}
+		}
+		if _, err := vmm.GetSnapshot(params.SnapshotID); err != nil {
+			return &vms.RestoreVMSnapshotNotFound{}
+		}
+		if params.RestoreConfig != nil && params.RestoreConfig.CloneName != "" {
+			if payload, ok := conflictError(vmm.Busy("clone")); ok {
+				return vms.NewRestoreVMSnapshotConflict().WithPayload(payload)
+			}
+			cloneName := params.RestoreConfig.CloneName
+			op := vmmManager.Operations().Start("clone-vm", vmm.ID(), "Clone VM "+vmm.Name()+" as "+cloneName, func(op *ops.Operation) (interface{}, error) {
+				clone, err := vmmManager.NewVmmFromSnapshot(op, vmm, params.SnapshotID, cloneName)
+				if err != nil {
+					return nil, err
+				}
+				return clone.GetModel(), nil
+			})
+			return vms.NewRestoreVMSnapshotAccepted().WithPayload(op.GetModel())
+		}
+		if payload, ok := conflictError(vmm.Busy("restore")); ok {
+			return vms.NewRestoreVMSnapshotConflict().WithPayload(payload)
+		}
+		op := vmmManager.Operations().Start("restore-vm", vmm.ID(), "Restore VM "+vmm.Name(), func(op *ops.Operation) (interface{}, error) {
+			if err := vmm.RestoreSnapshot(op, params.SnapshotID); err != nil {
+				return nil, err
+			}
+			return vmm.GetModel(), nil
+		})
+		return vms.NewRestoreVMSnapshotAccepted().WithPayload(op.GetModel())
+	})
+
+	api.VmsGetVMVsockProxyListHandler = vms.GetVMVsockProxyListHandlerFunc(func(params vms.GetVMVsockProxyListParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMVsockProxyListNotFound{}
+		}
+		payload := []*models.VsockProxy{}
+		for _, proxy := range vmm.VsockProxies() {
+			payload = append(payload, vmm.GetVsockProxyModel(proxy))
+		}
+		return &vms.GetVMVsockProxyListOK{Payload: payload}
+	})
+
+	api.VmsCreateVMVsockProxyHandler = vms.CreateVMVsockProxyHandlerFunc(func(params vms.CreateVMVsockProxyParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.CreateVMVsockProxyNotFound{}
+		}
+		proxyConfig := params.ProxyConfig
+		if proxyConfig.HostPort < 0 || proxyConfig.HostPort > 65535 {
+			return &vms.CreateVMVsockProxyBadRequest{}
+		} else if proxyConfig.GuestPort == nil || *proxyConfig.GuestPort <= 0 || *proxyConfig.GuestPort > 65535 {
+			return &vms.CreateVMVsockProxyBadRequest{}
+		}
+		proxy, err := vmm.AddVsockProxy(proxyConfig.BindAddress, uint32(proxyConfig.HostPort), uint32(*proxyConfig.GuestPort))
+		if err != nil {
+			e := err.Error()
+			errPayload := vms.NewCreateVMVsockProxyDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		return &vms.CreateVMVsockProxyOK{Payload: vmm.GetVsockProxyModel(proxy)}
+	})
+
+	api.VmsDeleteVMVsockProxyHandler = vms.DeleteVMVsockProxyHandlerFunc(func(params vms.DeleteVMVsockProxyParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.DeleteVMVsockProxyNotFound{}
+		}
+		if err := vmm.RemoveVsockProxy(params.ProxyID); err != nil {
+			return &vms.DeleteVMVsockProxyNotFound{}
+		}
+		return &vms.DeleteVMVsockProxyOK{}
+	})
+
+	api.VmsGetVMAgentInfoHandler = vms.GetVMAgentInfoHandlerFunc(func(params vms.GetVMAgentInfoParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMAgentInfoNotFound{}
+		}
+		info, err := vmm.GetAgentInfoModel()
+		if err != nil {
+			e := err.Error()
+			errPayload := vms.NewGetVMAgentInfoDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		return &vms.GetVMAgentInfoOK{Payload: info}
+	})
+
+	api.VmsGetVMBalloonHandler = vms.GetVMBalloonHandlerFunc(func(params vms.GetVMBalloonParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMBalloonNotFound{}
+		}
+		balloon, err := vmm.GetBalloonModel()
+		if err != nil {
+			println(err.Error())
+			return &vms.GetVMBalloonBadRequest{}
+		}
+		return &vms.GetVMBalloonOK{Payload: balloon}
+	})
+
+	api.VmsUpdateVMBalloonHandler = vms.UpdateVMBalloonHandlerFunc(func(params vms.UpdateVMBalloonParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.UpdateVMBalloonNotFound{}
+		}
+		err = vmm.SetBalloonTarget(*params.BalloonConfig.AmountMib)
+		if payload, ok := conflictError(err); ok {
+			return vms.NewUpdateVMBalloonConflict().WithPayload(payload)
+		} else if err != nil {
+			println(err.Error())
+			return &vms.UpdateVMBalloonBadRequest{}
+		}
+		balloon, err := vmm.GetBalloonModel()
+		if err != nil {
+			e := err.Error()
+			errPayload := vms.NewUpdateVMBalloonDefault(500)
+			errPayload.SetPayload(&models.Error{
+				Code:    500,
+				Message: &e,
+			})
+			return errPayload
+		}
+		return &vms.UpdateVMBalloonOK{Payload: balloon}
+	})
+
+	api.VmsGetVMResourcesHandler = vms.GetVMResourcesHandlerFunc(func(params vms.GetVMResourcesParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMResourcesNotFound{}
+		}
+		return &vms.GetVMResourcesOK{Payload: vmm.GetResourcesModel()}
+	})
+
+	api.VmsUpdateVMResourcesHandler = vms.UpdateVMResourcesHandlerFunc(func(params vms.UpdateVMResourcesParams) middleware.Responder {
+		resources := vmm.ResourcesFromModel(params.ResourceLimits)
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.UpdateVMResourcesNotFound{}
+		}
+		err = vmm.SetResources(resources)
+		if payload, ok := conflictError(err); ok {
+			return vms.NewUpdateVMResourcesConflict().WithPayload(payload)
+		} else if err != nil {
+			println(err.Error())
+			return &vms.UpdateVMResourcesBadRequest{}
+		}
+		return &vms.UpdateVMResourcesOK{Payload: vmm.GetResourcesModel()}
+	})
+
 	api.VmsShutdownVMHandler = vms.ShutdownVMHandlerFunc(func(params vms.ShutdownVMParams) middleware.Responder {
 		vmm, err := vmmManager.Get(params.VMID)
 		if err != nil {
 			return &vms.ShutdownVMNotFound{}
 		}
 		err = vmm.Shutdown()
-		if err != nil {
+		if payload, ok := conflictError(err); ok {
+			return vms.NewShutdownVMConflict().WithPayload(payload)
+		} else if err != nil {
 			println(err.Error())
 			return &vms.ShutdownVMBadRequest{}
 		}
@@ -192,7 +555,9 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return &vms.RestartVMNotFound{}
 		}
 		err = vmm.Restart()
-		if err != nil {
+		if payload, ok := conflictError(err); ok {
+			return vms.NewRestartVMConflict().WithPayload(payload)
+		} else if err != nil {
 			println(err.Error())
 			return &vms.RestartVMBadRequest{}
 		}
@@ -205,7 +570,9 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return &vms.ResetVMNotFound{}
 		}
 		err = vmm.Reset()
-		if err != nil {
+		if payload, ok := conflictError(err); ok {
+			return vms.NewResetVMConflict().WithPayload(payload)
+		} else if err != nil {
 			println(err.Error())
 			return &vms.ResetVMBadRequest{}
 		}
@@ -219,7 +586,17 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 	api.ImagesPushImageHandler = images.PushImageHandlerFunc(func(params images.PushImageParams) middleware.Responder {
 		//will receive a payload which contains a file for upload.. this may also be a build context...
 
-		if drv, err := vmmManager.Storage().GetStorage(*params.TargetStorage); err != nil {
+		if params.InFileBlob == nil || params.TargetStorage == nil {
+			e := "An image file and a storage target are required"
+			errPayload := images.NewPushImageDefault(400)
+			errPayload.SetPayload(&models.Error{
+				Code:    400,
+				Message: &e,
+			})
+			return errPayload
+		}
+		drv, err := vmmManager.Storage().GetStorage(*params.TargetStorage)
+		if err != nil {
 			e := err.Error()
 			errPayload := images.NewPushImageDefault(500)
 			errPayload.SetPayload(&models.Error{
@@ -227,24 +604,20 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 				Message: &e,
 			})
 			return errPayload
-		} else {
-
-			//lets figure out what we need to do with image
-
-			err = drv.ImportImageFromRdr(params.InFileBlob)
-			if err != nil {
-				e := err.Error()
-				errPayload := images.NewPushImageDefault(500)
-				errPayload.SetPayload(&models.Error{
-					Code:    500,
-					Message: &e,
-				})
-				return errPayload
-			}
-
+		}
+		var size int64
+		if file, ok := params.InFileBlob.(*runtime.File); ok && file.Header != nil {
+			size = file.Header.Size
 		}
 
-		return &images.PushImageOK{}
+		//the upload is held open by the operation so it can still be read once the request is done
+		op := vmmManager.Operations().Start("import-image", *params.TargetStorage, "Import image to "+*params.TargetStorage, func(op *ops.Operation) (interface{}, error) {
+			return nil, drv.ImportImageFromRdr(struct {
+				io.Reader
+				io.Closer
+			}{&ops.Reader{Op: op, Reader: params.InFileBlob, Size: size, To: 90}, params.InFileBlob})
+		})
+		return images.NewPushImageAccepted().WithPayload(op.GetModel())
 	})
 
 	if api.NetworkingGetNetworkHandler == nil {
@@ -277,11 +650,16 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return middleware.NotImplemented("operation storage.GetStorageList has not yet been implemented")
 		})
 	}
-	if api.VmsGetVMHandler == nil {
-		api.VmsGetVMHandler = vms.GetVMHandlerFunc(func(params vms.GetVMParams) middleware.Responder {
-			return middleware.NotImplemented("operation vms.GetVM has not yet been implemented")
-		})
-	}
+	api.VmsGetVMHandler = vms.GetVMHandlerFunc(func(params vms.GetVMParams) middleware.Responder {
+		vmm, err := vmmManager.Get(params.VMID)
+		if err != nil {
+			return &vms.GetVMNotFound{}
+		}
+		return &vms.GetVMOK{
+			Payload: vmm.GetModel(),
+		}
+	})
+
 	if api.VmsGetVMDiskHandler == nil {
 		api.VmsGetVMDiskHandler = vms.GetVMDiskHandlerFunc(func(params vms.GetVMDiskParams) middleware.Responder {
 			return middleware.NotImplemented("operation vms.GetVMDisk has not yet been implemented")
@@ -302,11 +680,67 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 			return middleware.NotImplemented("operation vms.GetVMInterfaceList has not yet been implemented")
 		})
 	}
-	if api.VmsGetVMListHandler == nil {
-		api.VmsGetVMListHandler = vms.GetVMListHandlerFunc(func(params vms.GetVMListParams) middleware.Responder {
-			return middleware.NotImplemented("operation vms.GetVMList has not yet been implemented")
-		})
-	}
+	api.VmsGetVMListHandler = vms.GetVMListHandlerFunc(func(params vms.GetVMListParams) middleware.Responder {
+		ls := vmmManager.List(true)
+		skip := int(*params.Skip)
+		if skip > len(ls) {
+			skip = len(ls)
+		}
+		ls = ls[skip:]
+		if limit := int(*params.Limit); limit > 0 && limit < len(ls) {
+			ls = ls[:limit]
+		}
+		resp := &vms.GetVMListOK{
+			Payload: make([]*models.VMListItem, len(ls)),
+		}
+		for index, vmm := range ls {
+			resp.Payload[index] = vmm.GetListModel()
+		}
+		return resp
+	})
+
+	api.SystemGetDoctorReportHandler = system.GetDoctorReportHandlerFunc(func(params system.GetDoctorReportParams) middleware.Responder {
+		return &system.GetDoctorReportOK{Payload: vmm.GetDoctorReportModel(vmmManager.Doctor(false))}
+	})
+
+	api.SystemFixDoctorProblemsHandler = system.FixDoctorProblemsHandlerFunc(func(params system.FixDoctorProblemsParams) middleware.Responder {
+		return &system.FixDoctorProblemsOK{Payload: vmm.GetDoctorReportModel(vmmManager.Doctor(true))}
+	})
+
+	api.SystemGetOperationListHandler = system.GetOperationListHandlerFunc(func(params system.GetOperationListParams) middleware.Responder {
+		list := vmmManager.Operations().List()
+		resp := &system.GetOperationListOK{
+			Payload: make([]*models.Operation, len(list)),
+		}
+		for index, op := range list {
+			resp.Payload[index] = op.GetModel()
+		}
+		return resp
+	})
+
+	api.SystemGetOperationHandler = system.GetOperationHandlerFunc(func(params system.GetOperationParams) middleware.Responder {
+		op, err := vmmManager.Operations().Get(params.OperationID)
+		if err != nil {
+			return &system.GetOperationNotFound{}
+		}
+		return &system.GetOperationOK{Payload: op.GetModel()}
+	})
+
+	api.SystemCancelOperationHandler = system.CancelOperationHandlerFunc(func(params system.CancelOperationParams) middleware.Responder {
+		op, err := vmmManager.Operations().Get(params.OperationID)
+		if err != nil {
+			return &system.CancelOperationNotFound{}
+		}
+		if err := op.Cancel(); err != nil {
+			e := err.Error()
+			return system.NewCancelOperationConflict().WithPayload(&models.Error{
+				Code:    409,
+				Message: &e,
+			})
+		}
+		return &system.CancelOperationOK{Payload: op.GetModel()}
+	})
+
 	if api.VmsGetVMVolumeHandler == nil {
 		api.VmsGetVMVolumeHandler = vms.GetVMVolumeHandlerFunc(func(params vms.GetVMVolumeParams) middleware.Responder {
 			return middleware.NotImplemented("operation vms.GetVMVolume has not yet been implemented")
@@ -353,6 +787,18 @@ func configureAPI(api *operations.ServerAPI) http.Handler {
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// conflictError is the payload of the 409 returned when err is because another operation is in progress on the vm
+func conflictError(err error) (*models.Error, bool) {
+	if err == nil || !vmm.IsConflict(err) {
+		return nil, false
+	}
+	e := err.Error()
+	return &models.Error{
+		Code:    409,
+		Message: &e,
+	}, true
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
@@ -366,18 +812,124 @@ func configureTLS(tlsConfig *tls.Config) {
 // This function can be called multiple times, depending on the number of serving schemes.
 // scheme value will be set accordingly: "http", "https" or "unix"
 func configureServer(s *http.Server, scheme, addr string) {
+	//keep hold of the connection so the user on the other end of a console session can be worked out
+	s.ConnContext = func(ctx context.Context, conn net.Conn) context.Context {
+		return context.WithValue(ctx, connContextKey{}, conn)
+	}
+}
+
+type connContextKey struct{}
+
+// requestUser identifies who made a request - the local user for the unix socket and the remote host otherwise
+func requestUser(r *http.Request) string {
+	if conn, ok := r.Context().Value(connContextKey{}).(net.Conn); ok {
+		if _, isUnix := conn.(*net.UnixConn); isUnix {
+			if user, err := peercred.Username(conn); err == nil {
+				return user
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
 }
 
 // The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 // The middleware executes after routing but before authentication, binding and validation
 func setupMiddlewares(handler http.Handler) http.Handler {
-	return handler
+	return metrics.InstrumentHandler(handler, func(r *http.Request) string {
+		if route := middleware.MatchedRouteFrom(r); route != nil {
+			return route.PathPattern
+		}
+		return "unmatched"
+	})
 }
 
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return wsMiddleware(handler)
+	return metricsMiddleware(wsMiddleware(drainMiddleware(handler)))
+}
+
+// drainMiddleware tracks the api requests in flight so the vmm manager waits for them when the daemon exits - requests
+// that arrive after that are turned away
+func drainMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done, ok := vmmManager.BeginRequest()
+		if !ok {
+			http.Error(w, "The daemon is exiting", http.StatusServiceUnavailable)
+			return
+		}
+		defer done()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// metricsMiddleware serves the prometheus metrics alongside the api on every listener
+func metricsMiddleware(next http.Handler) http.Handler {
+	metricsHandler := metrics.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// followLogs streams the lines already in the log followed by new lines as newline delimited JSON until the
+// client goes away
+func followLogs(inVmm *vmm.Vmm, source string, since time.Time, tail int, r *http.Request) middleware.Responder {
+	//subscribe before reading what is there already so nothing is missed in between
+	follow, cancel, err := inVmm.FollowLogs(source)
+	if err != nil {
+		println(err.Error())
+		return &vms.GetVMLogsBadRequest{}
+	}
+	entries, err := inVmm.Logs(source, since, tail)
+	if err != nil {
+		cancel()
+		println(err.Error())
+		return &vms.GetVMLogsBadRequest{}
+	}
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer cancel()
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(200)
+		flusher, _ := rw.(http.Flusher)
+		enc := json.NewEncoder(rw)
+		last := time.Time{}
+		for _, entry := range entries {
+			if err := enc.Encode(inVmm.GetLogEntryModel(entry)); err != nil {
+				return
+			}
+			last = entry.Time
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case entry, ok := <-follow:
+				if !ok {
+					return
+				}
+				if !entry.Time.After(last) {
+					//already sent from the log file
+					continue
+				}
+				if err := enc.Encode(inVmm.GetLogEntryModel(entry)); err != nil {
+					return
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			}
+		}
+	})
 }
 
 func wsMiddleware(next http.Handler) http.Handler {
@@ -416,6 +968,8 @@ func serveWs(w http.ResponseWriter, r *http.Request) {
 		println("upgrade:", err)
 		return
 	}
+	defer ws.Close()
+	user := requestUser(r)
 	ws.SetCloseHandler(func(code int, text string) error {
 		fmt.Printf("WebSocket Closed: %d : %s\n", code, text)
 		return nil
@@ -430,16 +984,32 @@ func serveWs(w http.ResponseWriter, r *http.Request) {
 		switch inboundMsg.Operation {
 		case "connect-console":
 			vmID := inboundMsg.Payload["id"].(string)
-			err = handleConsoleConnect(ws, vmID)
+			readOnly, _ := inboundMsg.Payload["readOnly"].(bool)
+			cols, rows, _ := payloadSize(inboundMsg.Payload)
+			err = handleConsoleConnect(ws, vmID, user, readOnly, cols, rows)
 			if err != nil {
 				println(err.Error())
 			}
 			println("Respooling")
+		case "exec", "put-file", "get-file":
+			//these stream until they are done so the connection isnt reused afterwards
+			if err := handleAgentOperation(ws, inboundMsg); err != nil {
+				println(err.Error())
+			}
+			return
 		}
 	}
 
-	defer ws.Close()
+}
 
+// payloadSize reads the terminal size sent by a console client
+func payloadSize(payload map[string]interface{}) (int, int, bool) {
+	cols, colsOk := payload["cols"].(float64)
+	rows, rowsOk := payload["rows"].(float64)
+	if !colsOk || !rowsOk || cols <= 0 || rows <= 0 {
+		return 0, 0, false
+	}
+	return int(cols), int(rows), true
 }
 
 var NullByte byte = 0
@@ -453,52 +1023,41 @@ var (
 	readWaitDuration  time.Duration = time.Duration(400 * time.Millisecond)
 )
 
-func handleConsoleConnect(ws *websocket.Conn, id string) error {
+// handleConsoleConnect attaches a websocket to the console of a vm - binary messages carry the console input and
+// output while text messages carry control messages such as {"operation": "resize", "payload": {"cols", "rows"}}
+func handleConsoleConnect(ws *websocket.Conn, id string, user string, readOnly bool, cols int, rows int) error {
 	vmm, err := vmmManager.Get(id)
 	if err != nil {
 		return err
 	} else {
-		outP, _, inP, err := vmm.Console()
+		console, err := vmmManager.OpenConsole(vmm, user, readOnly, cols, rows)
 		if err != nil {
 			return err
 		}
+		defer console.Close()
 		go func() {
-			//use the pipes..
-			outScanner := bufio.NewReaderSize(outP, 1024)
+			//the console replays its scrollback first then streams the output as it arrives
 			obuff := make([]byte, 1024)
 			for {
 
 				//ws.SetWriteDeadline(time.Now().Add(writeWaitDuration))
 
-				//println("new writer")
-
-				wo, err := ws.NextWriter(2)
+				n, err := console.Read(obuff)
 				if err != nil {
-					println("next_write:", err.Error())
+					println("read:", err.Error())
+					//let the client know the console has gone so it stops waiting on it
+					ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, err.Error()), time.Now().Add(writeWaitDuration))
 					return
+				} else if n == 0 {
+					continue
 				}
 
-				n, err := outScanner.Read(obuff)
+				wo, err := ws.NextWriter(websocket.BinaryMessage)
 				if err != nil {
-					println("read:", err.Error())
-					return
-				} else if n == 0 {
+					println("next_write:", err.Error())
 					return
 				}
-				//println("Sending: [" + string(obuff[:n]) + "]")
-
-				//_, err = io.Copy(wo, outP)
-
 				_, err = wo.Write(obuff[:n])
-
-				// err = ws.WriteJSON(&OutboundJsonMessage{
-				// 	ID:        "",
-				// 	Operation: "console-output",
-				// 	Code:      0,
-				// 	Payload: map[string]interface{}{
-				// 		"output": string(b),
-				// 	},
-				// })
 				if err != nil {
 					wo.Close()
 					println("write:", err.Error())
@@ -507,43 +1066,237 @@ func handleConsoleConnect(ws *websocket.Conn, id string) error {
 				wo.Close()
 			}
 		}()
-		buff := make([]byte, 1024)
 
 		for {
 			//ws.SetReadDeadline(time.Now().Add(readWaitDuration))
 			mt, rd, err := ws.NextReader()
-			//mt, msg, err := ws.ReadMessage()
 			if err != nil {
 				println("read:", err.Error())
 				break
 			}
-			if mt == 2 {
-				n, err := rd.Read(buff)
-				//println("Copying StdIn: " + string(buff[:n]))
-				inP.Write(buff[:n])
-				if err != nil {
+			if mt == websocket.BinaryMessage {
+				if readOnly {
+					//read only clients can watch but their input is dropped
+					continue
+				}
+				if _, err := io.Copy(console, rd); err != nil {
 					return err
 				}
-				//io.Copy(inP, rd)
-				//inP.Write(msg)
+			} else if mt == websocket.TextMessage {
+				controlMsg := &InboundJsonMessage{}
+				if err := json.NewDecoder(rd).Decode(controlMsg); err != nil {
+					println("control:", err.Error())
+					continue
+				}
+				switch controlMsg.Operation {
+				case "resize":
+					if readOnly {
+						continue
+					}
+					if cols, rows, ok := payloadSize(controlMsg.Payload); ok {
+						resizeConsole(console, cols, rows)
+					}
+				}
 			} else {
 				print("differ mt")
 			}
-
-			// inboundMsg := &InboundJsonMessage{}
-			// err := ws.ReadJSON(inboundMsg)
-			// if err != nil {
-			// 	println("read:", err.Error())
-			// 	break
-			// }
-			// switch inboundMsg.Operation {
-			// case "console-input":
-			// 	inp := inboundMsg.Payload["input"].(string)
-			// 	inP.Write([]byte(inp))
-			// default:
-			// 	break
-			// }
 		}
 	}
 	return nil
 }
+
+func resizeConsole(console io.ReadWriteCloser, cols int, rows int) {
+	if resizer, ok := console.(vmm.ConsoleResizer); ok {
+		if err := resizer.Resize(cols, rows); err != nil {
+			println("resize:", err.Error())
+		}
+	}
+}
+
+const (
+	execStdout byte = 1
+	execStderr byte = 2
+)
+
+// handleAgentOperation runs exec, put-file or get-file against the guest agent of a vm. Binary messages carry stdin
+// and file contents - exec output is sent as binary messages whose first byte is 1 for stdout or 2 for stderr
+// followed by {"operation": "exit", "code"}. A failure is sent as {"operation": "error", "payload": {"message"}}
+func handleAgentOperation(ws *websocket.Conn, msg *InboundJsonMessage) error {
+	err := runAgentOperation(ws, msg)
+	if err != nil {
+		ws.WriteJSON(&OutboundJsonMessage{
+			ID:        msg.ID,
+			Operation: "error",
+			Payload: map[string]interface{}{
+				"message": err.Error(),
+			},
+		})
+	}
+	return err
+}
+
+func runAgentOperation(ws *websocket.Conn, msg *InboundJsonMessage) error {
+	id, _ := msg.Payload["id"].(string)
+	inVmm, err := vmmManager.Get(id)
+	if err != nil {
+		return err
+	}
+	client, err := inVmm.Agent()
+	if err != nil {
+		return err
+	}
+	path, _ := msg.Payload["path"].(string)
+	switch msg.Operation {
+	case "exec":
+		dir, _ := msg.Payload["dir"].(string)
+		return handleExec(ws, msg.ID, client, payloadStrings(msg.Payload, "command"), payloadStrings(msg.Payload, "env"), dir)
+	case "put-file":
+		mode, _ := msg.Payload["mode"].(float64)
+		return handlePutFile(ws, msg.ID, client, path, os.FileMode(mode))
+	case "get-file":
+		return handleGetFile(ws, msg.ID, client, path)
+	}
+	return fmt.Errorf("Unknown operation %s", msg.Operation)
+}
+
+func payloadStrings(payload map[string]interface{}, key string) []string {
+	values := []string{}
+	items, _ := payload[key].([]interface{})
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// wsBinaryWriter sends everything written to it as binary messages starting with prefix
+type wsBinaryWriter struct {
+	ws     *websocket.Conn
+	prefix []byte
+}
+
+func (w *wsBinaryWriter) Write(p []byte) (int, error) {
+	msg := make([]byte, 0, len(w.prefix)+len(p))
+	msg = append(append(msg, w.prefix...), p...)
+	if err := w.ws.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// handleExec runs a command in the guest - binary messages from the client are its stdin until the client sends
+// {"operation": "close-stdin"}. The command is killed if the client goes away.
+func handleExec(ws *websocket.Conn, id string, client *agent.Client, command []string, env []string, dir string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdin, stdinWriter := io.Pipe()
+	//once the command is done anything still being sent as stdin is dropped
+	defer stdin.Close()
+	go func() {
+		for {
+			mt, rd, err := ws.NextReader()
+			if err != nil {
+				stdinWriter.Close()
+				cancel()
+				return
+			}
+			if mt == websocket.BinaryMessage {
+				io.Copy(stdinWriter, rd)
+				continue
+			}
+			controlMsg := &InboundJsonMessage{}
+			if err := json.NewDecoder(rd).Decode(controlMsg); err == nil && controlMsg.Operation == "close-stdin" {
+				stdinWriter.Close()
+			}
+		}
+	}()
+	code, err := client.Exec(ctx, command, env, dir, stdin, &wsBinaryWriter{ws: ws, prefix: []byte{execStdout}},
+		&wsBinaryWriter{ws: ws, prefix: []byte{execStderr}})
+	if err != nil {
+		return err
+	}
+	return ws.WriteJSON(&OutboundJsonMessage{
+		ID:        id,
+		Operation: "exit",
+		Code:      code,
+	})
+}
+
+// handlePutFile writes the binary messages from the client to a file in the guest until the client sends
+// {"operation": "eof"} then replies with {"operation": "done", "payload": {"path", "size", "mode"}}
+func handlePutFile(ws *websocket.Conn, id string, client *agent.Client, path string, mode os.FileMode) error {
+	rd, wr := io.Pipe()
+	type putResult struct {
+		info *agent.FileInfo
+		err  error
+	}
+	done := make(chan putResult, 1)
+	go func() {
+		info, err := client.PutFile(path, mode, rd)
+		//stops the copy below if the agent gave up early
+		rd.CloseWithError(io.ErrClosedPipe)
+		done <- putResult{info: info, err: err}
+	}()
+	for {
+		mt, msgRd, err := ws.NextReader()
+		if err != nil {
+			wr.CloseWithError(err)
+			<-done
+			return err
+		}
+		if mt == websocket.BinaryMessage {
+			if _, err := io.Copy(wr, msgRd); err != nil {
+				break
+			}
+			continue
+		}
+		controlMsg := &InboundJsonMessage{}
+		if err := json.NewDecoder(msgRd).Decode(controlMsg); err == nil && controlMsg.Operation == "eof" {
+			wr.Close()
+			break
+		}
+	}
+	result := <-done
+	if result.err != nil {
+		return result.err
+	}
+	return ws.WriteJSON(&OutboundJsonMessage{
+		ID:        id,
+		Operation: "done",
+		Payload: map[string]interface{}{
+			"path": result.info.Path,
+			"size": result.info.Size,
+			"mode": result.info.Mode,
+		},
+	})
+}
+
+// handleGetFile sends {"operation": "file", "payload": {"path", "size", "mode"}} then the contents of a file in the
+// guest as binary messages followed by {"operation": "eof"}
+func handleGetFile(ws *websocket.Conn, id string, client *agent.Client, path string) error {
+	info, rd, err := client.GetFile(path)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	err = ws.WriteJSON(&OutboundJsonMessage{
+		ID:        id,
+		Operation: "file",
+		Payload: map[string]interface{}{
+			"path": info.Path,
+			"size": info.Size,
+			"mode": info.Mode,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(&wsBinaryWriter{ws: ws}, rd); err != nil {
+		return err
+	}
+	return ws.WriteJSON(&OutboundJsonMessage{
+		ID:        id,
+		Operation: "eof",
+	})
+}
@@ -34,6 +34,58 @@ func init() {
     "version": "1.0.0"
   },
   "paths": {
+    "/doctor": {
+      "get": {
+        "description": "Looks for orphaned jailer chroots, disks without a VM, VMs whose disks are missing, stale tap devices and leaked nbd devices",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Check for leaked resources",
+        "operationId": "getDoctorReport",
+        "responses": {
+          "200": {
+            "description": "Problems found",
+            "schema": {
+              "$ref": "#/definitions/DoctorReport"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Checks for leaked resources and cleans up the ones that can be cleaned up safely",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Clean up leaked resources",
+        "operationId": "fixDoctorProblems",
+        "responses": {
+          "200": {
+            "description": "Problems found and whether they were fixed",
+            "schema": {
+              "$ref": "#/definitions/DoctorReport"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/images": {
       "get": {
         "description": "Returns a list of Images",
@@ -201,10 +253,10 @@ func init() {
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The image is being imported",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/Operation"
             }
           },
           "default": {
@@ -421,6 +473,180 @@ func init() {
         }
       }
     },
+    "/operations": {
+      "get": {
+        "description": "Returns the long running operations that are in progress or finished recently",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get a list of Operations",
+        "operationId": "getOperationList",
+        "responses": {
+          "200": {
+            "description": "Array of Operations",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Operation"
+              }
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/operations/{operationID}": {
+      "get": {
+        "description": "Returns the progress of an operation and its result or error once it has finished",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Return an Operation",
+        "operationId": "getOperation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Operation to return",
+            "name": "operationID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
+          },
+          "404": {
+            "description": "Operation not found"
+          }
+        }
+      },
+      "delete": {
+        "description": "Asks an operation to stop - it is cancelled once it has cleaned up what it did",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Cancel an Operation",
+        "operationId": "cancelOperation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Operation to cancel",
+            "name": "operationID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
+          },
+          "404": {
+            "description": "Operation not found"
+          },
+          "409": {
+            "description": "The operation has already finished",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/recordings": {
+      "get": {
+        "description": "Returns the recordings of interactive console sessions, newest first",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a list of console recordings",
+        "operationId": "getConsoleRecordingList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return recordings of this VM",
+            "name": "vmID",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ConsoleRecording"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/recordings/{recordingID}": {
+      "get": {
+        "description": "Returns a console recording including the asciicast v2 recording itself",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a console recording",
+        "operationId": "getConsoleRecording",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the recording to return",
+            "name": "recordingID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/ConsoleRecording"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Recording not found"
+          }
+        }
+      }
+    },
     "/storage": {
       "get": {
         "description": "Get all storage",
@@ -737,10 +963,10 @@ func init() {
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The VM is being created - the result of the operation is the VM",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/Operation"
             }
           },
           "400": {
@@ -748,6 +974,12 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
@@ -831,7 +1063,7 @@ func init() {
         }
       },
       "delete": {
-        "description": "Destroy an isntance of VM",
+        "description": "Destroys a stopped VM along with its disks, snapshots and logs",
         "produces": [
           "application/json"
         ],
@@ -843,7 +1075,7 @@ func init() {
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to delete",
             "name": "vmID",
             "in": "path",
             "required": true
@@ -851,35 +1083,38 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
-            "schema": {
-              "$ref": "#/definitions/item"
-            }
+            "description": "successful operation"
           },
           "400": {
-            "description": "Invalid ID supplied"
+            "description": "The VM is still running or couldnt be deleted"
           },
           "404": {
             "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/console": {
+    "/vms/{vmID}/agent": {
       "get": {
-        "description": "Get a console for a VM instance",
+        "description": "Pings the guest agent over vsock and returns its version and the network interfaces of the guest",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a console for a VM instance",
-        "operationId": "getVMConsole",
+        "summary": "Get information from the VM guest agent",
+        "operationId": "getVMAgentInfo",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
@@ -887,31 +1122,34 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "Guest agent information",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/VMAgentInfo"
             }
           },
-          "400": {
-            "description": "Invalid ID supplied"
-          },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/disks": {
+    "/vms/{vmID}/balloon": {
       "get": {
-        "description": "Returns a list of VM Attached Disks",
+        "description": "Returns the target and actual size of the balloon device of a VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VM Attached Disks",
-        "operationId": "getVMDiskList",
+        "summary": "Get the memory balloon of a VM",
+        "operationId": "getVMBalloon",
         "parameters": [
           {
             "type": "string",
@@ -919,32 +1157,21 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
-            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of Disks",
+            "description": "VM balloon",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/VMDisk"
-              }
+              "$ref": "#/definitions/VMBalloon"
             }
           },
+          "400": {
+            "description": "VM has no balloon device"
+          },
+          "404": {
+            "description": "VM not found"
+          },
           "default": {
             "description": "unexpected error",
             "schema": {
@@ -953,16 +1180,16 @@ func init() {
           }
         }
       },
-      "post": {
-        "description": "Create or attach a VM Disk",
+      "put": {
+        "description": "Sets the memory the balloon device takes from the guest - a running VM is resized straight away",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create or attach a VM Disk",
-        "operationId": "createVMDisk",
+        "summary": "Resize the memory balloon of a VM",
+        "operationId": "updateVMBalloon",
         "parameters": [
           {
             "type": "string",
@@ -972,28 +1199,34 @@ func init() {
             "required": true
           },
           {
-            "description": "Create new VM instance",
-            "name": "vmDiskConfid",
+            "description": "New balloon target",
+            "name": "balloonConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/NewVMDisk"
+              "$ref": "#/definitions/UpdateVMBalloon"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "VM balloon",
             "schema": {
-              "$ref": "#/definitions/VMDisk"
+              "$ref": "#/definitions/VMBalloon"
             }
           },
           "400": {
-            "description": "Invalid ID supplied"
+            "description": "Invalid balloon target"
           },
           "404": {
             "description": "VM not found"
           },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "default": {
             "description": "unexpected error",
             "schema": {
@@ -1003,7 +1236,145 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/disks/{diskID}": {
+    "/vms/{vmID}/console": {
+      "get": {
+        "description": "Get a console for a VM instance",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a console for a VM instance",
+        "operationId": "getVMConsole",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/disks": {
+      "get": {
+        "description": "Returns a list of VM Attached Disks",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a list of VM Attached Disks",
+        "operationId": "getVMDiskList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of Disks",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMDisk"
+              }
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create or attach a VM Disk",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Create or attach a VM Disk",
+        "operationId": "createVMDisk",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Create new VM instance",
+            "name": "vmDiskConfid",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewVMDisk"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VMDisk"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/disks/{diskID}": {
       "get": {
         "description": "Returns an isntance of VM Disk",
         "produces": [
@@ -1409,17 +1780,17 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/reset": {
+    "/vms/{vmID}/logs": {
       "get": {
-        "description": "Forcefully Reset an instance of VM",
+        "description": "Returns the serial console output and firecracker log of a VM instance. When follow is set new lines are streamed as newline delimited JSON until the client disconnects",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Reset a VM instance",
-        "operationId": "resetVM",
+        "summary": "Return VM logs",
+        "operationId": "getVMLogs",
         "parameters": [
           {
             "type": "string",
@@ -1427,13 +1798,49 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "enum": [
+              "serial",
+              "firecracker",
+              "all"
+            ],
+            "type": "string",
+            "default": "serial",
+            "description": "The log to return",
+            "name": "source",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return this many of the most recent lines",
+            "name": "tail",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return lines written at or after this time",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep the request open and stream new lines as they are written",
+            "name": "follow",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMLogEntry"
+              }
             }
           },
           "400": {
@@ -1445,17 +1852,17 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/restart": {
+    "/vms/{vmID}/metrics": {
       "get": {
-        "description": "Gracefully Restart an instance of VM",
+        "description": "Returns the metrics collected from the VMM of a VM instance",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Restart a VM instance",
-        "operationId": "restartVM",
+        "summary": "Return VM metrics",
+        "operationId": "getVMMetrics",
         "parameters": [
           {
             "type": "string",
@@ -1463,13 +1870,20 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Flush the VMM metrics before returning them",
+            "name": "flush",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/VMMetrics"
             }
           },
           "400": {
@@ -1481,17 +1895,17 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/shutdown": {
+    "/vms/{vmID}/pause": {
       "get": {
-        "description": "Gracefully Shutdown an instance of VM",
+        "description": "Pauses the vCPUs of a running instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Shutdown a VM instance",
-        "operationId": "shutdownVM",
+        "summary": "Pause a VM instance",
+        "operationId": "pauseVM",
         "parameters": [
           {
             "type": "string",
@@ -1513,21 +1927,27 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM or it isnt running",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/start": {
+    "/vms/{vmID}/reset": {
       "get": {
-        "description": "Starts an isntance of VM",
+        "description": "Forcefully Reset an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Start a VM instance",
-        "operationId": "startVM",
+        "summary": "Reset a VM instance",
+        "operationId": "resetVM",
         "parameters": [
           {
             "type": "string",
@@ -1549,25 +1969,31 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/stop": {
+    "/vms/{vmID}/resources": {
       "get": {
-        "description": "Stops an isntance of VM",
+        "description": "Returns the cgroup resource limits of a VM and, while it is running, the values in effect and its usage",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Stop a VM instance",
-        "operationId": "stopVM",
+        "summary": "Get the resource limits of a VM",
+        "operationId": "getVMResources",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
@@ -1575,31 +2001,26 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "VM resources",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/VMResources"
             }
           },
-          "400": {
-            "description": "Invalid ID supplied"
-          },
           "404": {
             "description": "VM not found"
           }
         }
-      }
-    },
-    "/vms/{vmID}/volumes": {
-      "get": {
-        "description": "Returns a list of VM Volumes",
+      },
+      "put": {
+        "description": "Replaces the cgroup resource limits of a VM - a running VM has them applied straight away",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VM Volumes",
-        "operationId": "getVMVolumeList",
+        "summary": "Change the resource limits of a VM",
+        "operationId": "updateVMResources",
         "parameters": [
           {
             "type": "string",
@@ -1609,71 +2030,62 @@ func init() {
             "required": true
           },
           {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
-            "in": "query"
+            "description": "New resource limits",
+            "name": "resourceLimits",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/VMResourceLimits"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of VM Volumes",
+            "description": "VM resources",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/VMVolume"
-              }
+              "$ref": "#/definitions/VMResources"
             }
           },
-          "default": {
-            "description": "unexpected error",
+          "400": {
+            "description": "Invalid resource limits"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "post": {
-        "description": "Create or attach a VM Volume",
+      }
+    },
+    "/vms/{vmID}/restart": {
+      "get": {
+        "description": "Gracefully Restart an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create or attach a VM Volume",
-        "operationId": "createVMVolume",
+        "summary": "Restart a VM instance",
+        "operationId": "restartVM",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "VM Volume Config",
-            "name": "volumeConfig",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/NewVMVolume"
-            }
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMVolume"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
@@ -1682,8 +2094,8 @@ func init() {
           "404": {
             "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -1691,17 +2103,17 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/volumes/{volumeID}": {
+    "/vms/{vmID}/resume": {
       "get": {
-        "description": "Returns an isntance of VM",
+        "description": "Resumes a paused instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM instance",
-        "operationId": "getVMVolume",
+        "summary": "Resume a VM instance",
+        "operationId": "resumeVM",
         "parameters": [
           {
             "type": "string",
@@ -1709,11 +2121,46 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
           },
+          "409": {
+            "description": "Another operation is in progress on the VM or it isnt paused",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/shutdown": {
+      "get": {
+        "description": "Gracefully Shutdown an instance of VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Shutdown a VM instance",
+        "operationId": "shutdownVM",
+        "parameters": [
           {
             "type": "string",
-            "description": "ID of VM Volume to use",
-            "name": "volumeID",
+            "description": "ID of VM to return",
+            "name": "vmID",
             "in": "path",
             "required": true
           }
@@ -1722,7 +2169,7 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMVolume"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
@@ -1731,61 +2178,102 @@ func init() {
           "404": {
             "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "put": {
-        "description": "Update an instance of VM interface",
+      }
+    },
+    "/vms/{vmID}/snapshots": {
+      "get": {
+        "description": "Returns a list of snapshots taken of a VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Update a VM Interface instance",
-        "operationId": "updateVMVolume",
+        "summary": "Get a list of VM Snapshots",
+        "operationId": "getVMSnapshotList",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of Snapshots",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMSnapshot"
+              }
+            }
+          },
+          "404": {
+            "description": "VM not found"
           },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Snapshots the memory, device state and disks of a running VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Snapshot a VM",
+        "operationId": "createVMSnapshot",
+        "parameters": [
           {
             "type": "string",
-            "description": "ID of VM Volume to use",
-            "name": "volumeID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "description": "Pet to add to the store",
-            "name": "vmInterfaceConfig",
+            "description": "Create new VM snapshot",
+            "name": "snapshotConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateVMVolume"
+              "$ref": "#/definitions/NewVMSnapshot"
             }
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The snapshot is being taken - the result of the operation is the snapshot",
             "schema": {
-              "$ref": "#/definitions/VMVolume"
+              "$ref": "#/definitions/Operation"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM Interface not found"
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
           "default": {
             "description": "unexpected error",
@@ -1794,29 +2282,31 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/vms/{vmID}/snapshots/{snapshotID}": {
       "delete": {
-        "description": "Returns an isntance of VM",
+        "description": "Deletes a snapshot and the files it uses in the storage target",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM instance",
-        "operationId": "deleteVMVolume",
+        "summary": "Delete a VM Snapshot",
+        "operationId": "deleteVMSnapshot",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
             "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "volumeID",
+            "description": "ID of VM Snapshot to use",
+            "name": "snapshotID",
             "in": "path",
             "required": true
           }
@@ -1832,7 +2322,13 @@ func init() {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM not found"
+            "description": "VM or Snapshot not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
           "default": {
             "description": "unexpected error",
@@ -1842,174 +2338,1389 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "CloudInitUserData": {
-      "type": "object",
-      "properties": {
-        "BootCmd": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "DisableRoot": {
-          "type": "boolean"
-        },
-        "Groups": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Locale": {
-          "type": "string"
-        },
-        "PackageUpdate": {
-          "type": "boolean"
-        },
-        "PackageUpgrade": {
-          "type": "boolean"
-        },
-        "Packages": {
-          "type": "array",
-          "items": {
-            "type": "string"
+    },
+    "/vms/{vmID}/snapshots/{snapshotID}/restore": {
+      "post": {
+        "description": "Restores a VM from a snapshot, or restores the snapshot into a new clone of the VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Restore a VM Snapshot",
+        "operationId": "restoreVMSnapshot",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Snapshot to use",
+            "name": "snapshotID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Restore options",
+            "name": "restoreConfig",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/RestoreVMSnapshot"
+            }
           }
-        },
-        "RunCmd": {
-          "type": "array",
-          "items": {
-            "type": "string"
+        ],
+        "responses": {
+          "202": {
+            "description": "The snapshot is being restored - the result of the operation is the VM",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM or Snapshot not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
-        },
-        "SshAuthorisedKeys": {
-          "type": "array",
-          "items": {
-            "type": "string"
+        }
+      }
+    },
+    "/vms/{vmID}/start": {
+      "get": {
+        "description": "Starts an isntance of VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Start a VM instance",
+        "operationId": "startVM",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
           }
-        },
-        "Users": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/CloudInitUserDataUser"
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "CloudInitUserDataUser": {
+    "/vms/{vmID}/stop": {
+      "get": {
+        "description": "Stops an isntance of VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Stop a VM instance",
+        "operationId": "stopVM",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/volumes": {
+      "get": {
+        "description": "Returns a list of VM Volumes",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a list of VM Volumes",
+        "operationId": "getVMVolumeList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of VM Volumes",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMVolume"
+              }
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create or attach a VM Volume",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Create or attach a VM Volume",
+        "operationId": "createVMVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "VM Volume Config",
+            "name": "volumeConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewVMVolume"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VMVolume"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/volumes/{volumeID}": {
+      "get": {
+        "description": "Returns an isntance of VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Return a VM instance",
+        "operationId": "getVMVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Volume to use",
+            "name": "volumeID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VMVolume"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Update an instance of VM interface",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Update a VM Interface instance",
+        "operationId": "updateVMVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Volume to use",
+            "name": "volumeID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Pet to add to the store",
+            "name": "vmInterfaceConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UpdateVMVolume"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VMVolume"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM Interface not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Returns an isntance of VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Return a VM instance",
+        "operationId": "deleteVMVolume",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Interface to use",
+            "name": "volumeID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/item"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/vsock/proxies": {
+      "get": {
+        "description": "Returns the host ports being proxied to vsock ports in the VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a list of VM vsock proxies",
+        "operationId": "getVMVsockProxyList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of vsock proxies",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VsockProxy"
+              }
+            }
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Listens on a TCP port on the host and forwards connections to a vsock port in the VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Proxy a host port to a VM vsock port",
+        "operationId": "createVMVsockProxy",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Create new vsock proxy",
+            "name": "proxyConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewVsockProxy"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VsockProxy"
+            }
+          },
+          "400": {
+            "description": "Invalid proxy supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/vms/{vmID}/vsock/proxies/{proxyID}": {
+      "delete": {
+        "description": "Stops proxying a host port and drops its connections",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Delete a VM vsock proxy",
+        "operationId": "deleteVMVsockProxy",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of vsock proxy to delete",
+            "name": "proxyID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/item"
+            }
+          },
+          "404": {
+            "description": "VM or proxy not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "CloudInitUserData": {
+      "type": "object",
+      "properties": {
+        "BootCmd": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "DisableRoot": {
+          "type": "boolean"
+        },
+        "Groups": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Locale": {
+          "type": "string"
+        },
+        "PackageUpdate": {
+          "type": "boolean"
+        },
+        "PackageUpgrade": {
+          "type": "boolean"
+        },
+        "Packages": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "RunCmd": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "SshAuthorisedKeys": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Users": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CloudInitUserDataUser"
+          }
+        }
+      }
+    },
+    "CloudInitUserDataUser": {
+      "type": "object",
+      "properties": {
+        "ExpireDate": {
+          "type": "string"
+        },
+        "Gecos": {
+          "type": "string"
+        },
+        "Groups": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Inactive": {
+          "type": "boolean"
+        },
+        "LockPassword": {
+          "type": "boolean"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Password": {
+          "type": "string"
+        },
+        "PrimaryGroup": {
+          "type": "string"
+        },
+        "Shell": {
+          "type": "string"
+        },
+        "SshAuthorisedKeys": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Sudo": {
+          "type": "string"
+        },
+        "System": {
+          "type": "boolean"
+        }
+      }
+    },
+    "ConsoleRecording": {
+      "type": "object",
+      "properties": {
+        "cast": {
+          "description": "The asciicast v2 recording - only returned when getting a single recording",
+          "type": "string"
+        },
+        "duration": {
+          "type": "number",
+          "format": "double"
+        },
+        "height": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "startedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "title": {
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "width": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "ConsoleRecording"
+      }
+    },
+    "DiskImage": {
+      "type": "object",
+      "properties": {
+        "Hash": {
+          "type": "string"
+        },
+        "IsRoot": {
+          "type": "boolean"
+        },
+        "Size": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "DoctorProblem": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "error": {
+          "description": "Why fixing the problem failed",
+          "type": "string"
+        },
+        "fixable": {
+          "description": "Whether the problem can be cleaned up by fixing",
+          "type": "boolean"
+        },
+        "fixed": {
+          "type": "boolean"
+        },
+        "kind": {
+          "description": "orphaned-chroot, orphaned-disk, missing-disk, stale-tap or leaked-nbd",
+          "type": "string"
+        },
+        "resource": {
+          "description": "Path or device the problem is with",
+          "type": "string"
+        },
+        "vmID": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "DoctorProblem"
+      }
+    },
+    "DoctorReport": {
+      "type": "object",
+      "properties": {
+        "checkedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "problems": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DoctorProblem"
+          }
+        }
+      },
+      "xml": {
+        "name": "DoctorReport"
+      }
+    },
+    "Image": {
+      "type": "object",
+      "properties": {
+        "Architecture": {
+          "type": "string",
+          "enum": [
+            "x86_64",
+            "aarch64"
+          ]
+        },
+        "BootParams": {
+          "type": "string"
+        },
+        "CloudInitUserData": {
+          "$ref": "#/definitions/CloudInitUserData"
+        },
+        "Contains": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ImageContains"
+          }
+        },
+        "CreatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "ID": {
+          "type": "string"
+        },
+        "Kernel": {
+          "$ref": "#/definitions/KernelImage"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "OtherDisks": {
+          "type": "array",
+          "default": null,
+          "items": {
+            "$ref": "#/definitions/DiskImage"
+          }
+        },
+        "RootDisk": {
+          "$ref": "#/definitions/DiskImage"
+        },
+        "Size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "Source": {
+          "type": "string",
+          "enum": [
+            "promethium",
+            "docker",
+            "tar",
+            "raw",
+            "qcow2",
+            "capstan",
+            "kernel"
+          ]
+        },
+        "SourceURI": {
+          "type": "string"
+        },
+        "Type": {
+          "type": "string",
+          "enum": [
+            "standard",
+            "osv",
+            "qemu",
+            "kernel"
+          ]
+        },
+        "Version": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "Image"
+      }
+    },
+    "ImageContains": {
+      "type": "string",
+      "enum": [
+        "Kernel",
+        "RootDisk",
+        "AdditionalDisks",
+        "CloudInitUserData"
+      ]
+    },
+    "ImagePushPullTarget": {
+      "type": "object",
+      "properties": {
+        "Blob": {
+          "type": "string",
+          "format": "binary"
+        },
+        "SourceURI": {
+          "type": "string"
+        },
+        "TargetStorage": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "ImagePushPullTarget"
+      }
+    },
+    "KernelImage": {
+      "type": "object",
+      "properties": {
+        "Hash": {
+          "type": "string"
+        },
+        "Size": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "MetaDataNetworkBondsConfig": {
+      "type": "object",
+      "properties": {
+        "ad-select": {
+          "type": "string",
+          "enum": [
+            "stable",
+            "bandwidth",
+            "count"
+          ]
+        },
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "all-slaves-active": {
+          "type": "boolean"
+        },
+        "arp-all-targets": {
+          "type": "string",
+          "enum": [
+            "any",
+            "all"
+          ]
+        },
+        "arp-interval": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "arp-ip-targets": {
+          "type": "array",
+          "maxItems": 16,
+          "items": {
+            "type": "string",
+            "format": "ipv4"
+          }
+        },
+        "arp-validate": {
+          "type": "string",
+          "enum": [
+            "none",
+            "active",
+            "backup",
+            "all"
+          ]
+        },
+        "dhcp4": {
+          "type": "boolean"
+        },
+        "dhcp6": {
+          "type": "boolean"
+        },
+        "down-delay": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "fail-over-mac-policy": {
+          "type": "string",
+          "enum": [
+            "none",
+            "active",
+            "follow"
+          ]
+        },
+        "gateway4": {
+          "type": "string",
+          "format": "ipv4"
+        },
+        "gateway6": {
+          "type": "string",
+          "format": "ipv6"
+        },
+        "gratuitous-arp": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "interfaces": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "lacp-rate": {
+          "type": "string",
+          "enum": [
+            "slow",
+            "fast"
+          ]
+        },
+        "learn-packet-interval": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "mii-monitor-interval": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "min-links": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "mode": {
+          "type": "string",
+          "enum": [
+            "balance-rr",
+            "active-backup",
+            "balance-xor",
+            "broadcast",
+            "802.3ad",
+            "balance-tlb",
+            "balance-alb"
+          ]
+        },
+        "mtu": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "nameservers": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        },
+        "packets-per-slave": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "primary-reselect-policy": {
+          "type": "string",
+          "enum": [
+            "always",
+            "better",
+            "failure"
+          ]
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
+          }
+        },
+        "transmit-hash-policy": {
+          "type": "string",
+          "enum": [
+            "layer2",
+            "layer2+3",
+            "layer3+4",
+            "encap2+3",
+            "encap3+4"
+          ]
+        },
+        "up-delay": {
+          "type": "integer",
+          "format": "int32"
+        }
+      },
+      "xml": {
+        "name": "bond"
+      }
+    },
+    "MetaDataNetworkBridgesConfig": {
       "type": "object",
       "properties": {
-        "ExpireDate": {
-          "type": "string"
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "Gecos": {
-          "type": "string"
+        "ageing-time": {
+          "type": "integer",
+          "format": "int32"
         },
-        "Groups": {
+        "dhcp4": {
+          "type": "boolean"
+        },
+        "dhcp6": {
+          "type": "boolean"
+        },
+        "forward-delay": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "gateway4": {
+          "type": "string",
+          "format": "ipv4"
+        },
+        "gateway6": {
+          "type": "string",
+          "format": "ipv6"
+        },
+        "hello-time": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "interfaces": {
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "Inactive": {
+        "max-age": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "mtu": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "nameservers": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        },
+        "path-cost": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "priority": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
+          }
+        },
+        "stp": {
+          "type": "boolean",
+          "default": true
+        }
+      },
+      "xml": {
+        "name": "bridge"
+      }
+    },
+    "MetaDataNetworkConfig": {
+      "type": "object",
+      "properties": {
+        "bonds": {
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/MetaDataNetworkBondsConfig"
+          }
+        },
+        "bridges": {
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/MetaDataNetworkBridgesConfig"
+          }
+        },
+        "interfaces": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
+          }
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
+          },
+          "xml": {
+            "name": "route",
+            "wrapped": true
+          }
+        },
+        "vlans": {
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/definitions/MetaDataNetworkVlansConfig"
+          }
+        }
+      },
+      "xml": {
+        "name": "network"
+      }
+    },
+    "MetaDataNetworkEthernetsConfig": {
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "dhcp4": {
           "type": "boolean"
         },
-        "LockPassword": {
+        "dhcp6": {
           "type": "boolean"
         },
-        "Name": {
+        "gateway4": {
+          "type": "string",
+          "format": "ipv4"
+        },
+        "gateway6": {
+          "type": "string",
+          "format": "ipv6"
+        },
+        "mtu": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "nameservers": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
+          }
+        }
+      },
+      "xml": {
+        "name": "ethernet"
+      }
+    },
+    "MetaDataNetworkEthernetsDNSConfig": {
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "search": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "hostname"
+          }
+        }
+      }
+    },
+    "MetaDataNetworkEthernetsMatchConfig": {
+      "type": "object",
+      "properties": {
+        "driver": {
           "type": "string"
         },
-        "Password": {
+        "macaddress": {
           "type": "string"
         },
-        "PrimaryGroup": {
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "MetaDataNetworkInterfaceConfig": {
+      "type": "object",
+      "properties": {
+        "config": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
+        },
+        "id": {
+          "type": "string"
+        },
+        "macAddress": {
+          "type": "string"
+        },
+        "networkID": {
+          "type": "string"
+        }
+      }
+    },
+    "MetaDataNetworkRoutesConfig": {
+      "type": "object",
+      "properties": {
+        "metric": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "to": {
+          "type": "string"
+        },
+        "via": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "route"
+      }
+    },
+    "MetaDataNetworkVlansConfig": {
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "dhcp4": {
+          "type": "boolean"
+        },
+        "dhcp6": {
+          "type": "boolean"
+        },
+        "gateway4": {
+          "type": "string",
+          "format": "ipv4"
+        },
+        "gateway6": {
+          "type": "string",
+          "format": "ipv6"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "link": {
           "type": "string"
         },
-        "Shell": {
-          "type": "string"
+        "mtu": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "nameservers": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
         },
-        "SshAuthorisedKeys": {
+        "routes": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
           }
-        },
-        "Sudo": {
-          "type": "string"
-        },
-        "System": {
-          "type": "boolean"
         }
+      },
+      "xml": {
+        "name": "vlan"
       }
     },
-    "DiskImage": {
+    "Network": {
       "type": "object",
       "properties": {
-        "Hash": {
-          "type": "string"
-        },
-        "IsRoot": {
+        "enabled": {
           "type": "boolean"
         },
-        "Size": {
-          "type": "integer",
-          "format": "int64"
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "masterInterface": {
+          "$ref": "#/definitions/NetworkMasterInterface"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "linux",
+            "ovs"
+          ]
         }
       }
     },
-    "Image": {
+    "NetworkIP4Config": {
       "type": "object",
       "properties": {
-        "Architecture": {
+        "address": {
           "type": "string",
-          "enum": [
-            "x86_64",
-            "aarch64"
-          ]
-        },
-        "BootParams": {
-          "type": "string"
+          "format": "ipv4"
         },
-        "CloudInitUserData": {
-          "$ref": "#/definitions/CloudInitUserData"
+        "dhcp": {
+          "type": "boolean"
         },
-        "Contains": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/ImageContains"
-          }
+        "enabled": {
+          "type": "boolean"
         },
-        "CreatedAt": {
+        "gateway": {
           "type": "string",
-          "format": "date-time"
-        },
-        "ID": {
-          "type": "string"
-        },
-        "Kernel": {
-          "$ref": "#/definitions/KernelImage"
+          "format": "ipv4"
         },
-        "Name": {
+        "vlan": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    },
+    "NetworkInterface": {
+      "type": "object"
+    },
+    "NetworkMasterInterface": {
+      "type": "object",
+      "properties": {
+        "device": {
           "type": "string"
         },
-        "OtherDisks": {
-          "type": "array",
-          "default": null,
-          "items": {
-            "$ref": "#/definitions/DiskImage"
-          }
-        },
-        "RootDisk": {
-          "$ref": "#/definitions/DiskImage"
+        "enabled": {
+          "type": "boolean"
+        }
+      }
+    },
+    "NewImage": {
+      "type": "object",
+      "required": [
+        "operation"
+      ],
+      "properties": {
+        "buildContext": {
+          "type": "string",
+          "format": "byte"
         },
-        "Size": {
-          "type": "integer",
-          "format": "int64"
+        "buildContextImageType": {
+          "type": "string",
+          "enum": [
+            "standard",
+            "osv",
+            "qemu"
+          ]
         },
-        "Source": {
+        "buildContextType": {
           "type": "string",
           "enum": [
             "promethium",
@@ -2017,387 +3728,546 @@ func init() {
             "tar",
             "raw",
             "qcow2",
-            "capstan",
-            "kernel"
+            "capstan"
           ]
         },
-        "SourceURI": {
+        "cloneFrom": {
           "type": "string"
         },
-        "Type": {
+        "operation": {
           "type": "string",
           "enum": [
-            "standard",
-            "osv",
-            "qemu",
-            "kernel"
+            "clone",
+            "build"
           ]
+        }
+      },
+      "xml": {
+        "name": "NewImage"
+      }
+    },
+    "NewNetwork": {
+      "type": "object",
+      "required": [
+        "type",
+        "physicalInterface"
+      ],
+      "properties": {
+        "physicalInterface": {
+          "type": "string"
         },
-        "Version": {
+        "type": {
+          "type": "string",
+          "enum": [
+            "linux",
+            "ovs"
+          ]
+        }
+      }
+    },
+    "NewStorage": {
+      "type": "object"
+    },
+    "NewVM": {
+      "type": "object",
+      "properties": {
+        "autoStart": {
+          "type": "boolean"
+        },
+        "cpus": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "fromImage": {
+          "type": "string"
+        },
+        "kernelImage": {
+          "type": "string"
+        },
+        "memory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "primaryNetworkID": {
+          "type": "string"
+        },
+        "rootDiskSize": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "storageName": {
+          "type": "string"
+        },
+        "type": {
+          "description": "Only needed for mock VMs which are created without an image - other VMs get their type from their image",
           "type": "string"
         }
       },
       "xml": {
-        "name": "Image"
+        "name": "NewVM"
       }
     },
-    "ImageContains": {
-      "type": "string",
-      "enum": [
-        "Kernel",
-        "RootDisk",
-        "AdditionalDisks",
-        "CloudInitUserData"
-      ]
+    "NewVMDisk": {
+      "type": "object",
+      "properties": {
+        "backendStorageID": {
+          "type": "string"
+        },
+        "isRoot": {
+          "type": "boolean"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "NewVMDisk"
+      }
     },
-    "ImagePushPullTarget": {
+    "NewVMInterface": {
       "type": "object",
       "properties": {
-        "Blob": {
-          "type": "string",
-          "format": "binary"
+        "config": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
         },
-        "SourceURI": {
+        "macAddress": {
           "type": "string"
         },
-        "TargetStorage": {
+        "networkID": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "NewVMInterface"
+      }
+    },
+    "NewVMSnapshot": {
+      "type": "object",
+      "properties": {
+        "name": {
           "type": "string"
         }
       },
       "xml": {
-        "name": "ImagePushPullTarget"
+        "name": "NewVMSnapshot"
+      }
+    },
+    "NewVMVolume": {
+      "type": "object",
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VMVolume"
       }
     },
-    "KernelImage": {
+    "NewVsockProxy": {
       "type": "object",
+      "required": [
+        "guestPort"
+      ],
       "properties": {
-        "Hash": {
+        "bindAddress": {
+          "description": "Address on the host to listen on - defaults to 127.0.0.1",
           "type": "string"
         },
-        "Size": {
+        "guestPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 4294967295,
+          "minimum": 1
+        },
+        "hostPort": {
+          "description": "Port on the host to listen on - 0 picks a free port",
           "type": "integer",
           "format": "int64"
         }
+      },
+      "xml": {
+        "name": "NewVsockProxy"
       }
     },
-    "MetaDataNetworkBondsConfig": {
+    "Operation": {
       "type": "object",
       "properties": {
-        "ad-select": {
-          "type": "string",
-          "enum": [
-            "stable",
-            "bandwidth",
-            "count"
-          ]
-        },
-        "addresses": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "all-slaves-active": {
+        "completed": {
+          "description": "Whether the operation has finished - it may have failed or been cancelled",
           "type": "boolean"
         },
-        "arp-all-targets": {
+        "completedAt": {
           "type": "string",
-          "enum": [
-            "any",
-            "all"
-          ]
+          "format": "date-time"
         },
-        "arp-interval": {
-          "type": "integer",
-          "format": "int32"
+        "createdAt": {
+          "type": "string",
+          "format": "date-time"
         },
-        "arp-ip-targets": {
-          "type": "array",
-          "maxItems": 16,
-          "items": {
-            "type": "string",
-            "format": "ipv4"
-          }
+        "description": {
+          "type": "string"
         },
-        "arp-validate": {
-          "type": "string",
-          "enum": [
-            "none",
-            "active",
-            "backup",
-            "all"
-          ]
+        "error": {
+          "type": "string"
         },
-        "dhcp4": {
-          "type": "boolean"
+        "id": {
+          "type": "string"
         },
-        "dhcp6": {
-          "type": "boolean"
+        "kind": {
+          "description": "create-vm, import-image, snapshot-vm, restore-vm or clone-vm",
+          "type": "string"
         },
-        "down-delay": {
+        "message": {
+          "description": "What the operation is doing",
+          "type": "string"
+        },
+        "progress": {
+          "description": "Percentage done",
           "type": "integer",
           "format": "int32"
         },
-        "fail-over-mac-policy": {
+        "result": {
+          "description": "What the operation produced once it has completed",
+          "type": "object"
+        },
+        "status": {
           "type": "string",
           "enum": [
-            "none",
-            "active",
-            "follow"
+            "running",
+            "completed",
+            "failed",
+            "cancelled"
           ]
         },
-        "gateway4": {
-          "type": "string",
-          "format": "ipv4"
-        },
-        "gateway6": {
-          "type": "string",
-          "format": "ipv6"
-        },
-        "gratuitous-arp": {
-          "type": "integer",
-          "format": "int32"
-        },
-        "interfaces": {
+        "target": {
+          "description": "ID of what the operation is on when it is known up front",
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "Operation"
+      }
+    },
+    "PhysicalInterface": {
+      "type": "object",
+      "properties": {
+        "addresses": {
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "lacp-rate": {
-          "type": "string",
-          "enum": [
-            "slow",
-            "fast"
-          ]
+        "macAddress": {
+          "type": "string"
         },
-        "learn-packet-interval": {
+        "mtu": {
           "type": "integer",
           "format": "int32"
         },
-        "mii-monitor-interval": {
-          "type": "integer",
-          "format": "int32"
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "RestoreVMSnapshot": {
+      "type": "object",
+      "properties": {
+        "cloneName": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "RestoreVMSnapshot"
+      }
+    },
+    "Storage": {
+      "type": "object"
+    },
+    "StorageDisk": {
+      "type": "object"
+    },
+    "StorageImage": {
+      "type": "object"
+    },
+    "StorageKernel": {
+      "type": "object"
+    },
+    "UpdateNetwork": {
+      "type": "object"
+    },
+    "UpdateStorage": {
+      "type": "object"
+    },
+    "UpdateVM": {
+      "type": "object",
+      "properties": {
+        "autoStart": {
+          "type": "boolean"
         },
-        "min-links": {
+        "bootCmd": {
+          "type": "string"
+        },
+        "cpus": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "mode": {
-          "type": "string",
-          "enum": [
-            "balance-rr",
-            "active-backup",
-            "balance-xor",
-            "broadcast",
-            "802.3ad",
-            "balance-tlb",
-            "balance-alb"
-          ]
+        "entryPoint": {
+          "type": "string"
         },
-        "mtu": {
+        "memory": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "nameservers": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        "name": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "UpdateVM"
+      }
+    },
+    "UpdateVMBalloon": {
+      "type": "object",
+      "required": [
+        "amountMib"
+      ],
+      "properties": {
+        "amountMib": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "UpdateVMBalloon"
+      }
+    },
+    "UpdateVMDisk": {
+      "type": "object",
+      "properties": {
+        "isRoot": {
+          "type": "boolean"
         },
-        "packets-per-slave": {
+        "size": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "primary-reselect-policy": {
-          "type": "string",
-          "enum": [
-            "always",
-            "better",
-            "failure"
-          ]
+        "storageURI": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "UpdateVMDisk"
+      }
+    },
+    "UpdateVMInterface": {
+      "type": "object",
+      "properties": {
+        "config": {
+          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
         },
-        "routes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
-          }
+        "macAddress": {
+          "type": "string"
         },
-        "transmit-hash-policy": {
+        "networkID": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "UpdateVMInterface"
+      }
+    },
+    "UpdateVMVolume": {
+      "type": "object",
+      "properties": {
+        "id": {
           "type": "string",
-          "enum": [
-            "layer2",
-            "layer2+3",
-            "layer3+4",
-            "encap2+3",
-            "encap3+4"
-          ]
-        },
-        "up-delay": {
-          "type": "integer",
-          "format": "int32"
+          "format": "uuid4"
         }
       },
       "xml": {
-        "name": "bond"
+        "name": "VMVolume"
       }
     },
-    "MetaDataNetworkBridgesConfig": {
+    "VM": {
       "type": "object",
       "properties": {
-        "addresses": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "autoStart": {
+          "type": "boolean"
         },
-        "ageing-time": {
+        "balloonActualMib": {
+          "description": "Memory currently held by the balloon device in MiB",
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "dhcp4": {
-          "type": "boolean"
+        "balloonTargetMib": {
+          "description": "Memory the balloon device is asked to take from the guest in MiB",
+          "type": "integer",
+          "format": "int64"
         },
-        "dhcp6": {
+        "bootCmd": {
+          "type": "string"
+        },
+        "clusterID": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "clustered": {
           "type": "boolean"
         },
-        "forward-delay": {
+        "cpus": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "gateway4": {
-          "type": "string",
-          "format": "ipv4"
+        "crashCount": {
+          "description": "Number of consecutive unexpected exits",
+          "type": "integer",
+          "format": "int64"
         },
-        "gateway6": {
+        "createdAt": {
           "type": "string",
-          "format": "ipv6"
-        },
-        "hello-time": {
-          "type": "integer",
-          "format": "int32"
+          "format": "date-time"
         },
-        "interfaces": {
+        "disks": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/VMDisk"
+          },
+          "xml": {
+            "name": "disk",
+            "wrapped": true
           }
         },
-        "max-age": {
-          "type": "integer",
-          "format": "int32"
+        "entryPoint": {
+          "type": "string"
         },
-        "mtu": {
-          "type": "integer",
-          "format": "int32"
+        "health": {
+          "description": "VM health check status",
+          "type": "string",
+          "enum": [
+            "none",
+            "starting",
+            "healthy",
+            "unhealthy"
+          ]
         },
-        "nameservers": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        "healthMessage": {
+          "description": "Last health check failure",
+          "type": "string"
         },
-        "path-cost": {
-          "type": "integer",
-          "format": "int32"
+        "hostNode": {
+          "type": "string"
         },
-        "priority": {
+        "hostNodeID": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "lastExitReason": {
+          "description": "Reason the VM process last exited",
+          "type": "string"
+        },
+        "memory": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "routes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
-          }
+        "name": {
+          "type": "string"
         },
-        "stp": {
-          "type": "boolean",
-          "default": true
-        }
-      },
-      "xml": {
-        "name": "bridge"
-      }
-    },
-    "MetaDataNetworkConfig": {
-      "type": "object",
-      "properties": {
-        "bonds": {
-          "type": "object",
-          "additionalProperties": {
-            "$ref": "#/definitions/MetaDataNetworkBondsConfig"
-          }
+        "network": {
+          "$ref": "#/definitions/MetaDataNetworkConfig"
         },
-        "bridges": {
-          "type": "object",
-          "additionalProperties": {
-            "$ref": "#/definitions/MetaDataNetworkBridgesConfig"
-          }
+        "restartPolicy": {
+          "description": "What to do when the VM process exits unexpectedly",
+          "type": "string",
+          "enum": [
+            "no",
+            "on-failure",
+            "always",
+            "unless-stopped"
+          ]
         },
-        "interfaces": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
-          }
+        "startedAt": {
+          "type": "string",
+          "format": "date-time"
         },
-        "routes": {
+        "status": {
+          "description": "VM status",
+          "type": "string",
+          "enum": [
+            "starting",
+            "started",
+            "restarting",
+            "stopping",
+            "stopped",
+            "paused"
+          ]
+        },
+        "stoppedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "firecracker-standard",
+            "firecracker-osv",
+            "qemu-standard"
+          ]
+        },
+        "volumes": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
+            "$ref": "#/definitions/VMVolume"
           },
           "xml": {
-            "name": "route",
+            "name": "volume",
             "wrapped": true
           }
         },
-        "vlans": {
-          "type": "object",
-          "additionalProperties": {
-            "$ref": "#/definitions/MetaDataNetworkVlansConfig"
-          }
+        "vsockCID": {
+          "description": "Guest CID of the vsock device - 0 when the VM has no vsock device",
+          "type": "integer",
+          "format": "int64"
         }
       },
       "xml": {
-        "name": "network"
+        "name": "VM"
       }
     },
-    "MetaDataNetworkEthernetsConfig": {
+    "VMAgentInfo": {
       "type": "object",
       "properties": {
-        "addresses": {
+        "hostname": {
+          "type": "string"
+        },
+        "interfaces": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/VMAgentInterface"
           }
         },
-        "dhcp4": {
-          "type": "boolean"
-        },
-        "dhcp6": {
-          "type": "boolean"
-        },
-        "gateway4": {
-          "type": "string",
-          "format": "ipv4"
-        },
-        "gateway6": {
-          "type": "string",
-          "format": "ipv6"
-        },
-        "mtu": {
-          "type": "integer",
-          "format": "int32"
-        },
-        "nameservers": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        "uptime": {
+          "description": "Seconds since the guest booted",
+          "type": "number",
+          "format": "double"
         },
-        "routes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
-          }
+        "version": {
+          "type": "string"
         }
       },
       "xml": {
-        "name": "ethernet"
+        "name": "VMAgentInfo"
       }
     },
-    "MetaDataNetworkEthernetsDNSConfig": {
+    "VMAgentInterface": {
       "type": "object",
       "properties": {
         "addresses": {
@@ -2406,802 +4276,1323 @@ func init() {
             "type": "string"
           }
         },
-        "search": {
-          "type": "array",
-          "items": {
-            "type": "string",
-            "format": "hostname"
-          }
-        }
-      }
-    },
-    "MetaDataNetworkEthernetsMatchConfig": {
-      "type": "object",
-      "properties": {
-        "driver": {
+        "mac": {
           "type": "string"
         },
-        "macaddress": {
-          "type": "string"
+        "mtu": {
+          "type": "integer",
+          "format": "int64"
         },
         "name": {
           "type": "string"
+        },
+        "up": {
+          "type": "boolean"
         }
+      },
+      "xml": {
+        "name": "VMAgentInterface"
       }
     },
-    "MetaDataNetworkInterfaceConfig": {
+    "VMBalloon": {
       "type": "object",
       "properties": {
-        "config": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
+        "actualMib": {
+          "description": "Memory currently held by the balloon in MiB",
+          "type": "integer",
+          "format": "int64"
         },
-        "id": {
-          "type": "string"
+        "amountMib": {
+          "description": "Memory the balloon is asked to take from the guest in MiB",
+          "type": "integer",
+          "format": "int64"
         },
-        "macAddress": {
-          "type": "string"
+        "deflateOnOom": {
+          "type": "boolean"
         },
-        "networkID": {
-          "type": "string"
+        "statsInterval": {
+          "description": "Seconds between balloon statistics updates - 0 when statistics are disabled",
+          "type": "integer",
+          "format": "int64"
         }
+      },
+      "xml": {
+        "name": "VMBalloon"
       }
     },
-    "MetaDataNetworkRoutesConfig": {
+    "VMDisk": {
       "type": "object",
       "properties": {
-        "metric": {
-          "type": "integer",
-          "format": "int32"
+        "id": {
+          "type": "string",
+          "format": "uuid4"
         },
-        "to": {
-          "type": "string"
+        "isRoot": {
+          "type": "boolean"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
         },
-        "via": {
+        "storageURI": {
           "type": "string"
         }
       },
       "xml": {
-        "name": "route"
+        "name": "VMDisk"
       }
     },
-    "MetaDataNetworkVlansConfig": {
+    "VMIOLimit": {
       "type": "object",
       "properties": {
-        "addresses": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "dhcp4": {
-          "type": "boolean"
-        },
-        "dhcp6": {
-          "type": "boolean"
-        },
-        "gateway4": {
-          "type": "string",
-          "format": "ipv4"
-        },
-        "gateway6": {
-          "type": "string",
-          "format": "ipv6"
+        "device": {
+          "description": "Disk image, block device or major:minor number - empty applies to every device backing the VM disks",
+          "type": "string"
         },
-        "id": {
+        "readBps": {
           "type": "integer",
-          "format": "int32"
-        },
-        "link": {
-          "type": "string"
+          "format": "int64"
         },
-        "mtu": {
+        "readIops": {
           "type": "integer",
-          "format": "int32"
+          "format": "int64"
         },
-        "nameservers": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsDNSConfig"
+        "writeBps": {
+          "type": "integer",
+          "format": "int64"
         },
-        "routes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/MetaDataNetworkRoutesConfig"
-          }
+        "writeIops": {
+          "type": "integer",
+          "format": "int64"
         }
       },
       "xml": {
-        "name": "vlan"
+        "name": "VMIOLimit"
       }
     },
-    "Network": {
+    "VMInterface": {
       "type": "object",
       "properties": {
-        "enabled": {
-          "type": "boolean"
+        "config": {
+          "$ref": ""
         },
         "id": {
           "type": "string",
           "format": "uuid4"
         },
-        "masterInterface": {
-          "$ref": "#/definitions/NetworkMasterInterface"
-        },
-        "type": {
+        "networkID": {
           "type": "string",
-          "enum": [
-            "linux",
-            "ovs"
-          ]
+          "format": "uuid4"
         }
+      },
+      "xml": {
+        "name": "VMInterface"
       }
     },
-    "NetworkIP4Config": {
+    "VMListItem": {
       "type": "object",
       "properties": {
-        "address": {
+        "createdAt": {
           "type": "string",
-          "format": "ipv4"
+          "format": "date-time"
         },
-        "dhcp": {
-          "type": "boolean"
+        "health": {
+          "description": "VM health check status",
+          "type": "string",
+          "enum": [
+            "none",
+            "starting",
+            "healthy",
+            "unhealthy"
+          ]
         },
-        "enabled": {
-          "type": "boolean"
+        "id": {
+          "type": "string",
+          "format": "uuid4"
         },
-        "gateway": {
+        "name": {
+          "type": "string"
+        },
+        "startedAt": {
           "type": "string",
-          "format": "ipv4"
+          "format": "date-time"
         },
-        "vlan": {
-          "type": "integer",
-          "format": "int32"
+        "status": {
+          "description": "VM status",
+          "type": "string",
+          "enum": [
+            "starting",
+            "started",
+            "restarting",
+            "stopping",
+            "stopped",
+            "paused"
+          ]
+        },
+        "stoppedAt": {
+          "type": "string",
+          "format": "date-time"
         }
+      },
+      "xml": {
+        "name": "VMListItem"
       }
     },
-    "NetworkInterface": {
-      "type": "object"
-    },
-    "NetworkMasterInterface": {
+    "VMLogEntry": {
       "type": "object",
       "properties": {
-        "device": {
+        "line": {
           "type": "string"
         },
-        "enabled": {
-          "type": "boolean"
+        "source": {
+          "type": "string"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
         }
+      },
+      "xml": {
+        "name": "VMLogEntry"
       }
     },
-    "NewImage": {
+    "VMMetrics": {
       "type": "object",
-      "required": [
-        "operation"
-      ],
       "properties": {
-        "buildContext": {
-          "type": "string",
-          "format": "byte"
+        "counters": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "number",
+            "format": "double"
+          }
         },
-        "buildContextImageType": {
-          "type": "string",
-          "enum": [
-            "standard",
-            "osv",
-            "qemu"
-          ]
+        "gauges": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "number",
+            "format": "double"
+          }
         },
-        "buildContextType": {
-          "type": "string",
-          "enum": [
-            "promethium",
-            "docker",
-            "tar",
-            "raw",
-            "qcow2",
-            "capstan"
-          ]
+        "parseErrors": {
+          "type": "integer",
+          "format": "int64"
         },
-        "cloneFrom": {
-          "type": "string"
+        "samples": {
+          "type": "integer",
+          "format": "int64"
         },
-        "operation": {
+        "updatedAt": {
           "type": "string",
-          "enum": [
-            "clone",
-            "build"
-          ]
+          "format": "date-time"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
         }
       },
       "xml": {
-        "name": "NewImage"
+        "name": "VMMetrics"
       }
     },
-    "NewNetwork": {
+    "VMResourceLimits": {
       "type": "object",
-      "required": [
-        "type",
-        "physicalInterface"
-      ],
       "properties": {
-        "physicalInterface": {
-          "type": "string"
+        "cpuMax": {
+          "description": "Most cpu time the VM can use in cpus - 0 is unlimited",
+          "type": "number",
+          "format": "double"
         },
-        "type": {
-          "type": "string",
-          "enum": [
-            "linux",
-            "ovs"
-          ]
+        "cpuWeight": {
+          "description": "Relative share of cpu time from 1 to 10000 - 0 uses the default of 100",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ioMax": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/VMIOLimit"
+          }
+        },
+        "memoryOverheadMib": {
+          "description": "Memory allowed on top of the guest memory - 0 leaves memory unlimited",
+          "type": "integer",
+          "format": "int64"
+        },
+        "pidsMax": {
+          "type": "integer",
+          "format": "int64"
         }
+      },
+      "xml": {
+        "name": "VMResourceLimits"
       }
     },
-    "NewStorage": {
-      "type": "object"
+    "VMResources": {
+      "type": "object",
+      "properties": {
+        "cpuUsageSeconds": {
+          "type": "number",
+          "format": "double"
+        },
+        "effective": {
+          "description": "Values of the cgroup control files of the running VM",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "limits": {
+          "$ref": "#/definitions/VMResourceLimits"
+        },
+        "memoryCurrent": {
+          "description": "Memory used by the VM process in bytes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "pidsCurrent": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "VMResources"
+      }
     },
-    "NewVM": {
+    "VMSnapshot": {
       "type": "object",
       "properties": {
-        "autoStart": {
+        "compatible": {
           "type": "boolean"
         },
         "cpus": {
           "type": "integer",
           "format": "int64"
         },
-        "fromImage": {
+        "createdAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "firecrackerVersion": {
           "type": "string"
         },
-        "kernelImage": {
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "memory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VMSnapshot"
+      }
+    },
+    "VMVolume": {
+      "type": "object",
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VMVolume"
+      }
+    },
+    "VsockProxy": {
+      "type": "object",
+      "properties": {
+        "bindAddress": {
           "type": "string"
         },
-        "memory": {
+        "connections": {
           "type": "integer",
           "format": "int64"
         },
-        "name": {
-          "type": "string"
-        },
-        "primaryNetworkID": {
-          "type": "string"
+        "guestPort": {
+          "type": "integer",
+          "format": "int64"
         },
-        "rootDiskSize": {
+        "hostPort": {
           "type": "integer",
           "format": "int64"
         },
-        "storageName": {
-          "type": "string"
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
         }
       },
       "xml": {
-        "name": "NewVM"
+        "name": "VsockProxy"
       }
     },
-    "NewVMDisk": {
+    "error": {
       "type": "object",
+      "required": [
+        "message"
+      ],
       "properties": {
-        "backendStorageID": {
-          "type": "string"
+        "code": {
+          "type": "integer",
+          "format": "int64"
         },
-        "isRoot": {
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "item": {
+      "type": "object",
+      "required": [
+        "description"
+      ],
+      "properties": {
+        "completed": {
           "type": "boolean"
         },
-        "size": {
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "id": {
           "type": "integer",
-          "format": "int64"
+          "format": "int64",
+          "readOnly": true
+        }
+      }
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "API for Promethium Daemon",
+    "title": "Promethium Daemon API",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/doctor": {
+      "get": {
+        "description": "Looks for orphaned jailer chroots, disks without a VM, VMs whose disks are missing, stale tap devices and leaked nbd devices",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Check for leaked resources",
+        "operationId": "getDoctorReport",
+        "responses": {
+          "200": {
+            "description": "Problems found",
+            "schema": {
+              "$ref": "#/definitions/DoctorReport"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Checks for leaked resources and cleans up the ones that can be cleaned up safely",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Clean up leaked resources",
+        "operationId": "fixDoctorProblems",
+        "responses": {
+          "200": {
+            "description": "Problems found and whether they were fixed",
+            "schema": {
+              "$ref": "#/definitions/DoctorReport"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/images": {
+      "get": {
+        "description": "Returns a list of Images",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "images"
+        ],
+        "summary": "Get a list of Images",
+        "operationId": "getImagesList",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of Images",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Image"
+              }
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create a new Image",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "images"
+        ],
+        "summary": "Create a new Image",
+        "operationId": "createImage",
+        "parameters": [
+          {
+            "description": "Create new Image",
+            "name": "imageConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewImage"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/images/pull": {
+      "post": {
+        "description": "Pull an image to the server",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "images"
+        ],
+        "summary": "Pull an image to the server",
+        "operationId": "pullImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Storage Target",
+            "name": "targetStorage",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "SourceURI for remote pull",
+            "name": "sourceURI",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/VM"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/images/push": {
+      "post": {
+        "description": "Push an image to the server",
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "images"
+        ],
+        "summary": "Push an image to the server",
+        "operationId": "pushImage",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "The file to upload.",
+            "name": "inFileBlob",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Storage Target",
+            "name": "targetStorage",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "SourceURI for remote pull",
+            "name": "sourceURI",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "The image is being imported",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "NewVMDisk"
       }
     },
-    "NewVMInterface": {
-      "type": "object",
-      "properties": {
-        "config": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
-        },
-        "macAddress": {
-          "type": "string"
-        },
-        "networkID": {
-          "type": "string"
+    "/networking": {
+      "get": {
+        "description": "Get all networks",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "getNetworkList",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Network"
+              }
+            }
+          }
         }
       },
-      "xml": {
-        "name": "NewVMInterface"
-      }
-    },
-    "NewVMVolume": {
-      "type": "object",
-      "properties": {
-        "id": {
-          "type": "string",
-          "format": "uuid4"
+      "post": {
+        "description": "Create new network",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "createNetwork",
+        "parameters": [
+          {
+            "description": "Create new Network (bridge)",
+            "name": "netConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewNetwork"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Network"
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "VMVolume"
       }
     },
-    "PhysicalInterface": {
-      "type": "object",
-      "properties": {
-        "addresses": {
-          "type": "array",
-          "items": {
-            "type": "string"
+    "/networking/physicalInterfaces": {
+      "get": {
+        "description": "Get all physical interfaces",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "getPhysicalInterfaces",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/PhysicalInterface"
+              }
+            }
           }
-        },
-        "macAddress": {
-          "type": "string"
-        },
-        "mtu": {
-          "type": "integer",
-          "format": "int32"
-        },
-        "name": {
-          "type": "string"
         }
       }
     },
-    "Storage": {
-      "type": "object"
-    },
-    "StorageDisk": {
-      "type": "object"
-    },
-    "StorageImage": {
-      "type": "object"
-    },
-    "StorageKernel": {
-      "type": "object"
-    },
-    "UpdateNetwork": {
-      "type": "object"
-    },
-    "UpdateStorage": {
-      "type": "object"
-    },
-    "UpdateVM": {
-      "type": "object",
-      "properties": {
-        "autoStart": {
-          "type": "boolean"
-        },
-        "bootCmd": {
-          "type": "string"
-        },
-        "cpus": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "entryPoint": {
-          "type": "string"
-        },
-        "memory": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "name": {
-          "type": "string"
+    "/networking/{networkID}": {
+      "get": {
+        "description": "Get a network (bridge)",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "getNetwork",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "networkID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Network"
+            }
+          }
         }
       },
-      "xml": {
-        "name": "UpdateVM"
-      }
-    },
-    "UpdateVMDisk": {
-      "type": "object",
-      "properties": {
-        "isRoot": {
-          "type": "boolean"
-        },
-        "size": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "storageURI": {
-          "type": "string"
+      "put": {
+        "description": "Update network",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "updateNetwork",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "networkID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Create new VM instance",
+            "name": "netConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UpdateNetwork"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Network"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Storage not found"
+          }
         }
       },
-      "xml": {
-        "name": "UpdateVMDisk"
+      "delete": {
+        "description": "Get a network (bridge)",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "destroyNetwork",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Network to delete",
+            "name": "networkID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Network"
+            }
+          }
+        }
       }
     },
-    "UpdateVMInterface": {
-      "type": "object",
-      "properties": {
-        "config": {
-          "$ref": "#/definitions/MetaDataNetworkEthernetsConfig"
-        },
-        "macAddress": {
-          "type": "string"
-        },
-        "networkID": {
-          "type": "string"
+    "/networking/{networkID}/interfaces": {
+      "get": {
+        "description": "Get a network (bridge) interfaces",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "networking"
+        ],
+        "operationId": "getNetworkInterfaces",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "networkID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/NetworkInterface"
+              }
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "UpdateVMInterface"
       }
     },
-    "UpdateVMVolume": {
-      "type": "object",
-      "properties": {
-        "id": {
-          "type": "string",
-          "format": "uuid4"
+    "/operations": {
+      "get": {
+        "description": "Returns the long running operations that are in progress or finished recently",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get a list of Operations",
+        "operationId": "getOperationList",
+        "responses": {
+          "200": {
+            "description": "Array of Operations",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Operation"
+              }
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "VMVolume"
       }
     },
-    "VM": {
-      "type": "object",
-      "properties": {
-        "autoStart": {
-          "type": "boolean"
-        },
-        "bootCmd": {
-          "type": "string"
-        },
-        "clusterID": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "clustered": {
-          "type": "boolean"
-        },
-        "cpus": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "createdAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "disks": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/VMDisk"
-          },
-          "xml": {
-            "name": "disk",
-            "wrapped": true
+    "/operations/{operationID}": {
+      "get": {
+        "description": "Returns the progress of an operation and its result or error once it has finished",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Return an Operation",
+        "operationId": "getOperation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Operation to return",
+            "name": "operationID",
+            "in": "path",
+            "required": true
           }
-        },
-        "entryPoint": {
-          "type": "string"
-        },
-        "hostNode": {
-          "type": "string"
-        },
-        "hostNodeID": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "id": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "memory": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "name": {
-          "type": "string"
-        },
-        "network": {
-          "$ref": "#/definitions/MetaDataNetworkConfig"
-        },
-        "startedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "status": {
-          "description": "VM status",
-          "type": "string",
-          "enum": [
-            "starting",
-            "started",
-            "restarting",
-            "stopping",
-            "stopped"
-          ]
-        },
-        "stoppedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "type": {
-          "type": "string",
-          "enum": [
-            "firecracker-standard",
-            "firecracker-osv",
-            "qemu-standard"
-          ]
-        },
-        "volumes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/VMVolume"
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
           },
-          "xml": {
-            "name": "volume",
-            "wrapped": true
+          "404": {
+            "description": "Operation not found"
           }
         }
       },
-      "xml": {
-        "name": "VM"
-      }
-    },
-    "VMDisk": {
-      "type": "object",
-      "properties": {
-        "id": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "isRoot": {
-          "type": "boolean"
-        },
-        "size": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "storageURI": {
-          "type": "string"
+      "delete": {
+        "description": "Asks an operation to stop - it is cancelled once it has cleaned up what it did",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Cancel an Operation",
+        "operationId": "cancelOperation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Operation to cancel",
+            "name": "operationID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Operation"
+            }
+          },
+          "404": {
+            "description": "Operation not found"
+          },
+          "409": {
+            "description": "The operation has already finished",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "VMDisk"
       }
     },
-    "VMInterface": {
-      "type": "object",
-      "properties": {
-        "config": {
-          "$ref": ""
-        },
-        "id": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "networkID": {
-          "type": "string",
-          "format": "uuid4"
+    "/recordings": {
+      "get": {
+        "description": "Returns the recordings of interactive console sessions, newest first",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a list of console recordings",
+        "operationId": "getConsoleRecordingList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return recordings of this VM",
+            "name": "vmID",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/ConsoleRecording"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      },
-      "xml": {
-        "name": "VMInterface"
       }
     },
-    "VMListItem": {
-      "type": "object",
-      "properties": {
-        "createdAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "id": {
-          "type": "string",
-          "format": "uuid4"
-        },
-        "name": {
-          "type": "string"
-        },
-        "startedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "status": {
-          "description": "VM status",
-          "type": "string",
-          "enum": [
-            "starting",
-            "started",
-            "restarting",
-            "stopping",
-            "stopped"
-          ]
-        },
-        "stoppedAt": {
-          "type": "string",
-          "format": "date-time"
+    "/recordings/{recordingID}": {
+      "get": {
+        "description": "Returns a console recording including the asciicast v2 recording itself",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Get a console recording",
+        "operationId": "getConsoleRecording",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the recording to return",
+            "name": "recordingID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/ConsoleRecording"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Recording not found"
+          }
         }
-      },
-      "xml": {
-        "name": "VMListItem"
       }
     },
-    "VMVolume": {
-      "type": "object",
-      "properties": {
-        "id": {
-          "type": "string",
-          "format": "uuid4"
+    "/storage": {
+      "get": {
+        "description": "Get all storage",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "storage"
+        ],
+        "operationId": "getStorageList",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Storage"
+              }
+            }
+          }
         }
       },
-      "xml": {
-        "name": "VMVolume"
-      }
-    },
-    "error": {
-      "type": "object",
-      "required": [
-        "message"
-      ],
-      "properties": {
-        "code": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "message": {
-          "type": "string"
+      "post": {
+        "description": "Create new storage",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "storage"
+        ],
+        "operationId": "createStorage",
+        "parameters": [
+          {
+            "description": "Create new VM instance",
+            "name": "storageConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewStorage"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Storage"
+            }
+          }
         }
       }
     },
-    "item": {
-      "type": "object",
-      "required": [
-        "description"
-      ],
-      "properties": {
-        "completed": {
-          "type": "boolean"
-        },
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "readOnly": true
-        }
-      }
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "API for Promethium Daemon",
-    "title": "Promethium Daemon API",
-    "version": "1.0.0"
-  },
-  "paths": {
-    "/images": {
+    "/storage/{storageID}": {
       "get": {
-        "description": "Returns a list of Images",
+        "description": "Get storage item",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "storage"
+        ],
+        "operationId": "getStorage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of Storage to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successful operation",
+            "schema": {
+              "$ref": "#/definitions/Storage"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Storage not found"
+          }
+        }
+      },
+      "put": {
+        "description": "Update storage item",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "images"
+          "storage"
         ],
-        "summary": "Get a list of Images",
-        "operationId": "getImagesList",
+        "operationId": "updateStorage",
         "parameters": [
           {
-            "type": "integer",
-            "format": "int64",
-            "name": "since",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
           },
           {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
-            "in": "query"
+            "description": "Create new VM instance",
+            "name": "storageConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UpdateStorage"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of Images",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/Image"
-              }
+              "$ref": "#/definitions/Storage"
             }
           },
-          "default": {
-            "description": "unexpected error",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Storage not found"
           }
         }
       },
-      "post": {
-        "description": "Create a new Image",
+      "delete": {
+        "description": "Remove storage item",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "images"
+          "storage"
         ],
-        "summary": "Create a new Image",
-        "operationId": "createImage",
+        "operationId": "destroyStorage",
         "parameters": [
           {
-            "description": "Create new Image",
-            "name": "imageConfig",
-            "in": "body",
-            "required": true,
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "Storage not found"
+          }
+        }
+      }
+    },
+    "/storage/{storageID}/disks": {
+      "get": {
+        "description": "Get storage item",
+        "tags": [
+          "storage"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
             "schema": {
-              "$ref": "#/definitions/NewImage"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/StorageDisk"
+              }
             }
           }
+        }
+      }
+    },
+    "/storage/{storageID}/images": {
+      "get": {
+        "description": "Get storage item",
+        "tags": [
+          "storage"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
+          }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "OK",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/StorageImage"
+              }
             }
-          },
-          "default": {
-            "description": "unexpected error",
+          }
+        }
+      }
+    },
+    "/storage/{storageID}/kernels": {
+      "get": {
+        "description": "Get storage item",
+        "tags": [
+          "storage"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "storageID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
             "schema": {
-              "$ref": "#/definitions/error"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/StorageKernel"
+              }
             }
           }
         }
       }
     },
-    "/images/pull": {
-      "post": {
-        "description": "Pull an image to the server",
-        "consumes": [
-          "multipart/form-data"
-        ],
+    "/vms": {
+      "get": {
+        "description": "Returns a list of VMs",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "images"
+          "vms"
         ],
-        "summary": "Pull an image to the server",
-        "operationId": "pullImage",
+        "summary": "Get a list of VMs",
+        "operationId": "getVMList",
         "parameters": [
           {
-            "type": "string",
-            "description": "Storage Target",
-            "name": "targetStorage",
-            "in": "formData"
+            "type": "integer",
+            "format": "int64",
+            "name": "since",
+            "in": "query"
           },
           {
-            "type": "string",
-            "description": "SourceURI for remote pull",
-            "name": "sourceURI",
-            "in": "formData"
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "Array of VMs",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMListItem"
+              }
             }
           },
           "default": {
@@ -3211,49 +5602,41 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/images/push": {
+      },
       "post": {
-        "description": "Push an image to the server",
-        "consumes": [
-          "multipart/form-data"
-        ],
+        "description": "Create an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "images"
+          "vms"
         ],
-        "summary": "Push an image to the server",
-        "operationId": "pushImage",
+        "summary": "Create a VM instance",
+        "operationId": "createVM",
         "parameters": [
           {
-            "type": "file",
-            "description": "The file to upload.",
-            "name": "inFileBlob",
-            "in": "formData"
-          },
-          {
-            "type": "string",
-            "description": "Storage Target",
-            "name": "targetStorage",
-            "in": "formData"
-          },
-          {
-            "type": "string",
-            "description": "SourceURI for remote pull",
-            "name": "sourceURI",
-            "in": "formData"
+            "description": "Create new VM instance",
+            "name": "vmConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewVM"
+            }
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The VM is being created - the result of the operation is the VM",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/Operation"
             }
           },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
           "default": {
             "description": "unexpected error",
             "schema": {
@@ -3263,45 +5646,66 @@ func init() {
         }
       }
     },
-    "/networking": {
+    "/vms/{vmID}": {
       "get": {
-        "description": "Get all networks",
+        "description": "Returns an isntance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
+        ],
+        "summary": "Return a VM instance",
+        "operationId": "getVM",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          }
         ],
-        "operationId": "getNetworkList",
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/Network"
-              }
+              "$ref": "#/definitions/VM"
             }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
           }
         }
       },
-      "post": {
-        "description": "Create new network",
+      "put": {
+        "description": "Update an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
         ],
-        "operationId": "createNetwork",
+        "summary": "Update a VM instance",
+        "operationId": "updateVM",
         "parameters": [
           {
-            "description": "Create new Network (bridge)",
-            "name": "netConfig",
+            "type": "string",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Pet to add to the store",
+            "name": "vmConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/NewNetwork"
+              "$ref": "#/definitions/UpdateVM"
             }
           }
         ],
@@ -3309,204 +5713,304 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/Network"
+              "$ref": "#/definitions/VM"
             }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
           }
         }
-      }
-    },
-    "/networking/physicalInterfaces": {
-      "get": {
-        "description": "Get all physical interfaces",
+      },
+      "delete": {
+        "description": "Destroys a stopped VM along with its disks, snapshots and logs",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
+        ],
+        "summary": "Destroy a VM instance",
+        "operationId": "deleteVM",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to delete",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          }
         ],
-        "operationId": "getPhysicalInterfaces",
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "successful operation"
+          },
+          "400": {
+            "description": "The VM is still running or couldnt be deleted"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/PhysicalInterface"
-              }
+              "$ref": "#/definitions/error"
             }
           }
         }
       }
     },
-    "/networking/{networkID}": {
+    "/vms/{vmID}/agent": {
       "get": {
-        "description": "Get a network (bridge)",
+        "description": "Pings the guest agent over vsock and returns its version and the network interfaces of the guest",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
         ],
-        "operationId": "getNetwork",
+        "summary": "Get information from the VM guest agent",
+        "operationId": "getVMAgentInfo",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
-            "name": "networkID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "Guest agent information",
             "schema": {
-              "$ref": "#/definitions/Network"
+              "$ref": "#/definitions/VMAgentInfo"
+            }
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "put": {
-        "description": "Update network",
+      }
+    },
+    "/vms/{vmID}/balloon": {
+      "get": {
+        "description": "Returns the target and actual size of the balloon device of a VM",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
         ],
-        "operationId": "updateNetwork",
+        "summary": "Get the memory balloon of a VM",
+        "operationId": "getVMBalloon",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
-            "name": "networkID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "Create new VM instance",
-            "name": "netConfig",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/UpdateNetwork"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "VM balloon",
             "schema": {
-              "$ref": "#/definitions/Network"
+              "$ref": "#/definitions/VMBalloon"
             }
           },
           "400": {
-            "description": "Invalid ID supplied"
+            "description": "VM has no balloon device"
           },
           "404": {
-            "description": "Storage not found"
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
-      "delete": {
-        "description": "Get a network (bridge)",
+      "put": {
+        "description": "Sets the memory the balloon device takes from the guest - a running VM is resized straight away",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
         ],
-        "operationId": "destroyNetwork",
+        "summary": "Resize the memory balloon of a VM",
+        "operationId": "updateVMBalloon",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of Network to delete",
-            "name": "networkID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "New balloon target",
+            "name": "balloonConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UpdateVMBalloon"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "VM balloon",
             "schema": {
-              "$ref": "#/definitions/Network"
+              "$ref": "#/definitions/VMBalloon"
+            }
+          },
+          "400": {
+            "description": "Invalid balloon target"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           }
         }
       }
     },
-    "/networking/{networkID}/interfaces": {
+    "/vms/{vmID}/console": {
       "get": {
-        "description": "Get a network (bridge) interfaces",
+        "description": "Get a console for a VM instance",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "networking"
+          "vms"
         ],
-        "operationId": "getNetworkInterfaces",
+        "summary": "Get a console for a VM instance",
+        "operationId": "getVMConsole",
         "parameters": [
           {
             "type": "string",
             "description": "ID of VM to return",
-            "name": "networkID",
+            "name": "vmID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/NetworkInterface"
-              }
+              "$ref": "#/definitions/VM"
             }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
           }
         }
       }
     },
-    "/storage": {
+    "/vms/{vmID}/disks": {
       "get": {
-        "description": "Get all storage",
+        "description": "Returns a list of VM Attached Disks",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "storage"
+          "vms"
+        ],
+        "summary": "Get a list of VM Attached Disks",
+        "operationId": "getVMDiskList",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
+          }
         ],
-        "operationId": "getStorageList",
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "Array of Disks",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/Storage"
+                "$ref": "#/definitions/VMDisk"
               }
             }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
       "post": {
-        "description": "Create new storage",
+        "description": "Create or attach a VM Disk",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "storage"
+          "vms"
         ],
-        "operationId": "createStorage",
+        "summary": "Create or attach a VM Disk",
+        "operationId": "createVMDisk",
         "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
           {
             "description": "Create new VM instance",
-            "name": "storageConfig",
+            "name": "vmDiskConfid",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/NewStorage"
+              "$ref": "#/definitions/NewVMDisk"
             }
           }
         ],
@@ -3514,27 +6018,47 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/Storage"
+              "$ref": "#/definitions/VMDisk"
+            }
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           }
         }
       }
     },
-    "/storage/{storageID}": {
+    "/vms/{vmID}/disks/{diskID}": {
       "get": {
-        "description": "Get storage item",
+        "description": "Returns an isntance of VM Disk",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "storage"
+          "vms"
         ],
-        "operationId": "getStorage",
+        "summary": "Return a VM Disk",
+        "operationId": "getVMDisk",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of Storage to return",
-            "name": "storageID",
+            "description": "ID of VM to return",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Disk to use",
+            "name": "diskID",
             "in": "path",
             "required": true
           }
@@ -3543,41 +6067,55 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/Storage"
+              "$ref": "#/definitions/VMDisk"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "Storage not found"
+            "description": "VM or Disk not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
       "put": {
-        "description": "Update storage item",
+        "description": "Update an instance of VM interface",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "storage"
+          "vms"
         ],
-        "operationId": "updateStorage",
+        "summary": "Update a VM Interface instance",
+        "operationId": "updateVMDisk",
         "parameters": [
           {
             "type": "string",
             "description": "ID of VM to return",
-            "name": "storageID",
+            "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "description": "Create new VM instance",
-            "name": "storageConfig",
+            "type": "string",
+            "description": "ID of VM Disk to use",
+            "name": "diskID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Pet to add to the store",
+            "name": "vmDiskConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateStorage"
+              "$ref": "#/definitions/UpdateVMDisk"
             }
           }
         ],
@@ -3585,149 +6123,89 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/Storage"
+              "$ref": "#/definitions/UpdateVMDisk"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "Storage not found"
+            "description": "VM Interface not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
       "delete": {
-        "description": "Remove storage item",
+        "description": "Returns an isntance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
-          "storage"
+          "vms"
         ],
-        "operationId": "destroyStorage",
+        "summary": "Return a VM instance",
+        "operationId": "deleteVMDrive",
         "parameters": [
           {
             "type": "string",
             "description": "ID of VM to return",
-            "name": "storageID",
+            "name": "vmID",
             "in": "path",
             "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "OK"
-          },
-          "400": {
-            "description": "Invalid ID supplied"
           },
-          "404": {
-            "description": "Storage not found"
-          }
-        }
-      }
-    },
-    "/storage/{storageID}/disks": {
-      "get": {
-        "description": "Get storage item",
-        "tags": [
-          "storage"
-        ],
-        "parameters": [
-          {
-            "type": "string",
-            "description": "ID of VM to return",
-            "name": "storageID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "OK",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/StorageDisk"
-              }
-            }
-          }
-        }
-      }
-    },
-    "/storage/{storageID}/images": {
-      "get": {
-        "description": "Get storage item",
-        "tags": [
-          "storage"
-        ],
-        "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
-            "name": "storageID",
+            "description": "ID of VM Interface to use",
+            "name": "diskID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/StorageImage"
-              }
+              "$ref": "#/definitions/item"
             }
-          }
-        }
-      }
-    },
-    "/storage/{storageID}/kernels": {
-      "get": {
-        "description": "Get storage item",
-        "tags": [
-          "storage"
-        ],
-        "parameters": [
-          {
-            "type": "string",
-            "description": "ID of VM to return",
-            "name": "storageID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "OK",
+          },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/StorageKernel"
-              }
+              "$ref": "#/definitions/error"
             }
           }
         }
       }
     },
-    "/vms": {
+    "/vms/{vmID}/interfaces": {
       "get": {
-        "description": "Returns a list of VMs",
+        "description": "Returns a list of VM Network Itnerfaces",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VMs",
-        "operationId": "getVMList",
+        "summary": "Get a list of VM Network Interfaces",
+        "operationId": "getVMInterfaceList",
         "parameters": [
           {
-            "type": "integer",
-            "format": "int64",
-            "name": "since",
-            "in": "query"
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
           },
           {
             "type": "integer",
@@ -3746,11 +6224,11 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Array of VMs",
+            "description": "Array of VM Network Interfaces",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/VMListItem"
+                "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
               }
             }
           },
@@ -3763,23 +6241,30 @@ func init() {
         }
       },
       "post": {
-        "description": "Create an instance of VM",
+        "description": "Create a new VM Network Itnerface",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create a VM instance",
-        "operationId": "createVM",
+        "summary": "Create a new VM Network Itnerface",
+        "operationId": "createVMInterface",
         "parameters": [
+          {
+            "type": "string",
+            "description": "ID of VM to use",
+            "name": "vmID",
+            "in": "path",
+            "required": true
+          },
           {
             "description": "Create new VM instance",
-            "name": "vmConfig",
+            "name": "interfaceConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/NewVM"
+              "$ref": "#/definitions/NewVMInterface"
             }
           }
         ],
@@ -3787,7 +6272,7 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
             }
           },
           "400": {
@@ -3795,21 +6280,27 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}": {
+    "/vms/{vmID}/interfaces/{interfaceID}": {
       "get": {
-        "description": "Returns an isntance of VM",
+        "description": "Returns an instance of VM Network Interface",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM instance",
-        "operationId": "getVM",
+        "summary": "Return a VM Network Interface",
+        "operationId": "getVMInterace",
         "parameters": [
           {
             "type": "string",
@@ -3817,13 +6308,20 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Interface to use",
+            "name": "interfaceID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
             }
           },
           "400": {
@@ -3831,19 +6329,25 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
       "put": {
-        "description": "Update an instance of VM",
+        "description": "Update an instance of VM Network Interface",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Update a VM instance",
-        "operationId": "updateVM",
+        "summary": "Update a VM Network Interface instance",
+        "operationId": "updateVMInterface",
         "parameters": [
           {
             "type": "string",
@@ -3853,12 +6357,19 @@ func init() {
             "required": true
           },
           {
-            "description": "Pet to add to the store",
-            "name": "vmConfig",
+            "type": "string",
+            "description": "ID of VM Interface to use",
+            "name": "interfaceID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Updated VM Interface Config",
+            "name": "vmInterfaceConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateVM"
+              "$ref": "#/definitions/UpdateVMInterface"
             }
           }
         ],
@@ -3866,27 +6377,33 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM not found"
+            "description": "VM Interface not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
       "delete": {
-        "description": "Destroy an isntance of VM",
+        "description": "Destroy a VM Network Interface",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Destroy a VM instance",
-        "operationId": "deleteVM",
+        "summary": "Destroy a VM Network Interface",
+        "operationId": "deleteVMInterface",
         "parameters": [
           {
             "type": "string",
@@ -3894,6 +6411,13 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Interface to use",
+            "name": "interfaceID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
@@ -3908,21 +6432,27 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/console": {
+    "/vms/{vmID}/logs": {
       "get": {
-        "description": "Get a console for a VM instance",
+        "description": "Returns the serial console output and firecracker log of a VM instance. When follow is set new lines are streamed as newline delimited JSON until the client disconnects",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a console for a VM instance",
-        "operationId": "getVMConsole",
+        "summary": "Return VM logs",
+        "operationId": "getVMLogs",
         "parameters": [
           {
             "type": "string",
@@ -3930,13 +6460,49 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "enum": [
+              "serial",
+              "firecracker",
+              "all"
+            ],
+            "type": "string",
+            "default": "serial",
+            "description": "The log to return",
+            "name": "source",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return this many of the most recent lines",
+            "name": "tail",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return lines written at or after this time",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep the request open and stream new lines as they are written",
+            "name": "follow",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMLogEntry"
+              }
             }
           },
           "400": {
@@ -3948,91 +6514,74 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/disks": {
+    "/vms/{vmID}/metrics": {
       "get": {
-        "description": "Returns a list of VM Attached Disks",
+        "description": "Returns the metrics collected from the VMM of a VM instance",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VM Attached Disks",
-        "operationId": "getVMDiskList",
+        "summary": "Return VM metrics",
+        "operationId": "getVMMetrics",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
+            "type": "boolean",
+            "default": false,
+            "description": "Flush the VMM metrics before returning them",
+            "name": "flush",
             "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of Disks",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/VMDisk"
-              }
+              "$ref": "#/definitions/VMMetrics"
             }
           },
-          "default": {
-            "description": "unexpected error",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
           }
         }
-      },
-      "post": {
-        "description": "Create or attach a VM Disk",
+      }
+    },
+    "/vms/{vmID}/pause": {
+      "get": {
+        "description": "Pauses the vCPUs of a running instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create or attach a VM Disk",
-        "operationId": "createVMDisk",
+        "summary": "Pause a VM instance",
+        "operationId": "pauseVM",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "Create new VM instance",
-            "name": "vmDiskConfid",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/NewVMDisk"
-            }
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMDisk"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
@@ -4041,8 +6590,8 @@ func init() {
           "404": {
             "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM or it isnt running",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -4050,17 +6599,17 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/disks/{diskID}": {
+    "/vms/{vmID}/reset": {
       "get": {
-        "description": "Returns an isntance of VM Disk",
+        "description": "Forcefully Reset an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM Disk",
-        "operationId": "getVMDisk",
+        "summary": "Reset a VM instance",
+        "operationId": "resetVM",
         "parameters": [
           {
             "type": "string",
@@ -4068,102 +6617,123 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "string",
-            "description": "ID of VM Disk to use",
-            "name": "diskID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMDisk"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM or Disk not found"
+            "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "put": {
-        "description": "Update an instance of VM interface",
+      }
+    },
+    "/vms/{vmID}/resources": {
+      "get": {
+        "description": "Returns the cgroup resource limits of a VM and, while it is running, the values in effect and its usage",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Update a VM Interface instance",
-        "operationId": "updateVMDisk",
+        "summary": "Get the resource limits of a VM",
+        "operationId": "getVMResources",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "VM resources",
+            "schema": {
+              "$ref": "#/definitions/VMResources"
+            }
           },
+          "404": {
+            "description": "VM not found"
+          }
+        }
+      },
+      "put": {
+        "description": "Replaces the cgroup resource limits of a VM - a running VM has them applied straight away",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Change the resource limits of a VM",
+        "operationId": "updateVMResources",
+        "parameters": [
           {
             "type": "string",
-            "description": "ID of VM Disk to use",
-            "name": "diskID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "description": "Pet to add to the store",
-            "name": "vmDiskConfig",
+            "description": "New resource limits",
+            "name": "resourceLimits",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateVMDisk"
+              "$ref": "#/definitions/VMResourceLimits"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "VM resources",
             "schema": {
-              "$ref": "#/definitions/UpdateVMDisk"
+              "$ref": "#/definitions/VMResources"
             }
           },
           "400": {
-            "description": "Invalid ID supplied"
+            "description": "Invalid resource limits"
           },
           "404": {
-            "description": "VM Interface not found"
+            "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "delete": {
-        "description": "Returns an isntance of VM",
+      }
+    },
+    "/vms/{vmID}/restart": {
+      "get": {
+        "description": "Gracefully Restart an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM instance",
-        "operationId": "deleteVMDrive",
+        "summary": "Restart a VM instance",
+        "operationId": "restartVM",
         "parameters": [
           {
             "type": "string",
@@ -4171,20 +6741,13 @@ func init() {
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "diskID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/item"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
@@ -4193,8 +6756,8 @@ func init() {
           "404": {
             "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -4202,91 +6765,73 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/interfaces": {
+    "/vms/{vmID}/resume": {
       "get": {
-        "description": "Returns a list of VM Network Itnerfaces",
+        "description": "Resumes a paused instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VM Network Interfaces",
-        "operationId": "getVMInterfaceList",
+        "summary": "Resume a VM instance",
+        "operationId": "resumeVM",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
-            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of VM Network Interfaces",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
-              }
+              "$ref": "#/definitions/VM"
             }
           },
-          "default": {
-            "description": "unexpected error",
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM or it isnt paused",
             "schema": {
               "$ref": "#/definitions/error"
             }
           }
         }
-      },
-      "post": {
-        "description": "Create a new VM Network Itnerface",
+      }
+    },
+    "/vms/{vmID}/shutdown": {
+      "get": {
+        "description": "Gracefully Shutdown an instance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create a new VM Network Itnerface",
-        "operationId": "createVMInterface",
+        "summary": "Shutdown a VM instance",
+        "operationId": "shutdownVM",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "Create new VM instance",
-            "name": "interfaceConfig",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/NewVMInterface"
-            }
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
+              "$ref": "#/definitions/VM"
             }
           },
           "400": {
@@ -4295,8 +6840,8 @@ func init() {
           "404": {
             "description": "VM not found"
           },
-          "default": {
-            "description": "unexpected error",
+          "409": {
+            "description": "Another operation is in progress on the VM",
             "schema": {
               "$ref": "#/definitions/error"
             }
@@ -4304,43 +6849,36 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/interfaces/{interfaceID}": {
+    "/vms/{vmID}/snapshots": {
       "get": {
-        "description": "Returns an instance of VM Network Interface",
+        "description": "Returns a list of snapshots taken of a VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM Network Interface",
-        "operationId": "getVMInterace",
+        "summary": "Get a list of VM Snapshots",
+        "operationId": "getVMSnapshotList",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
-          },
-          {
-            "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "interfaceID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "Array of Snapshots",
             "schema": {
-              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMSnapshot"
+              }
             }
           },
-          "400": {
-            "description": "Invalid ID supplied"
-          },
           "404": {
             "description": "VM not found"
           },
@@ -4352,53 +6890,52 @@ func init() {
           }
         }
       },
-      "put": {
-        "description": "Update an instance of VM Network Interface",
+      "post": {
+        "description": "Snapshots the memory, device state and disks of a running VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Update a VM Network Interface instance",
-        "operationId": "updateVMInterface",
+        "summary": "Snapshot a VM",
+        "operationId": "createVMSnapshot",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "interfaceID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "Updated VM Interface Config",
-            "name": "vmInterfaceConfig",
+            "description": "Create new VM snapshot",
+            "name": "snapshotConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateVMInterface"
+              "$ref": "#/definitions/NewVMSnapshot"
             }
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The snapshot is being taken - the result of the operation is the snapshot",
             "schema": {
-              "$ref": "#/definitions/MetaDataNetworkInterfaceConfig"
+              "$ref": "#/definitions/Operation"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM Interface not found"
+            "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
           "default": {
             "description": "unexpected error",
@@ -4407,29 +6944,31 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/vms/{vmID}/snapshots/{snapshotID}": {
       "delete": {
-        "description": "Destroy a VM Network Interface",
+        "description": "Deletes a snapshot and the files it uses in the storage target",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Destroy a VM Network Interface",
-        "operationId": "deleteVMInterface",
+        "summary": "Delete a VM Snapshot",
+        "operationId": "deleteVMSnapshot",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
             "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "interfaceID",
+            "description": "ID of VM Snapshot to use",
+            "name": "snapshotID",
             "in": "path",
             "required": true
           }
@@ -4445,7 +6984,13 @@ func init() {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM not found"
+            "description": "VM or Snapshot not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
           "default": {
             "description": "unexpected error",
@@ -4456,53 +7001,80 @@ func init() {
         }
       }
     },
-    "/vms/{vmID}/reset": {
-      "get": {
-        "description": "Forcefully Reset an instance of VM",
+    "/vms/{vmID}/snapshots/{snapshotID}/restore": {
+      "post": {
+        "description": "Restores a VM from a snapshot, or restores the snapshot into a new clone of the VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Reset a VM instance",
-        "operationId": "resetVM",
+        "summary": "Restore a VM Snapshot",
+        "operationId": "restoreVMSnapshot",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "ID of VM Snapshot to use",
+            "name": "snapshotID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Restore options",
+            "name": "restoreConfig",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/RestoreVMSnapshot"
+            }
           }
         ],
         "responses": {
-          "200": {
-            "description": "successful operation",
+          "202": {
+            "description": "The snapshot is being restored - the result of the operation is the VM",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/Operation"
             }
           },
           "400": {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM not found"
+            "description": "VM or Snapshot not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/restart": {
+    "/vms/{vmID}/start": {
       "get": {
-        "description": "Gracefully Restart an instance of VM",
+        "description": "Starts an isntance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Restart a VM instance",
-        "operationId": "restartVM",
+        "summary": "Start a VM instance",
+        "operationId": "startVM",
         "parameters": [
           {
             "type": "string",
@@ -4524,21 +7096,27 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/shutdown": {
+    "/vms/{vmID}/stop": {
       "get": {
-        "description": "Gracefully Shutdown an instance of VM",
+        "description": "Stops an isntance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Shutdown a VM instance",
-        "operationId": "shutdownVM",
+        "summary": "Stop a VM instance",
+        "operationId": "stopVM",
         "parameters": [
           {
             "type": "string",
@@ -4560,71 +7138,101 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "409": {
+            "description": "Another operation is in progress on the VM",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/start": {
+    "/vms/{vmID}/volumes": {
       "get": {
-        "description": "Starts an isntance of VM",
+        "description": "Returns a list of VM Volumes",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Start a VM instance",
-        "operationId": "startVM",
+        "summary": "Get a list of VM Volumes",
+        "operationId": "getVMVolumeList",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int32",
+            "default": 0,
+            "name": "skip",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "successful operation",
+            "description": "Array of VM Volumes",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VMVolume"
+              }
             }
           },
-          "400": {
-            "description": "Invalid ID supplied"
-          },
-          "404": {
-            "description": "VM not found"
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
-      }
-    },
-    "/vms/{vmID}/stop": {
-      "get": {
-        "description": "Stops an isntance of VM",
+      },
+      "post": {
+        "description": "Create or attach a VM Volume",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Stop a VM instance",
-        "operationId": "stopVM",
+        "summary": "Create or attach a VM Volume",
+        "operationId": "createVMVolume",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "VM Volume Config",
+            "name": "volumeConfig",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/NewVMVolume"
+            }
           }
         ],
         "responses": {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VM"
+              "$ref": "#/definitions/VMVolume"
             }
           },
           "400": {
@@ -4632,54 +7240,56 @@ func init() {
           },
           "404": {
             "description": "VM not found"
+          },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/vms/{vmID}/volumes": {
+    "/vms/{vmID}/volumes/{volumeID}": {
       "get": {
-        "description": "Returns a list of VM Volumes",
+        "description": "Returns an isntance of VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Get a list of VM Volumes",
-        "operationId": "getVMVolumeList",
+        "summary": "Return a VM instance",
+        "operationId": "getVMVolume",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "type": "integer",
-            "format": "int32",
-            "default": 20,
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "integer",
-            "format": "int32",
-            "default": 0,
-            "name": "skip",
-            "in": "query"
+            "type": "string",
+            "description": "ID of VM Volume to use",
+            "name": "volumeID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Array of VM Volumes",
+            "description": "successful operation",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/VMVolume"
-              }
+              "$ref": "#/definitions/VMVolume"
             }
           },
+          "400": {
+            "description": "Invalid ID supplied"
+          },
+          "404": {
+            "description": "VM not found"
+          },
           "default": {
             "description": "unexpected error",
             "schema": {
@@ -4687,32 +7297,39 @@ func init() {
             }
           }
         }
-      },
-      "post": {
-        "description": "Create or attach a VM Volume",
+      },
+      "put": {
+        "description": "Update an instance of VM interface",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Create or attach a VM Volume",
-        "operationId": "createVMVolume",
+        "summary": "Update a VM Interface instance",
+        "operationId": "updateVMVolume",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to use",
+            "description": "ID of VM to return",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "description": "VM Volume Config",
-            "name": "volumeConfig",
+            "type": "string",
+            "description": "ID of VM Volume to use",
+            "name": "volumeID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Pet to add to the store",
+            "name": "vmInterfaceConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/NewVMVolume"
+              "$ref": "#/definitions/UpdateVMVolume"
             }
           }
         ],
@@ -4727,7 +7344,7 @@ func init() {
             "description": "Invalid ID supplied"
           },
           "404": {
-            "description": "VM not found"
+            "description": "VM Interface not found"
           },
           "default": {
             "description": "unexpected error",
@@ -4736,10 +7353,8 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/vms/{vmID}/volumes/{volumeID}": {
-      "get": {
+      },
+      "delete": {
         "description": "Returns an isntance of VM",
         "produces": [
           "application/json"
@@ -4748,7 +7363,7 @@ func init() {
           "vms"
         ],
         "summary": "Return a VM instance",
-        "operationId": "getVMVolume",
+        "operationId": "deleteVMVolume",
         "parameters": [
           {
             "type": "string",
@@ -4759,7 +7374,7 @@ func init() {
           },
           {
             "type": "string",
-            "description": "ID of VM Volume to use",
+            "description": "ID of VM Interface to use",
             "name": "volumeID",
             "in": "path",
             "required": true
@@ -4769,7 +7384,7 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMVolume"
+              "$ref": "#/definitions/item"
             }
           },
           "400": {
@@ -4785,39 +7400,74 @@ func init() {
             }
           }
         }
-      },
-      "put": {
-        "description": "Update an instance of VM interface",
+      }
+    },
+    "/vms/{vmID}/vsock/proxies": {
+      "get": {
+        "description": "Returns the host ports being proxied to vsock ports in the VM",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Update a VM Interface instance",
-        "operationId": "updateVMVolume",
+        "summary": "Get a list of VM vsock proxies",
+        "operationId": "getVMVsockProxyList",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Array of vsock proxies",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/VsockProxy"
+              }
+            }
+          },
+          "404": {
+            "description": "VM not found"
           },
+          "default": {
+            "description": "unexpected error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Listens on a TCP port on the host and forwards connections to a vsock port in the VM",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "vms"
+        ],
+        "summary": "Proxy a host port to a VM vsock port",
+        "operationId": "createVMVsockProxy",
+        "parameters": [
           {
             "type": "string",
-            "description": "ID of VM Volume to use",
-            "name": "volumeID",
+            "description": "ID of VM to use",
+            "name": "vmID",
             "in": "path",
             "required": true
           },
           {
-            "description": "Pet to add to the store",
-            "name": "vmInterfaceConfig",
+            "description": "Create new vsock proxy",
+            "name": "proxyConfig",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/UpdateVMVolume"
+              "$ref": "#/definitions/NewVsockProxy"
             }
           }
         ],
@@ -4825,14 +7475,14 @@ func init() {
           "200": {
             "description": "successful operation",
             "schema": {
-              "$ref": "#/definitions/VMVolume"
+              "$ref": "#/definitions/VsockProxy"
             }
           },
           "400": {
-            "description": "Invalid ID supplied"
+            "description": "Invalid proxy supplied"
           },
           "404": {
-            "description": "VM Interface not found"
+            "description": "VM not found"
           },
           "default": {
             "description": "unexpected error",
@@ -4841,29 +7491,31 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/vms/{vmID}/vsock/proxies/{proxyID}": {
       "delete": {
-        "description": "Returns an isntance of VM",
+        "description": "Stops proxying a host port and drops its connections",
         "produces": [
           "application/json"
         ],
         "tags": [
           "vms"
         ],
-        "summary": "Return a VM instance",
-        "operationId": "deleteVMVolume",
+        "summary": "Delete a VM vsock proxy",
+        "operationId": "deleteVMVsockProxy",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of VM to return",
+            "description": "ID of VM to use",
             "name": "vmID",
             "in": "path",
             "required": true
           },
           {
             "type": "string",
-            "description": "ID of VM Interface to use",
-            "name": "volumeID",
+            "description": "ID of vsock proxy to delete",
+            "name": "proxyID",
             "in": "path",
             "required": true
           }
@@ -4875,11 +7527,8 @@ func init() {
               "$ref": "#/definitions/item"
             }
           },
-          "400": {
-            "description": "Invalid ID supplied"
-          },
           "404": {
-            "description": "VM not found"
+            "description": "VM or proxy not found"
           },
           "default": {
             "description": "unexpected error",
@@ -4992,6 +7641,52 @@ func init() {
         }
       }
     },
+    "ConsoleRecording": {
+      "type": "object",
+      "properties": {
+        "cast": {
+          "description": "The asciicast v2 recording - only returned when getting a single recording",
+          "type": "string"
+        },
+        "duration": {
+          "type": "number",
+          "format": "double"
+        },
+        "height": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "startedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "title": {
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "width": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "ConsoleRecording"
+      }
+    },
     "DiskImage": {
       "type": "object",
       "properties": {
@@ -5007,6 +7702,57 @@ func init() {
         }
       }
     },
+    "DoctorProblem": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "error": {
+          "description": "Why fixing the problem failed",
+          "type": "string"
+        },
+        "fixable": {
+          "description": "Whether the problem can be cleaned up by fixing",
+          "type": "boolean"
+        },
+        "fixed": {
+          "type": "boolean"
+        },
+        "kind": {
+          "description": "orphaned-chroot, orphaned-disk, missing-disk, stale-tap or leaked-nbd",
+          "type": "string"
+        },
+        "resource": {
+          "description": "Path or device the problem is with",
+          "type": "string"
+        },
+        "vmID": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "DoctorProblem"
+      }
+    },
+    "DoctorReport": {
+      "type": "object",
+      "properties": {
+        "checkedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "problems": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DoctorProblem"
+          }
+        }
+      },
+      "xml": {
+        "name": "DoctorReport"
+      }
+    },
     "Image": {
       "type": "object",
       "properties": {
@@ -5716,6 +8462,10 @@ func init() {
         },
         "storageName": {
           "type": "string"
+        },
+        "type": {
+          "description": "Only needed for mock VMs which are created without an image - other VMs get their type from their image",
+          "type": "string"
         }
       },
       "xml": {
@@ -5757,6 +8507,17 @@ func init() {
         "name": "NewVMInterface"
       }
     },
+    "NewVMSnapshot": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "NewVMSnapshot"
+      }
+    },
     "NewVMVolume": {
       "type": "object",
       "properties": {
@@ -5769,6 +8530,91 @@ func init() {
         "name": "VMVolume"
       }
     },
+    "NewVsockProxy": {
+      "type": "object",
+      "required": [
+        "guestPort"
+      ],
+      "properties": {
+        "bindAddress": {
+          "description": "Address on the host to listen on - defaults to 127.0.0.1",
+          "type": "string"
+        },
+        "guestPort": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 4294967295,
+          "minimum": 1
+        },
+        "hostPort": {
+          "description": "Port on the host to listen on - 0 picks a free port",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "NewVsockProxy"
+      }
+    },
+    "Operation": {
+      "type": "object",
+      "properties": {
+        "completed": {
+          "description": "Whether the operation has finished - it may have failed or been cancelled",
+          "type": "boolean"
+        },
+        "completedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "createdAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "description": {
+          "type": "string"
+        },
+        "error": {
+          "type": "string"
+        },
+        "id": {
+          "type": "string"
+        },
+        "kind": {
+          "description": "create-vm, import-image, snapshot-vm, restore-vm or clone-vm",
+          "type": "string"
+        },
+        "message": {
+          "description": "What the operation is doing",
+          "type": "string"
+        },
+        "progress": {
+          "description": "Percentage done",
+          "type": "integer",
+          "format": "int32"
+        },
+        "result": {
+          "description": "What the operation produced once it has completed",
+          "type": "object"
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "running",
+            "completed",
+            "failed",
+            "cancelled"
+          ]
+        },
+        "target": {
+          "description": "ID of what the operation is on when it is known up front",
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "Operation"
+      }
+    },
     "PhysicalInterface": {
       "type": "object",
       "properties": {
@@ -5790,6 +8636,17 @@ func init() {
         }
       }
     },
+    "RestoreVMSnapshot": {
+      "type": "object",
+      "properties": {
+        "cloneName": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "RestoreVMSnapshot"
+      }
+    },
     "Storage": {
       "type": "object"
     },
@@ -5826,14 +8683,30 @@ func init() {
         },
         "memory": {
           "type": "integer",
-          "format": "int64"
-        },
-        "name": {
-          "type": "string"
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "UpdateVM"
+      }
+    },
+    "UpdateVMBalloon": {
+      "type": "object",
+      "required": [
+        "amountMib"
+      ],
+      "properties": {
+        "amountMib": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
         }
       },
       "xml": {
-        "name": "UpdateVM"
+        "name": "UpdateVMBalloon"
       }
     },
     "UpdateVMDisk": {
@@ -5889,6 +8762,16 @@ func init() {
         "autoStart": {
           "type": "boolean"
         },
+        "balloonActualMib": {
+          "description": "Memory currently held by the balloon device in MiB",
+          "type": "integer",
+          "format": "int64"
+        },
+        "balloonTargetMib": {
+          "description": "Memory the balloon device is asked to take from the guest in MiB",
+          "type": "integer",
+          "format": "int64"
+        },
         "bootCmd": {
           "type": "string"
         },
@@ -5903,6 +8786,11 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "crashCount": {
+          "description": "Number of consecutive unexpected exits",
+          "type": "integer",
+          "format": "int64"
+        },
         "createdAt": {
           "type": "string",
           "format": "date-time"
@@ -5920,6 +8808,20 @@ func init() {
         "entryPoint": {
           "type": "string"
         },
+        "health": {
+          "description": "VM health check status",
+          "type": "string",
+          "enum": [
+            "none",
+            "starting",
+            "healthy",
+            "unhealthy"
+          ]
+        },
+        "healthMessage": {
+          "description": "Last health check failure",
+          "type": "string"
+        },
         "hostNode": {
           "type": "string"
         },
@@ -5931,6 +8833,10 @@ func init() {
           "type": "string",
           "format": "uuid4"
         },
+        "lastExitReason": {
+          "description": "Reason the VM process last exited",
+          "type": "string"
+        },
         "memory": {
           "type": "integer",
           "format": "int64"
@@ -5941,6 +8847,16 @@ func init() {
         "network": {
           "$ref": "#/definitions/MetaDataNetworkConfig"
         },
+        "restartPolicy": {
+          "description": "What to do when the VM process exits unexpectedly",
+          "type": "string",
+          "enum": [
+            "no",
+            "on-failure",
+            "always",
+            "unless-stopped"
+          ]
+        },
         "startedAt": {
           "type": "string",
           "format": "date-time"
@@ -5953,7 +8869,8 @@ func init() {
             "started",
             "restarting",
             "stopping",
-            "stopped"
+            "stopped",
+            "paused"
           ]
         },
         "stoppedAt": {
@@ -5977,12 +8894,95 @@ func init() {
             "name": "volume",
             "wrapped": true
           }
+        },
+        "vsockCID": {
+          "description": "Guest CID of the vsock device - 0 when the VM has no vsock device",
+          "type": "integer",
+          "format": "int64"
         }
       },
       "xml": {
         "name": "VM"
       }
     },
+    "VMAgentInfo": {
+      "type": "object",
+      "properties": {
+        "hostname": {
+          "type": "string"
+        },
+        "interfaces": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/VMAgentInterface"
+          }
+        },
+        "uptime": {
+          "description": "Seconds since the guest booted",
+          "type": "number",
+          "format": "double"
+        },
+        "version": {
+          "type": "string"
+        }
+      },
+      "xml": {
+        "name": "VMAgentInfo"
+      }
+    },
+    "VMAgentInterface": {
+      "type": "object",
+      "properties": {
+        "addresses": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "mac": {
+          "type": "string"
+        },
+        "mtu": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "up": {
+          "type": "boolean"
+        }
+      },
+      "xml": {
+        "name": "VMAgentInterface"
+      }
+    },
+    "VMBalloon": {
+      "type": "object",
+      "properties": {
+        "actualMib": {
+          "description": "Memory currently held by the balloon in MiB",
+          "type": "integer",
+          "format": "int64"
+        },
+        "amountMib": {
+          "description": "Memory the balloon is asked to take from the guest in MiB",
+          "type": "integer",
+          "format": "int64"
+        },
+        "deflateOnOom": {
+          "type": "boolean"
+        },
+        "statsInterval": {
+          "description": "Seconds between balloon statistics updates - 0 when statistics are disabled",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "VMBalloon"
+      }
+    },
     "VMDisk": {
       "type": "object",
       "properties": {
@@ -6005,6 +9005,34 @@ func init() {
         "name": "VMDisk"
       }
     },
+    "VMIOLimit": {
+      "type": "object",
+      "properties": {
+        "device": {
+          "description": "Disk image, block device or major:minor number - empty applies to every device backing the VM disks",
+          "type": "string"
+        },
+        "readBps": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "readIops": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "writeBps": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "writeIops": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "VMIOLimit"
+      }
+    },
     "VMInterface": {
       "type": "object",
       "properties": {
@@ -6031,6 +9059,16 @@ func init() {
           "type": "string",
           "format": "date-time"
         },
+        "health": {
+          "description": "VM health check status",
+          "type": "string",
+          "enum": [
+            "none",
+            "starting",
+            "healthy",
+            "unhealthy"
+          ]
+        },
         "id": {
           "type": "string",
           "format": "uuid4"
@@ -6050,7 +9088,8 @@ func init() {
             "started",
             "restarting",
             "stopping",
-            "stopped"
+            "stopped",
+            "paused"
           ]
         },
         "stoppedAt": {
@@ -6062,6 +9101,167 @@ func init() {
         "name": "VMListItem"
       }
     },
+    "VMLogEntry": {
+      "type": "object",
+      "properties": {
+        "line": {
+          "type": "string"
+        },
+        "source": {
+          "type": "string"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      },
+      "xml": {
+        "name": "VMLogEntry"
+      }
+    },
+    "VMMetrics": {
+      "type": "object",
+      "properties": {
+        "counters": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "number",
+            "format": "double"
+          }
+        },
+        "gauges": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "number",
+            "format": "double"
+          }
+        },
+        "parseErrors": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "samples": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "updatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VMMetrics"
+      }
+    },
+    "VMResourceLimits": {
+      "type": "object",
+      "properties": {
+        "cpuMax": {
+          "description": "Most cpu time the VM can use in cpus - 0 is unlimited",
+          "type": "number",
+          "format": "double"
+        },
+        "cpuWeight": {
+          "description": "Relative share of cpu time from 1 to 10000 - 0 uses the default of 100",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ioMax": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/VMIOLimit"
+          }
+        },
+        "memoryOverheadMib": {
+          "description": "Memory allowed on top of the guest memory - 0 leaves memory unlimited",
+          "type": "integer",
+          "format": "int64"
+        },
+        "pidsMax": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "VMResourceLimits"
+      }
+    },
+    "VMResources": {
+      "type": "object",
+      "properties": {
+        "cpuUsageSeconds": {
+          "type": "number",
+          "format": "double"
+        },
+        "effective": {
+          "description": "Values of the cgroup control files of the running VM",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "limits": {
+          "$ref": "#/definitions/VMResourceLimits"
+        },
+        "memoryCurrent": {
+          "description": "Memory used by the VM process in bytes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "pidsCurrent": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "xml": {
+        "name": "VMResources"
+      }
+    },
+    "VMSnapshot": {
+      "type": "object",
+      "properties": {
+        "compatible": {
+          "type": "boolean"
+        },
+        "cpus": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "createdAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "firecrackerVersion": {
+          "type": "string"
+        },
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "memory": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VMSnapshot"
+      }
+    },
     "VMVolume": {
       "type": "object",
       "properties": {
@@ -6074,6 +9274,37 @@ func init() {
         "name": "VMVolume"
       }
     },
+    "VsockProxy": {
+      "type": "object",
+      "properties": {
+        "bindAddress": {
+          "type": "string"
+        },
+        "connections": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "guestPort": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "hostPort": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "id": {
+          "type": "string",
+          "format": "uuid4"
+        },
+        "vmID": {
+          "type": "string",
+          "format": "uuid4"
+        }
+      },
+      "xml": {
+        "name": "VsockProxy"
+      }
+    },
     "error": {
       "type": "object",
       "required": [
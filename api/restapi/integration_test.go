@@ -0,0 +1,268 @@
+package restapi_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/api/client/system"
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/testdaemon"
+	"github.com/gorilla/websocket"
+)
+
+func startDaemon(t *testing.T, instances ...*config.VmmConfig) (*testdaemon.Daemon, func()) {
+	appRoot, err := ioutil.TempDir("", "promethium-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := testdaemon.Start(appRoot, instances...)
+	if err != nil {
+		os.RemoveAll(appRoot)
+		t.Fatal(err)
+	}
+	return d, func() {
+		d.Close()
+		os.RemoveAll(appRoot)
+	}
+}
+
+func mockVmmConfig(id string, mock *config.VmmMockConfig) *config.VmmConfig {
+	return &config.VmmConfig{
+		ID:     id,
+		Name:   "mock-" + id,
+		Memory: 128,
+		Cpus:   1,
+		Type:   config.MockVmm,
+		Mock:   mock,
+	}
+}
+
+func waitForStatus(t *testing.T, d *testdaemon.Daemon, id string, status string) *models.VM {
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		resp, err := d.Client.Vms.GetVM(vms.NewGetVMParams().WithVMID(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Payload.Status == status {
+			return resp.Payload
+		} else if time.Now().After(deadline) {
+			t.Fatalf("expected %s to be %q, it is %q", id, status, resp.Payload.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func waitForOperation(t *testing.T, d *testdaemon.Daemon, op *models.Operation) *models.Operation {
+	deadline := time.Now().Add(3 * time.Second)
+	for !op.Completed {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for operation %s", op.ID)
+		}
+		time.Sleep(10 * time.Millisecond)
+		resp, err := d.Client.System.GetOperation(system.NewGetOperationParams().WithOperationID(op.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		op = resp.Payload
+	}
+	return op
+}
+
+// readWs reads binary messages from the console until want has been seen
+func readWs(t *testing.T, ws *websocket.Conn, want string) {
+	seen := []byte{}
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for !bytes.Contains(seen, []byte(want)) {
+		mt, msg, err := ws.ReadMessage()
+		if err != nil {
+			t.Fatalf("expected the console to show %q, got %q: %v", want, seen, err)
+		}
+		if mt == websocket.BinaryMessage {
+			seen = append(seen, msg...)
+		}
+	}
+}
+
+func TestMockVmLifecycle(t *testing.T) {
+	d, cleanup := startDaemon(t)
+	defer cleanup()
+
+	created, err := d.Client.Vms.CreateVM(vms.NewCreateVMParams().WithVMConfig(&models.NewVM{
+		Name:   "lifecycle",
+		Cpus:   1,
+		Memory: 128,
+		Type:   string(config.MockVmm),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := waitForOperation(t, d, created.Payload)
+	if op.Status != models.OperationStatusCompleted {
+		t.Fatalf("expected the vm to be created, got %s: %s", op.Status, op.Error)
+	}
+	id := op.Result.(map[string]interface{})["id"].(string)
+
+	list, err := d.Client.Vms.GetVMList(vms.NewGetVMListParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Payload) != 1 || string(list.Payload[0].ID) != id {
+		t.Fatalf("expected the new vm to be listed, got %+v", list.Payload)
+	}
+
+	if _, err := d.Client.Vms.StartVM(vms.NewStartVMParams().WithVMID(id)); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, id, "Running")
+
+	ws, _, err := websocket.DefaultDialer.Dial(d.ConsoleURL(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.WriteJSON(map[string]interface{}{
+		"operation": "connect-console",
+		"payload":   map[string]interface{}{"id": id},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	readWs(t, ws, "lifecycle login:")
+	if err := ws.WriteMessage(websocket.BinaryMessage, []byte("whoami\n")); err != nil {
+		t.Fatal(err)
+	}
+	readWs(t, ws, "whoami\n")
+	ws.Close()
+
+	if _, err := d.Client.Vms.DeleteVM(vms.NewDeleteVMParams().WithVMID(id)); err == nil {
+		t.Error("expected a running vm to not be deleted")
+	}
+	if _, err := d.Client.Vms.StopVM(vms.NewStopVMParams().WithVMID(id)); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, id, "Not started")
+
+	if _, err := d.Client.Vms.DeleteVM(vms.NewDeleteVMParams().WithVMID(id)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Client.Vms.GetVM(vms.NewGetVMParams().WithVMID(id)); err == nil {
+		t.Error("expected the deleted vm to be gone")
+	}
+	for _, path := range []string{
+		filepath.Join(d.AppRoot, "instances", id+".json"),
+		filepath.Join(d.AppRoot, "firecracker", id),
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", path, err)
+		}
+	}
+}
+
+func TestMockVmsLoadedOnStart(t *testing.T) {
+	autoStart := mockVmmConfig("autostart", &config.VmmMockConfig{BootDelay: 20})
+	autoStart.AutoStart = true
+	broken := mockVmmConfig("broken", &config.VmmMockConfig{FailStart: "no kvm"})
+	d, cleanup := startDaemon(t, autoStart, broken)
+	defer cleanup()
+
+	waitForStatus(t, d, "autostart", "Running")
+	if _, err := d.Client.Vms.StartVM(vms.NewStartVMParams().WithVMID("broken")); err == nil {
+		t.Fatal("expected the broken vm to fail to start")
+	}
+	vm := waitForStatus(t, d, "broken", "Not started")
+	if vm.LastExitReason != "no kvm" {
+		t.Errorf("expected the failure to be the exit reason, got %q", vm.LastExitReason)
+	}
+}
+
+func TestMockVmsStartOrder(t *testing.T) {
+	dependency := mockVmmConfig("dependency", &config.VmmMockConfig{BootDelay: 100})
+	dependent := mockVmmConfig("dependent", nil)
+	dependent.AutoStart = true
+	dependent.DependsOn = []string{"dependency"}
+	broken := mockVmmConfig("broken", &config.VmmMockConfig{FailStart: "no kvm"})
+	brokenDependent := mockVmmConfig("broken-dependent", nil)
+	brokenDependent.AutoStart = true
+	brokenDependent.DependsOn = []string{"broken"}
+	cycleA := mockVmmConfig("cycle-a", nil)
+	cycleA.AutoStart = true
+	cycleA.DependsOn = []string{"cycle-b"}
+	cycleB := mockVmmConfig("cycle-b", nil)
+	cycleB.AutoStart = true
+	cycleB.DependsOn = []string{"cycle-a"}
+	unknown := mockVmmConfig("unknown", nil)
+	unknown.AutoStart = true
+	unknown.DependsOn = []string{"missing"}
+	d, cleanup := startDaemon(t, dependency, dependent, broken, brokenDependent, cycleA, cycleB, unknown)
+	defer cleanup()
+
+	//the dependent is only started once its dependency has booted
+	waitForStatus(t, d, "dependent", "Running")
+	waitForStatus(t, d, "dependency", "Running")
+	time.Sleep(100 * time.Millisecond)
+	for _, id := range []string{"broken-dependent", "cycle-a", "cycle-b", "unknown"} {
+		waitForStatus(t, d, id, "Not started")
+	}
+}
+
+func TestMockVmPauseResumeConflicts(t *testing.T) {
+	d, cleanup := startDaemon(t, mockVmmConfig("pause", nil))
+	defer cleanup()
+
+	_, err := d.Client.Vms.PauseVM(vms.NewPauseVMParams().WithVMID("pause"))
+	if _, ok := err.(*vms.PauseVMConflict); !ok {
+		t.Fatalf("expected pausing a vm that isnt started to conflict, got %v", err)
+	}
+	if _, err := d.Client.Vms.StartVM(vms.NewStartVMParams().WithVMID("pause")); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, "pause", "Running")
+	_, err = d.Client.Vms.ResumeVM(vms.NewResumeVMParams().WithVMID("pause"))
+	if _, ok := err.(*vms.ResumeVMConflict); !ok {
+		t.Fatalf("expected resuming a running vm to conflict, got %v", err)
+	}
+	resp, err := d.Client.Vms.PauseVM(vms.NewPauseVMParams().WithVMID("pause"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Payload.Status != "Paused" {
+		t.Errorf("expected the vm to be paused, it is %q", resp.Payload.Status)
+	}
+	_, err = d.Client.Vms.PauseVM(vms.NewPauseVMParams().WithVMID("pause"))
+	if conflict, ok := err.(*vms.PauseVMConflict); !ok {
+		t.Fatalf("expected pausing a paused vm to conflict, got %v", err)
+	} else if conflict.Payload.Code != 409 {
+		t.Errorf("expected the conflict to be reported, got %v", conflict.Payload)
+	}
+	if _, err := d.Client.Vms.ResumeVM(vms.NewResumeVMParams().WithVMID("pause")); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, "pause", "Running")
+}
+
+func TestVsockProxyGuestPortValidation(t *testing.T) {
+	vsock := mockVmmConfig("vsock", nil)
+	vsock.Vsock = &config.VmmVsockConfig{CID: config.MinVsockCID}
+	d, cleanup := startDaemon(t, vsock)
+	defer cleanup()
+
+	guestPort := int64(70000)
+	_, err := d.Client.Vms.CreateVMVsockProxy(vms.NewCreateVMVsockProxyParams().WithVMID("vsock").WithProxyConfig(&models.NewVsockProxy{
+		GuestPort: &guestPort,
+	}))
+	if _, ok := err.(*vms.CreateVMVsockProxyBadRequest); !ok {
+		t.Fatalf("expected a guest port above 65535 to be refused, got %v", err)
+	}
+	resp, err := d.Client.Vms.GetVMVsockProxyList(vms.NewGetVMVsockProxyListParams().WithVMID("vsock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Payload) != 0 {
+		t.Errorf("expected no proxy to be created, got %v", resp.Payload)
+	}
+}
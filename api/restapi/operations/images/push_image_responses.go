@@ -13,42 +13,42 @@ import (
 	models "github.com/768bit/promethium/api/models"
 )
 
-// PushImageOKCode is the HTTP code returned for type PushImageOK
-const PushImageOKCode int = 200
+// PushImageAcceptedCode is the HTTP code returned for type PushImageAccepted
+const PushImageAcceptedCode int = 202
 
-/*PushImageOK successful operation
+/*PushImageAccepted The image is being imported
 
-swagger:response pushImageOK
+swagger:response pushImageAccepted
 */
-type PushImageOK struct {
+type PushImageAccepted struct {
 
 	/*
 	  In: Body
 	*/
-	Payload *models.VM `json:"body,omitempty"`
+	Payload *models.Operation `json:"body,omitempty"`
 }
 
-// NewPushImageOK creates PushImageOK with default headers values
-func NewPushImageOK() *PushImageOK {
+// NewPushImageAccepted creates PushImageAccepted with default headers values
+func NewPushImageAccepted() *PushImageAccepted {
 
-	return &PushImageOK{}
+	return &PushImageAccepted{}
 }
 
-// WithPayload adds the payload to the push image o k response
-func (o *PushImageOK) WithPayload(payload *models.VM) *PushImageOK {
+// WithPayload adds the payload to the push image accepted response
+func (o *PushImageAccepted) WithPayload(payload *models.Operation) *PushImageAccepted {
 	o.Payload = payload
 	return o
 }
 
-// SetPayload sets the payload to the push image o k response
-func (o *PushImageOK) SetPayload(payload *models.VM) {
+// SetPayload sets the payload to the push image accepted response
+func (o *PushImageAccepted) SetPayload(payload *models.Operation) {
 	o.Payload = payload
 }
 
 // WriteResponse to the client
-func (o *PushImageOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+func (o *PushImageAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.WriteHeader(200)
+	rw.WriteHeader(202)
 	if o.Payload != nil {
 		payload := o.Payload
 		if err := producer.Produce(rw, payload); err != nil {
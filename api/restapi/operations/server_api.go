@@ -22,6 +22,7 @@ import (
 	"github.com/768bit/promethium/api/restapi/operations/images"
 	"github.com/768bit/promethium/api/restapi/operations/networking"
 	"github.com/768bit/promethium/api/restapi/operations/storage"
+	"github.com/768bit/promethium/api/restapi/operations/system"
 	"github.com/768bit/promethium/api/restapi/operations/vms"
 )
 
@@ -52,6 +53,9 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		StorageGetStorageStorageIDKernelsHandler: storage.GetStorageStorageIDKernelsHandlerFunc(func(params storage.GetStorageStorageIDKernelsParams) middleware.Responder {
 			return middleware.NotImplemented("operation StorageGetStorageStorageIDKernels has not yet been implemented")
 		}),
+		SystemCancelOperationHandler: system.CancelOperationHandlerFunc(func(params system.CancelOperationParams) middleware.Responder {
+			return middleware.NotImplemented("operation SystemCancelOperation has not yet been implemented")
+		}),
 		ImagesCreateImageHandler: images.CreateImageHandlerFunc(func(params images.CreateImageParams) middleware.Responder {
 			return middleware.NotImplemented("operation ImagesCreateImage has not yet been implemented")
 		}),
@@ -70,9 +74,15 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsCreateVMInterfaceHandler: vms.CreateVMInterfaceHandlerFunc(func(params vms.CreateVMInterfaceParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsCreateVMInterface has not yet been implemented")
 		}),
+		VmsCreateVMSnapshotHandler: vms.CreateVMSnapshotHandlerFunc(func(params vms.CreateVMSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsCreateVMSnapshot has not yet been implemented")
+		}),
 		VmsCreateVMVolumeHandler: vms.CreateVMVolumeHandlerFunc(func(params vms.CreateVMVolumeParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsCreateVMVolume has not yet been implemented")
 		}),
+		VmsCreateVMVsockProxyHandler: vms.CreateVMVsockProxyHandlerFunc(func(params vms.CreateVMVsockProxyParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsCreateVMVsockProxy has not yet been implemented")
+		}),
 		VmsDeleteVMHandler: vms.DeleteVMHandlerFunc(func(params vms.DeleteVMParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsDeleteVM has not yet been implemented")
 		}),
@@ -82,15 +92,33 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsDeleteVMInterfaceHandler: vms.DeleteVMInterfaceHandlerFunc(func(params vms.DeleteVMInterfaceParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsDeleteVMInterface has not yet been implemented")
 		}),
+		VmsDeleteVMSnapshotHandler: vms.DeleteVMSnapshotHandlerFunc(func(params vms.DeleteVMSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsDeleteVMSnapshot has not yet been implemented")
+		}),
 		VmsDeleteVMVolumeHandler: vms.DeleteVMVolumeHandlerFunc(func(params vms.DeleteVMVolumeParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsDeleteVMVolume has not yet been implemented")
 		}),
+		VmsDeleteVMVsockProxyHandler: vms.DeleteVMVsockProxyHandlerFunc(func(params vms.DeleteVMVsockProxyParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsDeleteVMVsockProxy has not yet been implemented")
+		}),
 		NetworkingDestroyNetworkHandler: networking.DestroyNetworkHandlerFunc(func(params networking.DestroyNetworkParams) middleware.Responder {
 			return middleware.NotImplemented("operation NetworkingDestroyNetwork has not yet been implemented")
 		}),
 		StorageDestroyStorageHandler: storage.DestroyStorageHandlerFunc(func(params storage.DestroyStorageParams) middleware.Responder {
 			return middleware.NotImplemented("operation StorageDestroyStorage has not yet been implemented")
 		}),
+		SystemFixDoctorProblemsHandler: system.FixDoctorProblemsHandlerFunc(func(params system.FixDoctorProblemsParams) middleware.Responder {
+			return middleware.NotImplemented("operation SystemFixDoctorProblems has not yet been implemented")
+		}),
+		VmsGetConsoleRecordingHandler: vms.GetConsoleRecordingHandlerFunc(func(params vms.GetConsoleRecordingParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetConsoleRecording has not yet been implemented")
+		}),
+		VmsGetConsoleRecordingListHandler: vms.GetConsoleRecordingListHandlerFunc(func(params vms.GetConsoleRecordingListParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetConsoleRecordingList has not yet been implemented")
+		}),
+		SystemGetDoctorReportHandler: system.GetDoctorReportHandlerFunc(func(params system.GetDoctorReportParams) middleware.Responder {
+			return middleware.NotImplemented("operation SystemGetDoctorReport has not yet been implemented")
+		}),
 		ImagesGetImagesListHandler: images.GetImagesListHandlerFunc(func(params images.GetImagesListParams) middleware.Responder {
 			return middleware.NotImplemented("operation ImagesGetImagesList has not yet been implemented")
 		}),
@@ -103,6 +131,12 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		NetworkingGetNetworkListHandler: networking.GetNetworkListHandlerFunc(func(params networking.GetNetworkListParams) middleware.Responder {
 			return middleware.NotImplemented("operation NetworkingGetNetworkList has not yet been implemented")
 		}),
+		SystemGetOperationHandler: system.GetOperationHandlerFunc(func(params system.GetOperationParams) middleware.Responder {
+			return middleware.NotImplemented("operation SystemGetOperation has not yet been implemented")
+		}),
+		SystemGetOperationListHandler: system.GetOperationListHandlerFunc(func(params system.GetOperationListParams) middleware.Responder {
+			return middleware.NotImplemented("operation SystemGetOperationList has not yet been implemented")
+		}),
 		NetworkingGetPhysicalInterfacesHandler: networking.GetPhysicalInterfacesHandlerFunc(func(params networking.GetPhysicalInterfacesParams) middleware.Responder {
 			return middleware.NotImplemented("operation NetworkingGetPhysicalInterfaces has not yet been implemented")
 		}),
@@ -115,6 +149,12 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsGetVMHandler: vms.GetVMHandlerFunc(func(params vms.GetVMParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsGetVM has not yet been implemented")
 		}),
+		VmsGetVMAgentInfoHandler: vms.GetVMAgentInfoHandlerFunc(func(params vms.GetVMAgentInfoParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMAgentInfo has not yet been implemented")
+		}),
+		VmsGetVMBalloonHandler: vms.GetVMBalloonHandlerFunc(func(params vms.GetVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMBalloon has not yet been implemented")
+		}),
 		VmsGetVMConsoleHandler: vms.GetVMConsoleHandlerFunc(func(params vms.GetVMConsoleParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsGetVMConsole has not yet been implemented")
 		}),
@@ -133,12 +173,30 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsGetVMListHandler: vms.GetVMListHandlerFunc(func(params vms.GetVMListParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsGetVMList has not yet been implemented")
 		}),
+		VmsGetVMLogsHandler: vms.GetVMLogsHandlerFunc(func(params vms.GetVMLogsParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMLogs has not yet been implemented")
+		}),
+		VmsGetVMMetricsHandler: vms.GetVMMetricsHandlerFunc(func(params vms.GetVMMetricsParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMMetrics has not yet been implemented")
+		}),
+		VmsGetVMResourcesHandler: vms.GetVMResourcesHandlerFunc(func(params vms.GetVMResourcesParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMResources has not yet been implemented")
+		}),
+		VmsGetVMSnapshotListHandler: vms.GetVMSnapshotListHandlerFunc(func(params vms.GetVMSnapshotListParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMSnapshotList has not yet been implemented")
+		}),
 		VmsGetVMVolumeHandler: vms.GetVMVolumeHandlerFunc(func(params vms.GetVMVolumeParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsGetVMVolume has not yet been implemented")
 		}),
 		VmsGetVMVolumeListHandler: vms.GetVMVolumeListHandlerFunc(func(params vms.GetVMVolumeListParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsGetVMVolumeList has not yet been implemented")
 		}),
+		VmsGetVMVsockProxyListHandler: vms.GetVMVsockProxyListHandlerFunc(func(params vms.GetVMVsockProxyListParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsGetVMVsockProxyList has not yet been implemented")
+		}),
+		VmsPauseVMHandler: vms.PauseVMHandlerFunc(func(params vms.PauseVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsPauseVM has not yet been implemented")
+		}),
 		ImagesPullImageHandler: images.PullImageHandlerFunc(func(params images.PullImageParams) middleware.Responder {
 			return middleware.NotImplemented("operation ImagesPullImage has not yet been implemented")
 		}),
@@ -151,6 +209,12 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsRestartVMHandler: vms.RestartVMHandlerFunc(func(params vms.RestartVMParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsRestartVM has not yet been implemented")
 		}),
+		VmsRestoreVMSnapshotHandler: vms.RestoreVMSnapshotHandlerFunc(func(params vms.RestoreVMSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsRestoreVMSnapshot has not yet been implemented")
+		}),
+		VmsResumeVMHandler: vms.ResumeVMHandlerFunc(func(params vms.ResumeVMParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsResumeVM has not yet been implemented")
+		}),
 		VmsShutdownVMHandler: vms.ShutdownVMHandlerFunc(func(params vms.ShutdownVMParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsShutdownVM has not yet been implemented")
 		}),
@@ -169,12 +233,18 @@ func NewServerAPI(spec *loads.Document) *ServerAPI {
 		VmsUpdateVMHandler: vms.UpdateVMHandlerFunc(func(params vms.UpdateVMParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsUpdateVM has not yet been implemented")
 		}),
+		VmsUpdateVMBalloonHandler: vms.UpdateVMBalloonHandlerFunc(func(params vms.UpdateVMBalloonParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsUpdateVMBalloon has not yet been implemented")
+		}),
 		VmsUpdateVMDiskHandler: vms.UpdateVMDiskHandlerFunc(func(params vms.UpdateVMDiskParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsUpdateVMDisk has not yet been implemented")
 		}),
 		VmsUpdateVMInterfaceHandler: vms.UpdateVMInterfaceHandlerFunc(func(params vms.UpdateVMInterfaceParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsUpdateVMInterface has not yet been implemented")
 		}),
+		VmsUpdateVMResourcesHandler: vms.UpdateVMResourcesHandlerFunc(func(params vms.UpdateVMResourcesParams) middleware.Responder {
+			return middleware.NotImplemented("operation VmsUpdateVMResources has not yet been implemented")
+		}),
 		VmsUpdateVMVolumeHandler: vms.UpdateVMVolumeHandlerFunc(func(params vms.UpdateVMVolumeParams) middleware.Responder {
 			return middleware.NotImplemented("operation VmsUpdateVMVolume has not yet been implemented")
 		}),
@@ -217,6 +287,8 @@ type ServerAPI struct {
 	StorageGetStorageStorageIDImagesHandler storage.GetStorageStorageIDImagesHandler
 	// StorageGetStorageStorageIDKernelsHandler sets the operation handler for the get storage storage ID kernels operation
 	StorageGetStorageStorageIDKernelsHandler storage.GetStorageStorageIDKernelsHandler
+	// SystemCancelOperationHandler sets the operation handler for the cancel operation operation
+	SystemCancelOperationHandler system.CancelOperationHandler
 	// ImagesCreateImageHandler sets the operation handler for the create image operation
 	ImagesCreateImageHandler images.CreateImageHandler
 	// NetworkingCreateNetworkHandler sets the operation handler for the create network operation
@@ -229,20 +301,36 @@ type ServerAPI struct {
 	VmsCreateVMDiskHandler vms.CreateVMDiskHandler
 	// VmsCreateVMInterfaceHandler sets the operation handler for the create VM interface operation
 	VmsCreateVMInterfaceHandler vms.CreateVMInterfaceHandler
+	// VmsCreateVMSnapshotHandler sets the operation handler for the create VM snapshot operation
+	VmsCreateVMSnapshotHandler vms.CreateVMSnapshotHandler
 	// VmsCreateVMVolumeHandler sets the operation handler for the create VM volume operation
 	VmsCreateVMVolumeHandler vms.CreateVMVolumeHandler
+	// VmsCreateVMVsockProxyHandler sets the operation handler for the create VM vsock proxy operation
+	VmsCreateVMVsockProxyHandler vms.CreateVMVsockProxyHandler
 	// VmsDeleteVMHandler sets the operation handler for the delete VM operation
 	VmsDeleteVMHandler vms.DeleteVMHandler
 	// VmsDeleteVMDriveHandler sets the operation handler for the delete VM drive operation
 	VmsDeleteVMDriveHandler vms.DeleteVMDriveHandler
 	// VmsDeleteVMInterfaceHandler sets the operation handler for the delete VM interface operation
 	VmsDeleteVMInterfaceHandler vms.DeleteVMInterfaceHandler
+	// VmsDeleteVMSnapshotHandler sets the operation handler for the delete VM snapshot operation
+	VmsDeleteVMSnapshotHandler vms.DeleteVMSnapshotHandler
 	// VmsDeleteVMVolumeHandler sets the operation handler for the delete VM volume operation
 	VmsDeleteVMVolumeHandler vms.DeleteVMVolumeHandler
+	// VmsDeleteVMVsockProxyHandler sets the operation handler for the delete VM vsock proxy operation
+	VmsDeleteVMVsockProxyHandler vms.DeleteVMVsockProxyHandler
 	// NetworkingDestroyNetworkHandler sets the operation handler for the destroy network operation
 	NetworkingDestroyNetworkHandler networking.DestroyNetworkHandler
 	// StorageDestroyStorageHandler sets the operation handler for the destroy storage operation
 	StorageDestroyStorageHandler storage.DestroyStorageHandler
+	// SystemFixDoctorProblemsHandler sets the operation handler for the fix doctor problems operation
+	SystemFixDoctorProblemsHandler system.FixDoctorProblemsHandler
+	// VmsGetConsoleRecordingHandler sets the operation handler for the get console recording operation
+	VmsGetConsoleRecordingHandler vms.GetConsoleRecordingHandler
+	// VmsGetConsoleRecordingListHandler sets the operation handler for the get console recording list operation
+	VmsGetConsoleRecordingListHandler vms.GetConsoleRecordingListHandler
+	// SystemGetDoctorReportHandler sets the operation handler for the get doctor report operation
+	SystemGetDoctorReportHandler system.GetDoctorReportHandler
 	// ImagesGetImagesListHandler sets the operation handler for the get images list operation
 	ImagesGetImagesListHandler images.GetImagesListHandler
 	// NetworkingGetNetworkHandler sets the operation handler for the get network operation
@@ -251,6 +339,10 @@ type ServerAPI struct {
 	NetworkingGetNetworkInterfacesHandler networking.GetNetworkInterfacesHandler
 	// NetworkingGetNetworkListHandler sets the operation handler for the get network list operation
 	NetworkingGetNetworkListHandler networking.GetNetworkListHandler
+	// SystemGetOperationHandler sets the operation handler for the get operation operation
+	SystemGetOperationHandler system.GetOperationHandler
+	// SystemGetOperationListHandler sets the operation handler for the get operation list operation
+	SystemGetOperationListHandler system.GetOperationListHandler
 	// NetworkingGetPhysicalInterfacesHandler sets the operation handler for the get physical interfaces operation
 	NetworkingGetPhysicalInterfacesHandler networking.GetPhysicalInterfacesHandler
 	// StorageGetStorageHandler sets the operation handler for the get storage operation
@@ -259,6 +351,10 @@ type ServerAPI struct {
 	StorageGetStorageListHandler storage.GetStorageListHandler
 	// VmsGetVMHandler sets the operation handler for the get VM operation
 	VmsGetVMHandler vms.GetVMHandler
+	// VmsGetVMAgentInfoHandler sets the operation handler for the get VM agent info operation
+	VmsGetVMAgentInfoHandler vms.GetVMAgentInfoHandler
+	// VmsGetVMBalloonHandler sets the operation handler for the get VM balloon operation
+	VmsGetVMBalloonHandler vms.GetVMBalloonHandler
 	// VmsGetVMConsoleHandler sets the operation handler for the get VM console operation
 	VmsGetVMConsoleHandler vms.GetVMConsoleHandler
 	// VmsGetVMDiskHandler sets the operation handler for the get VM disk operation
@@ -271,10 +367,22 @@ type ServerAPI struct {
 	VmsGetVMInterfaceListHandler vms.GetVMInterfaceListHandler
 	// VmsGetVMListHandler sets the operation handler for the get VM list operation
 	VmsGetVMListHandler vms.GetVMListHandler
+	// VmsGetVMLogsHandler sets the operation handler for the get VM logs operation
+	VmsGetVMLogsHandler vms.GetVMLogsHandler
+	// VmsGetVMMetricsHandler sets the operation handler for the get VM metrics operation
+	VmsGetVMMetricsHandler vms.GetVMMetricsHandler
+	// VmsGetVMResourcesHandler sets the operation handler for the get VM resources operation
+	VmsGetVMResourcesHandler vms.GetVMResourcesHandler
+	// VmsGetVMSnapshotListHandler sets the operation handler for the get VM snapshot list operation
+	VmsGetVMSnapshotListHandler vms.GetVMSnapshotListHandler
 	// VmsGetVMVolumeHandler sets the operation handler for the get VM volume operation
 	VmsGetVMVolumeHandler vms.GetVMVolumeHandler
 	// VmsGetVMVolumeListHandler sets the operation handler for the get VM volume list operation
 	VmsGetVMVolumeListHandler vms.GetVMVolumeListHandler
+	// VmsGetVMVsockProxyListHandler sets the operation handler for the get VM vsock proxy list operation
+	VmsGetVMVsockProxyListHandler vms.GetVMVsockProxyListHandler
+	// VmsPauseVMHandler sets the operation handler for the pause VM operation
+	VmsPauseVMHandler vms.PauseVMHandler
 	// ImagesPullImageHandler sets the operation handler for the pull image operation
 	ImagesPullImageHandler images.PullImageHandler
 	// ImagesPushImageHandler sets the operation handler for the push image operation
@@ -283,6 +391,10 @@ type ServerAPI struct {
 	VmsResetVMHandler vms.ResetVMHandler
 	// VmsRestartVMHandler sets the operation handler for the restart VM operation
 	VmsRestartVMHandler vms.RestartVMHandler
+	// VmsRestoreVMSnapshotHandler sets the operation handler for the restore VM snapshot operation
+	VmsRestoreVMSnapshotHandler vms.RestoreVMSnapshotHandler
+	// VmsResumeVMHandler sets the operation handler for the resume VM operation
+	VmsResumeVMHandler vms.ResumeVMHandler
 	// VmsShutdownVMHandler sets the operation handler for the shutdown VM operation
 	VmsShutdownVMHandler vms.ShutdownVMHandler
 	// VmsStartVMHandler sets the operation handler for the start VM operation
@@ -295,10 +407,14 @@ type ServerAPI struct {
 	StorageUpdateStorageHandler storage.UpdateStorageHandler
 	// VmsUpdateVMHandler sets the operation handler for the update VM operation
 	VmsUpdateVMHandler vms.UpdateVMHandler
+	// VmsUpdateVMBalloonHandler sets the operation handler for the update VM balloon operation
+	VmsUpdateVMBalloonHandler vms.UpdateVMBalloonHandler
 	// VmsUpdateVMDiskHandler sets the operation handler for the update VM disk operation
 	VmsUpdateVMDiskHandler vms.UpdateVMDiskHandler
 	// VmsUpdateVMInterfaceHandler sets the operation handler for the update VM interface operation
 	VmsUpdateVMInterfaceHandler vms.UpdateVMInterfaceHandler
+	// VmsUpdateVMResourcesHandler sets the operation handler for the update VM resources operation
+	VmsUpdateVMResourcesHandler vms.UpdateVMResourcesHandler
 	// VmsUpdateVMVolumeHandler sets the operation handler for the update VM volume operation
 	VmsUpdateVMVolumeHandler vms.UpdateVMVolumeHandler
 
@@ -380,6 +496,10 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "storage.GetStorageStorageIDKernelsHandler")
 	}
 
+	if o.SystemCancelOperationHandler == nil {
+		unregistered = append(unregistered, "system.CancelOperationHandler")
+	}
+
 	if o.ImagesCreateImageHandler == nil {
 		unregistered = append(unregistered, "images.CreateImageHandler")
 	}
@@ -404,10 +524,18 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.CreateVMInterfaceHandler")
 	}
 
+	if o.VmsCreateVMSnapshotHandler == nil {
+		unregistered = append(unregistered, "vms.CreateVMSnapshotHandler")
+	}
+
 	if o.VmsCreateVMVolumeHandler == nil {
 		unregistered = append(unregistered, "vms.CreateVMVolumeHandler")
 	}
 
+	if o.VmsCreateVMVsockProxyHandler == nil {
+		unregistered = append(unregistered, "vms.CreateVMVsockProxyHandler")
+	}
+
 	if o.VmsDeleteVMHandler == nil {
 		unregistered = append(unregistered, "vms.DeleteVMHandler")
 	}
@@ -420,10 +548,18 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.DeleteVMInterfaceHandler")
 	}
 
+	if o.VmsDeleteVMSnapshotHandler == nil {
+		unregistered = append(unregistered, "vms.DeleteVMSnapshotHandler")
+	}
+
 	if o.VmsDeleteVMVolumeHandler == nil {
 		unregistered = append(unregistered, "vms.DeleteVMVolumeHandler")
 	}
 
+	if o.VmsDeleteVMVsockProxyHandler == nil {
+		unregistered = append(unregistered, "vms.DeleteVMVsockProxyHandler")
+	}
+
 	if o.NetworkingDestroyNetworkHandler == nil {
 		unregistered = append(unregistered, "networking.DestroyNetworkHandler")
 	}
@@ -432,6 +568,22 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "storage.DestroyStorageHandler")
 	}
 
+	if o.SystemFixDoctorProblemsHandler == nil {
+		unregistered = append(unregistered, "system.FixDoctorProblemsHandler")
+	}
+
+	if o.VmsGetConsoleRecordingHandler == nil {
+		unregistered = append(unregistered, "vms.GetConsoleRecordingHandler")
+	}
+
+	if o.VmsGetConsoleRecordingListHandler == nil {
+		unregistered = append(unregistered, "vms.GetConsoleRecordingListHandler")
+	}
+
+	if o.SystemGetDoctorReportHandler == nil {
+		unregistered = append(unregistered, "system.GetDoctorReportHandler")
+	}
+
 	if o.ImagesGetImagesListHandler == nil {
 		unregistered = append(unregistered, "images.GetImagesListHandler")
 	}
@@ -448,6 +600,14 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "networking.GetNetworkListHandler")
 	}
 
+	if o.SystemGetOperationHandler == nil {
+		unregistered = append(unregistered, "system.GetOperationHandler")
+	}
+
+	if o.SystemGetOperationListHandler == nil {
+		unregistered = append(unregistered, "system.GetOperationListHandler")
+	}
+
 	if o.NetworkingGetPhysicalInterfacesHandler == nil {
 		unregistered = append(unregistered, "networking.GetPhysicalInterfacesHandler")
 	}
@@ -464,6 +624,14 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.GetVMHandler")
 	}
 
+	if o.VmsGetVMAgentInfoHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMAgentInfoHandler")
+	}
+
+	if o.VmsGetVMBalloonHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMBalloonHandler")
+	}
+
 	if o.VmsGetVMConsoleHandler == nil {
 		unregistered = append(unregistered, "vms.GetVMConsoleHandler")
 	}
@@ -488,6 +656,22 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.GetVMListHandler")
 	}
 
+	if o.VmsGetVMLogsHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMLogsHandler")
+	}
+
+	if o.VmsGetVMMetricsHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMMetricsHandler")
+	}
+
+	if o.VmsGetVMResourcesHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMResourcesHandler")
+	}
+
+	if o.VmsGetVMSnapshotListHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMSnapshotListHandler")
+	}
+
 	if o.VmsGetVMVolumeHandler == nil {
 		unregistered = append(unregistered, "vms.GetVMVolumeHandler")
 	}
@@ -496,6 +680,14 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.GetVMVolumeListHandler")
 	}
 
+	if o.VmsGetVMVsockProxyListHandler == nil {
+		unregistered = append(unregistered, "vms.GetVMVsockProxyListHandler")
+	}
+
+	if o.VmsPauseVMHandler == nil {
+		unregistered = append(unregistered, "vms.PauseVMHandler")
+	}
+
 	if o.ImagesPullImageHandler == nil {
 		unregistered = append(unregistered, "images.PullImageHandler")
 	}
@@ -512,6 +704,14 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.RestartVMHandler")
 	}
 
+	if o.VmsRestoreVMSnapshotHandler == nil {
+		unregistered = append(unregistered, "vms.RestoreVMSnapshotHandler")
+	}
+
+	if o.VmsResumeVMHandler == nil {
+		unregistered = append(unregistered, "vms.ResumeVMHandler")
+	}
+
 	if o.VmsShutdownVMHandler == nil {
 		unregistered = append(unregistered, "vms.ShutdownVMHandler")
 	}
@@ -536,6 +736,10 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.UpdateVMHandler")
 	}
 
+	if o.VmsUpdateVMBalloonHandler == nil {
+		unregistered = append(unregistered, "vms.UpdateVMBalloonHandler")
+	}
+
 	if o.VmsUpdateVMDiskHandler == nil {
 		unregistered = append(unregistered, "vms.UpdateVMDiskHandler")
 	}
@@ -544,6 +748,10 @@ func (o *ServerAPI) Validate() error {
 		unregistered = append(unregistered, "vms.UpdateVMInterfaceHandler")
 	}
 
+	if o.VmsUpdateVMResourcesHandler == nil {
+		unregistered = append(unregistered, "vms.UpdateVMResourcesHandler")
+	}
+
 	if o.VmsUpdateVMVolumeHandler == nil {
 		unregistered = append(unregistered, "vms.UpdateVMVolumeHandler")
 	}
@@ -664,6 +872,11 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/storage/{storageID}/kernels"] = storage.NewGetStorageStorageIDKernels(o.context, o.StorageGetStorageStorageIDKernelsHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/operations/{operationID}"] = system.NewCancelOperation(o.context, o.SystemCancelOperationHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -694,11 +907,21 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/vms/{vmID}/interfaces"] = vms.NewCreateVMInterface(o.context, o.VmsCreateVMInterfaceHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vms/{vmID}/snapshots"] = vms.NewCreateVMSnapshot(o.context, o.VmsCreateVMSnapshotHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/vms/{vmID}/volumes"] = vms.NewCreateVMVolume(o.context, o.VmsCreateVMVolumeHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vms/{vmID}/vsock/proxies"] = vms.NewCreateVMVsockProxy(o.context, o.VmsCreateVMVsockProxyHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -714,11 +937,21 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/vms/{vmID}/interfaces/{interfaceID}"] = vms.NewDeleteVMInterface(o.context, o.VmsDeleteVMInterfaceHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/vms/{vmID}/snapshots/{snapshotID}"] = vms.NewDeleteVMSnapshot(o.context, o.VmsDeleteVMSnapshotHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/vms/{vmID}/volumes/{volumeID}"] = vms.NewDeleteVMVolume(o.context, o.VmsDeleteVMVolumeHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/vms/{vmID}/vsock/proxies/{proxyID}"] = vms.NewDeleteVMVsockProxy(o.context, o.VmsDeleteVMVsockProxyHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -729,6 +962,26 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/storage/{storageID}"] = storage.NewDestroyStorage(o.context, o.StorageDestroyStorageHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/doctor"] = system.NewFixDoctorProblems(o.context, o.SystemFixDoctorProblemsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/recordings/{recordingID}"] = vms.NewGetConsoleRecording(o.context, o.VmsGetConsoleRecordingHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/recordings"] = vms.NewGetConsoleRecordingList(o.context, o.VmsGetConsoleRecordingListHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/doctor"] = system.NewGetDoctorReport(o.context, o.SystemGetDoctorReportHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -749,6 +1002,16 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/networking"] = networking.NewGetNetworkList(o.context, o.NetworkingGetNetworkListHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/operations/{operationID}"] = system.NewGetOperation(o.context, o.SystemGetOperationHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/operations"] = system.NewGetOperationList(o.context, o.SystemGetOperationListHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -769,6 +1032,16 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/vms/{vmID}"] = vms.NewGetVM(o.context, o.VmsGetVMHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/agent"] = vms.NewGetVMAgentInfo(o.context, o.VmsGetVMAgentInfoHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/balloon"] = vms.NewGetVMBalloon(o.context, o.VmsGetVMBalloonHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -799,6 +1072,26 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/vms"] = vms.NewGetVMList(o.context, o.VmsGetVMListHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/logs"] = vms.NewGetVMLogs(o.context, o.VmsGetVMLogsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/metrics"] = vms.NewGetVMMetrics(o.context, o.VmsGetVMMetricsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/resources"] = vms.NewGetVMResources(o.context, o.VmsGetVMResourcesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/snapshots"] = vms.NewGetVMSnapshotList(o.context, o.VmsGetVMSnapshotListHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -809,6 +1102,16 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/vms/{vmID}/volumes"] = vms.NewGetVMVolumeList(o.context, o.VmsGetVMVolumeListHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/vsock/proxies"] = vms.NewGetVMVsockProxyList(o.context, o.VmsGetVMVsockProxyListHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/pause"] = vms.NewPauseVM(o.context, o.VmsPauseVMHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -829,6 +1132,16 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/vms/{vmID}/restart"] = vms.NewRestartVM(o.context, o.VmsRestartVMHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/vms/{vmID}/snapshots/{snapshotID}/restore"] = vms.NewRestoreVMSnapshot(o.context, o.VmsRestoreVMSnapshotHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/vms/{vmID}/resume"] = vms.NewResumeVM(o.context, o.VmsResumeVMHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -859,6 +1172,11 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["PUT"]["/vms/{vmID}"] = vms.NewUpdateVM(o.context, o.VmsUpdateVMHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/vms/{vmID}/balloon"] = vms.NewUpdateVMBalloon(o.context, o.VmsUpdateVMBalloonHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -869,6 +1187,11 @@ func (o *ServerAPI) initHandlerCache() {
 	}
 	o.handlers["PUT"]["/vms/{vmID}/interfaces/{interfaceID}"] = vms.NewUpdateVMInterface(o.context, o.VmsUpdateVMInterfaceHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/vms/{vmID}/resources"] = vms.NewUpdateVMResources(o.context, o.VmsUpdateVMResourcesHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -13,42 +13,42 @@ import (
 	models "github.com/768bit/promethium/api/models"
 )
 
-// CreateVMOKCode is the HTTP code returned for type CreateVMOK
-const CreateVMOKCode int = 200
+// CreateVMAcceptedCode is the HTTP code returned for type CreateVMAccepted
+const CreateVMAcceptedCode int = 202
 
-/*CreateVMOK successful operation
+/*CreateVMAccepted The VM is being created - the result of the operation is the VM
 
-swagger:response createVmOK
+swagger:response createVmAccepted
 */
-type CreateVMOK struct {
+type CreateVMAccepted struct {
 
 	/*
 	  In: Body
 	*/
-	Payload *models.VM `json:"body,omitempty"`
+	Payload *models.Operation `json:"body,omitempty"`
 }
 
-// NewCreateVMOK creates CreateVMOK with default headers values
-func NewCreateVMOK() *CreateVMOK {
+// NewCreateVMAccepted creates CreateVMAccepted with default headers values
+func NewCreateVMAccepted() *CreateVMAccepted {
 
-	return &CreateVMOK{}
+	return &CreateVMAccepted{}
 }
 
-// WithPayload adds the payload to the create Vm o k response
-func (o *CreateVMOK) WithPayload(payload *models.VM) *CreateVMOK {
+// WithPayload adds the payload to the create Vm accepted response
+func (o *CreateVMAccepted) WithPayload(payload *models.Operation) *CreateVMAccepted {
 	o.Payload = payload
 	return o
 }
 
-// SetPayload sets the payload to the create Vm o k response
-func (o *CreateVMOK) SetPayload(payload *models.VM) {
+// SetPayload sets the payload to the create Vm accepted response
+func (o *CreateVMAccepted) SetPayload(payload *models.Operation) {
 	o.Payload = payload
 }
 
 // WriteResponse to the client
-func (o *CreateVMOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+func (o *CreateVMAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.WriteHeader(200)
+	rw.WriteHeader(202)
 	if o.Payload != nil {
 		payload := o.Payload
 		if err := producer.Produce(rw, payload); err != nil {
@@ -104,3 +104,61 @@ func (o *CreateVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtim
 
 	rw.WriteHeader(404)
 }
+
+/*CreateVMDefault unexpected error
+
+swagger:response createVmDefault
+*/
+type CreateVMDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewCreateVMDefault creates CreateVMDefault with default headers values
+func NewCreateVMDefault(code int) *CreateVMDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &CreateVMDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the create VM default response
+func (o *CreateVMDefault) WithStatusCode(code int) *CreateVMDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the create VM default response
+func (o *CreateVMDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the create VM default response
+func (o *CreateVMDefault) WithPayload(payload *models.Error) *CreateVMDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the create VM default response
+func (o *CreateVMDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CreateVMDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -33,7 +33,7 @@ func NewDeleteVM(ctx *middleware.Context, handler DeleteVMHandler) *DeleteVM {
 
 Destroy a VM instance
 
-Destroy an isntance of VM
+Destroys a stopped VM along with its disks, snapshots and logs
 
 */
 type DeleteVM struct {
@@ -30,7 +30,7 @@ type DeleteVMParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
-	/*ID of VM to return
+	/*ID of VM to delete
 	  Required: true
 	  In: path
 	*/
@@ -21,11 +21,6 @@ const DeleteVMOKCode int = 200
 swagger:response deleteVmOK
 */
 type DeleteVMOK struct {
-
-	/*
-	  In: Body
-	*/
-	Payload *models.Item `json:"body,omitempty"`
 }
 
 // NewDeleteVMOK creates DeleteVMOK with default headers values
@@ -34,33 +29,18 @@ func NewDeleteVMOK() *DeleteVMOK {
 	return &DeleteVMOK{}
 }
 
-// WithPayload adds the payload to the delete Vm o k response
-func (o *DeleteVMOK) WithPayload(payload *models.Item) *DeleteVMOK {
-	o.Payload = payload
-	return o
-}
-
-// SetPayload sets the payload to the delete Vm o k response
-func (o *DeleteVMOK) SetPayload(payload *models.Item) {
-	o.Payload = payload
-}
-
 // WriteResponse to the client
 func (o *DeleteVMOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
 	rw.WriteHeader(200)
-	if o.Payload != nil {
-		payload := o.Payload
-		if err := producer.Produce(rw, payload); err != nil {
-			panic(err) // let the recovery middleware deal with this
-		}
-	}
 }
 
 // DeleteVMBadRequestCode is the HTTP code returned for type DeleteVMBadRequest
 const DeleteVMBadRequestCode int = 400
 
-/*DeleteVMBadRequest Invalid ID supplied
+/*DeleteVMBadRequest The VM is still running or couldnt be deleted
 
 swagger:response deleteVmBadRequest
 */
@@ -104,3 +84,47 @@ func (o *DeleteVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtim
 
 	rw.WriteHeader(404)
 }
+
+// DeleteVMConflictCode is the HTTP code returned for type DeleteVMConflict
+const DeleteVMConflictCode int = 409
+
+/*DeleteVMConflict Another operation is in progress on the VM
+
+swagger:response deleteVmConflict
+*/
+type DeleteVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeleteVMConflict creates DeleteVMConflict with default headers values
+func NewDeleteVMConflict() *DeleteVMConflict {
+
+	return &DeleteVMConflict{}
+}
+
+// WithPayload adds the payload to the delete Vm conflict response
+func (o *DeleteVMConflict) WithPayload(payload *models.Error) *DeleteVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete Vm conflict response
+func (o *DeleteVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeleteVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -104,3 +104,47 @@ func (o *ResetVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime
 
 	rw.WriteHeader(404)
 }
+
+// ResetVMConflictCode is the HTTP code returned for type ResetVMConflict
+const ResetVMConflictCode int = 409
+
+/*ResetVMConflict Another operation is in progress on the VM
+
+swagger:response resetVmConflict
+*/
+type ResetVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewResetVMConflict creates ResetVMConflict with default headers values
+func NewResetVMConflict() *ResetVMConflict {
+
+	return &ResetVMConflict{}
+}
+
+// WithPayload adds the payload to the reset Vm conflict response
+func (o *ResetVMConflict) WithPayload(payload *models.Error) *ResetVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the reset Vm conflict response
+func (o *ResetVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResetVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -104,3 +104,47 @@ func (o *RestartVMNotFound) WriteResponse(rw http.ResponseWriter, producer runti
 
 	rw.WriteHeader(404)
 }
+
+// RestartVMConflictCode is the HTTP code returned for type RestartVMConflict
+const RestartVMConflictCode int = 409
+
+/*RestartVMConflict Another operation is in progress on the VM
+
+swagger:response restartVmConflict
+*/
+type RestartVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewRestartVMConflict creates RestartVMConflict with default headers values
+func NewRestartVMConflict() *RestartVMConflict {
+
+	return &RestartVMConflict{}
+}
+
+// WithPayload adds the payload to the restart Vm conflict response
+func (o *RestartVMConflict) WithPayload(payload *models.Error) *RestartVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the restart Vm conflict response
+func (o *RestartVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RestartVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -104,3 +104,47 @@ func (o *ShutdownVMNotFound) WriteResponse(rw http.ResponseWriter, producer runt
 
 	rw.WriteHeader(404)
 }
+
+// ShutdownVMConflictCode is the HTTP code returned for type ShutdownVMConflict
+const ShutdownVMConflictCode int = 409
+
+/*ShutdownVMConflict Another operation is in progress on the VM
+
+swagger:response shutdownVmConflict
+*/
+type ShutdownVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewShutdownVMConflict creates ShutdownVMConflict with default headers values
+func NewShutdownVMConflict() *ShutdownVMConflict {
+
+	return &ShutdownVMConflict{}
+}
+
+// WithPayload adds the payload to the shutdown Vm conflict response
+func (o *ShutdownVMConflict) WithPayload(payload *models.Error) *ShutdownVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the shutdown Vm conflict response
+func (o *ShutdownVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ShutdownVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -104,3 +104,47 @@ func (o *StartVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime
 
 	rw.WriteHeader(404)
 }
+
+// StartVMConflictCode is the HTTP code returned for type StartVMConflict
+const StartVMConflictCode int = 409
+
+/*StartVMConflict Another operation is in progress on the VM
+
+swagger:response startVmConflict
+*/
+type StartVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewStartVMConflict creates StartVMConflict with default headers values
+func NewStartVMConflict() *StartVMConflict {
+
+	return &StartVMConflict{}
+}
+
+// WithPayload adds the payload to the start Vm conflict response
+func (o *StartVMConflict) WithPayload(payload *models.Error) *StartVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the start Vm conflict response
+func (o *StartVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *StartVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -104,3 +104,47 @@ func (o *StopVMNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.
 
 	rw.WriteHeader(404)
 }
+
+// StopVMConflictCode is the HTTP code returned for type StopVMConflict
+const StopVMConflictCode int = 409
+
+/*StopVMConflict Another operation is in progress on the VM
+
+swagger:response stopVmConflict
+*/
+type StopVMConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewStopVMConflict creates StopVMConflict with default headers values
+func NewStopVMConflict() *StopVMConflict {
+
+	return &StopVMConflict{}
+}
+
+// WithPayload adds the payload to the stop Vm conflict response
+func (o *StopVMConflict) WithPayload(payload *models.Error) *StopVMConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the stop Vm conflict response
+func (o *StopVMConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *StopVMConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// promethium-agent runs inside a guest and lets the daemon run commands, copy files and shut the guest down over
+// vsock. It is built statically so it can be dropped into any image:
+//
+//	CGO_ENABLED=0 go build -ldflags "-s -w" -o promethium-agent ./cmd/agent
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/768bit/promethium/lib/agent"
+)
+
+func main() {
+	port := flag.Uint("port", uint(agent.DefaultPort), "the vsock port to listen on")
+	listen := flag.String("listen", "", "listen on a tcp address instead of vsock")
+	flag.Parse()
+
+	var listener net.Listener
+	var err error
+	if *listen != "" {
+		listener, err = net.Listen("tcp", *listen)
+	} else {
+		listener, err = agent.ListenVsock(uint32(*port))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("promethium-agent %s listening on %s", agent.Version, listener.Addr().String())
+	log.Fatal(agent.NewServer().Serve(listener))
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	client "github.com/768bit/promethium/api/client"
+	"github.com/768bit/promethium/api/client/system"
+	"github.com/768bit/promethium/api/models"
+	"github.com/urfave/cli/v2"
+)
+
+const progressBarWidth = 40
+
+// NoWaitFlag is given to the commands that start an operation so they can return without waiting for it
+var NoWaitFlag = &cli.BoolFlag{
+	Name:  "no-wait",
+	Usage: "print the id of the operation instead of waiting for it to finish",
+}
+
+// WaitForOperation polls an operation and draws its progress until it finishes - an interrupt cancels the operation
+// and waits for it to stop. The error is set when the operation didnt complete.
+func WaitForOperation(apiCli *client.Promethium, op *models.Operation, out io.Writer) (*models.Operation, error) {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	cancelled := false
+	for !op.Completed {
+		DrawProgress(out, op)
+		select {
+		case <-interrupt:
+			if !cancelled {
+				cancelled = true
+				fmt.Fprintf(out, "\nCancelling %s...\n", op.ID)
+				if _, err := apiCli.System.CancelOperation(system.NewCancelOperationParams().WithOperationID(op.ID)); err != nil {
+					fmt.Fprintf(out, "Unable to cancel: %s\n", err.Error())
+				}
+			}
+		case <-ticker.C:
+		}
+		resp, err := apiCli.System.GetOperation(system.NewGetOperationParams().WithOperationID(op.ID))
+		if err != nil {
+			fmt.Fprintln(out)
+			return op, err
+		}
+		op = resp.Payload
+	}
+	DrawProgress(out, op)
+	fmt.Fprintln(out)
+	switch op.Status {
+	case models.OperationStatusCompleted:
+		return op, nil
+	case models.OperationStatusCancelled:
+		return op, errors.New("The operation was cancelled")
+	default:
+		return op, errors.New(op.Error)
+	}
+}
+
+// DrawProgress redraws the progress bar of an operation on the current line
+func DrawProgress(out io.Writer, op *models.Operation) {
+	filled := int(op.Progress) * progressBarWidth / 100
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	message := op.Message
+	if op.Completed {
+		message = op.Status
+	}
+	//pad the message so a shorter one clears what was drawn before
+	fmt.Fprintf(out, "\r[%s] %3d%% %-30s", bar, op.Progress, message)
+}
+
+// OperationResultID is the ID of what an operation produced - results are decoded without their type so it is
+// looked up by name
+func OperationResultID(op *models.Operation) string {
+	if result, ok := op.Result.(map[string]interface{}); ok {
+		if id, ok := result["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/urfave/cli/v2"
+)
+
+var RunHelperCommand = cli.Command{
+	Name:  "helper",
+	Usage: "Run the privileged helper the Promethium Daemon uses once it has dropped its privileges. It keeps running when the daemon exits so the jailers it started survive a restart.",
+	Action: func(c *cli.Context) error {
+		if os.Geteuid() != 0 {
+			return errors.New("The privileged helper has to be run as root")
+		}
+		cfg, err := config.LoadPromethiumDaemonConfig()
+		if err != nil {
+			return err
+		}
+		uid, err := config.GetUserId(cfg.User)
+		if err != nil {
+			return err
+		}
+		gid, err := config.GetGroupId(cfg.Group)
+		if err != nil {
+			return err
+		}
+		server := privhelper.NewServer(privhelper.NewLocal(cfg.AppRoot, privhelper.DefaultCgroupRoot), uid)
+		//a helper of another build is replaced without the jailers it looks after losing their stdio
+		if taken, err := server.TakeOver(cfg.GetHelperSocketPath()); err != nil {
+			return fmt.Errorf("Unable to take over from the running privileged helper: %s", err.Error())
+		} else if taken > 0 {
+			log.Printf("Took over %d jailers from the running privileged helper", taken)
+		}
+		if err := server.Listen(cfg.GetHelperSocketPath(), gid); err != nil {
+			return err
+		}
+		log.Printf("Privileged helper listening on %s", cfg.GetHelperSocketPath())
+		return server.Serve()
+	},
+}
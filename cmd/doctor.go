@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	client "github.com/768bit/promethium/api/client"
+	"github.com/768bit/promethium/api/client/system"
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/cmd/common"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var DoctorCommand = cli.Command{
+	Name:  "doctor",
+	Usage: "Check for resources left behind by VMs that are gone and VMs missing their disks.",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "fix",
+			Usage: "clean up the problems that can be cleaned up safely",
+		},
+		&cli.StringFlag{
+			Name: "host, h",
+		},
+		&cli.IntFlag{
+			Name: "port, p",
+		},
+		&cli.BoolFlag{
+			Name: "tcp, t",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		var apiCli *client.Promethium
+		if !c.Bool("tcp") && c.String("host") == "" && c.Int("port") == 0 {
+			apiCli = common.MakeClientUnix()
+		} else {
+			if !c.Bool("tcp") {
+				return errors.New("Must use the --tcp, -t flag if connecting to tcp socket")
+			}
+			host := c.String("host")
+			if host == "" {
+				host = "http://127.0.0.1"
+			}
+			port := c.Int("port")
+			if port == 0 {
+				port = 8921
+			}
+			apiCli = common.MakeClient(host, port, "")
+		}
+		var report *models.DoctorReport
+		if c.Bool("fix") {
+			resp, err := apiCli.System.FixDoctorProblems(system.NewFixDoctorProblemsParams())
+			if err != nil {
+				return err
+			}
+			report = resp.Payload
+		} else {
+			resp, err := apiCli.System.GetDoctorReport(system.NewGetDoctorReportParams())
+			if err != nil {
+				return err
+			}
+			report = resp.Payload
+		}
+		if len(report.Problems) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"Kind", "Resource", "VM", "Description", "Status"}, nil, nil, false)
+		unfixed := 0
+		for _, problem := range report.Problems {
+			status := "fixable"
+			if problem.Fixed {
+				status = "fixed"
+			} else if problem.Error != "" {
+				status = "failed: " + problem.Error
+			} else if !problem.Fixable {
+				status = "manual"
+			}
+			if !problem.Fixed {
+				unfixed++
+			}
+			printer.RenderRow([]string{problem.Kind, problem.Resource, problem.VMID, problem.Description, status}, nil)
+		}
+		if c.Bool("fix") && unfixed > 0 {
+			return fmt.Errorf("%d problems were not fixed", unfixed)
+		}
+		return nil
+	},
+}
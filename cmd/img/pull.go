@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/768bit/promethium/api/client/images"
+	"github.com/768bit/promethium/cmd/common"
 	"github.com/go-openapi/runtime"
 )
 
@@ -17,6 +18,7 @@ var PullImageCommand = cli.Command{
 			Aliases: []string{"t"},
 			Value:   "default-local",
 		},
+		common.NoWaitFlag,
 	},
 	Action: func(c *cli.Context) error {
 		//get args (which is path)
@@ -35,10 +37,15 @@ var PullImageCommand = cli.Command{
 					params.SetInFileBlob(runtime.NamedReader("inFileBlob", brdr))
 					params.SetTargetStorage(&storageTarget)
 					resp, err := ApiCli.Images.PushImage(params)
+					brdr.Close()
 					if err != nil {
 						return err
 					}
-					println(resp.Error())
+					if c.Bool("no-wait") {
+						println(resp.Payload.ID)
+					} else if _, err := common.WaitForOperation(ApiCli, resp.Payload, os.Stdout); err != nil {
+						return err
+					}
 				} else {
 					println("Remote Path", path)
 				}
@@ -34,8 +34,11 @@ func main() {
 	app.Commands = []*cli.Command{
 		&InstallCommand,
 		&daemon.RunDaemonCommand,
+		&daemon.RunHelperCommand,
 		&vmm.VmmSubCommand,
 		&img.ImagesSubCommand,
+		&DoctorCommand,
+		&OperationsCommand,
 	}
 
 	err := app.Run(os.Args)
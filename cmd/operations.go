@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	client "github.com/768bit/promethium/api/client"
+	"github.com/768bit/promethium/api/client/system"
+	"github.com/768bit/promethium/cmd/common"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var operationsCli *client.Promethium
+
+var OperationsCommand = cli.Command{
+	Name:    "operations",
+	Aliases: []string{"ops"},
+	Usage:   "Follow and cancel long running operations like creating VMs and importing images.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name: "host, h",
+		},
+		&cli.IntFlag{
+			Name: "port, p",
+		},
+		&cli.BoolFlag{
+			Name: "tcp, t",
+		},
+	},
+	Before: func(context *cli.Context) error {
+		if !context.Bool("tcp") && context.String("host") == "" && context.Int("port") == 0 {
+			operationsCli = common.MakeClientUnix()
+		} else {
+			if !context.Bool("tcp") {
+				return errors.New("Must use the --tcp, -t flag if connecting to tcp socket")
+			}
+			host := context.String("host")
+			if host == "" {
+				host = "http://127.0.0.1"
+			}
+			port := context.Int("port")
+			if port == 0 {
+				port = 8921
+			}
+			operationsCli = common.MakeClient(host, port, "")
+		}
+		return nil
+	},
+	Subcommands: []*cli.Command{
+		&ListOperationsCommand,
+		&WaitOperationCommand,
+		&CancelOperationCommand,
+	},
+}
+
+var ListOperationsCommand = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "List the operations in progress or finished recently.",
+	Action: func(c *cli.Context) error {
+		resp, err := operationsCli.System.GetOperationList(system.NewGetOperationListParams())
+		if err != nil {
+			return err
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"ID", "Kind", "Description", "Status", "Progress", "Created", "Error"}, nil, nil, false)
+		for _, op := range resp.Payload {
+			printer.RenderRow([]string{op.ID, op.Kind, op.Description, op.Status, fmt.Sprintf("%d%%", op.Progress), op.CreatedAt.String(), op.Error}, nil)
+		}
+		return nil
+	},
+}
+
+var WaitOperationCommand = cli.Command{
+	Name:      "wait",
+	Usage:     "Wait for an operation to finish.",
+	ArgsUsage: "<operation id>",
+	Action: func(c *cli.Context) error {
+		resp, err := operationsCli.System.GetOperation(system.NewGetOperationParams().WithOperationID(c.Args().Get(0)))
+		if err != nil {
+			return err
+		}
+		op, err := common.WaitForOperation(operationsCli, resp.Payload, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if id := common.OperationResultID(op); id != "" {
+			println(id)
+		}
+		return nil
+	},
+}
+
+var CancelOperationCommand = cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancel an operation.",
+	ArgsUsage: "<operation id>",
+	Action: func(c *cli.Context) error {
+		_, err := operationsCli.System.CancelOperation(system.NewCancelOperationParams().WithOperationID(c.Args().Get(0)))
+		return err
+	},
+}
@@ -0,0 +1,36 @@
+package vmm
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var AgentInfoCommand = cli.Command{
+	Name:      "agent",
+	Usage:     "Show the guest agent version and network interfaces of an instance.",
+	ArgsUsage: "<vm id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetVMAgentInfoParams()
+		params.SetVMID(c.Args().Get(0))
+		resp, err := ApiCli.Vms.GetVMAgentInfo(params)
+		if err != nil {
+			return err
+		}
+		info := resp.Payload
+		uptime := time.Duration(info.Uptime * float64(time.Second)).Round(time.Second)
+		fmt.Printf("Agent: %s\nHostname: %s\nUptime: %s\n\n", info.Version, info.Hostname, uptime.String())
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"Interface", "MAC", "MTU", "Up", "Addresses"}, nil, nil, false)
+		for _, iface := range info.Interfaces {
+			printer.RenderRow([]string{iface.Name, iface.Mac, strconv.FormatInt(iface.Mtu, 10), strconv.FormatBool(iface.Up), strings.Join(iface.Addresses, ", ")}, nil)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,47 @@
+package vmm
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/urfave/cli/v2"
+)
+
+var BalloonCommand = cli.Command{
+	Name:      "balloon",
+	Usage:     "Show or resize the memory balloon of an instance.",
+	ArgsUsage: "<vm id> [target MiB]",
+	Action: func(c *cli.Context) error {
+		vmID := c.Args().Get(0)
+		var balloon *models.VMBalloon
+		if c.NArg() > 1 {
+			amount, err := strconv.ParseInt(c.Args().Get(1), 10, 64)
+			if err != nil {
+				return fmt.Errorf("Invalid balloon target %q: %s", c.Args().Get(1), err.Error())
+			}
+			params := vms.NewUpdateVMBalloonParams()
+			params.SetVMID(vmID)
+			params.SetBalloonConfig(&models.UpdateVMBalloon{AmountMib: &amount})
+			resp, err := ApiCli.Vms.UpdateVMBalloon(params)
+			if err != nil {
+				return err
+			}
+			balloon = resp.Payload
+		} else {
+			params := vms.NewGetVMBalloonParams()
+			params.SetVMID(vmID)
+			resp, err := ApiCli.Vms.GetVMBalloon(params)
+			if err != nil {
+				return err
+			}
+			balloon = resp.Payload
+		}
+		fmt.Printf("Target: %d MiB\nActual: %d MiB\nDeflate on OOM: %t\n", balloon.AmountMib, balloon.ActualMib, balloon.DeflateOnOom)
+		if balloon.StatsInterval > 0 {
+			fmt.Printf("Statistics every: %ds\n", balloon.StatsInterval)
+		}
+		return nil
+	},
+}
@@ -1,14 +1,19 @@
 package vmm
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/768bit/promethium/cmd/common"
+	"github.com/gorilla/websocket"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh/terminal"
 	"golang.org/x/sys/unix"
 )
 
@@ -25,187 +30,196 @@ var (
 	readWaitDuration  time.Duration = time.Duration(400 * time.Millisecond)
 )
 
-func setSaneTermMode() {
-	raw, err := unix.IoctlGetTermios(STDINFILENO, unix.TCGETS)
-	if err != nil {
-		println(err.Error())
-		return
+// DefaultDetachKeys leaves the console without sending anything to the vm - the same as virsh console
+const DefaultDetachKeys = "ctrl-]"
+
+// parseDetachKeys turns a comma separated list of keys like "ctrl-p,ctrl-q" or "ctrl-],q" into the bytes typed
+func parseDetachKeys(keys string) ([]byte, error) {
+	sequence := []byte{}
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		switch {
+		case len(key) == 1:
+			sequence = append(sequence, key[0])
+		case strings.HasPrefix(strings.ToLower(key), "ctrl-") && len(key) == 6:
+			char := key[5]
+			switch {
+			case char >= 'a' && char <= 'z':
+				sequence = append(sequence, char-'a'+1)
+			case char >= '@' && char <= '_':
+				//covers ctrl-@, ctrl-A to ctrl-Z and ctrl-[ \ ] ^ _
+				sequence = append(sequence, char-'@')
+			default:
+				return nil, fmt.Errorf("Invalid detach key %s", key)
+			}
+		default:
+			return nil, fmt.Errorf("Invalid detach key %s", key)
+		}
 	}
-	rawState := *raw
-	rawState.Iflag &^= unix.IGNBRK | unix.INLCR | unix.IGNCR | unix.IUTF8 | unix.IXOFF | unix.IUCLC | unix.IXANY
-	rawState.Iflag |= unix.BRKINT | unix.ICRNL | unix.IMAXBEL
-	rawState.Oflag |= unix.OPOST | unix.ONLCR
-	rawState.Oflag &^= unix.OLCUC | unix.OCRNL | unix.ONOCR | unix.ONLRET
-	rawState.Cflag |= unix.CREAD
-	err = unix.IoctlSetTermios(STDINFILENO, unix.TCSETS, &rawState)
-
-	if err != nil {
-		println(err.Error())
+	if len(sequence) == 0 {
+		return nil, errors.New("The detach key sequence cannot be empty")
 	}
-
-	exec.Command("stty", "-F", "/dev/tty", "sane").Run()
-
+	return sequence, nil
 }
 
-var EOF_CHAR = byte(0x4)
-
 var InstanceConsoleCommand = cli.Command{
 	Name:  "console",
 	Usage: "Get instance console.",
-	Flags: []cli.Flag{},
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "read-only",
+			Aliases: []string{"ro"},
+			Usage:   "watch the console without sending any input",
+		},
+		&cli.StringFlag{
+			Name:    "detach-keys",
+			Value:   DefaultDetachKeys,
+			EnvVars: []string{"PROMETHIUM_DETACH_KEYS"},
+			Usage:   "the key sequence that leaves the console without stopping anything running on it",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		id := c.Args().Get(0)
+		readOnly := c.Bool("read-only")
+		detachKeys, err := parseDetachKeys(c.String("detach-keys"))
+		if err != nil {
+			return err
+		}
+		isTerminal := terminal.IsTerminal(STDINFILENO)
+		cols, rows := 80, 24
+		if isTerminal {
+			if w, h, err := terminal.GetSize(STDINFILENO); err == nil {
+				cols, rows = w, h
+			}
+		}
 		ws, err := common.MakeWebSocketClientUnix("/consolews")
 		if err != nil {
 			return err
 		}
 		ws.SetCloseHandler(func(code int, text string) error {
-			fmt.Printf("WebSocket Closed: %d : %s\n", code, text)
+			fmt.Printf("WebSocket Closed: %d : %s\r\n", code, text)
 			return nil
 		})
 		defer ws.Close()
+		//gorilla only allows one writer at a time and input and resizes are sent from different goroutines
+		writeLock := sync.Mutex{}
 		err = ws.WriteJSON(common.OutboundJsonMessage{
 			ID:        "",
 			Operation: "connect-console",
 			Payload: map[string]interface{}{
-				"id": id,
+				"id":       id,
+				"readOnly": readOnly,
+				"cols":     cols,
+				"rows":     rows,
 			},
 		})
 		if err != nil {
 			return err
 		}
 
-		raw, err := unix.IoctlGetTermios(STDINFILENO, unix.TCGETS)
-		if err != nil {
-			return err
-		}
-		rawState := *raw
-
-		rawState.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
-		// t.Iflag &^= BRKINT | ISTRIP | ICRNL | IXON // Stevens RAW
-		rawState.Oflag &^= unix.OPOST
-		rawState.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
-		rawState.Cflag &^= unix.CSIZE | unix.PARENB
-		rawState.Cflag |= unix.CS8
-		rawState.Cc[unix.VMIN] = 1
-		rawState.Cc[unix.VTIME] = 0
-
-		err = unix.IoctlSetTermios(STDINFILENO, unix.TCSETS, &rawState)
-
-		if err != nil {
-			return err
+		if isTerminal {
+			//the pty on the vm side does all the terminal handling so everything typed is passed straight through
+			state, err := terminal.MakeRaw(STDINFILENO)
+			if err != nil {
+				return err
+			}
+			defer terminal.Restore(STDINFILENO, state)
+
+			if !readOnly {
+				winch := make(chan os.Signal, 1)
+				signal.Notify(winch, unix.SIGWINCH)
+				defer signal.Stop(winch)
+				go func() {
+					for range winch {
+						w, h, err := terminal.GetSize(STDINFILENO)
+						if err != nil {
+							continue
+						}
+						writeLock.Lock()
+						err = ws.WriteJSON(common.OutboundJsonMessage{
+							Operation: "resize",
+							Payload: map[string]interface{}{
+								"cols": w,
+								"rows": h,
+							},
+						})
+						writeLock.Unlock()
+						if err != nil {
+							return
+						}
+					}
+				}()
+			}
 		}
 
-		defer setSaneTermMode()
-
-		// disable input buffering
-		//exec.Command("stty", "-F", "/dev/tty", "cbreak", "isig", "min", "1").Run()
-		// do not display entered characters on the screen
-		//exec.Command("stty", "-F", "/dev/tty", "-echo").Run()
-		doExit := false
+		detached := false
 		go func() {
-
-			inScanner := bufio.NewReaderSize(STDINFILE, 1)
-			ibuff := make([]byte, 1)
+			ibuff := make([]byte, 1024)
+			matched := 0
 			for {
-
-				//ws.SetReadDeadline(time.Now().Add(writeWaitDuration))
-				wo, err := ws.NextWriter(2)
+				n, err := STDINFILE.Read(ibuff)
 				if err != nil {
-					println("next_write:", err.Error())
+					if err != io.EOF {
+						println("stdin_read:", err.Error())
+					}
 					return
 				}
-
-				n, err := inScanner.Read(ibuff)
-				if err != nil {
-					println("stdin_read:", err.Error())
-					return
-				} else if n > 1 {
-					println("Larger")
+				//hold back what could be the start of the detach sequence until it is clear it isnt
+				out := make([]byte, 0, n+len(detachKeys))
+				for _, b := range ibuff[:n] {
+					if b == detachKeys[matched] {
+						matched++
+						if matched == len(detachKeys) {
+							print("\r\nDetached from console\r\n")
+							detached = true
+							writeLock.Lock()
+							ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "detached"), time.Now().Add(writeWaitDuration))
+							writeLock.Unlock()
+							ws.Close()
+							return
+						}
+						continue
+					}
+					if matched > 0 {
+						out = append(out, detachKeys[:matched]...)
+						matched = 0
+						if b == detachKeys[0] {
+							matched = 1
+							continue
+						}
+					}
+					out = append(out, b)
 				}
-
-				//check if the value is ^D
-
-				if ibuff[0] == EOF_CHAR {
-					print("\r\nExiting...\r\n")
-					doExit = true
+				if readOnly || len(out) == 0 {
+					//only watching - the detach sequence is the only thing that does anything
+					continue
 				}
-
-				_, err = wo.Write(ibuff[:n])
-				//wo.Write()
-
-				//	_, err = io.Copy(wo, STDINFILE)
-
-				// err = ws.WriteJSON(&common.OutboundJsonMessage{
-				// 	ID:        "",
-				// 	Operation: "console-input",
-				// 	Payload: map[string]interface{}{
-				// 		"input": string(b),
-				// 	},
-				// })
+				writeLock.Lock()
+				err = ws.WriteMessage(websocket.BinaryMessage, out)
+				writeLock.Unlock()
 				if err != nil {
-					wo.Close()
 					println("stdin_write:", err.Error())
 					return
 				}
-				wo.Close()
-				if doExit {
-					ws.Close()
-					return
-				}
 			}
 		}()
-		buff := make([]byte, 1024)
 		for {
-			//ws.SetReadDeadline(time.Now().Add(readWaitDuration))
 			mt, rd, err := ws.NextReader()
-
-			//mt, msg, err := ws.ReadMessage()
 			if err != nil {
-				if doExit {
+				if detached || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 					return nil
 				}
 				return err
 			}
-			if mt == 2 {
-				n, err := rd.Read(buff)
-				if err != nil {
-					if doExit {
-						return nil
-					}
-					return err
-				}
-				// if err != nil {
-				// 	println("read:", err.Error())
-				// 	break
-				// } else if n > 0 {
-				// 	os.Stdout.Write(buff[:n])
-				// }
-				//_, err = io.Copy(os.Stdout, rd)
-				_, err = os.Stdout.Write(buff[:n])
-				if err != nil {
-					if doExit {
-						return nil
-					}
-					return err
+			if mt != websocket.BinaryMessage {
+				continue
+			}
+			if _, err := io.Copy(os.Stdout, rd); err != nil {
+				if detached {
+					return nil
 				}
-			} else {
-				println("differ mt")
+				return err
 			}
-
-			// inboundMsg := &common.InboundJsonMessage{}
-			// err = ws.ReadJSON(inboundMsg)
-			// if err != nil {
-			// 	return err
-			// }
-			// switch inboundMsg.Operation {
-			// case "console-output":
-			// 	content := inboundMsg.Payload["output"].(string)
-			// 	os.Stdout.WriteString(content)
-			// }
-			//fmt.Println(resp)
 		}
-		return nil
-
 	},
 }
@@ -0,0 +1,205 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/768bit/promethium/cmd/common"
+	"github.com/gorilla/websocket"
+	"github.com/urfave/cli/v2"
+)
+
+var CopyCommand = cli.Command{
+	Name:      "cp",
+	Usage:     "Copy a file to or from an instance through the guest agent.",
+	ArgsUsage: "<vm id>:<path> <local path> | <local path> <vm id>:<path>",
+	Description: "Use - as the local path to read from stdin or write to stdout. When copying into a directory in the " +
+		"instance the path must end with / and the file keeps its name.",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 2 {
+			return errors.New("A source and destination are required")
+		}
+		src, dst := c.Args().Get(0), c.Args().Get(1)
+		srcVm, srcPath, srcRemote := splitRemotePath(src)
+		dstVm, dstPath, dstRemote := splitRemotePath(dst)
+		switch {
+		case srcRemote && dstRemote:
+			return errors.New("Copying between instances isnt supported")
+		case srcRemote:
+			return copyFromInstance(srcVm, srcPath, dst)
+		case dstRemote:
+			return copyToInstance(src, dstVm, dstPath)
+		}
+		return errors.New("One of the paths must be in an instance (<vm id>:<path>)")
+	},
+}
+
+// splitRemotePath splits <vm id>:<path> - local paths that happen to contain a colon have to start with / or ./
+func splitRemotePath(arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return "", arg, false
+	}
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", arg, false
+	}
+	return parts[0], parts[1], true
+}
+
+func copyToInstance(localPath string, vmID string, remotePath string) error {
+	var src io.Reader = os.Stdin
+	mode := os.FileMode(0644)
+	if localPath != "-" {
+		fd, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer fd.Close()
+		info, err := fd.Stat()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return errors.New(localPath + " is not a regular file")
+		}
+		mode = info.Mode().Perm()
+		if strings.HasSuffix(remotePath, "/") {
+			remotePath = path.Join(remotePath, filepath.Base(localPath))
+		}
+		src = fd
+	}
+	ws, err := common.MakeWebSocketClientUnix("/consolews")
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	err = ws.WriteJSON(common.OutboundJsonMessage{
+		Operation: "put-file",
+		Payload: map[string]interface{}{
+			"id":   vmID,
+			"path": remotePath,
+			"mode": mode,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	buff := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buff)
+		if n > 0 {
+			if werr := ws.WriteMessage(websocket.BinaryMessage, buff[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := ws.WriteJSON(common.OutboundJsonMessage{Operation: "eof"}); err != nil {
+		return err
+	}
+	for {
+		mt, rd, err := ws.NextReader()
+		if err != nil {
+			return err
+		}
+		if mt != websocket.TextMessage {
+			continue
+		}
+		msg, err := readAgentMessage(rd)
+		if err != nil {
+			return err
+		}
+		if msg.Operation == "done" {
+			return nil
+		}
+	}
+}
+
+func copyFromInstance(vmID string, remotePath string, localPath string) error {
+	ws, err := common.MakeWebSocketClientUnix("/consolews")
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	err = ws.WriteJSON(common.OutboundJsonMessage{
+		Operation: "get-file",
+		Payload: map[string]interface{}{
+			"id":   vmID,
+			"path": remotePath,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	var out *os.File
+	tmpPath := ""
+	defer func() {
+		//only left set when the copy didnt finish
+		if tmpPath != "" {
+			out.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+	for {
+		mt, rd, err := ws.NextReader()
+		if err != nil {
+			return err
+		}
+		if mt == websocket.BinaryMessage {
+			if out == nil {
+				return errors.New("Received file contents before the file")
+			}
+			if _, err := io.Copy(out, rd); err != nil {
+				return err
+			}
+			continue
+		}
+		msg, err := readAgentMessage(rd)
+		if err != nil {
+			return err
+		}
+		switch msg.Operation {
+		case "file":
+			if localPath == "-" {
+				out = os.Stdout
+				continue
+			}
+			target := localPath
+			if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+				target = filepath.Join(localPath, path.Base(remotePath))
+			}
+			mode, _ := msg.Payload["mode"].(float64)
+			//written alongside and renamed into place so a failed copy doesnt leave half a file behind
+			out, err = os.OpenFile(target+".part", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(mode).Perm()|0200)
+			if err != nil {
+				return err
+			}
+			tmpPath = out.Name()
+			localPath = target
+		case "eof":
+			if out == nil {
+				return errors.New("Received the end of the file before the file")
+			}
+			if tmpPath == "" {
+				return nil
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+			if err := os.Rename(tmpPath, localPath); err != nil {
+				return fmt.Errorf("Unable to move the copied file into place: %s", err.Error())
+			}
+			tmpPath = ""
+			return nil
+		}
+	}
+}
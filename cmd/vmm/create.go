@@ -1,9 +1,13 @@
 package vmm
 
 import (
+	"errors"
 	"fmt"
+	"os"
+
 	"github.com/768bit/promethium/api/client/vms"
 	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/cmd/common"
 	"github.com/docker/go-units"
 	"github.com/urfave/cli/v2"
 )
@@ -31,17 +35,15 @@ var CreateInstanceCommand = cli.Command{
 			Required: true,
 		},
 		&cli.StringFlag{
-			Name:     "disk-size, d",
-			Aliases:  []string{"d"},
-			Required: true,
+			Name:    "disk-size, d",
+			Aliases: []string{"d"},
 		},
 		&cli.StringFlag{
 			Name: "net",
 		},
 		&cli.StringFlag{
-			Name:     "image",
-			Aliases:  []string{"i"},
-			Required: true,
+			Name:    "image",
+			Aliases: []string{"i"},
 		},
 		&cli.StringFlag{
 			Name:    "kernel-image",
@@ -51,13 +53,25 @@ var CreateInstanceCommand = cli.Command{
 			Name:  "storage",
 			Value: "default-local",
 		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "only needed for mock VMs (--type mock) which are created without an image or disk",
+		},
+		common.NoWaitFlag,
 	},
 	Action: func(c *cli.Context) error {
-		ds, err := units.FromHumanSize(c.String("disk-size"))
-		if err != nil {
-			return err
+		var ds int64
+		if c.String("type") != "mock" {
+			if c.String("image") == "" || c.String("disk-size") == "" {
+				return errors.New("An image and disk size are needed to create a VM")
+			}
+			var err error
+			ds, err = units.FromHumanSize(c.String("disk-size"))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Parsed Size: %s -> %d\n", c.String("disk-size"), ds)
 		}
-		fmt.Printf("Parsed Size: %s -> %d\n", c.String("disk-size"), ds)
 		params := vms.NewCreateVMParams()
 		params.SetVMConfig(&models.NewVM{
 			Cpus:             c.Int64("cpu"),
@@ -69,12 +83,21 @@ var CreateInstanceCommand = cli.Command{
 			FromImage:        c.String("image"),
 			KernelImage:      c.String("kernel-image"),
 			StorageName:      c.String("storage"),
+			Type:             c.String("type"),
 		})
 		resp, err := ApiCli.Vms.CreateVM(params)
 		if err != nil {
 			return err
 		}
-		print(resp.Payload.ID.String())
+		if c.Bool("no-wait") {
+			println(resp.Payload.ID)
+			return nil
+		}
+		op, err := common.WaitForOperation(ApiCli, resp.Payload, os.Stdout)
+		if err != nil {
+			return err
+		}
+		println(common.OperationResultID(op))
 		return nil
 	},
 }
@@ -0,0 +1,23 @@
+package vmm
+
+import (
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/urfave/cli/v2"
+)
+
+var DeleteInstanceCommand = cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"rm"},
+	Usage:     "Delete a stopped instance along with its disks, snapshots and logs.",
+	ArgsUsage: "<vm id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewDeleteVMParams()
+		params.SetVMID(c.Args().Get(0))
+		_, err := ApiCli.Vms.DeleteVM(params)
+		if err != nil {
+			return err
+		}
+		println("deleted")
+		return nil
+	},
+}
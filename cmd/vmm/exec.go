@@ -0,0 +1,137 @@
+package vmm
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/768bit/promethium/cmd/common"
+	"github.com/gorilla/websocket"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	execStdout byte = 1
+	execStderr byte = 2
+)
+
+var ExecCommand = cli.Command{
+	Name:      "exec",
+	Usage:     "Run a command in an instance through the guest agent.",
+	ArgsUsage: "<vm id> <command> [args...]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "interactive",
+			Aliases: []string{"i"},
+			Usage:   "send stdin to the command",
+		},
+		&cli.StringSliceFlag{
+			Name:    "env",
+			Aliases: []string{"e"},
+			Usage:   "set an environment variable (KEY=VALUE)",
+		},
+		&cli.StringFlag{
+			Name:    "workdir",
+			Aliases: []string{"w"},
+			Usage:   "the directory to run the command in",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() < 2 {
+			return errors.New("A vm id and a command to run are required")
+		}
+		ws, err := common.MakeWebSocketClientUnix("/consolews")
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+		err = ws.WriteJSON(common.OutboundJsonMessage{
+			Operation: "exec",
+			Payload: map[string]interface{}{
+				"id":      c.Args().Get(0),
+				"command": c.Args().Slice()[1:],
+				"env":     c.StringSlice("env"),
+				"dir":     c.String("workdir"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		writeLock := sync.Mutex{}
+		closeStdin := func() {
+			writeLock.Lock()
+			defer writeLock.Unlock()
+			ws.WriteJSON(common.OutboundJsonMessage{Operation: "close-stdin"})
+		}
+		if c.Bool("interactive") {
+			go func() {
+				defer closeStdin()
+				buff := make([]byte, 32*1024)
+				for {
+					n, err := os.Stdin.Read(buff)
+					if n > 0 {
+						writeLock.Lock()
+						werr := ws.WriteMessage(websocket.BinaryMessage, buff[:n])
+						writeLock.Unlock()
+						if werr != nil {
+							return
+						}
+					}
+					if err != nil {
+						return
+					}
+				}
+			}()
+		} else {
+			closeStdin()
+		}
+		for {
+			mt, rd, err := ws.NextReader()
+			if err != nil {
+				return err
+			}
+			if mt == websocket.BinaryMessage {
+				output, err := ioutil.ReadAll(rd)
+				if err != nil {
+					return err
+				}
+				if len(output) == 0 {
+					continue
+				}
+				var out io.Writer = os.Stdout
+				if output[0] == execStderr {
+					out = os.Stderr
+				}
+				out.Write(output[1:])
+				continue
+			}
+			msg, err := readAgentMessage(rd)
+			if err != nil {
+				return err
+			}
+			if msg.Operation == "exit" {
+				if msg.Code != 0 {
+					//exit with the same code as the command without printing anything
+					return cli.Exit("", msg.Code)
+				}
+				return nil
+			}
+		}
+	},
+}
+
+// readAgentMessage decodes a control message from the daemon - error messages are returned as an error
+func readAgentMessage(rd io.Reader) (*common.InboundJsonMessage, error) {
+	msg := &common.InboundJsonMessage{}
+	if err := json.NewDecoder(rd).Decode(msg); err != nil {
+		return nil, err
+	}
+	if msg.Operation == "error" {
+		message, _ := msg.Payload["message"].(string)
+		return nil, errors.New(message)
+	}
+	return msg, nil
+}
@@ -0,0 +1,81 @@
+package vmm
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/lib/testdaemon"
+	"github.com/urfave/cli/v2"
+)
+
+func runVmmCommand(d *testdaemon.Daemon, args ...string) error {
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{&VmmSubCommand}
+	return app.Run(append([]string{"promethium", "vmm", "--tcp", "--host", d.Host, "--port", strconv.Itoa(d.Port)}, args...))
+}
+
+func waitForStatus(t *testing.T, d *testdaemon.Daemon, id string, status string) {
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		resp, err := d.Client.Vms.GetVM(vms.NewGetVMParams().WithVMID(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Payload.Status == status {
+			return
+		} else if time.Now().After(deadline) {
+			t.Fatalf("expected %s to be %q, it is %q", id, status, resp.Payload.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMockVmCommands(t *testing.T) {
+	appRoot, err := ioutil.TempDir("", "promethium-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appRoot)
+	d, err := testdaemon.Start(appRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := runVmmCommand(d, "create", "--name", "cli", "--cpu", "1", "--mem", "128"); err == nil {
+		t.Error("expected a vm without an image to be refused")
+	}
+	if err := runVmmCommand(d, "create", "--name", "cli", "--cpu", "1", "--mem", "128", "--type", "mock"); err != nil {
+		t.Fatal(err)
+	}
+	list, err := d.Client.Vms.GetVMList(vms.NewGetVMListParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Payload) != 1 || list.Payload[0].Name != "cli" {
+		t.Fatalf("expected the vm to have been created, got %+v", list.Payload)
+	}
+	id := list.Payload[0].ID.String()
+
+	if err := runVmmCommand(d, "list"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVmmCommand(d, "start", id); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, id, "Running")
+	if err := runVmmCommand(d, "stop", id); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, d, id, "Not started")
+	if err := runVmmCommand(d, "delete", id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Manager.Get(id); err == nil {
+		t.Error("expected the vm to have been deleted")
+	}
+}
@@ -18,13 +18,13 @@ var ListInstancesCommand = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		printer := tableprinter.New(os.Stdout)
-		printer.Render([]string{"ID"}, nil, nil, false)
+		printer.Render([]string{"ID", "Name", "Status", "Health"}, nil, nil, false)
 		list, err := ApiCli.Vms.GetVMList(nil)
 		if err != nil {
 			return err
 		}
 		for _, item := range list.Payload {
-			printer.RenderRow([]string{item.ID.String()}, nil)
+			printer.RenderRow([]string{item.ID.String(), item.Name, item.Status, item.Health}, nil)
 		}
 		return nil
 	},
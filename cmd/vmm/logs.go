@@ -0,0 +1,146 @@
+package vmm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/urfave/cli/v2"
+)
+
+var InstanceLogsCommand = cli.Command{
+	Name:      "logs",
+	Usage:     "Show the serial console output and firecracker log of an instance.",
+	ArgsUsage: "<vm id>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "follow",
+			Aliases: []string{"f"},
+			Usage:   "keep printing new lines as they are written",
+		},
+		&cli.Int64Flag{
+			Name:    "tail",
+			Aliases: []string{"n"},
+			Usage:   "only show this many of the most recent lines",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "only show lines written since a time (RFC3339) or for a duration (e.g. 10m)",
+		},
+		&cli.StringFlag{
+			Name:  "source",
+			Value: "serial",
+			Usage: "the log to show: serial, firecracker or all",
+		},
+		&cli.BoolFlag{
+			Name:    "timestamps",
+			Aliases: []string{"t"},
+			Usage:   "show the time each line was written",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetVMLogsParams()
+		params.SetVMID(c.Args().Get(0))
+		source := c.String("source")
+		params.SetSource(&source)
+		if c.IsSet("tail") {
+			tail := c.Int64("tail")
+			params.SetTail(&tail)
+		}
+		if c.String("since") != "" {
+			since, err := parseSince(c.String("since"))
+			if err != nil {
+				return err
+			}
+			params.SetSince(&since)
+		}
+		printEntry := func(entry *models.VMLogEntry) {
+			if c.Bool("timestamps") {
+				fmt.Printf("%s ", entry.Time.String())
+			}
+			if source == "all" {
+				fmt.Printf("[%s] ", entry.Source)
+			}
+			fmt.Println(entry.Line)
+		}
+		if c.Bool("follow") {
+			return followLogs(params, printEntry)
+		}
+		resp, err := ApiCli.Vms.GetVMLogs(params)
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Payload {
+			printEntry(entry)
+		}
+		return nil
+	},
+}
+
+func parseSince(value string) (strfmt.DateTime, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return strfmt.DateTime(time.Now().Add(-duration)), nil
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return strfmt.DateTime{}, fmt.Errorf("Unable to parse since %s: expected a duration or an RFC3339 time", value)
+	}
+	return strfmt.DateTime(since), nil
+}
+
+// followLogs streams the log until interrupted - the generated client expects a single JSON array so the
+// newline delimited stream is read here instead
+func followLogs(params *vms.GetVMLogsParams, printEntry func(entry *models.VMLogEntry)) error {
+	follow := true
+	params.SetFollow(&follow)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params.SetContext(ctx)
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+	_, err := ApiCli.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getVMLogs",
+		Method:             "GET",
+		PathPattern:        "/vms/{vmID}/logs",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			if response.Code() != 200 {
+				return nil, fmt.Errorf("Unable to follow logs: %s", response.Message())
+			}
+			dec := json.NewDecoder(response.Body())
+			for {
+				entry := &models.VMLogEntry{}
+				if err := dec.Decode(entry); err == io.EOF {
+					return nil, nil
+				} else if err != nil {
+					if ctx.Err() != nil {
+						return nil, nil
+					}
+					return nil, err
+				}
+				printEntry(entry)
+			}
+		}),
+		Context: ctx,
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
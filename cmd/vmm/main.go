@@ -47,11 +47,25 @@ var VmmSubCommand = cli.Command{
 	Subcommands: []*cli.Command{
 		&ListInstancesCommand,
 		&CreateInstanceCommand,
+		&DeleteInstanceCommand,
 		&StartInstanceCommand,
 		&StopInstanceCommand,
+		&PauseInstanceCommand,
+		&ResumeInstanceCommand,
+		&SnapshotCommand,
+		&VsockCommand,
+		&BalloonCommand,
+		&ResourcesCommand,
+		&InstanceMetricsCommand,
+		&InstanceLogsCommand,
 		&ShutdownInstanceCommand,
 		&ResetInstanceCommand,
 		&RestartInstanceCommand,
 		&InstanceConsoleCommand,
+		&ListRecordingsCommand,
+		&ConsoleReplayCommand,
+		&ExecCommand,
+		&CopyCommand,
+		&AgentInfoCommand,
 	},
 }
@@ -0,0 +1,54 @@
+package vmm
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var InstanceMetricsCommand = cli.Command{
+	Name:      "metrics",
+	Usage:     "Show the metrics collected for an instance.",
+	ArgsUsage: "<vm id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "group",
+			Usage: "only show metrics from this group (e.g. block, net, vcpu)",
+		},
+		&cli.BoolFlag{
+			Name:  "flush",
+			Usage: "ask firecracker to write out its metrics first",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetVMMetricsParams()
+		params.SetVMID(c.Args().Get(0))
+		flush := c.Bool("flush")
+		params.SetFlush(&flush)
+		resp, err := ApiCli.Vms.GetVMMetrics(params)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Samples: %d Updated: %s\n", resp.Payload.Samples, resp.Payload.UpdatedAt.String())
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"Metric", "Type", "Value"}, nil, nil, false)
+		group := c.String("group")
+		for _, set := range []struct {
+			kind   string
+			values map[string]float64
+		}{{"counter", resp.Payload.Counters}, {"gauge", resp.Payload.Gauges}} {
+			for _, name := range metrics.Names(set.values) {
+				if group != "" && !strings.HasPrefix(name, group+".") {
+					continue
+				}
+				printer.RenderRow([]string{name, set.kind, fmt.Sprintf("%v", set.values[name])}, nil)
+			}
+		}
+		return nil
+	},
+}
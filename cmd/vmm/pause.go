@@ -0,0 +1,21 @@
+package vmm
+
+import (
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/urfave/cli/v2"
+)
+
+var PauseInstanceCommand = cli.Command{
+	Name:  "pause",
+	Usage: "Pause instance.",
+	Action: func(c *cli.Context) error {
+		params := vms.NewPauseVMParams()
+		params.SetVMID(c.Args().Get(0))
+		resp, err := ApiCli.Vms.PauseVM(params)
+		if err != nil {
+			return err
+		}
+		println(resp.Payload.Status)
+		return nil
+	},
+}
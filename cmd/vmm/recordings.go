@@ -0,0 +1,93 @@
+package vmm
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/lib/recording"
+	"github.com/docker/go-units"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var ListRecordingsCommand = cli.Command{
+	Name:  "recordings",
+	Usage: "List recorded console sessions.",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "vm",
+			Usage: "only list the recordings of this instance",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetConsoleRecordingListParams()
+		if c.String("vm") != "" {
+			vmID := c.String("vm")
+			params.SetVMID(&vmID)
+		}
+		list, err := ApiCli.Vms.GetConsoleRecordingList(params)
+		if err != nil {
+			return err
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"ID", "VM", "User", "Started", "Duration", "Size"}, nil, nil, false)
+		for _, item := range list.Payload {
+			duration := time.Duration(item.Duration * float64(time.Second)).Round(time.Second)
+			printer.RenderRow([]string{item.ID.String(), item.VMID.String(), item.User, item.StartedAt.String(), duration.String(), units.HumanSize(float64(item.Size))}, nil)
+		}
+		return nil
+	},
+}
+
+var ConsoleReplayCommand = cli.Command{
+	Name:      "console-replay",
+	Usage:     "Replay a recorded console session.",
+	ArgsUsage: "<recording id>",
+	Flags: []cli.Flag{
+		&cli.Float64Flag{
+			Name:  "speed",
+			Value: 1,
+			Usage: "play the recording this many times faster than it happened",
+		},
+		&cli.DurationFlag{
+			Name:  "max-wait",
+			Usage: "cap pauses in the recording at this duration (e.g. 2s)",
+		},
+		&cli.StringFlag{
+			Name:  "save",
+			Usage: "save the asciicast file instead of replaying it",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.Args().Get(0) == "" {
+			return errors.New("A recording id is required")
+		}
+		params := vms.NewGetConsoleRecordingParams()
+		params.SetRecordingID(c.Args().Get(0))
+		resp, err := ApiCli.Vms.GetConsoleRecording(params)
+		if err != nil {
+			return err
+		}
+		if c.String("save") != "" {
+			return ioutil.WriteFile(c.String("save"), []byte(resp.Payload.Cast), 0640)
+		}
+		_, events, err := recording.Parse(strings.NewReader(resp.Payload.Cast))
+		if err != nil {
+			return err
+		}
+		stop := make(chan struct{})
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			<-interrupt
+			close(stop)
+		}()
+		return recording.Play(events, os.Stdout, c.Float64("speed"), c.Duration("max-wait"), stop)
+	},
+}
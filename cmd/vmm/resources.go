@@ -0,0 +1,119 @@
+package vmm
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var ResourcesCommand = cli.Command{
+	Name:      "resources",
+	Usage:     "Show or change the cgroup resource limits of an instance.",
+	ArgsUsage: "<vm id>",
+	Description: "Without flags the limits are shown. Flags change just those limits - 0 lifts a limit. The io flags " +
+		"apply to every device backing the disks of the instance.",
+	Flags: []cli.Flag{
+		&cli.Int64Flag{Name: "cpu-weight", Usage: "relative share of cpu time from 1 to 10000"},
+		&cli.Float64Flag{Name: "cpu-max", Usage: "most cpu time the instance can use in cpus"},
+		&cli.Int64Flag{Name: "memory-overhead", Usage: "memory in MiB allowed on top of the guest memory"},
+		&cli.Int64Flag{Name: "pids-max", Usage: "most threads the instance can have"},
+		&cli.Int64Flag{Name: "io-read-bps", Usage: "read bytes per second"},
+		&cli.Int64Flag{Name: "io-write-bps", Usage: "write bytes per second"},
+		&cli.Int64Flag{Name: "io-read-iops", Usage: "read operations per second"},
+		&cli.Int64Flag{Name: "io-write-iops", Usage: "write operations per second"},
+	},
+	Action: func(c *cli.Context) error {
+		vmID := c.Args().Get(0)
+		params := vms.NewGetVMResourcesParams()
+		params.SetVMID(vmID)
+		resp, err := ApiCli.Vms.GetVMResources(params)
+		if err != nil {
+			return err
+		}
+		resources := resp.Payload
+		if c.NumFlags() > 0 {
+			limits := resources.Limits
+			if limits == nil {
+				limits = &models.VMResourceLimits{}
+			}
+			if c.IsSet("cpu-weight") {
+				limits.CPUWeight = c.Int64("cpu-weight")
+			}
+			if c.IsSet("cpu-max") {
+				limits.CPUMax = c.Float64("cpu-max")
+			}
+			if c.IsSet("memory-overhead") {
+				limits.MemoryOverheadMib = c.Int64("memory-overhead")
+			}
+			if c.IsSet("pids-max") {
+				limits.PidsMax = c.Int64("pids-max")
+			}
+			if c.IsSet("io-read-bps") || c.IsSet("io-write-bps") || c.IsSet("io-read-iops") || c.IsSet("io-write-iops") {
+				limits.IoMax = []*models.VMIOLimit{{
+					ReadBps:   c.Int64("io-read-bps"),
+					WriteBps:  c.Int64("io-write-bps"),
+					ReadIops:  c.Int64("io-read-iops"),
+					WriteIops: c.Int64("io-write-iops"),
+				}}
+			}
+			update := vms.NewUpdateVMResourcesParams()
+			update.SetVMID(vmID)
+			update.SetResourceLimits(limits)
+			resp, err := ApiCli.Vms.UpdateVMResources(update)
+			if err != nil {
+				return err
+			}
+			resources = resp.Payload
+		}
+		printResources(resources)
+		return nil
+	},
+}
+
+func printResources(resources *models.VMResources) {
+	limits := resources.Limits
+	if limits == nil {
+		limits = &models.VMResourceLimits{}
+	}
+	cpuMax := "unlimited"
+	if limits.CPUMax != 0 {
+		cpuMax = strconv.FormatFloat(limits.CPUMax, 'f', -1, 64) + " cpus"
+	}
+	fmt.Printf("CPU weight: %s\nCPU max: %s\nMemory overhead: %s\nPids max: %s\n", limitString(limits.CPUWeight, ""), cpuMax,
+		limitString(limits.MemoryOverheadMib, " MiB"), limitString(limits.PidsMax, ""))
+	for _, limit := range limits.IoMax {
+		device := limit.Device
+		if device == "" {
+			device = "all disks"
+		}
+		fmt.Printf("IO %s: read %s/s %s iops, write %s/s %s iops\n", device, limitString(limit.ReadBps, "B"),
+			limitString(limit.ReadIops, ""), limitString(limit.WriteBps, "B"), limitString(limit.WriteIops, ""))
+	}
+	if len(resources.Effective) == 0 {
+		return
+	}
+	fmt.Printf("\nMemory used: %d MiB\nThreads: %d\nCPU time: %.2fs\n\n", resources.MemoryCurrent/1024/1024, resources.PidsCurrent, resources.CPUUsageSeconds)
+	files := []string{}
+	for file := range resources.Effective {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	printer := tableprinter.New(os.Stdout)
+	printer.Render([]string{"Control File", "Value"}, nil, nil, false)
+	for _, file := range files {
+		printer.RenderRow([]string{file, resources.Effective[file]}, nil)
+	}
+}
+
+func limitString(limit int64, unit string) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(limit, 10) + unit
+}
@@ -0,0 +1,21 @@
+package vmm
+
+import (
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/urfave/cli/v2"
+)
+
+var ResumeInstanceCommand = cli.Command{
+	Name:  "resume",
+	Usage: "Resume instance.",
+	Action: func(c *cli.Context) error {
+		params := vms.NewResumeVMParams()
+		params.SetVMID(c.Args().Get(0))
+		resp, err := ApiCli.Vms.ResumeVM(params)
+		if err != nil {
+			return err
+		}
+		println(resp.Payload.Status)
+		return nil
+	},
+}
@@ -0,0 +1,133 @@
+package vmm
+
+import (
+	"os"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/cmd/common"
+	"github.com/docker/go-units"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var SnapshotCommand = cli.Command{
+	Name:    "snapshot",
+	Aliases: []string{"snap"},
+	Usage:   "Manage instance snapshots.",
+	Subcommands: []*cli.Command{
+		&ListSnapshotsCommand,
+		&CreateSnapshotCommand,
+		&DeleteSnapshotCommand,
+		&RestoreSnapshotCommand,
+	},
+}
+
+var ListSnapshotsCommand = cli.Command{
+	Name:      "list",
+	Aliases:   []string{"ls"},
+	Usage:     "List snapshots of an instance.",
+	ArgsUsage: "<vm id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetVMSnapshotListParams()
+		params.SetVMID(c.Args().Get(0))
+		list, err := ApiCli.Vms.GetVMSnapshotList(params)
+		if err != nil {
+			return err
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"ID", "Name", "Created", "Size", "Firecracker", "Compatible"}, nil, nil, false)
+		for _, item := range list.Payload {
+			compatible := "yes"
+			if !item.Compatible {
+				compatible = "no"
+			}
+			printer.RenderRow([]string{item.ID.String(), item.Name, item.CreatedAt.String(), units.HumanSize(float64(item.Size)), item.FirecrackerVersion, compatible}, nil)
+		}
+		return nil
+	},
+}
+
+var CreateSnapshotCommand = cli.Command{
+	Name:      "create",
+	Usage:     "Snapshot the memory, device state and disks of a running instance.",
+	ArgsUsage: "<vm id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name: "name",
+		},
+		common.NoWaitFlag,
+	},
+	Action: func(c *cli.Context) error {
+		params := vms.NewCreateVMSnapshotParams()
+		params.SetVMID(c.Args().Get(0))
+		params.SetSnapshotConfig(&models.NewVMSnapshot{
+			Name: c.String("name"),
+		})
+		resp, err := ApiCli.Vms.CreateVMSnapshot(params)
+		if err != nil {
+			return err
+		}
+		if c.Bool("no-wait") {
+			println(resp.Payload.ID)
+			return nil
+		}
+		op, err := common.WaitForOperation(ApiCli, resp.Payload, os.Stdout)
+		if err != nil {
+			return err
+		}
+		println(common.OperationResultID(op))
+		return nil
+	},
+}
+
+var DeleteSnapshotCommand = cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"rm"},
+	Usage:     "Delete a snapshot of an instance.",
+	ArgsUsage: "<vm id> <snapshot id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewDeleteVMSnapshotParams()
+		params.SetVMID(c.Args().Get(0))
+		params.SetSnapshotID(c.Args().Get(1))
+		_, err := ApiCli.Vms.DeleteVMSnapshot(params)
+		return err
+	},
+}
+
+var RestoreSnapshotCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "Restore a stopped instance from a snapshot, or restore the snapshot into a new clone.",
+	ArgsUsage: "<vm id> <snapshot id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "clone",
+			Usage: "name of a new instance to restore the snapshot into",
+		},
+		common.NoWaitFlag,
+	},
+	Action: func(c *cli.Context) error {
+		params := vms.NewRestoreVMSnapshotParams()
+		params.SetVMID(c.Args().Get(0))
+		params.SetSnapshotID(c.Args().Get(1))
+		if c.String("clone") != "" {
+			params.SetRestoreConfig(&models.RestoreVMSnapshot{
+				CloneName: c.String("clone"),
+			})
+		}
+		resp, err := ApiCli.Vms.RestoreVMSnapshot(params)
+		if err != nil {
+			return err
+		}
+		if c.Bool("no-wait") {
+			println(resp.Payload.ID)
+			return nil
+		}
+		op, err := common.WaitForOperation(ApiCli, resp.Payload, os.Stdout)
+		if err != nil {
+			return err
+		}
+		println(common.OperationResultID(op))
+		return nil
+	},
+}
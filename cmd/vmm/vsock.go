@@ -0,0 +1,94 @@
+package vmm
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/768bit/promethium/api/client/vms"
+	"github.com/768bit/promethium/api/models"
+	"github.com/landoop/tableprinter"
+	"github.com/urfave/cli/v2"
+)
+
+var VsockCommand = cli.Command{
+	Name:  "vsock",
+	Usage: "Reach services inside an instance over vsock.",
+	Subcommands: []*cli.Command{
+		&ListVsockProxiesCommand,
+		&CreateVsockProxyCommand,
+		&DeleteVsockProxyCommand,
+	},
+}
+
+var ListVsockProxiesCommand = cli.Command{
+	Name:      "list",
+	Aliases:   []string{"ls"},
+	Usage:     "List the host ports proxied to an instance.",
+	ArgsUsage: "<vm id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewGetVMVsockProxyListParams()
+		params.SetVMID(c.Args().Get(0))
+		list, err := ApiCli.Vms.GetVMVsockProxyList(params)
+		if err != nil {
+			return err
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Render([]string{"ID", "Host", "Guest Port", "Connections"}, nil, nil, false)
+		for _, item := range list.Payload {
+			host := fmt.Sprintf("%s:%d", item.BindAddress, item.HostPort)
+			printer.RenderRow([]string{item.ID.String(), host, strconv.FormatInt(item.GuestPort, 10), strconv.FormatInt(item.Connections, 10)}, nil)
+		}
+		return nil
+	},
+}
+
+var CreateVsockProxyCommand = cli.Command{
+	Name:      "proxy",
+	Usage:     "Proxy a TCP port on the host to a vsock port in an instance.",
+	ArgsUsage: "<vm id> <guest port>",
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "host-port",
+			Usage: "port to listen on - a free port is picked when not set",
+		},
+		&cli.StringFlag{
+			Name:  "bind",
+			Value: "127.0.0.1",
+			Usage: "address to listen on",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		guestPort, err := strconv.ParseInt(c.Args().Get(1), 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid guest port %s", c.Args().Get(1))
+		}
+		params := vms.NewCreateVMVsockProxyParams()
+		params.SetVMID(c.Args().Get(0))
+		params.SetProxyConfig(&models.NewVsockProxy{
+			BindAddress: c.String("bind"),
+			HostPort:    c.Int64("host-port"),
+			GuestPort:   &guestPort,
+		})
+		resp, err := ApiCli.Vms.CreateVMVsockProxy(params)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s %s:%d\n", resp.Payload.ID.String(), resp.Payload.BindAddress, resp.Payload.HostPort)
+		return nil
+	},
+}
+
+var DeleteVsockProxyCommand = cli.Command{
+	Name:      "delete",
+	Aliases:   []string{"rm"},
+	Usage:     "Stop proxying a host port to an instance.",
+	ArgsUsage: "<vm id> <proxy id>",
+	Action: func(c *cli.Context) error {
+		params := vms.NewDeleteVMVsockProxyParams()
+		params.SetVMID(c.Args().Get(0))
+		params.SetProxyID(c.Args().Get(1))
+		_, err := ApiCli.Vms.DeleteVMVsockProxy(params)
+		return err
+	},
+}
@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func startServer(t *testing.T) (*Client, chan bool, func() error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown := make(chan bool, 1)
+	server := NewServer()
+	server.Shutdown = func() error {
+		shutdown <- true
+		return nil
+	}
+	go server.Serve(listener)
+	return NewClient(func() (net.Conn, error) {
+		return net.Dial("tcp", listener.Addr().String())
+	}), shutdown, listener.Close
+}
+
+func TestPingAndShutdown(t *testing.T) {
+	client, shutdown, stop := startServer(t)
+	defer stop()
+	resp, err := client.Ping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Version != Version {
+		t.Errorf("expected version %s got %s", Version, resp.Version)
+	}
+	if _, err := client.NetInfo(); err != nil {
+		t.Error(err)
+	}
+	if err := client.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if !<-shutdown {
+		t.Error("expected the shutdown to be run")
+	}
+}
+
+func TestExec(t *testing.T) {
+	client, _, stop := startServer(t)
+	defer stop()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code, err := client.Exec(context.Background(), []string{"sh", "-c", "cat; echo $GREETING >&2; exit 3"}, []string{"GREETING=hello"}, "",
+		strings.NewReader("from stdin"), stdout, stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 3 {
+		t.Errorf("expected exit code 3 got %d", code)
+	}
+	if stdout.String() != "from stdin" || stderr.String() != "hello\n" {
+		t.Errorf("unexpected output %q %q", stdout.String(), stderr.String())
+	}
+	if _, err := client.Exec(context.Background(), []string{"/does/not/exist"}, nil, "", nil, nil, nil); err == nil {
+		t.Error("expected a command that cant start to fail")
+	}
+}
+
+func TestExecCancel(t *testing.T) {
+	client, _, stop := startServer(t)
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	stdout := &notifyWriter{wrote: make(chan bool, 1)}
+	go func() {
+		<-stdout.wrote
+		cancel()
+	}()
+	if _, err := client.Exec(ctx, []string{"sh", "-c", "echo started; sleep 30"}, nil, "", nil, stdout, nil); err != context.Canceled {
+		t.Errorf("expected the exec to be cancelled got %v", err)
+	}
+}
+
+type notifyWriter struct {
+	wrote chan bool
+}
+
+func (nw *notifyWriter) Write(p []byte) (int, error) {
+	select {
+	case nw.wrote <- true:
+	default:
+	}
+	return len(p), nil
+}
+
+func TestPutAndGetFile(t *testing.T) {
+	client, _, stop := startServer(t)
+	defer stop()
+	root, err := ioutil.TempDir("", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	path := filepath.Join(root, "file")
+	//larger than a single frame
+	contents := bytes.Repeat([]byte("promethium"), 10000)
+	info, err := client.PutFile(path, 0600, bytes.NewReader(contents))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != int64(len(contents)) || info.Mode != 0600 {
+		t.Errorf("unexpected file info %+v", info)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Mode().Perm() != 0600 {
+		t.Errorf("expected the mode to be set got %s", stat.Mode())
+	}
+	info, rdr, err := client.GetFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rdr.Close()
+	got, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, contents) || info.Size != int64(len(contents)) {
+		t.Error("expected the file to be read back")
+	}
+	if _, err := client.PutFile(filepath.Join(root, "missing", "file"), 0644, strings.NewReader("x")); err == nil {
+		t.Error("expected writing to a missing directory to fail")
+	}
+	if _, _, err := client.GetFile(root); err == nil {
+		t.Error("expected reading a directory to fail")
+	}
+}
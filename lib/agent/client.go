@@ -0,0 +1,241 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// DefaultTimeout is how long a request that doesnt stream anything can take
+const DefaultTimeout = 10 * time.Second
+
+// Client talks to the agent in a guest - every request dials a new connection
+type Client struct {
+	dial    func() (net.Conn, error)
+	Timeout time.Duration
+}
+
+func NewClient(dial func() (net.Conn, error)) *Client {
+	return &Client{
+		dial:    dial,
+		Timeout: DefaultTimeout,
+	}
+}
+
+func (c *Client) open(req *Request) (net.Conn, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := WriteFrame(conn, FrameRequest, b); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// call sends a request and decodes the single response it gets back
+func (c *Client) call(req *Request, resp interface{}) error {
+	conn, err := c.open(req)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if c.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.Timeout))
+	}
+	kind, payload, err := ReadFrame(conn)
+	if err != nil {
+		return err
+	}
+	return decodeFrame(kind, payload, resp)
+}
+
+func (c *Client) Ping() (*PingResponse, error) {
+	resp := &PingResponse{}
+	if err := c.call(&Request{Op: OpPing}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) NetInfo() (*NetInfo, error) {
+	resp := &NetInfo{}
+	if err := c.call(&Request{Op: OpNetInfo}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Shutdown asks the guest to shut down - it returns once the agent has accepted the request
+func (c *Client) Shutdown() error {
+	return c.call(&Request{Op: OpShutdown}, nil)
+}
+
+// Exec runs a command in the guest and returns its exit code. Stdin is sent until it reaches EOF - nil sends
+// nothing. Cancelling the context kills the command.
+func (c *Client) Exec(ctx context.Context, command []string, env []string, dir string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	conn, err := c.open(&Request{
+		Op:      OpExec,
+		Command: command,
+		Env:     env,
+		Dir:     dir,
+	})
+	if err != nil {
+		return -1, err
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			//the agent kills the command when the connection goes
+			conn.Close()
+		case <-done:
+		}
+	}()
+	kind, payload, err := ReadFrame(conn)
+	if err != nil {
+		return -1, err
+	}
+	if err := decodeFrame(kind, payload, nil); err != nil {
+		return -1, err
+	}
+	fw := newFrameWriter(conn)
+	go func() {
+		if stdin != nil {
+			io.Copy(&streamWriter{fw: fw, kind: FrameStdin}, stdin)
+		}
+		fw.write(FrameEOF, nil)
+	}()
+	for {
+		kind, payload, err := ReadFrame(conn)
+		if err != nil {
+			if ctx.Err() != nil {
+				return -1, ctx.Err()
+			}
+			return -1, err
+		}
+		switch kind {
+		case FrameStdout:
+			if stdout != nil {
+				stdout.Write(payload)
+			}
+		case FrameStderr:
+			if stderr != nil {
+				stderr.Write(payload)
+			}
+		case FrameExit:
+			status := &ExitStatus{}
+			if err := json.Unmarshal(payload, status); err != nil {
+				return -1, err
+			}
+			return status.Code, nil
+		default:
+			return -1, decodeFrame(kind, payload, nil)
+		}
+	}
+}
+
+// PutFile writes the contents of rdr to a file in the guest
+func (c *Client) PutFile(path string, mode os.FileMode, rdr io.Reader) (*FileInfo, error) {
+	conn, err := c.open(&Request{
+		Op:   OpPut,
+		Path: path,
+		Mode: uint32(mode.Perm()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	fw := newFrameWriter(conn)
+	if _, err := io.Copy(&streamWriter{fw: fw, kind: FrameData}, rdr); err != nil {
+		return nil, err
+	}
+	if err := fw.write(FrameEOF, nil); err != nil {
+		return nil, err
+	}
+	if c.Timeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(c.Timeout))
+	}
+	kind, payload, err := ReadFrame(conn)
+	if err != nil {
+		return nil, err
+	}
+	info := &FileInfo{}
+	if err := decodeFrame(kind, payload, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetFile reads a file from the guest - the contents are read from the returned reader which must be closed
+func (c *Client) GetFile(path string) (*FileInfo, io.ReadCloser, error) {
+	conn, err := c.open(&Request{
+		Op:   OpGet,
+		Path: path,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Timeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(c.Timeout))
+	}
+	kind, payload, err := ReadFrame(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	info := &FileInfo{}
+	if err := decodeFrame(kind, payload, info); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+	return info, &fileReader{conn: conn}, nil
+}
+
+// fileReader reads the data frames of a file until the EOF frame
+type fileReader struct {
+	conn    net.Conn
+	pending []byte
+	err     error
+}
+
+func (fr *fileReader) Read(p []byte) (int, error) {
+	for len(fr.pending) == 0 {
+		if fr.err != nil {
+			return 0, fr.err
+		}
+		kind, payload, err := ReadFrame(fr.conn)
+		switch {
+		case err == io.EOF:
+			fr.err = io.ErrUnexpectedEOF
+		case err != nil:
+			fr.err = err
+		case kind == FrameEOF:
+			fr.err = io.EOF
+		case kind == FrameData:
+			fr.pending = payload
+		default:
+			fr.err = errors.New("Unexpected frame while reading a file")
+		}
+	}
+	n := copy(p, fr.pending)
+	fr.pending = fr.pending[n:]
+	return n, nil
+}
+
+func (fr *fileReader) Close() error {
+	return fr.conn.Close()
+}
@@ -0,0 +1,195 @@
+// Package agent is the protocol spoken between the daemon and promethium-agent running inside a guest over vsock.
+//
+// Every connection carries a single request. Everything is sent as frames - a type byte, a big endian uint32 length
+// and the payload. Requests, responses, errors and exit statuses have JSON payloads while stdio and file contents are
+// sent as they are.
+package agent
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// DefaultPort is the vsock port the agent listens on in the guest
+const DefaultPort uint32 = 1024
+
+const Version = "0.1.0"
+
+// frames larger than this are rejected rather than allocated
+const maxFrameSize = 1 << 20
+
+// how much of a stream or file is sent in a single frame
+const chunkSize = 32 * 1024
+
+type FrameType byte
+
+const (
+	FrameRequest FrameType = iota + 1
+	FrameResponse
+	FrameError
+	FrameStdin
+	FrameStdout
+	FrameStderr
+	FrameData
+	FrameEOF
+	FrameExit
+)
+
+type Op string
+
+const (
+	OpPing     Op = "ping"
+	OpExec     Op = "exec"
+	OpPut      Op = "put"
+	OpGet      Op = "get"
+	OpShutdown Op = "shutdown"
+	OpNetInfo  Op = "netinfo"
+)
+
+// Request is the first frame sent on a connection
+type Request struct {
+	Op      Op       `json:"op"`
+	Command []string `json:"command,omitempty"` //exec
+	Env     []string `json:"env,omitempty"`     //exec - added to the environment of the agent
+	Dir     string   `json:"dir,omitempty"`     //exec
+	Path    string   `json:"path,omitempty"`    //put and get
+	Mode    uint32   `json:"mode,omitempty"`    //put - the permission bits of the file
+}
+
+type PingResponse struct {
+	Version  string  `json:"version"`
+	Hostname string  `json:"hostname"`
+	Uptime   float64 `json:"uptime"`
+}
+
+type FileInfo struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Mode uint32 `json:"mode"`
+}
+
+type ExitStatus struct {
+	Code int `json:"code"`
+}
+
+type Interface struct {
+	Name      string   `json:"name"`
+	MAC       string   `json:"mac,omitempty"`
+	MTU       int      `json:"mtu"`
+	Up        bool     `json:"up"`
+	Addresses []string `json:"addresses"`
+}
+
+type NetInfo struct {
+	Hostname   string       `json:"hostname"`
+	Interfaces []*Interface `json:"interfaces"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// WriteFrame writes a single frame
+func WriteFrame(w io.Writer, kind FrameType, payload []byte) error {
+	if len(payload) > maxFrameSize {
+		return fmt.Errorf("Frame of %d bytes is too large", len(payload))
+	}
+	header := make([]byte, 5)
+	header[0] = byte(kind)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReadFrame reads a single frame
+func ReadFrame(r io.Reader) (FrameType, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxFrameSize {
+		return 0, nil, fmt.Errorf("Frame of %d bytes is too large", size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return FrameType(header[0]), payload, nil
+}
+
+// frameWriter serialises the frames written to a connection - exec writes stdout and stderr from different goroutines
+type frameWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func newFrameWriter(w io.Writer) *frameWriter {
+	return &frameWriter{w: w}
+}
+
+func (fw *frameWriter) write(kind FrameType, payload []byte) error {
+	fw.lock.Lock()
+	defer fw.lock.Unlock()
+	return WriteFrame(fw.w, kind, payload)
+}
+
+func (fw *frameWriter) json(kind FrameType, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return fw.write(kind, b)
+}
+
+func (fw *frameWriter) error(err error) error {
+	return fw.json(FrameError, &errorResponse{Message: err.Error()})
+}
+
+// streamWriter sends everything written to it as frames of a single type
+type streamWriter struct {
+	fw   *frameWriter
+	kind FrameType
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		end := written + chunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if err := sw.fw.write(sw.kind, p[written:end]); err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+// decodeFrame turns a response frame into value and an error frame into an error
+func decodeFrame(kind FrameType, payload []byte, value interface{}) error {
+	switch kind {
+	case FrameError:
+		resp := &errorResponse{}
+		if err := json.Unmarshal(payload, resp); err != nil {
+			return err
+		}
+		return errors.New(resp.Message)
+	case FrameResponse:
+		if value == nil {
+			return nil
+		}
+		return json.Unmarshal(payload, value)
+	}
+	return fmt.Errorf("Unexpected frame type %d", kind)
+}
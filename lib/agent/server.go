@@ -0,0 +1,280 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Server answers requests from the daemon inside the guest
+type Server struct {
+	// Shutdown is run once the response to a shutdown request has been sent
+	Shutdown func() error
+}
+
+func NewServer() *Server {
+	return &Server{
+		Shutdown: Reboot,
+	}
+}
+
+// Serve handles connections until the listener is closed
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	kind, payload, err := ReadFrame(conn)
+	if err != nil {
+		return
+	}
+	fw := newFrameWriter(conn)
+	req := &Request{}
+	if kind != FrameRequest {
+		err = errors.New("Expected a request")
+	} else {
+		err = json.Unmarshal(payload, req)
+	}
+	if err == nil {
+		switch req.Op {
+		case OpPing:
+			err = fw.json(FrameResponse, ping())
+		case OpNetInfo:
+			var info *NetInfo
+			if info, err = netInfo(); err == nil {
+				err = fw.json(FrameResponse, info)
+			}
+		case OpExec:
+			err = s.exec(conn, fw, req)
+		case OpPut:
+			err = s.put(conn, fw, req)
+		case OpGet:
+			err = s.get(fw, req)
+		case OpShutdown:
+			if err = fw.json(FrameResponse, struct{}{}); err == nil && s.Shutdown != nil {
+				go func() {
+					if err := s.Shutdown(); err != nil {
+						log.Printf("Error shutting down: %s", err.Error())
+					}
+				}()
+			}
+		default:
+			err = errors.New("Unknown operation: " + string(req.Op))
+		}
+	}
+	if err != nil {
+		fw.error(err)
+	}
+}
+
+func ping() *PingResponse {
+	resp := &PingResponse{
+		Version: Version,
+	}
+	resp.Hostname, _ = os.Hostname()
+	if b, err := ioutil.ReadFile("/proc/uptime"); err == nil {
+		if fields := strings.Fields(string(b)); len(fields) > 0 {
+			resp.Uptime, _ = strconv.ParseFloat(fields[0], 64)
+		}
+	}
+	return resp
+}
+
+func netInfo() (*NetInfo, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	info := &NetInfo{
+		Interfaces: []*Interface{},
+	}
+	info.Hostname, _ = os.Hostname()
+	for _, iface := range ifaces {
+		item := &Interface{
+			Name:      iface.Name,
+			MAC:       iface.HardwareAddr.String(),
+			MTU:       iface.MTU,
+			Up:        iface.Flags&net.FlagUp != 0,
+			Addresses: []string{},
+		}
+		if addrs, err := iface.Addrs(); err == nil {
+			for _, addr := range addrs {
+				item.Addresses = append(item.Addresses, addr.String())
+			}
+		}
+		info.Interfaces = append(info.Interfaces, item)
+	}
+	return info, nil
+}
+
+// exec runs a command - a response is sent once it has started then its output is streamed until an exit frame
+// carries its exit code. The command is killed if the connection goes away before it finishes.
+func (s *Server) exec(conn net.Conn, fw *frameWriter, req *Request) error {
+	if len(req.Command) == 0 {
+		return errors.New("No command to run")
+	}
+	cmd := exec.Command(req.Command[0], req.Command[1:]...)
+	cmd.Env = append(os.Environ(), req.Env...)
+	cmd.Dir = req.Dir
+	cmd.Stdout = &streamWriter{fw: fw, kind: FrameStdout}
+	cmd.Stderr = &streamWriter{fw: fw, kind: FrameStderr}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if err := fw.json(FrameResponse, struct{}{}); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	go func() {
+		for {
+			kind, payload, err := ReadFrame(conn)
+			if err != nil {
+				stdin.Close()
+				cmd.Process.Kill()
+				return
+			}
+			switch kind {
+			case FrameStdin:
+				stdin.Write(payload)
+			case FrameEOF:
+				stdin.Close()
+			}
+		}
+	}()
+	status := &ExitStatus{}
+	if err := cmd.Wait(); err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return err
+		}
+		status.Code = exitErr.ExitCode()
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			//the same as a shell reports a command killed by a signal
+			status.Code = 128 + int(ws.Signal())
+		}
+	}
+	return fw.json(FrameExit, status)
+}
+
+// put writes a file from the data frames that follow the request - the file is written alongside and renamed into
+// place so a failed transfer doesnt leave half a file behind
+func (s *Server) put(conn net.Conn, fw *frameWriter, req *Request) error {
+	if req.Path == "" {
+		return errors.New("No path to write to")
+	}
+	mode := os.FileMode(req.Mode).Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(req.Path), ".promethium-agent-")
+	var size int64
+	for {
+		kind, payload, rerr := ReadFrame(conn)
+		if rerr != nil {
+			if tmp != nil {
+				tmp.Close()
+				os.Remove(tmp.Name())
+			}
+			return rerr
+		}
+		if kind == FrameEOF {
+			break
+		} else if kind != FrameData {
+			continue
+		}
+		if err == nil {
+			//keep reading after an error so the client gets to hear about it once it has sent everything
+			_, err = tmp.Write(payload)
+			size += int64(len(payload))
+		}
+	}
+	if err != nil {
+		if tmp != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), req.Path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return fw.json(FrameResponse, &FileInfo{
+		Path: req.Path,
+		Size: size,
+		Mode: uint32(mode),
+	})
+}
+
+// get sends a response describing the file followed by its contents in data frames and an EOF frame
+func (s *Server) get(fw *frameWriter, req *Request) error {
+	fd, err := os.Open(req.Path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	info, err := fd.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return errors.New(req.Path + " is not a regular file")
+	}
+	err = fw.json(FrameResponse, &FileInfo{
+		Path: req.Path,
+		Size: info.Size(),
+		Mode: uint32(info.Mode().Perm()),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(&streamWriter{fw: fw, kind: FrameData}, fd); err != nil {
+		//the response has gone so the client cant be told - dropping the connection without an EOF is enough
+		return nil
+	}
+	fw.write(FrameEOF, nil)
+	return nil
+}
+
+// Reboot asks init to reboot the guest falling back to the reboot syscall. Firecracker has no power management
+// so a guest that reboots is how it exits.
+func Reboot() error {
+	//give the response a moment to make it back to the host
+	time.Sleep(100 * time.Millisecond)
+	if err := exec.Command("reboot").Run(); err == nil {
+		return nil
+	}
+	syscall.Sync()
+	return syscall.Reboot(syscall.LINUX_REBOOT_CMD_RESTART)
+}
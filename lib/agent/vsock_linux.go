@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// VsockAddr is the address of one end of a vsock connection
+type VsockAddr struct {
+	CID  uint32
+	Port uint32
+}
+
+func (va *VsockAddr) Network() string {
+	return "vsock"
+}
+
+func (va *VsockAddr) String() string {
+	return fmt.Sprintf("%d:%d", va.CID, va.Port)
+}
+
+// ListenVsock listens on a vsock port in the guest - the net package doesnt know about AF_VSOCK so accepted
+// sockets are wrapped in an os.File which still gets deadlines from the runtime poller
+func ListenVsock(port uint32) (net.Listener, error) {
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrVM{CID: unix.VMADDR_CID_ANY, Port: port}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	if err := unix.Listen(fd, unix.SOMAXCONN); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return &vsockListener{
+		fd:   fd,
+		addr: &VsockAddr{CID: unix.VMADDR_CID_ANY, Port: port},
+	}, nil
+}
+
+type vsockListener struct {
+	fd   int
+	addr *VsockAddr
+}
+
+func (vl *vsockListener) Accept() (net.Conn, error) {
+	fd, sa, err := unix.Accept4(vl.fd, unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	remote := &VsockAddr{}
+	if vm, ok := sa.(*unix.SockaddrVM); ok {
+		remote.CID, remote.Port = vm.CID, vm.Port
+	}
+	return &vsockConn{
+		File:   os.NewFile(uintptr(fd), "vsock:"+remote.String()),
+		local:  vl.addr,
+		remote: remote,
+	}, nil
+}
+
+func (vl *vsockListener) Close() error {
+	//shutdown wakes up a blocked accept which close alone doesnt
+	unix.Shutdown(vl.fd, unix.SHUT_RDWR)
+	return unix.Close(vl.fd)
+}
+
+func (vl *vsockListener) Addr() net.Addr {
+	return vl.addr
+}
+
+type vsockConn struct {
+	*os.File
+	local  *VsockAddr
+	remote *VsockAddr
+}
+
+func (vc *vsockConn) LocalAddr() net.Addr {
+	return vc.local
+}
+
+func (vc *vsockConn) RemoteAddr() net.Addr {
+	return vc.remote
+}
@@ -2,6 +2,8 @@ package common
 
 import (
 	"io"
+
+	"github.com/768bit/promethium/lib/operations"
 )
 
 func NewVmmStorage(uri string) (VmmStorage, error) {
@@ -26,8 +28,13 @@ type StorageDriver interface {
 	LookupPath(path string) (string, bool, error)
 	WriteKernel(id string, source io.Reader) (string, error)
 	WriteCloudInit(id string, source io.Reader) (string, error)
-	WriteRootDisk(id string, source io.Reader, newSize int64, sourceIsRaw bool, growPart bool) (string, error)
+	WriteRootDisk(op *operations.Operation, id string, source io.Reader, newSize int64, sourceIsRaw bool, growPart bool) (string, error)
 	WriteAdditionalDisk(id string, index int, source io.Reader, newSize int64, sourceIsRaw bool, growPart bool) (string, error)
+	SnapshotPath(id string, snapshotID string) (string, error)
+	DeleteSnapshot(id string, snapshotID string) error
+	DiskIDs() ([]string, error)
+	DeleteDisks(id string) error
+	Capacity() (uint64, uint64, error)
 }
 
 type ImageSpec struct {
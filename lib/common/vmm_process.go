@@ -1,17 +1,39 @@
 package common
 
-import "time"
+import (
+	"io"
+	"time"
 
-import "io"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/768bit/promethium/lib/metrics"
+)
 
 type VmmProcess interface {
 	GetStatus() string
+	GetCrashCount() int64
+	GetRestartCount() int64
+	GetLastExitReason() string
 	Wait() error
-	Console() (io.ReadCloser, io.ReadCloser, io.WriteCloser, error)
+	Console(readOnly bool) (io.ReadWriteCloser, error)
 	Start() error
 	Stop() error
 	Shutdown() error
 	ShutdownTimeout(timeout time.Duration) error
 	Restart() error
 	Reset() error
+	Pause() error
+	Resume() error
+	Version() (string, error)
+	CreateSnapshot(statePath string, memPath string) error
+	LoadSnapshot(statePath string, memPath string) error
+	FlushMetrics() error
+	Metrics() *metrics.VmmMetricsSnapshot
+	UpdateBalloon(amountMiB int64) error
+	UpdateResources(resources *config.VmmResourcesConfig) error
+	Pid() int
+	ProcessStat() (*metrics.ProcStat, error)
+	Log(source string) *logging.Log
+	Reattach() (bool, error)
+	Detach() error
 }
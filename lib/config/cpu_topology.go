@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SysfsRoot and CPUInfoPath are where the host cpu topology is read from
+var SysfsRoot = "/sys"
+var CPUInfoPath = "/proc/cpuinfo"
+
+// ParseCPUList parses a kernel cpu list such as 0-3,8,10-11 into a sorted list of cpus
+func ParseCPUList(list string) ([]int, error) {
+	seen := map[int]bool{}
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("Invalid cpu %q in cpu list %q", bounds[0], list)
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil || last < first {
+				return nil, fmt.Errorf("Invalid cpu range %q in cpu list %q", part, list)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			seen[cpu] = true
+		}
+	}
+	cpus := make([]int, 0, len(seen))
+	for cpu := range seen {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+// FormatCPUList writes cpus as a kernel cpu list
+func FormatCPUList(cpus []int) string {
+	sorted := append([]int{}, cpus...)
+	sort.Ints(sorted)
+	parts := []string{}
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(sorted[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// HostCPUTopology is what vms can be given from the cpus of the host. Nodes maps each numa node to its cpus - it is
+// empty on hosts without numa support where everything is on node 0
+type HostCPUTopology struct {
+	Vendor string
+	Online []int
+	Nodes  map[int][]int
+}
+
+// ReadHostCPUTopology reads the cpu topology of the host from sysfs and /proc/cpuinfo
+func ReadHostCPUTopology() (*HostCPUTopology, error) {
+	online, err := ioutil.ReadFile(filepath.Join(SysfsRoot, "devices", "system", "cpu", "online"))
+	if err != nil {
+		return nil, err
+	}
+	topology := &HostCPUTopology{Nodes: map[int][]int{}}
+	if topology.Online, err = ParseCPUList(string(online)); err != nil {
+		return nil, err
+	}
+	nodesPath := filepath.Join(SysfsRoot, "devices", "system", "node")
+	entries, err := ioutil.ReadDir(nodesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "node") {
+			continue
+		}
+		node, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), "node"))
+		if err != nil {
+			continue
+		}
+		cpuList, err := ioutil.ReadFile(filepath.Join(nodesPath, entry.Name(), "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		if topology.Nodes[node], err = ParseCPUList(string(cpuList)); err != nil {
+			return nil, err
+		}
+	}
+	if fd, err := os.Open(CPUInfoPath); err == nil {
+		defer fd.Close()
+		scanner := bufio.NewScanner(fd)
+		for scanner.Scan() {
+			parts := strings.SplitN(scanner.Text(), ":", 2)
+			if len(parts) == 2 && strings.TrimSpace(parts[0]) == "vendor_id" {
+				topology.Vendor = strings.TrimSpace(parts[1])
+				break
+			}
+		}
+	}
+	return topology, nil
+}
+
+// NodeCPUs returns the cpus of a numa node
+func (ht *HostCPUTopology) NodeCPUs(node int) ([]int, error) {
+	if len(ht.Nodes) == 0 {
+		if node != 0 {
+			return nil, errors.New("The host doesnt have numa nodes so only node 0 can be used")
+		}
+		return ht.Online, nil
+	}
+	cpus, ok := ht.Nodes[node]
+	if !ok {
+		return nil, fmt.Errorf("The host doesnt have numa node %d", node)
+	}
+	return cpus, nil
+}
@@ -28,10 +28,10 @@ import (
 
 var IS_NEW_CONFIG bool = false
 
+// RootCommandList is what the daemon user may run with sudo - qemu-nbd, partprobe, the cgroups of vms and the
+// jailer go through the privileged helper instead
 var RootCommandList = []string{
 	"brctl",
-	"qemu-nbd",
-	"partprobe",
 	"udevadm|settle",
 }
 
@@ -54,13 +54,6 @@ func init() {
 	RootCommandList = olist
 }
 
-func getRootCommandListForPath(installBinPath string) []string {
-	olist := append([]string{}, RootCommandList...)
-	//now lets enable sudo on the binaries we need...
-	olist = append(olist, filepath.Join(installBinPath, "jailer"))
-	return olist
-}
-
 type PromethiumDaemonConfigUpdateCallbackArea string
 
 const (
@@ -81,9 +74,14 @@ type PromethiumDaemonConfig struct {
 	Group            string                      `json:"group"`
 	JailUser         string                      `json:"jailUser"`
 	JailGroup        string                      `json:"jailGroup"`
+	JailUIDRange     *JailUIDRangeConfig         `json:"jailUidRange,omitempty"`
 	Http             *HttpAPIConfig              `json:"http"`
 	Https            *HttpsAPIConfig             `json:"https"`
 	Unix             *UnixAPIConfig              `json:"unix"`
+	Logs             *LogsConfig                 `json:"logs"`
+	SSH              *SSHConfig                  `json:"ssh"`
+	StopVMsOnExit    bool                        `json:"stopVmsOnExit"`   //otherwise vms keep running across restarts
+	FixLeaksOnStart  bool                        `json:"fixLeaksOnStart"` //clean up what the doctor finds at startup
 	isNew            bool
 	linuxBridgeAvail bool
 	ovsBridgeAvail   bool
@@ -120,6 +118,7 @@ Type=notify
 ExecStart={{ .BinaryPath }} daemon --service.uid={{ .User }} --service.gid={{ .Group }}
 Restart=always
 RestartSec=30
+KillMode=process
 
 [Install]
 WantedBy=multi-user.target
@@ -197,9 +196,10 @@ func (pdc *PromethiumDaemonConfig) validate(doCreate bool) error {
 
 	if !vutils.Files.CheckPathExists(binPath) {
 		if doCreate {
+			//the helper only runs the binaries in it as root when only root can change them
 			vutils.Files.CreateDirIfNotExist(binPath)
-			DoChmod(binPath, 0700, true)
-			err = DoChown(binPath, uid, gid, true)
+			DoChmod(binPath, 0755, true)
+			err = DoChown(binPath, 0, 0, true)
 			if err != nil {
 				println(err.Error())
 			}
@@ -358,7 +358,6 @@ func installSudoers(groupname string, rootPath string) error {
 	//if vutils.Files.CheckPathExists("/etc/sudoers.d/promethium") {
 	//	return nil
 	//}
-	binPath := filepath.Join(rootPath, "bin")
 	t, err := template.New("sudoers").Parse(PROMETHIUM_SUDOERS_TEMPLATE)
 	if err != nil {
 		return err
@@ -366,7 +365,7 @@ func installSudoers(groupname string, rootPath string) error {
 	var tpl bytes.Buffer
 	t.Execute(&tpl, &SudoersTemplateData{
 		Group:           groupname,
-		RootCommandList: strings.Join(getRootCommandListForPath(binPath), ", "),
+		RootCommandList: strings.Join(RootCommandList, ", "),
 	})
 	println(tpl.String())
 	cmd := vutils.Exec.CreateAsyncCommand("/bin/bash", false, "-c", "echo \""+tpl.String()+"\" > /etc/sudoers.d/promethium")
@@ -488,9 +487,9 @@ func (pdc *PromethiumDaemonConfig) verifyOwnershipAndAccess() error {
 
 	//with each file or directory in the traget verify correct ownership...
 
-	//1. config should be owned by promethium user and group...
+	//1. the binaries belong to root so the daemon cant change what the helper runs...
 	binPath := filepath.Join(pdc.AppRoot, "bin")
-	err = checkPathPerms(binPath, uid, gid, 0700, 0700, true)
+	err = checkPathPerms(binPath, 0, 0, 0755, 0755, true)
 	if err != nil {
 		return err
 	}
@@ -559,6 +558,102 @@ type UnixAPIConfig struct {
 	Path   string `json:"path"`
 }
 
+const (
+	DefaultLogMaxSizeMB = 10
+	DefaultLogMaxFiles  = 5
+)
+
+// LogsConfig sets how large the serial and firecracker logs kept for each instance can grow before they are
+// rotated and how many of the rotated files are kept
+type LogsConfig struct {
+	MaxSizeMB int64 `json:"maxSizeMB"`
+	MaxFiles  int   `json:"maxFiles"`
+}
+
+// GetLogsConfig returns the log settings with the defaults filled in
+func (pdc *PromethiumDaemonConfig) GetLogsConfig() *LogsConfig {
+	logs := &LogsConfig{
+		MaxSizeMB: DefaultLogMaxSizeMB,
+		MaxFiles:  DefaultLogMaxFiles,
+	}
+	if pdc.Logs != nil {
+		if pdc.Logs.MaxSizeMB > 0 {
+			logs.MaxSizeMB = pdc.Logs.MaxSizeMB
+		}
+		if pdc.Logs.MaxFiles > 0 {
+			logs.MaxFiles = pdc.Logs.MaxFiles
+		}
+	}
+	return logs
+}
+
+// JailUIDRangeConfig gives each vm its own uid from Size uids starting at Start rather than running them all as the
+// jail user - the uids dont need users and all of the vms keep the jail group
+type JailUIDRangeConfig struct {
+	Start int `json:"start"`
+	Size  int `json:"size"`
+}
+
+func (jr *JailUIDRangeConfig) Validate() error {
+	if jr.Start <= 0 || jr.Size <= 0 {
+		return errors.New("The jail uid range needs a start above 0 and a size")
+	}
+	return nil
+}
+
+// Contains checks uid is in the range
+func (jr *JailUIDRangeConfig) Contains(uid int) bool {
+	return uid >= jr.Start && uid < jr.Start+jr.Size
+}
+
+// DefaultSSHPort is where the ssh console gateway listens when no port is set
+const DefaultSSHPort = 2222
+
+// SSHConfig enables an ssh server that attaches to the serial console of the instance named as the ssh user e.g.
+// ssh my-vm@host -p 2222
+type SSHConfig struct {
+	Enable      bool             `json:"enable"`
+	BindAddress string           `json:"bindAddress"`
+	Port        uint             `json:"port"`
+	HostKeyPath string           `json:"hostKeyPath"`
+	Users       []*SSHUserConfig `json:"users"`
+}
+
+// SSHUserConfig is an operator allowed to log in to the ssh gateway with any of their keys - keys are given in
+// authorized_keys format either inline or in a file
+type SSHUserConfig struct {
+	Name               string   `json:"name"`
+	AuthorizedKeys     []string `json:"authorizedKeys"`
+	AuthorizedKeysFile string   `json:"authorizedKeysFile"`
+}
+
+// GetListenAddress returns the address the ssh gateway listens on
+func (sc *SSHConfig) GetListenAddress() string {
+	port := sc.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+	return fmt.Sprintf("%s:%d", sc.BindAddress, port)
+}
+
+// GetSSHHostKeyPath returns where the host key of the ssh gateway is kept - it is generated there if it doesnt exist
+func (pdc *PromethiumDaemonConfig) GetSSHHostKeyPath() string {
+	if pdc.SSH != nil && pdc.SSH.HostKeyPath != "" {
+		return pdc.SSH.HostKeyPath
+	}
+	return filepath.Join(pdc.AppRoot, "ssh", "ssh_host_ecdsa_key")
+}
+
+// GetHelperSocketPath returns where the privileged helper listens
+func (pdc *PromethiumDaemonConfig) GetHelperSocketPath() string {
+	return filepath.Join(pdc.AppRoot, "privhelper.sock")
+}
+
+// GetHelperLogPath returns where the output of the privileged helper goes when the daemon starts it
+func (pdc *PromethiumDaemonConfig) GetHelperLogPath() string {
+	return filepath.Join(pdc.AppRoot, "privhelper.log")
+}
+
 type HttpAPIConfig struct {
 	Enable      bool   `json:"enable"`
 	BindAddress string `json:"bindAddress"`
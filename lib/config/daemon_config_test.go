@@ -18,3 +18,16 @@ func TestMakeCloudInitImage(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestJailUIDRange(t *testing.T) {
+	uidRange := &JailUIDRangeConfig{Start: 100000, Size: 10}
+	if err := uidRange.Validate(); err != nil {
+		t.Error(err)
+	}
+	if !uidRange.Contains(100000) || !uidRange.Contains(100009) || uidRange.Contains(100010) || uidRange.Contains(0) {
+		t.Error("unexpected range membership")
+	}
+	if err := (&JailUIDRangeConfig{Start: 100000}).Validate(); err == nil {
+		t.Error("expected an empty range to fail validation")
+	}
+}
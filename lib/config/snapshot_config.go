@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// VmmSnapshotConfig is the metadata for a snapshot of a vmm - it is kept alongside the vmm config while the memory,
+// device state and disk copies are written to the storage target of the vmm
+type VmmSnapshotConfig struct {
+	ID                 string           `json:"id"`
+	VmmID              string           `json:"vmmID"`
+	Name               string           `json:"name"`
+	CreatedAt          time.Time        `json:"createdAt"`
+	FirecrackerVersion string           `json:"firecrackerVersion"`
+	Memory             int64            `json:"memory"`
+	Cpus               int64            `json:"cpus"`
+	Kernel             string           `json:"kernel"`
+	BootCmd            string           `json:"bootCmd,omitempty"`
+	StateURI           string           `json:"stateUri"`
+	MemoryURI          string           `json:"memoryUri"`
+	Disks              []*VmmDiskConfig `json:"disks"` //copies of the vmm disks taken while the vmm was paused
+	Size               int64            `json:"size"`
+}
+
+var firecrackerVersionRegex = regexp.MustCompile(`v?(\d+)\.(\d+)\.(\d+)`)
+
+func parseFirecrackerVersion(version string) ([3]int, error) {
+	parsed := [3]int{}
+	match := firecrackerVersionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return parsed, errors.New("Unable to parse firecracker version: " + version)
+	}
+	for i := 0; i < 3; i++ {
+		parsed[i], _ = strconv.Atoi(match[i+1])
+	}
+	return parsed, nil
+}
+
+// CheckSnapshotCompatibility makes sure a snapshot taken with one version of firecracker can be loaded by another..
+// the snapshot format is only stable within a minor release so the major and minor versions have to match
+func CheckSnapshotCompatibility(snapshotVersion string, firecrackerVersion string) error {
+	snap, err := parseFirecrackerVersion(snapshotVersion)
+	if err != nil {
+		return err
+	}
+	current, err := parseFirecrackerVersion(firecrackerVersion)
+	if err != nil {
+		return err
+	}
+	if snap[0] != current[0] || snap[1] != current[1] {
+		return errors.New("Snapshot was taken with firecracker " + snapshotVersion + " and cannot be restored with " + firecrackerVersion)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestCheckSnapshotCompatibility(t *testing.T) {
+	if err := CheckSnapshotCompatibility("v0.24.2", "Firecracker v0.24.5"); err != nil {
+		t.Errorf("expected patch releases to be compatible: %s", err)
+	}
+	if err := CheckSnapshotCompatibility("v0.23.1", "Firecracker v0.24.0"); err == nil {
+		t.Error("expected different minor releases to be incompatible")
+	}
+	if err := CheckSnapshotCompatibility("v1.0.0", "v0.24.0"); err == nil {
+		t.Error("expected different major releases to be incompatible")
+	}
+	if err := CheckSnapshotCompatibility("", "v0.24.0"); err == nil {
+		t.Error("expected a missing version to be refused")
+	}
+}
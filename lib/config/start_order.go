@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ResolveStartOrder sorts the vmm configs so every vmm comes after the vmms it depends on. Where there is
+// no dependency between two vmms the lower startOrder goes first and then the name is used to keep the
+// order stable. Vmms with an unknown dependency, in a dependency cycle or depending on a vmm that cant be
+// ordered are left out - they are returned by id with the reason.
+func ResolveStartOrder(cfgs []*VmmConfig) ([]*VmmConfig, map[string]error) {
+	byKey := map[string]*VmmConfig{}
+	for _, cfg := range cfgs {
+		byKey[cfg.ID] = cfg
+	}
+	for _, cfg := range cfgs {
+		if _, ok := byKey[cfg.Name]; !ok && cfg.Name != "" {
+			byKey[cfg.Name] = cfg
+		}
+	}
+
+	unresolved := map[string]error{}
+	dependents := map[string][]*VmmConfig{}
+	waitingOn := map[string]int{}
+	for _, cfg := range cfgs {
+		seen := map[string]bool{}
+		for _, dep := range cfg.DependsOn {
+			depCfg, ok := byKey[dep]
+			if !ok {
+				unresolved[cfg.ID] = errors.New("VMM " + cfg.Name + " depends on unknown VMM " + dep)
+				continue
+			} else if depCfg.ID == cfg.ID {
+				unresolved[cfg.ID] = errors.New("VMM " + cfg.Name + " depends on itself")
+				continue
+			} else if seen[depCfg.ID] {
+				continue
+			}
+			seen[depCfg.ID] = true
+			dependents[depCfg.ID] = append(dependents[depCfg.ID], cfg)
+			waitingOn[cfg.ID]++
+		}
+	}
+
+	less := func(a, b *VmmConfig) bool {
+		if a.StartOrder != b.StartOrder {
+			return a.StartOrder < b.StartOrder
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	}
+
+	ready := []*VmmConfig{}
+	for _, cfg := range cfgs {
+		if waitingOn[cfg.ID] == 0 && unresolved[cfg.ID] == nil {
+			ready = append(ready, cfg)
+		}
+	}
+	ordered := []*VmmConfig{}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, next)
+		for _, dependent := range dependents[next.ID] {
+			waitingOn[dependent.ID]--
+			if waitingOn[dependent.ID] == 0 && unresolved[dependent.ID] == nil {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(ordered) == len(cfgs) {
+		return ordered, unresolved
+	}
+
+	//whatever is left is in a dependency cycle or depends on a vmm that cant be started
+	done := map[string]bool{}
+	for _, cfg := range ordered {
+		done[cfg.ID] = true
+	}
+	left := map[string]bool{}
+	for _, cfg := range cfgs {
+		left[cfg.ID] = !done[cfg.ID] && unresolved[cfg.ID] == nil
+	}
+	//vmms that nothing left depends on arent in a cycle - they are waiting on one or on a vmm that cant start
+	for changed := true; changed; {
+		changed = false
+		for _, cfg := range cfgs {
+			if !left[cfg.ID] {
+				continue
+			}
+			inCycle := false
+			for _, dependent := range dependents[cfg.ID] {
+				inCycle = inCycle || left[dependent.ID]
+			}
+			if inCycle {
+				continue
+			}
+			left[cfg.ID] = false
+			changed = true
+			for _, dep := range cfg.DependsOn {
+				if depCfg := byKey[dep]; depCfg != nil && !done[depCfg.ID] {
+					unresolved[cfg.ID] = errors.New("VMM " + cfg.Name + " depends on VMM " + depCfg.Name + " which cant be started")
+					break
+				}
+			}
+		}
+	}
+	cycle := []string{}
+	for _, cfg := range cfgs {
+		if left[cfg.ID] {
+			cycle = append(cycle, cfg.Name)
+		}
+	}
+	sort.Strings(cycle)
+	for _, cfg := range cfgs {
+		if left[cfg.ID] {
+			unresolved[cfg.ID] = errors.New("Dependency cycle between VMMs: " + strings.Join(cycle, ", "))
+		}
+	}
+	return ordered, unresolved
+}
+
+// ResolveDependencies returns the configs of the vmms the given vmm depends on
+func ResolveDependencies(cfg *VmmConfig, cfgs []*VmmConfig) []*VmmConfig {
+	deps := []*VmmConfig{}
+	for _, dep := range cfg.DependsOn {
+		var found *VmmConfig
+		for _, other := range cfgs {
+			if other.ID == dep {
+				found = other
+				break
+			} else if other.Name == dep && found == nil {
+				found = other
+			}
+		}
+		if found != nil {
+			deps = append(deps, found)
+		}
+	}
+	return deps
+}
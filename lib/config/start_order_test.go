@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestResolveStartOrder(t *testing.T) {
+	cfgs := []*VmmConfig{
+		{ID: "1", Name: "app", DependsOn: []string{"db", "cache"}},
+		{ID: "2", Name: "db", StartOrder: 10},
+		{ID: "3", Name: "cache", DependsOn: []string{"2"}},
+		{ID: "4", Name: "monitoring", StartOrder: -1},
+	}
+	ordered, unresolved := ResolveStartOrder(cfgs)
+	if len(unresolved) != 0 {
+		t.Fatal(unresolved)
+	}
+	expected := []string{"monitoring", "db", "cache", "app"}
+	for index, cfg := range ordered {
+		if cfg.Name != expected[index] {
+			t.Errorf("position %d: expected %s got %s", index, expected[index], cfg.Name)
+		}
+	}
+}
+
+func TestResolveStartOrderSkipsCycles(t *testing.T) {
+	cfgs := []*VmmConfig{
+		{ID: "1", Name: "a", DependsOn: []string{"c"}},
+		{ID: "2", Name: "b", DependsOn: []string{"a"}},
+		{ID: "3", Name: "c", DependsOn: []string{"b"}},
+		{ID: "4", Name: "d"},
+		{ID: "5", Name: "e", DependsOn: []string{"a", "d"}},
+	}
+	ordered, unresolved := ResolveStartOrder(cfgs)
+	if len(ordered) != 1 || ordered[0].Name != "d" {
+		t.Errorf("expected only d to be ordered, got %v", ordered)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if err := unresolved[id]; err == nil || err.Error() != "Dependency cycle between VMMs: a, b, c" {
+			t.Errorf("expected %s to be in the cycle, got %v", id, err)
+		}
+	}
+	if err := unresolved["5"]; err == nil || err.Error() != "VMM e depends on VMM a which cant be started" {
+		t.Errorf("expected e to be left out as it depends on the cycle, got %v", err)
+	}
+}
+
+func TestResolveStartOrderSkipsUnknownDependencies(t *testing.T) {
+	cfgs := []*VmmConfig{
+		{ID: "1", Name: "a", DependsOn: []string{"missing"}},
+		{ID: "2", Name: "b", DependsOn: []string{"a"}},
+		{ID: "3", Name: "c", DependsOn: []string{"3"}},
+		{ID: "4", Name: "d"},
+	}
+	ordered, unresolved := ResolveStartOrder(cfgs)
+	if len(ordered) != 1 || ordered[0].Name != "d" {
+		t.Errorf("expected only d to be ordered, got %v", ordered)
+	}
+	expected := map[string]string{
+		"1": "VMM a depends on unknown VMM missing",
+		"2": "VMM b depends on VMM a which cant be started",
+		"3": "VMM c depends on itself",
+	}
+	for id, msg := range expected {
+		if err := unresolved[id]; err == nil || err.Error() != msg {
+			t.Errorf("expected %s to be left out with %q, got %v", id, msg, err)
+		}
+	}
+	if len(unresolved) != len(expected) {
+		t.Errorf("unexpected vmms left out %v", unresolved)
+	}
+}
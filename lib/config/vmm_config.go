@@ -1,6 +1,16 @@
 package config
 
-import "github.com/768bit/promethium/lib/cloudconfig"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/768bit/promethium/lib/cloudconfig"
+)
 
 type VmmType string
 
@@ -8,6 +18,9 @@ const (
 	FirecrackerVmm    VmmType = "firecracker-standard"
 	OSvFirecrackerVmm VmmType = "firecracker-osv"
 	QemuVmm           VmmType = "qemu-standard"
+	//mock vmms dont run a vm at all - they pretend to as scripted by their mock config so everything above the
+	//process can be tested without kvm or firecracker
+	MockVmm VmmType = "mock"
 )
 
 type VmmConfig struct {
@@ -26,6 +39,149 @@ type VmmConfig struct {
 	BootCmd    string            `json:"bootCmd,omitempty"`
 	EntryPoint string            `json:"entryPoint,omitempty"`
 	AutoStart  bool              `json:"autoStart"`
+	//set when the vmm is stopped through the api so an unless-stopped vmm stays down when the daemon starts
+	StoppedByUser bool                `json:"stoppedByUser,omitempty"`
+	JailUID       int                 `json:"jailUid,omitempty"` //allocated when the daemon has a jail uid range
+	Vsock         *VmmVsockConfig     `json:"vsock,omitempty"`
+	Balloon       *VmmBalloonConfig   `json:"balloon,omitempty"`
+	CPU           *VmmCPUConfig       `json:"cpu,omitempty"`
+	Resources     *VmmResourcesConfig `json:"resources,omitempty"`
+	Mock          *VmmMockConfig      `json:"mock,omitempty"` //only used by mock vmms
+
+	RestartPolicy *VmmRestartPolicy       `json:"restartPolicy,omitempty"`
+	HealthChecks  []*VmmHealthCheckConfig `json:"healthChecks,omitempty"`
+
+	StartOrder int64    `json:"startOrder,omitempty"` //lower values start first when there are no dependencies between vmms
+	StartDelay int64    `json:"startDelay,omitempty"` //seconds to wait after starting this vmm before starting the next
+	DependsOn  []string `json:"dependsOn,omitempty"`  //ids or names of vmms that need to be up before this one
+}
+
+type VmmRestartPolicyType string
+
+const (
+	RestartPolicyNo            VmmRestartPolicyType = "no"
+	RestartPolicyOnFailure     VmmRestartPolicyType = "on-failure"
+	RestartPolicyAlways        VmmRestartPolicyType = "always"
+	RestartPolicyUnlessStopped VmmRestartPolicyType = "unless-stopped"
+)
+
+const (
+	DefaultRestartBackoff     int64 = 1
+	DefaultRestartMaxBackoff  int64 = 300
+	DefaultRestartResetWindow int64 = 600
+)
+
+// VmmRestartPolicy controls what happens when the vmm process exits without being asked to..
+// backoff values and the reset window are in seconds - the delay doubles for every consecutive crash
+// and the crash count is reset once the vmm has stayed up for longer than the reset window
+type VmmRestartPolicy struct {
+	Policy      VmmRestartPolicyType `json:"policy"`
+	MaxRetries  int64                `json:"maxRetries,omitempty"` //0 is unlimited
+	Backoff     int64                `json:"backoff,omitempty"`
+	MaxBackoff  int64                `json:"maxBackoff,omitempty"`
+	ResetWindow int64                `json:"resetWindow,omitempty"`
+}
+
+// GetRestartPolicy returns the restart policy with defaults filled in.. configs without a policy keep the old
+// behaviour of respawning autostart instances
+func (cfg *VmmConfig) GetRestartPolicy() *VmmRestartPolicy {
+	policy := &VmmRestartPolicy{
+		Policy: RestartPolicyNo,
+	}
+	if cfg.RestartPolicy != nil {
+		*policy = *cfg.RestartPolicy
+	} else if cfg.AutoStart {
+		policy.Policy = RestartPolicyUnlessStopped
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = DefaultRestartBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultRestartMaxBackoff
+	}
+	if policy.ResetWindow <= 0 {
+		policy.ResetWindow = DefaultRestartResetWindow
+	}
+	return policy
+}
+
+// Validate checks the policy type is one we understand
+func (rp *VmmRestartPolicy) Validate() error {
+	switch rp.Policy {
+	case RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
+	default:
+		return errors.New("Unknown restart policy: " + string(rp.Policy))
+	}
+	if rp.MaxRetries < 0 {
+		return errors.New("Restart policy maxRetries cannot be negative")
+	}
+	return nil
+}
+
+// ShouldRestart decides if an exited vmm should be brought back up based on how it exited
+// and how many times it has already crashed - a vmm stopped by the user is never brought back
+func (rp *VmmRestartPolicy) ShouldRestart(failed bool, stoppedByUser bool, crashCount int64) bool {
+	if stoppedByUser {
+		return false
+	}
+	switch rp.Policy {
+	case RestartPolicyOnFailure:
+		if !failed {
+			return false
+		}
+	case RestartPolicyAlways, RestartPolicyUnlessStopped:
+	default:
+		return false
+	}
+	return rp.MaxRetries == 0 || crashCount <= rp.MaxRetries
+}
+
+// StartOnBoot returns true when the daemon should start the vmm as it loads its config - always brings the vmm up
+// even if it was stopped by the user where unless-stopped leaves it down
+func (rp *VmmRestartPolicy) StartOnBoot(autoStart bool, stoppedByUser bool) bool {
+	switch rp.Policy {
+	case RestartPolicyAlways:
+		return true
+	case RestartPolicyUnlessStopped:
+		return autoStart && !stoppedByUser
+	default:
+		return autoStart
+	}
+}
+
+// BackoffDelay is the delay before the next restart attempt - it doubles with each consecutive crash
+func (rp *VmmRestartPolicy) BackoffDelay(crashCount int64) time.Duration {
+	delay := rp.Backoff
+	for i := int64(1); i < crashCount && delay < rp.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > rp.MaxBackoff {
+		delay = rp.MaxBackoff
+	}
+	return time.Duration(delay) * time.Second
+}
+
+// VmmMockConfig scripts how a mock vmm behaves - delays are in milliseconds
+type VmmMockConfig struct {
+	BootDelay      int64    `json:"bootDelay,omitempty"`      //how long the vmm is Starting for before it is Running
+	ShutdownDelay  int64    `json:"shutdownDelay,omitempty"`  //how long the guest takes to shut down when asked to
+	IgnoreShutdown bool     `json:"ignoreShutdown,omitempty"` //the guest never shuts down so shutdowns time out
+	ConsoleOutput  []string `json:"consoleOutput,omitempty"`  //lines written to the console over the boot delay
+	Echo           bool     `json:"echo,omitempty"`           //console input is written back to the console
+	FailStart      string   `json:"failStart,omitempty"`      //starting fails with this error
+	FailStartCount int64    `json:"failStartCount,omitempty"` //only this many starts fail - 0 fails every start
+	CrashAfter     int64    `json:"crashAfter,omitempty"`     //the vmm exits with a failure this long after it is Running
+}
+
+// Validate checks the delays make sense
+func (mc *VmmMockConfig) Validate() error {
+	if mc.BootDelay < 0 || mc.ShutdownDelay < 0 || mc.CrashAfter < 0 {
+		return errors.New("Mock delays cannot be negative")
+	}
+	if mc.FailStartCount < 0 {
+		return errors.New("Mock failStartCount cannot be negative")
+	}
+	return nil
 }
 
 type VmmDiskConfig struct {
@@ -50,3 +206,289 @@ type VmmNetworkInterfaceConfig struct {
 
 type VmmVolumeConfig struct {
 }
+
+const (
+	//cids 0-2 are reserved for the hypervisor, local loopback and the host
+	MinVsockCID         uint32 = 3
+	DefaultVsockUDSPath        = "vsock.sock"
+)
+
+// VmmVsockConfig gives the vm a vsock device. Firecracker exposes guest ports to the host through a unix socket at
+// UDSPath inside the jail. A CID of 0 is allocated when the vm is loaded. AgentPort is where the guest agent listens
+// when it isnt on the default port
+type VmmVsockConfig struct {
+	CID       uint32 `json:"cid,omitempty"`
+	UDSPath   string `json:"udsPath,omitempty"`
+	AgentPort uint32 `json:"agentPort,omitempty"`
+}
+
+// GetUDSPath returns the path of the vsock unix socket relative to the root of the jail
+func (vc *VmmVsockConfig) GetUDSPath() string {
+	if vc.UDSPath == "" {
+		return DefaultVsockUDSPath
+	}
+	return strings.TrimPrefix(filepath.Clean("/"+vc.UDSPath), "/")
+}
+
+// VmmBalloonConfig gives the vm a memory balloon. Inflating it to AmountMiB hands that much of the guest memory back
+// to the host. With DeflateOnOOM the guest can take memory back from the balloon rather than running out. Balloon
+// statistics are collected every StatsInterval seconds when it is set.
+type VmmBalloonConfig struct {
+	AmountMiB     int64 `json:"amountMib"`
+	DeflateOnOOM  bool  `json:"deflateOnOom,omitempty"`
+	StatsInterval int64 `json:"statsInterval,omitempty"`
+}
+
+// Validate checks the balloon fits in the memory of the vm
+func (bc *VmmBalloonConfig) Validate(memory int64) error {
+	if bc.AmountMiB < 0 {
+		return errors.New("Balloon size cannot be negative")
+	}
+	if bc.AmountMiB >= memory {
+		return fmt.Errorf("Balloon of %d MiB doesnt leave any of the %d MiB of memory for the guest", bc.AmountMiB, memory)
+	}
+	if bc.StatsInterval < 0 {
+		return errors.New("Balloon statistics interval cannot be negative")
+	}
+	return nil
+}
+
+const (
+	CPUTemplateC3 = "C3"
+	CPUTemplateT2 = "T2"
+)
+
+// VmmCPUConfig sets how the vcpus of the vm are presented to the guest and where they run on the host. CPUTemplate
+// masks the cpu features the guest sees and SMT presents the vcpus as hyperthread pairs. The vm is kept on the memory
+// and cpus of NumaNode and each vcpu is pinned to a cpu of CPUSet when it is set - the cpus of CPUSet have to be on
+// NumaNode. A vm whose vcpus cant be pinned fails to start.
+type VmmCPUConfig struct {
+	CPUTemplate string `json:"cpuTemplate,omitempty"`
+	SMT         bool   `json:"smt,omitempty"`
+	NumaNode    int    `json:"numaNode,omitempty"`
+	CPUSet      string `json:"cpuSet,omitempty"`
+}
+
+// Pinning returns the host cpus the vcpus are pinned to - nil when they arent pinned
+func (cc *VmmCPUConfig) Pinning() ([]int, error) {
+	if strings.TrimSpace(cc.CPUSet) == "" {
+		return nil, nil
+	}
+	cpus, err := ParseCPUList(cc.CPUSet)
+	if err != nil {
+		return nil, err
+	}
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("CPU set %q doesnt contain any cpus", cc.CPUSet)
+	}
+	return cpus, nil
+}
+
+// Validate checks the cpu config can be given to a vm with vcpus cpus on the host
+func (cc *VmmCPUConfig) Validate(vcpus int64, host *HostCPUTopology) error {
+	switch cc.CPUTemplate {
+	case "":
+	case CPUTemplateC3, CPUTemplateT2:
+		if host.Vendor != "GenuineIntel" {
+			return fmt.Errorf("CPU template %s needs an Intel host cpu not %s", cc.CPUTemplate, host.Vendor)
+		}
+	default:
+		return fmt.Errorf("Unknown cpu template %s", cc.CPUTemplate)
+	}
+	if cc.SMT && vcpus != 1 && vcpus%2 != 0 {
+		return fmt.Errorf("SMT needs 1 or an even number of vcpus not %d", vcpus)
+	}
+	if cc.NumaNode < 0 {
+		return errors.New("NUMA node cannot be negative")
+	}
+	nodeCPUs, err := host.NodeCPUs(cc.NumaNode)
+	if err != nil {
+		return err
+	}
+	pinning, err := cc.Pinning()
+	if err != nil {
+		return err
+	}
+	onNode := map[int]bool{}
+	for _, cpu := range nodeCPUs {
+		onNode[cpu] = true
+	}
+	online := map[int]bool{}
+	for _, cpu := range host.Online {
+		online[cpu] = true
+	}
+	for _, cpu := range pinning {
+		if !online[cpu] {
+			return fmt.Errorf("CPU %d of cpu set %s isnt online on the host", cpu, cc.CPUSet)
+		}
+		if !onNode[cpu] {
+			return fmt.Errorf("CPU %d of cpu set %s isnt on numa node %d", cpu, cc.CPUSet, cc.NumaNode)
+		}
+	}
+	return nil
+}
+
+// the period cpu.max quotas are given over in microseconds
+const CPUMaxPeriod = 100000
+
+// VmmResourcesConfig limits what the firecracker process of the vm can take from the host through its cgroup. The
+// memory limit is the memory of the guest plus MemoryOverheadMiB for firecracker itself and is only set when the
+// overhead is. Zero values leave a resource unlimited.
+type VmmResourcesConfig struct {
+	CPUWeight         int64         `json:"cpuWeight,omitempty"`
+	CPUMax            float64       `json:"cpuMax,omitempty"` //in cpus - 1.5 is one and a half cpus worth of time
+	MemoryOverheadMiB int64         `json:"memoryOverheadMib,omitempty"`
+	IOMax             []*VmmIOLimit `json:"ioMax,omitempty"`
+	PidsMax           int64         `json:"pidsMax,omitempty"`
+}
+
+// VmmIOLimit limits io to a device backing the disks of the vm. Device is the path of a disk image or block device
+// or a major:minor number - an empty Device applies the limit to every device backing the disks of the vm
+type VmmIOLimit struct {
+	Device    string `json:"device,omitempty"`
+	ReadBps   int64  `json:"readBps,omitempty"`
+	WriteBps  int64  `json:"writeBps,omitempty"`
+	ReadIOPS  int64  `json:"readIops,omitempty"`
+	WriteIOPS int64  `json:"writeIops,omitempty"`
+}
+
+func (rc *VmmResourcesConfig) Validate() error {
+	if rc.CPUWeight != 0 && (rc.CPUWeight < 1 || rc.CPUWeight > 10000) {
+		return fmt.Errorf("CPU weight has to be between 1 and 10000 not %d", rc.CPUWeight)
+	}
+	if rc.CPUMax < 0 || rc.MemoryOverheadMiB < 0 || rc.PidsMax < 0 {
+		return errors.New("Resource limits cannot be negative")
+	}
+	if rc.CPUMax != 0 && int64(rc.CPUMax*CPUMaxPeriod) < 1000 {
+		return errors.New("CPU max has to be at least 0.01 cpus")
+	}
+	for _, limit := range rc.IOMax {
+		if limit.ReadBps < 0 || limit.WriteBps < 0 || limit.ReadIOPS < 0 || limit.WriteIOPS < 0 {
+			return errors.New("IO limits cannot be negative")
+		}
+	}
+	return nil
+}
+
+// CPUWeightValue is the value for cpu.weight
+func (rc *VmmResourcesConfig) CPUWeightValue() string {
+	if rc.CPUWeight == 0 {
+		return "100"
+	}
+	return strconv.FormatInt(rc.CPUWeight, 10)
+}
+
+// CPUMaxValue is the value for cpu.max
+func (rc *VmmResourcesConfig) CPUMaxValue() string {
+	if rc.CPUMax == 0 {
+		return fmt.Sprintf("max %d", CPUMaxPeriod)
+	}
+	return fmt.Sprintf("%d %d", int64(rc.CPUMax*CPUMaxPeriod), CPUMaxPeriod)
+}
+
+// MemoryMaxValue is the value for memory.max for a vm with memory MiB of guest memory
+func (rc *VmmResourcesConfig) MemoryMaxValue(memory int64) string {
+	if rc.MemoryOverheadMiB == 0 {
+		return "max"
+	}
+	return strconv.FormatInt((memory+rc.MemoryOverheadMiB)*1024*1024, 10)
+}
+
+// PidsMaxValue is the value for pids.max
+func (rc *VmmResourcesConfig) PidsMaxValue() string {
+	return limitValue(rc.PidsMax)
+}
+
+// Value is the limit as it is written to io.max after the device
+func (il *VmmIOLimit) Value() string {
+	return fmt.Sprintf("rbps=%s wbps=%s riops=%s wiops=%s", limitValue(il.ReadBps), limitValue(il.WriteBps),
+		limitValue(il.ReadIOPS), limitValue(il.WriteIOPS))
+}
+
+func limitValue(limit int64) string {
+	if limit == 0 {
+		return "max"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+type VmmHealthCheckType string
+
+const (
+	HealthCheckTCP   VmmHealthCheckType = "tcp"
+	HealthCheckHTTP  VmmHealthCheckType = "http"
+	HealthCheckVsock VmmHealthCheckType = "vsock"
+	HealthCheckAgent VmmHealthCheckType = "agent" //pings the guest agent - no port needed
+)
+
+type VmmHealthRemediation string
+
+const (
+	HealthRemediationNone    VmmHealthRemediation = "none"
+	HealthRemediationRestart VmmHealthRemediation = "restart"
+	HealthRemediationReset   VmmHealthRemediation = "reset"
+)
+
+const (
+	DefaultHealthCheckInterval  int64 = 30
+	DefaultHealthCheckTimeout   int64 = 5
+	DefaultHealthCheckThreshold int64 = 3
+)
+
+// VmmHealthCheckConfig describes a single check run against the guest. Host defaults to the first address
+// of the first network interface. Interval, timeout and start period are in seconds.
+type VmmHealthCheckConfig struct {
+	Type        VmmHealthCheckType   `json:"type"`
+	Host        string               `json:"host,omitempty"`
+	Port        int64                `json:"port"`
+	Path        string               `json:"path,omitempty"`        //http only
+	Interval    int64                `json:"interval,omitempty"`    //how often the check runs
+	Timeout     int64                `json:"timeout,omitempty"`     //how long a single check can take
+	Threshold   int64                `json:"threshold,omitempty"`   //consecutive failures before the vm is unhealthy
+	StartPeriod int64                `json:"startPeriod,omitempty"` //failures are ignored for this long after a (re)start
+	Remediation VmmHealthRemediation `json:"remediation,omitempty"`
+}
+
+// WithDefaults returns a copy of the check with the defaults filled in
+func (hc *VmmHealthCheckConfig) WithDefaults() *VmmHealthCheckConfig {
+	check := *hc
+	if check.Interval <= 0 {
+		check.Interval = DefaultHealthCheckInterval
+	}
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultHealthCheckTimeout
+	}
+	if check.Threshold <= 0 {
+		check.Threshold = DefaultHealthCheckThreshold
+	}
+	if check.Remediation == "" {
+		check.Remediation = HealthRemediationNone
+	}
+	if check.Type == HealthCheckHTTP && check.Path == "" {
+		check.Path = "/"
+	}
+	return &check
+}
+
+// Validate checks the health check can actually be run
+func (hc *VmmHealthCheckConfig) Validate() error {
+	switch hc.Type {
+	case HealthCheckTCP, HealthCheckHTTP, HealthCheckVsock, HealthCheckAgent:
+	default:
+		return errors.New("Unknown health check type: " + string(hc.Type))
+	}
+	//vsock ports are 32 bit
+	maxPort := int64(65535)
+	if hc.Type == HealthCheckVsock {
+		maxPort = math.MaxUint32
+	}
+	if hc.Type != HealthCheckAgent && (hc.Port <= 0 || hc.Port > maxPort) {
+		return errors.New("Health check port is invalid")
+	}
+	switch hc.Remediation {
+	case "", HealthRemediationNone, HealthRemediationRestart, HealthRemediationReset:
+	default:
+		return errors.New("Unknown health check remediation: " + string(hc.Remediation))
+	}
+	return nil
+}
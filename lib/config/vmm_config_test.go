@@ -0,0 +1,275 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRestartPolicyDefaults(t *testing.T) {
+	cfg := &VmmConfig{AutoStart: true}
+	policy := cfg.GetRestartPolicy()
+	if policy.Policy != RestartPolicyUnlessStopped {
+		t.Errorf("expected autostart config to default to %s got %s", RestartPolicyUnlessStopped, policy.Policy)
+	}
+	cfg = &VmmConfig{}
+	if policy := cfg.GetRestartPolicy(); policy.Policy != RestartPolicyNo {
+		t.Errorf("expected config to default to %s got %s", RestartPolicyNo, policy.Policy)
+	}
+	if err := (&VmmRestartPolicy{Policy: "sometimes"}).Validate(); err == nil {
+		t.Error("expected unknown policy to fail validation")
+	}
+}
+
+func TestRestartPolicyShouldRestart(t *testing.T) {
+	policy := (&VmmConfig{RestartPolicy: &VmmRestartPolicy{Policy: RestartPolicyOnFailure, MaxRetries: 2}}).GetRestartPolicy()
+	if policy.ShouldRestart(false, false, 1) {
+		t.Error("on-failure should not restart a clean exit")
+	}
+	if !policy.ShouldRestart(true, false, 2) {
+		t.Error("on-failure should restart while under max retries")
+	}
+	if policy.ShouldRestart(true, false, 3) {
+		t.Error("on-failure should stop restarting after max retries")
+	}
+	policy.Policy = RestartPolicyAlways
+	if !policy.ShouldRestart(false, false, 1) {
+		t.Error("always should restart a clean exit")
+	}
+	policy.Policy = RestartPolicyNo
+	if policy.ShouldRestart(true, false, 1) {
+		t.Error("no should never restart")
+	}
+}
+
+func TestRestartPolicyStoppedByUser(t *testing.T) {
+	for _, policy := range []VmmRestartPolicyType{RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped} {
+		rp := (&VmmConfig{RestartPolicy: &VmmRestartPolicy{Policy: policy}}).GetRestartPolicy()
+		if !rp.ShouldRestart(true, false, 1) {
+			t.Errorf("%s should restart a crashed vmm", policy)
+		}
+		if rp.ShouldRestart(true, true, 1) || rp.ShouldRestart(false, true, 1) {
+			t.Errorf("%s should not restart a vmm stopped by the user", policy)
+		}
+	}
+}
+
+func TestRestartPolicyStartOnBoot(t *testing.T) {
+	always := &VmmRestartPolicy{Policy: RestartPolicyAlways}
+	if !always.StartOnBoot(false, false) || !always.StartOnBoot(true, true) {
+		t.Error("always should start on boot even when stopped by the user")
+	}
+	unlessStopped := &VmmRestartPolicy{Policy: RestartPolicyUnlessStopped}
+	if !unlessStopped.StartOnBoot(true, false) {
+		t.Error("unless-stopped should start an autostart vmm on boot")
+	}
+	if unlessStopped.StartOnBoot(true, true) {
+		t.Error("unless-stopped should leave a vmm stopped by the user down")
+	}
+	no := &VmmRestartPolicy{Policy: RestartPolicyNo}
+	if !no.StartOnBoot(true, true) || no.StartOnBoot(false, false) {
+		t.Error("no should only start autostart vmms on boot")
+	}
+}
+
+func TestRestartPolicyBackoff(t *testing.T) {
+	policy := &VmmRestartPolicy{Policy: RestartPolicyAlways, Backoff: 2, MaxBackoff: 10}
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, exp := range expected {
+		if delay := policy.BackoffDelay(int64(i + 1)); delay != exp {
+			t.Errorf("crash %d: expected delay %s got %s", i+1, exp, delay)
+		}
+	}
+}
+
+func TestHealthCheckDefaults(t *testing.T) {
+	check := (&VmmHealthCheckConfig{Type: HealthCheckHTTP, Port: 8080}).WithDefaults()
+	if err := check.Validate(); err != nil {
+		t.Error(err)
+	}
+	if check.Path != "/" || check.Interval != DefaultHealthCheckInterval || check.Threshold != DefaultHealthCheckThreshold {
+		t.Errorf("unexpected defaults: %+v", check)
+	}
+	if check.Remediation != HealthRemediationNone {
+		t.Errorf("expected remediation to default to %s got %s", HealthRemediationNone, check.Remediation)
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckTCP}).Validate(); err == nil {
+		t.Error("expected missing port to fail validation")
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckTCP, Port: 22, Remediation: "reboot"}).Validate(); err == nil {
+		t.Error("expected unknown remediation to fail validation")
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckAgent}).Validate(); err != nil {
+		t.Errorf("expected an agent check to not need a port got %s", err)
+	}
+}
+
+func TestVsockUDSPath(t *testing.T) {
+	paths := map[string]string{
+		"":                 DefaultVsockUDSPath,
+		"agent.sock":       "agent.sock",
+		"/run/agent.sock":  "run/agent.sock",
+		"../../etc/passwd": "etc/passwd",
+	}
+	for udsPath, expected := range paths {
+		if got := (&VmmVsockConfig{UDSPath: udsPath}).GetUDSPath(); got != expected {
+			t.Errorf("expected %q to be kept in the jail as %q got %q", udsPath, expected, got)
+		}
+	}
+}
+
+func TestVsockHealthCheckPort(t *testing.T) {
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckVsock, Port: 70000}).Validate(); err != nil {
+		t.Errorf("expected a 32 bit vsock port to be allowed: %v", err)
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckTCP, Port: 70000}).Validate(); err == nil {
+		t.Error("expected a tcp port over 65535 to be rejected")
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckVsock, Port: 1 << 32}).Validate(); err == nil {
+		t.Error("expected a vsock port over 32 bits to be rejected")
+	}
+	if err := (&VmmHealthCheckConfig{Type: HealthCheckVsock}).Validate(); err == nil {
+		t.Error("expected a vsock check without a port to be rejected")
+	}
+}
+
+func TestBalloonValidate(t *testing.T) {
+	if err := (&VmmBalloonConfig{AmountMiB: 256, StatsInterval: 5}).Validate(1024); err != nil {
+		t.Error(err)
+	}
+	if err := (&VmmBalloonConfig{AmountMiB: 1024}).Validate(1024); err == nil {
+		t.Error("expected a balloon as large as the memory to fail validation")
+	}
+	if err := (&VmmBalloonConfig{AmountMiB: -1}).Validate(1024); err == nil {
+		t.Error("expected a negative balloon to fail validation")
+	}
+}
+
+func TestCPUList(t *testing.T) {
+	cpus, err := ParseCPUList("8,0-3, 2,10-11\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FormatCPUList(cpus); got != "0-3,8,10-11" {
+		t.Errorf("expected 0-3,8,10-11 got %s", got)
+	}
+	for _, list := range []string{"a", "3-1", "-1"} {
+		if _, err := ParseCPUList(list); err == nil {
+			t.Errorf("expected %q to fail to parse", list)
+		}
+	}
+}
+
+func TestCPUValidate(t *testing.T) {
+	host := &HostCPUTopology{
+		Vendor: "AuthenticAMD",
+		Online: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		Nodes:  map[int][]int{0: {0, 1, 2, 3}, 1: {4, 5, 6, 7}},
+	}
+	if err := (&VmmCPUConfig{SMT: true, NumaNode: 1, CPUSet: "4-5"}).Validate(2, host); err != nil {
+		t.Error(err)
+	}
+	invalid := map[string]*VmmCPUConfig{
+		"a template on an amd host":   {CPUTemplate: CPUTemplateC3},
+		"an unknown template":         {CPUTemplate: "M5"},
+		"smt with an odd vcpu count":  {SMT: true},
+		"a missing numa node":         {NumaNode: 2},
+		"cpus from another numa node": {CPUSet: "2-5"},
+		"an offline cpu":              {CPUSet: "12"},
+	}
+	for name, cfg := range invalid {
+		if err := cfg.Validate(3, host); err == nil {
+			t.Errorf("expected %s to fail validation", name)
+		}
+	}
+	host.Vendor = "GenuineIntel"
+	host.Nodes = map[int][]int{}
+	if err := (&VmmCPUConfig{CPUTemplate: CPUTemplateT2, CPUSet: "6"}).Validate(1, host); err != nil {
+		t.Error(err)
+	}
+	if err := (&VmmCPUConfig{NumaNode: 1}).Validate(1, host); err == nil {
+		t.Error("expected node 1 to fail validation on a host without numa")
+	}
+}
+
+func TestReadHostCPUTopology(t *testing.T) {
+	root, err := ioutil.TempDir("", "sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	oldRoot, oldInfo := SysfsRoot, CPUInfoPath
+	SysfsRoot, CPUInfoPath = root, filepath.Join(root, "cpuinfo")
+	defer func() { SysfsRoot, CPUInfoPath = oldRoot, oldInfo }()
+	files := map[string]string{
+		"devices/system/cpu/online":         "0-3\n",
+		"devices/system/node/node0/cpulist": "0-1\n",
+		"devices/system/node/node1/cpulist": "2-3\n",
+		"devices/system/node/possible":      "0-1\n",
+		"cpuinfo":                           "processor\t: 0\nvendor_id\t: GenuineIntel\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	host, err := ReadHostCPUTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.Vendor != "GenuineIntel" || len(host.Online) != 4 || len(host.Nodes) != 2 {
+		t.Errorf("unexpected topology %+v", host)
+	}
+	if cpus, err := host.NodeCPUs(1); err != nil || FormatCPUList(cpus) != "2-3" {
+		t.Errorf("expected node 1 to have cpus 2-3 got %v %v", cpus, err)
+	}
+}
+
+func TestResourcesValues(t *testing.T) {
+	res := &VmmResourcesConfig{}
+	if err := res.Validate(); err != nil {
+		t.Error(err)
+	}
+	if res.CPUWeightValue() != "100" || res.CPUMaxValue() != "max 100000" || res.MemoryMaxValue(512) != "max" || res.PidsMaxValue() != "max" {
+		t.Error("expected an empty config to leave everything unlimited")
+	}
+	res = &VmmResourcesConfig{CPUWeight: 200, CPUMax: 1.5, MemoryOverheadMiB: 64, PidsMax: 32}
+	if res.CPUWeightValue() != "200" || res.CPUMaxValue() != "150000 100000" || res.MemoryMaxValue(512) != "603979776" || res.PidsMaxValue() != "32" {
+		t.Errorf("unexpected values %s %s %s %s", res.CPUWeightValue(), res.CPUMaxValue(), res.MemoryMaxValue(512), res.PidsMaxValue())
+	}
+	if got := (&VmmIOLimit{ReadBps: 1048576, WriteIOPS: 100}).Value(); got != "rbps=1048576 wbps=max riops=max wiops=100" {
+		t.Errorf("unexpected io limit %s", got)
+	}
+	invalid := []*VmmResourcesConfig{
+		{CPUWeight: 20000},
+		{CPUMax: 0.001},
+		{PidsMax: -1},
+		{IOMax: []*VmmIOLimit{{ReadBps: -1}}},
+	}
+	for _, res := range invalid {
+		if err := res.Validate(); err == nil {
+			t.Errorf("expected %+v to fail validation", res)
+		}
+	}
+}
+
+func TestMockValidate(t *testing.T) {
+	if err := (&VmmMockConfig{BootDelay: 100, CrashAfter: 1000, FailStartCount: 2}).Validate(); err != nil {
+		t.Error(err)
+	}
+	invalid := []*VmmMockConfig{
+		{BootDelay: -1},
+		{ShutdownDelay: -1},
+		{CrashAfter: -1},
+		{FailStartCount: -1},
+	}
+	for _, mock := range invalid {
+		if err := mock.Validate(); err == nil {
+			t.Errorf("expected %+v to fail validation", mock)
+		}
+	}
+}
@@ -1,16 +1,24 @@
 package lib
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/exec"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/768bit/promethium/api/restapi"
 	"github.com/768bit/promethium/api/restapi/operations"
 	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/768bit/promethium/lib/privhelper"
 	"github.com/768bit/promethium/lib/service"
+	"github.com/768bit/promethium/lib/service/daemon"
+	"github.com/768bit/promethium/lib/sshgateway"
 	"github.com/768bit/promethium/lib/vmm"
 	"github.com/go-openapi/loads"
 	"github.com/gorilla/mux"
@@ -43,6 +51,7 @@ type PromethiumDaemon struct {
 	vmmManager *vmm.VmmManager
 	status     PromethiumDaemonStatus
 	api        *mux.Router
+	sshGateway *sshgateway.Gateway
 }
 
 func (pd *PromethiumDaemon) init(foreground bool) error {
@@ -100,11 +109,21 @@ func (pd *PromethiumDaemon) captureInterrupts() {
 
 }
 func (pd *PromethiumDaemon) killVmmManager() error {
+	if pd.sshGateway != nil {
+		pd.sshGateway.Close()
+	}
 	log.Printf("Killing VmmManager and instances...")
 	return pd.vmmManager.Kill()
 }
 
 func (pd *PromethiumDaemon) waitKillVmmManager() error {
+	if pd.sshGateway != nil {
+		pd.sshGateway.Close()
+	}
+	if !pd.config.StopVMsOnExit {
+		log.Printf("Detaching from instances...")
+		return pd.vmmManager.Detach()
+	}
 	log.Printf("Killing VmmManager and instances with timeout...")
 	return pd.vmmManager.WaitKill()
 }
@@ -126,19 +145,19 @@ func (pd *PromethiumDaemon) setupDaemonise() {
 
 			log.Printf("Daemon Start...")
 
-			// Once initialization requiring root is done, call this.
-			if err := pd.Start(); err != nil {
-				return err
-			}
-
 			log.Printf("Dropping Daemon Privs...")
 
-			//if err := smgr.DropPrivileges(); err != nil {
-			//	return err
-			//	}
+			// Everything requiring root is done by the privileged helper from here on.
+			if err := pd.dropPrivileges(); err != nil {
+				return err
+			}
 
 			log.Printf("Daemon Privs Dropped...")
 
+			if err := pd.Start(); err != nil {
+				return err
+			}
+
 			err := pd.vmmManager.Start()
 			if err != nil {
 				return err
@@ -173,7 +192,12 @@ func (pd *PromethiumDaemon) setupForeground() {
 	pd.waitChan = make(chan bool)
 
 	pd.captureInterrupts()
-	err := pd.Start()
+	err := pd.dropPrivileges()
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	err = pd.Start()
 	if err != nil {
 		println(err.Error())
 	}
@@ -191,6 +215,7 @@ func (pd *PromethiumDaemon) Start() error {
 	if pd.vmmManager, err = vmm.NewVmmManager(pd.config); err != nil {
 		return err
 	}
+	metrics.Registry.MustRegister(vmm.NewCollector(pd.vmmManager))
 	swaggerSpec, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -230,6 +255,12 @@ func (pd *PromethiumDaemon) Start() error {
 		}
 	}()
 
+	if pd.config.SSH != nil && pd.config.SSH.Enable {
+		if err := pd.startSSHGateway(); err != nil {
+			log.Printf("Unable to start the SSH gateway: %s", err.Error())
+		}
+	}
+
 	//start api...
 	// pd.api = api.MakeNewApiRouter(pd.vmmManager)
 	// server := http.Server{
@@ -251,6 +282,127 @@ func (pd *PromethiumDaemon) Start() error {
 
 }
 
+// dropPrivileges starts the privileged helper and then drops to the daemon user and group - when the daemon isnt run
+// as root it uses a helper which has been started separately
+func (pd *PromethiumDaemon) dropPrivileges() error {
+	client := privhelper.NewClient(pd.config.GetHelperSocketPath())
+	privhelper.Default = client
+	if os.Geteuid() != 0 {
+		if err := client.Ping(); err != nil {
+			log.Printf("The privileged helper isnt available, privileged operations will fail: %s", err.Error())
+		}
+		return nil
+	}
+	uid, err := config.GetUserId(pd.config.User)
+	if err != nil {
+		return err
+	}
+	gid, err := config.GetGroupId(pd.config.Group)
+	if err != nil {
+		return err
+	}
+	if err := vmm.InstallEmbeddedBinaries(pd.config.AppRoot); err != nil {
+		return err
+	}
+	if err := pd.startPrivilegedHelper(client); err != nil {
+		return err
+	}
+	if _, err := daemon.DropPrivileges(uid, gid, ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// startPrivilegedHelper runs the helper in its own session and waits for it to listen - the helper outlives the
+// daemon as it holds the jailers of running vms so one left by a previous run of the daemon is used instead. One left
+// by another build is replaced, the new helper takes over its jailers.
+func (pd *PromethiumDaemon) startPrivilegedHelper(client *privhelper.Client) error {
+	version := privhelper.BinaryVersion()
+	if running, err := client.Version(); err == nil {
+		if version == "" || running == version {
+			log.Printf("Using the running privileged helper")
+			return nil
+		}
+		log.Printf("The running privileged helper is from another build of promethium, replacing it")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(pd.config.GetHelperLogPath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	cmd := exec.Command(exe, "helper")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true,
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		log.Printf("Privileged helper exited: %v", err)
+		close(exited)
+	}()
+	for i := 0; ; i++ {
+		running, err := client.Version()
+		if err == nil && (version == "" || running == version) {
+			return nil
+		}
+		select {
+		case <-exited:
+			if err == nil {
+				//the old helper wouldnt hand over so it is kept rather than losing the jailers of running vms
+				log.Printf("The privileged helper couldnt take over from the running one, it is kept")
+				return nil
+			}
+			return fmt.Errorf("The privileged helper exited before it started, see %s", pd.config.GetHelperLogPath())
+		default:
+		}
+		if i == 50 {
+			cmd.Process.Kill()
+			if err == nil {
+				err = errors.New("it didnt take over from the running one")
+			}
+			return fmt.Errorf("The privileged helper didnt start: %s", err.Error())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// startSSHGateway serves the consoles of instances over ssh - consoles are opened the same way as for the websocket
+// so sessions are recorded in the same place
+func (pd *PromethiumDaemon) startSSHGateway() error {
+	hostKey, err := sshgateway.LoadHostKey(pd.config.GetSSHHostKeyPath())
+	if err != nil {
+		return err
+	}
+	operators, err := sshgateway.LoadOperators(pd.config.SSH.Users)
+	if err != nil {
+		return err
+	}
+	pd.sshGateway = sshgateway.NewGateway(hostKey, operators, func(vm string, user string, cols int, rows int) (io.ReadWriteCloser, error) {
+		instance, err := pd.vmmManager.Find(vm)
+		if err != nil {
+			return nil, err
+		}
+		return pd.vmmManager.OpenConsole(instance, user+" (ssh)", false, cols, rows)
+	})
+	address := pd.config.SSH.GetListenAddress()
+	go func() {
+		log.Printf("SSH gateway listening on %s", address)
+		if err := pd.sshGateway.ListenAndServe(address); err != nil {
+			log.Printf("SSH gateway stopped: %s", err.Error())
+		}
+	}()
+	return nil
+}
+
 func (pd *PromethiumDaemon) stop() {
 
 	//signal a shutdown to teardown everything...
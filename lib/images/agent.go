@@ -0,0 +1,123 @@
+package images
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/768bit/vutils"
+)
+
+// AgentBinaryPath is the static promethium-agent binary injected into images that ask for it - when it is empty
+// promethium-agent is looked for next to the running binary then on the PATH
+var AgentBinaryPath = ""
+
+const agentInstallPath = "/usr/local/bin/promethium-agent"
+
+const agentSystemdUnit = `[Unit]
+Description=Promethium guest agent
+After=local-fs.target
+
+[Service]
+ExecStart=/usr/local/bin/promethium-agent
+Restart=always
+RestartSec=1
+
+[Install]
+WantedBy=multi-user.target
+`
+
+const agentOpenRCScript = `#!/sbin/openrc-run
+
+name="promethium-agent"
+description="Promethium guest agent"
+command="/usr/local/bin/promethium-agent"
+command_background="yes"
+pidfile="/run/promethium-agent.pid"
+`
+
+func findAgentBinary() (string, error) {
+	if AgentBinaryPath != "" {
+		if !vutils.Files.CheckPathExists(AgentBinaryPath) {
+			return "", errors.New("Unable to find the agent binary at " + AgentBinaryPath)
+		}
+		return AgentBinaryPath, nil
+	}
+	if self, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(self), "promethium-agent")
+		if vutils.Files.CheckPathExists(path) {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("promethium-agent")
+	if err != nil {
+		return "", errors.New("Unable to find the promethium-agent binary to inject")
+	}
+	return path, nil
+}
+
+// InjectAgent installs the guest agent into a mounted root filesystem and enables it for both systemd and openrc
+// so it starts whichever init the image uses
+func InjectAgent(rootPath string) error {
+	binary, err := findAgentBinary()
+	if err != nil {
+		return err
+	}
+	println("Injecting guest agent from " + binary)
+	target := filepath.Join(rootPath, agentInstallPath)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if err := vutils.Files.Copy(binary, target); err != nil {
+		return err
+	}
+	if err := os.Chmod(target, 0755); err != nil {
+		return err
+	}
+	if vutils.Files.CheckPathExists(filepath.Join(rootPath, "etc", "systemd")) {
+		unitPath := filepath.Join(rootPath, "etc", "systemd", "system", "promethium-agent.service")
+		if err := writeAgentFile(unitPath, agentSystemdUnit, 0644); err != nil {
+			return err
+		}
+		wants := filepath.Join(rootPath, "etc", "systemd", "system", "multi-user.target.wants")
+		if err := os.MkdirAll(wants, 0755); err != nil {
+			return err
+		}
+		link := filepath.Join(wants, "promethium-agent.service")
+		os.Remove(link)
+		if err := os.Symlink("/etc/systemd/system/promethium-agent.service", link); err != nil {
+			return err
+		}
+	}
+	if vutils.Files.CheckPathExists(filepath.Join(rootPath, "sbin", "openrc-run")) {
+		scriptPath := filepath.Join(rootPath, "etc", "init.d", "promethium-agent")
+		if err := writeAgentFile(scriptPath, agentOpenRCScript, 0755); err != nil {
+			return err
+		}
+		runlevel := filepath.Join(rootPath, "etc", "runlevels", "default")
+		if vutils.Files.CheckPathExists(runlevel) {
+			link := filepath.Join(runlevel, "promethium-agent")
+			os.Remove(link)
+			if err := os.Symlink("/etc/init.d/promethium-agent", link); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeAgentFile(path string, contents string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.WriteString(contents); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
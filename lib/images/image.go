@@ -34,6 +34,7 @@ type ImageConfFile struct {
 	Size       string         `yaml:"Size" json:"Size"`
 	Source     string         `yaml:"Source" json:"Source"`
 	KernelOnly bool           `yaml:"KernelOnly" json:"KernelOnly"`
+	Agent      bool           `yaml:"Agent" json:"Agent"` //inject the guest agent into the root filesystem
 	sizeBytes  int64
 	rootPath   string
 }
@@ -277,6 +278,13 @@ func BuildPackageFrom(imageRootPath string, outputPath string) (*Image, error) {
 			if err != nil {
 				return nil, err
 			}
+			if imgConf.Agent {
+				if err := InjectAgent(mp); err != nil {
+					qcimg.Unmount()
+					qcimg.Disconnect()
+					return nil, err
+				}
+			}
 			//now we have build the docker image now package it...
 			err = qcimg.Unmount()
 			if err != nil {
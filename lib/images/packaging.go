@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/768bit/promethium/lib/common"
 	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/operations"
 	"github.com/768bit/vutils"
 )
 
@@ -65,11 +67,34 @@ func (ib *ImageBuildSpec) Prepare(workspace string) error {
 	}
 }
 
-func (ib *ImageBuildSpec) RunBuild() error {
-	buildCmd := vutils.Exec.CreateAsyncCommand(ib.BuildScript, false, ib.workspace, ib.Version, ib.mountPoint)
-	err := buildCmd.BindToStdoutAndStdErr().StartAndWait()
+// Build prepares the workspace, runs the build script and packages the result as a step of op - the build script
+// cant report how far through it is so it takes the progress from 10 to 90 in one go
+func (ib *ImageBuildSpec) Build(op *operations.Operation, workspace string) error {
+	op.SetProgress(0, "Preparing workspace")
+	if err := ib.Prepare(workspace); err != nil {
+		return err
+	}
+	op.SetProgress(10, "Running build script")
+	if err := ib.RunBuild(op); err != nil {
+		ib.cleanup()
+		return err
+	}
+	op.SetProgress(90, "Packaging image")
+	return ib.Package(ib.workspace)
+}
+
+// RunBuild runs the build script against the mounted root filesystem - it is killed when op is cancelled
+func (ib *ImageBuildSpec) RunBuild(op *operations.Operation) error {
+	buildCmd := exec.CommandContext(op.Context(), ib.BuildScript, ib.workspace, ib.Version, ib.mountPoint)
+	buildCmd.Dir = ib.workspace
+	buildCmd.Stdout = os.Stdout
+	buildCmd.Stderr = os.Stderr
+	err := buildCmd.Run()
 	if err != nil {
 		fmt.Println(err)
+		if ctxErr := op.Context().Err(); ctxErr != nil {
+			return ctxErr
+		}
 		return err
 	}
 	return nil
@@ -88,6 +113,9 @@ func (ib *ImageBuildSpec) Package(output string) error {
 	imagePath := filepath.Join(ib.workspace, "root.qcow2")
 
 	_, err := NewImageFromQcow(ib.Name, ib.Version, ib.Type, ib.Size, ib.Source, ib.SourceURI, imagePath)
-	println(err)
+	if err != nil {
+		ib.cleanup()
+		return err
+	}
 	return ib.cleanup()
 }
@@ -0,0 +1,49 @@
+package images
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/operations"
+)
+
+func TestRunBuildCancel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prmbuild")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "build.sh")
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ib := &ImageBuildSpec{BuildScript: script, Version: "1", workspace: dir, mountPoint: dir}
+	mgr := operations.NewManager(operations.DefaultRetention)
+	op := mgr.Start("build-image", "test", "Build image test", func(op *operations.Operation) (interface{}, error) {
+		return nil, ib.RunBuild(op)
+	})
+	time.Sleep(100 * time.Millisecond)
+	if err := op.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := op.Wait()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected the build to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the build script to be killed when the operation is cancelled")
+	}
+	if state := op.State(); state.Status != operations.StatusCancelled {
+		t.Errorf("expected the operation to be cancelled, got %s", state.Status)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/768bit/promethium/lib/images/diskfs/partition"
 	"github.com/768bit/promethium/lib/images/diskfs/partition/gpt"
 	"github.com/768bit/promethium/lib/images/diskfs/partition/mbr"
+	"github.com/768bit/promethium/lib/privhelper"
 	"github.com/768bit/vutils"
 	"github.com/palantir/stacktrace"
 )
@@ -68,8 +70,7 @@ func getNbdDeviceList() ([]string, error) {
 			if matched {
 				resArr = append(resArr, item)
 				//quick chown...
-				cmd := vutils.Exec.CreateAsyncCommand("chown", false, fmt.Sprintf("%d", UID), item).Sudo()
-				if err := cmd.StartAndWait(); err != nil {
+				if err := privhelper.Default.Chown(privhelper.Owner(UID), -1, false, item); err != nil {
 					return nil, err
 				}
 			}
@@ -96,8 +97,7 @@ func loadNbd() error {
 }
 
 func runPartProbe(dev string) error {
-	cmd := vutils.Exec.CreateAsyncCommand("partprobe", false, dev).Sudo().BindToStdoutAndStdErr()
-	return cmd.StartAndWait()
+	return privhelper.Default.Partprobe(dev)
 }
 
 var TYPE_RX, _ = regexp.Compile(`TYPE="(\S+)"`)
@@ -147,6 +147,58 @@ func (qn *qemuNbd) getFirstDev() string {
 	return ""
 }
 
+// Usage returns the number of nbd devices with an image connected and the number of devices available
+func (qn *qemuNbd) Usage() (int, int) {
+	used := 0
+	for _, img := range qn.devMap {
+		if img != nil {
+			used++
+		}
+	}
+	return used, len(qn.devList)
+}
+
+// NbdConnection is an nbd device with an image connected by qemu-nbd
+type NbdConnection struct {
+	Device string
+	Image  string
+}
+
+// where the kernel lists block devices and processes - they are vars so they can be faked
+var (
+	sysBlockPath = "/sys/block"
+	procPath     = "/proc"
+)
+
+// Leaked returns the nbd devices with an image below root connected that we didnt connect - they are left behind
+// when the daemon exits without disconnecting its images
+func (qn *qemuNbd) Leaked(root string) []*NbdConnection {
+	devs, _ := filepath.Glob(filepath.Join(sysBlockPath, "nbd*"))
+	leaked := []*NbdConnection{}
+	for _, dev := range devs {
+		device := "/dev/" + filepath.Base(dev)
+		if img, ok := qn.devMap[device]; ok && img != nil {
+			continue
+		}
+		//the pid of the qemu-nbd serving the device is only there while it is connected
+		pid, err := ioutil.ReadFile(filepath.Join(dev, "pid"))
+		if err != nil {
+			continue
+		}
+		cmdline, err := ioutil.ReadFile(filepath.Join(procPath, strings.TrimSpace(string(pid)), "cmdline"))
+		if err != nil {
+			continue
+		}
+		args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		image := args[len(args)-1]
+		if rel, err := filepath.Rel(root, image); err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		leaked = append(leaked, &NbdConnection{Device: device, Image: image})
+	}
+	return leaked
+}
+
 func (qn *qemuNbd) Dispose() {
 	qn.disconnectAllDevices()
 }
@@ -157,8 +209,7 @@ func (qn *qemuNbd) connect(image *QemuImage) error {
 		if dev == "" {
 			return NoQemuNbdDeviceAvailable
 		}
-		cmd := vutils.Exec.CreateAsyncCommand("qemu-nbd", false, "-c", dev, "-f", image.sourceFormat, image.path).Sudo().BindToStdoutAndStdErr()
-		err := cmd.StartAndWait()
+		err := privhelper.Default.NbdConnect(dev, image.sourceFormat, image.path)
 		if err == nil {
 			image.connected = true
 			image.connectedDevice = dev
@@ -171,8 +222,7 @@ func (qn *qemuNbd) connect(image *QemuImage) error {
 }
 
 func (qn *qemuNbd) disconnect(image *QemuImage) error {
-	cmd := vutils.Exec.CreateAsyncCommand("qemu-nbd", false, "-d", image.connectedDevice).Sudo().BindToStdoutAndStdErr()
-	err := cmd.StartAndWait()
+	err := privhelper.Default.NbdDisconnect(image.connectedDevice)
 	if err == nil {
 		delete(qn.devMap, image.connectedDevice)
 		image.connected = false
@@ -3,6 +3,7 @@ package images
 import (
 	"fmt"
 	"github.com/768bit/vutils"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -638,3 +639,37 @@ func TestQcowCreateNewMbrMultiple(t *testing.T) {
 		}
 	}
 }
+
+func TestQemuNbdLeaked(t *testing.T) {
+	root, err := ioutil.TempDir("", "prmnbd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	previousBlock, previousProc := sysBlockPath, procPath
+	sysBlockPath, procPath = filepath.Join(root, "block"), filepath.Join(root, "proc")
+	defer func() { sysBlockPath, procPath = previousBlock, previousProc }()
+
+	connect := func(dev string, pid string, image string) {
+		os.MkdirAll(filepath.Join(sysBlockPath, dev), 0755)
+		if pid == "" {
+			return
+		}
+		ioutil.WriteFile(filepath.Join(sysBlockPath, dev, "pid"), []byte(pid+"\n"), 0644)
+		os.MkdirAll(filepath.Join(procPath, pid), 0755)
+		ioutil.WriteFile(filepath.Join(procPath, pid, "cmdline"), []byte("qemu-nbd\x00-c\x00/dev/"+dev+"\x00-f\x00qcow2\x00"+image+"\x00"), 0644)
+	}
+	connect("nbd0", "100", "/opt/promethium/storage/disks/vm/root.qcow2")
+	connect("nbd1", "", "")
+	connect("nbd2", "102", "/srv/other.qcow2")
+	connect("nbd3", "103", "/opt/promethium/cache/images/img.qcow2")
+
+	qn := &qemuNbd{devMap: map[string]*QemuImage{"/dev/nbd3": &QemuImage{}}}
+	leaked := qn.Leaked("/opt/promethium")
+	if len(leaked) != 1 {
+		t.Fatalf("expected 1 leaked device, got %d", len(leaked))
+	}
+	if leaked[0].Device != "/dev/nbd0" || leaked[0].Image != "/opt/promethium/storage/disks/vm/root.qcow2" {
+		t.Errorf("unexpected leaked device %+v", leaked[0])
+	}
+}
@@ -0,0 +1,195 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lines longer than this are split so a guest that never writes a newline cant grow the buffer forever
+const maxLineLength = 4096
+
+type Entry struct {
+	Time   time.Time
+	Source string
+	Line   string
+}
+
+// Log writes lines to a rotating file with the time each line was written so the log can be read back from a
+// point in time - anyone following the log is sent each line as it is written
+type Log struct {
+	source      string
+	file        *RotatingFile
+	lock        sync.Mutex
+	partial     []byte
+	subscribers map[chan Entry]bool
+}
+
+func NewLog(source string, path string, maxSize int64, maxFiles int) (*Log, error) {
+	file, err := OpenRotatingFile(path, maxSize, maxFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{
+		source:      source,
+		file:        file,
+		subscribers: map[chan Entry]bool{},
+	}, nil
+}
+
+func (l *Log) Source() string {
+	return l.source
+}
+
+// Write splits raw output into lines - anything after the last newline is held until the rest of the line arrives
+func (l *Log) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.partial = append(l.partial, p...)
+	for {
+		index := bytes.IndexByte(l.partial, '\n')
+		if index < 0 {
+			if len(l.partial) >= maxLineLength {
+				l.writeLine(string(l.partial[:maxLineLength]))
+				l.partial = l.partial[maxLineLength:]
+				continue
+			}
+			break
+		}
+		l.writeLine(string(l.partial[:index]))
+		l.partial = l.partial[index+1:]
+	}
+	//dont hang on to the underlying array of a large write
+	l.partial = append([]byte(nil), l.partial...)
+	return len(p), nil
+}
+
+func (l *Log) WriteLine(line string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.writeLine(line)
+}
+
+func (l *Log) writeLine(line string) {
+	entry := Entry{
+		Time:   time.Now().UTC(),
+		Source: l.source,
+		Line:   strings.TrimRight(line, "\r"),
+	}
+	l.file.Write([]byte(entry.Time.Format(time.RFC3339Nano) + " " + entry.Line + "\n"))
+	for ch := range l.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			//a slow follower misses lines rather than holding up the vm
+		}
+	}
+}
+
+// Subscribe returns a channel that is sent every line written from now on - it is closed by Unsubscribe or when
+// the log is closed
+func (l *Log) Subscribe() chan Entry {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	ch := make(chan Entry, 256)
+	if l.subscribers == nil {
+		close(ch)
+		return ch
+	}
+	l.subscribers[ch] = true
+	return ch
+}
+
+func (l *Log) Unsubscribe(ch chan Entry) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.subscribers[ch]; ok {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Read returns the entries written at or after since (when it is set) - when tail is more than zero only that
+// many of the most recent entries are returned
+func (l *Log) Read(since time.Time, tail int) ([]Entry, error) {
+	return ReadEntries(l.file.Path(), l.file.MaxFiles(), l.source, since, tail)
+}
+
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if len(l.partial) > 0 {
+		l.writeLine(string(l.partial))
+		l.partial = nil
+	}
+	for ch := range l.subscribers {
+		close(ch)
+	}
+	l.subscribers = nil
+	return l.file.Close()
+}
+
+// ReadEntries reads a log file and the files rotated from it, oldest first
+func ReadEntries(path string, maxFiles int, source string, since time.Time, tail int) ([]Entry, error) {
+	entries := []Entry{}
+	for index := maxFiles; index >= 0; index-- {
+		fd, err := os.Open(RotatedPath(path, index))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(fd)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			entry := parseEntry(scanner.Text(), source)
+			if !since.IsZero() && entry.Time.Before(since) {
+				continue
+			}
+			entries = append(entries, entry)
+			if tail > 0 && len(entries) > tail*2 {
+				//trim as we go so a large log isnt held in memory
+				entries = append([]Entry(nil), entries[len(entries)-tail:]...)
+			}
+		}
+		err = scanner.Err()
+		fd.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, nil
+}
+
+func parseEntry(text string, source string) Entry {
+	entry := Entry{Source: source, Line: text}
+	parts := strings.SplitN(text, " ", 2)
+	if ts, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+		entry.Time = ts
+		if len(parts) > 1 {
+			entry.Line = parts[1]
+		} else {
+			entry.Line = ""
+		}
+	}
+	return entry
+}
+
+// Merge combines the entries of several logs in the order they were written
+func Merge(lists ...[]Entry) []Entry {
+	merged := []Entry{}
+	for _, list := range lists {
+		merged = append(merged, list...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tempLog(t *testing.T, maxSize int64, maxFiles int) (*Log, string) {
+	dir, err := ioutil.TempDir("", "logging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewLog("serial", filepath.Join(dir, "serial.log"), maxSize, maxFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, dir
+}
+
+func TestLogSplitsLines(t *testing.T) {
+	l, dir := tempLog(t, 0, 0)
+	defer os.RemoveAll(dir)
+	l.Write([]byte("Booting the kernel\r\nSta"))
+	l.Write([]byte("rting init\nlogin: "))
+	entries, err := l.Read(time.Time{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Line != "Booting the kernel" || entries[1].Line != "Starting init" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if entries[0].Source != "serial" || entries[0].Time.IsZero() {
+		t.Errorf("expected the source and time to be set %+v", entries[0])
+	}
+	l.Close()
+	entries, _ = ReadEntries(filepath.Join(dir, "serial.log"), 0, "serial", time.Time{}, 0)
+	if len(entries) != 3 || entries[2].Line != "login: " {
+		t.Errorf("expected the partial line to be written on close %+v", entries)
+	}
+}
+
+func TestLogRotates(t *testing.T) {
+	l, dir := tempLog(t, 200, 2)
+	defer os.RemoveAll(dir)
+	for i := 0; i < 40; i++ {
+		l.WriteLine(strings.Repeat("x", 10))
+	}
+	l.Close()
+	if _, err := os.Stat(filepath.Join(dir, "serial.log.2")); err != nil {
+		t.Error("expected two rotated files")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "serial.log.3")); err == nil {
+		t.Error("expected only two rotated files to be kept")
+	}
+	for _, name := range []string{"serial.log", "serial.log.1"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.Size() > 200 {
+			t.Errorf("expected %s to be no larger than the max size", name)
+		}
+	}
+	entries, err := ReadEntries(filepath.Join(dir, "serial.log"), 2, "serial", time.Time{}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 {
+		t.Errorf("expected the tail to be limited got %d", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Time.Before(entries[i-1].Time) {
+			t.Error("expected entries oldest first")
+		}
+	}
+}
+
+func TestLogSinceAndFollow(t *testing.T) {
+	l, dir := tempLog(t, 0, 0)
+	defer os.RemoveAll(dir)
+	l.WriteLine("before")
+	time.Sleep(5 * time.Millisecond)
+	since := time.Now()
+	l.WriteLine("after")
+	entries, _ := l.Read(since, 0)
+	if len(entries) != 1 || entries[0].Line != "after" {
+		t.Errorf("expected only entries since the time given %+v", entries)
+	}
+	ch := l.Subscribe()
+	l.WriteLine("followed")
+	select {
+	case entry := <-ch:
+		if entry.Line != "followed" {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected followers to be sent new lines")
+	}
+	l.Close()
+	if _, ok := <-ch; ok {
+		t.Error("expected followers to be closed with the log")
+	}
+}
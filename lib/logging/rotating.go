@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotatingFile is a log file that is rotated once it reaches maxSize - the previous files are kept as
+// path.1 (newest) to path.<maxFiles> (oldest)
+type RotatingFile struct {
+	lock     sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	fd       *os.File
+	size     int64
+}
+
+func OpenRotatingFile(path string, maxSize int64, maxFiles int) (*RotatingFile, error) {
+	rf := &RotatingFile{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *RotatingFile) open() error {
+	fd, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return err
+	}
+	rf.fd = fd
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *RotatingFile) Path() string {
+	return rf.path
+}
+
+func (rf *RotatingFile) MaxFiles() int {
+	return rf.maxFiles
+}
+
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+	if rf.fd == nil {
+		return 0, os.ErrClosed
+	}
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.fd.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *RotatingFile) rotate() error {
+	rf.fd.Close()
+	rf.fd = nil
+	if rf.maxFiles < 1 {
+		//nothing is kept so just start again
+		if err := os.Truncate(rf.path, 0); err != nil {
+			return err
+		}
+		return rf.open()
+	}
+	os.Remove(RotatedPath(rf.path, rf.maxFiles))
+	for index := rf.maxFiles - 1; index >= 1; index-- {
+		os.Rename(RotatedPath(rf.path, index), RotatedPath(rf.path, index+1))
+	}
+	if err := os.Rename(rf.path, RotatedPath(rf.path, 1)); err != nil {
+		return err
+	}
+	return rf.open()
+}
+
+func (rf *RotatingFile) Close() error {
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+	if rf.fd == nil {
+		return nil
+	}
+	err := rf.fd.Close()
+	rf.fd = nil
+	return err
+}
+
+// RotatedPath returns the path of a previous log file - index 0 is the current file
+func RotatedPath(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s.%d", path, index)
+}
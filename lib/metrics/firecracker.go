@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FirecrackerSample is a single flush of the firecracker metrics fifo flattened into "group.metric" names.
+// Firecracker reports counters as the change since the previous flush, latencies are reported as gauges
+type FirecrackerSample struct {
+	Timestamp time.Time
+	Counters  map[string]float64
+	Gauges    map[string]float64
+}
+
+// ParseFirecrackerMetrics parses one line written to the metrics fifo
+func ParseFirecrackerMetrics(line []byte) (*FirecrackerSample, error) {
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return nil, err
+	}
+	sample := &FirecrackerSample{
+		Counters: map[string]float64{},
+		Gauges:   map[string]float64{},
+	}
+	if ts, ok := raw["utc_timestamp_ms"].(float64); ok {
+		sample.Timestamp = time.Unix(0, int64(ts)*int64(time.Millisecond))
+		delete(raw, "utc_timestamp_ms")
+	} else {
+		sample.Timestamp = time.Now()
+	}
+	for group, values := range raw {
+		flatten(sample, group, values, isGauge(group))
+	}
+	if len(sample.Counters) == 0 && len(sample.Gauges) == 0 {
+		return nil, errors.New("No metrics found in firecracker metrics line")
+	}
+	return sample, nil
+}
+
+// latencies, timings and aggregates (min/max/sum) are point in time values rather than counts
+func isGauge(name string) bool {
+	return strings.HasSuffix(name, "_us") || strings.HasSuffix(name, "_agg")
+}
+
+func flatten(sample *FirecrackerSample, name string, value interface{}, gauge bool) {
+	switch v := value.(type) {
+	case float64:
+		if gauge {
+			sample.Gauges[name] = v
+		} else {
+			sample.Counters[name] = v
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			childName := name + "." + key
+			flatten(sample, childName, child, gauge || isGauge(key))
+		}
+	}
+}
+
+// VmmMetrics aggregates the samples read from the metrics fifo of a vmm - counters are totals since the metrics
+// were created and gauges hold the value from the latest sample
+type VmmMetrics struct {
+	lock        sync.RWMutex
+	counters    map[string]float64
+	gauges      map[string]float64
+	samples     int64
+	parseErrors int64
+	updatedAt   time.Time
+}
+
+type VmmMetricsSnapshot struct {
+	Counters    map[string]float64
+	Gauges      map[string]float64
+	Samples     int64
+	ParseErrors int64
+	UpdatedAt   time.Time
+}
+
+func NewVmmMetrics() *VmmMetrics {
+	return &VmmMetrics{
+		counters: map[string]float64{},
+		gauges:   map[string]float64{},
+	}
+}
+
+func (vm *VmmMetrics) Record(sample *FirecrackerSample) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+	for name, value := range sample.Counters {
+		vm.counters[name] += value
+	}
+	for name, value := range sample.Gauges {
+		vm.gauges[name] = value
+	}
+	vm.samples++
+	vm.updatedAt = sample.Timestamp
+}
+
+// SetGauges records gauges that come from somewhere other than the metrics fifo - they arent counted as a sample
+func (vm *VmmMetrics) SetGauges(values map[string]float64) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+	for name, value := range values {
+		vm.gauges[name] = value
+	}
+}
+
+// Consume records every line read from the metrics fifo until the reader is closed - lines that cant be parsed
+// are counted and skipped
+func (vm *VmmMetrics) Consume(rdr io.Reader) error {
+	scanner := bufio.NewScanner(rdr)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		sample, err := ParseFirecrackerMetrics(line)
+		if err != nil {
+			vm.lock.Lock()
+			vm.parseErrors++
+			vm.lock.Unlock()
+			continue
+		}
+		vm.Record(sample)
+	}
+	return scanner.Err()
+}
+
+func (vm *VmmMetrics) Snapshot() *VmmMetricsSnapshot {
+	vm.lock.RLock()
+	defer vm.lock.RUnlock()
+	snap := &VmmMetricsSnapshot{
+		Counters:    make(map[string]float64, len(vm.counters)),
+		Gauges:      make(map[string]float64, len(vm.gauges)),
+		Samples:     vm.samples,
+		ParseErrors: vm.parseErrors,
+		UpdatedAt:   vm.updatedAt,
+	}
+	for name, value := range vm.counters {
+		snap.Counters[name] = value
+	}
+	for name, value := range vm.gauges {
+		snap.Gauges[name] = value
+	}
+	return snap
+}
+
+// Names returns the sorted metric names in a map of metrics
+func Names(values map[string]float64) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
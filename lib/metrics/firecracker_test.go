@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+// lines recorded from the metrics fifo of firecracker v0.21
+const recordedMetrics = `{"utc_timestamp_ms":1581934563321,"api_server":{"process_startup_time_us":1043,"process_startup_time_cpu_us":0,"sync_response_fails":0,"sync_vmm_send_timeout_count":0},"block":{"activate_fails":0,"cfg_fails":0,"event_fails":0,"execute_fails":0,"invalid_reqs_count":0,"flush_count":3,"queue_event_count":412,"rate_limiter_event_count":0,"update_count":0,"update_fails":0,"read_bytes":8429568,"write_bytes":1204224,"read_count":1398,"write_count":211,"rate_limiter_throttled_events":0},"get_api_requests":{"instance_info_count":12,"instance_info_fails":0,"machine_cfg_count":0,"machine_cfg_fails":0},"i8042":{"error_count":0,"missed_read_count":0,"missed_write_count":0,"read_count":0,"reset_count":0,"write_count":0},"latencies_us":{"full_create_snapshot":0,"diff_create_snapshot":0,"load_snapshot":0,"pause_vm":0,"resume_vm":0},"net":{"activate_fails":0,"cfg_fails":0,"no_rx_avail_buffer":0,"event_fails":0,"rx_queue_event_count":22,"rx_event_rate_limiter_count":0,"rx_tap_event_count":24,"rx_bytes_count":5142,"rx_packets_count":24,"rx_fails":0,"rx_count":24,"tx_bytes_count":3780,"tx_fails":0,"tx_count":31,"tx_packets_count":31,"tx_queue_event_count":31},"vcpu":{"exit_io_in":1822,"exit_io_out":9710,"exit_mmio_read":413,"exit_mmio_write":1320,"failures":0,"filter_cpuid":0},"vmm":{"device_events":0,"panic_count":0}}
+{"utc_timestamp_ms":1581934623322,"block":{"flush_count":1,"read_bytes":4096,"write_bytes":40960,"read_count":1,"write_count":10},"latencies_us":{"pause_vm":162,"resume_vm":84},"net":{"rx_bytes_count":858,"tx_bytes_count":540,"rx_packets_count":4,"tx_packets_count":5},"vcpu":{"exit_io_in":12,"exit_io_out":40,"exit_mmio_read":2,"exit_mmio_write":9,"failures":0,"exit_io_in_agg":{"min_us":1,"max_us":30,"sum_us":240}}}
+`
+
+func TestParseFirecrackerMetrics(t *testing.T) {
+	line := strings.SplitN(recordedMetrics, "\n", 2)[0]
+	sample, err := ParseFirecrackerMetrics([]byte(line))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.Timestamp.Unix() != 1581934563 {
+		t.Errorf("unexpected timestamp %s", sample.Timestamp)
+	}
+	expectedCounters := map[string]float64{
+		"block.read_bytes":                     8429568,
+		"block.write_count":                    211,
+		"net.rx_bytes_count":                   5142,
+		"net.tx_bytes_count":                   3780,
+		"vcpu.exit_io_out":                     9710,
+		"vcpu.exit_mmio_read":                  413,
+		"get_api_requests.instance_info_count": 12,
+	}
+	for name, expected := range expectedCounters {
+		if sample.Counters[name] != expected {
+			t.Errorf("%s: expected %v got %v", name, expected, sample.Counters[name])
+		}
+	}
+	if _, ok := sample.Gauges["latencies_us.pause_vm"]; !ok {
+		t.Error("expected latencies to be parsed as gauges")
+	}
+	if _, ok := sample.Gauges["api_server.process_startup_time_us"]; !ok {
+		t.Error("expected timings to be parsed as gauges")
+	}
+	if _, ok := sample.Counters["latencies_us.pause_vm"]; ok {
+		t.Error("latencies should not be counted")
+	}
+	if _, ok := sample.Counters["utc_timestamp_ms"]; ok {
+		t.Error("the timestamp should not be recorded as a metric")
+	}
+	if _, err := ParseFirecrackerMetrics([]byte(`{"utc_timestamp_ms":1}`)); err == nil {
+		t.Error("expected a line without metrics to fail")
+	}
+	if _, err := ParseFirecrackerMetrics([]byte(`{"block":`)); err == nil {
+		t.Error("expected a truncated line to fail")
+	}
+}
+
+func TestVmmMetricsAggregates(t *testing.T) {
+	vm := NewVmmMetrics()
+	if err := vm.Consume(strings.NewReader(recordedMetrics + "not json\n\n")); err != nil {
+		t.Fatal(err)
+	}
+	snap := vm.Snapshot()
+	if snap.Samples != 2 || snap.ParseErrors != 1 {
+		t.Errorf("expected 2 samples and 1 parse error got %d and %d", snap.Samples, snap.ParseErrors)
+	}
+	if snap.Counters["block.read_bytes"] != 8429568+4096 {
+		t.Errorf("expected counters to be summed got %v", snap.Counters["block.read_bytes"])
+	}
+	if snap.Counters["vcpu.exit_io_in"] != 1822+12 {
+		t.Errorf("expected counters to be summed got %v", snap.Counters["vcpu.exit_io_in"])
+	}
+	if snap.Gauges["latencies_us.pause_vm"] != 162 {
+		t.Errorf("expected gauges to hold the latest value got %v", snap.Gauges["latencies_us.pause_vm"])
+	}
+	if snap.Gauges["vcpu.exit_io_in_agg.max_us"] != 30 {
+		t.Errorf("expected aggregates to be gauges got %v", snap.Gauges["vcpu.exit_io_in_agg.max_us"])
+	}
+	if snap.UpdatedAt.Unix() != 1581934623 {
+		t.Errorf("unexpected update time %s", snap.UpdatedAt)
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// the kernel reports cpu times in USER_HZ which is 100 on every platform firecracker runs on
+const userHZ = 100
+
+var ProcRoot = "/proc"
+
+// ProcStat is the resource usage of a process read from /proc/<pid>/stat
+type ProcStat struct {
+	Pid        int
+	PPid       int
+	Comm       string
+	CPUSeconds float64
+	RSSBytes   uint64
+}
+
+func ReadProcStat(pid int) (*ProcStat, error) {
+	data, err := ioutil.ReadFile(filepath.Join(ProcRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseProcStat(string(data), os.Getpagesize())
+}
+
+// ParseProcStat parses the contents of /proc/<pid>/stat - the command name is in brackets and may contain spaces
+// so the remaining fields are split from the last closing bracket
+func ParseProcStat(stat string, pageSize int) (*ProcStat, error) {
+	open := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if open < 0 || end < open {
+		return nil, errors.New("Unable to parse process stat")
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(stat[:open]))
+	if err != nil {
+		return nil, err
+	}
+	//fields from state (3) onwards
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 22 {
+		return nil, errors.New("Unable to parse process stat: not enough fields")
+	}
+	ps := &ProcStat{
+		Pid:  pid,
+		Comm: stat[open+1 : end],
+	}
+	if ps.PPid, err = strconv.Atoi(fields[1]); err != nil {
+		return nil, err
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	ps.CPUSeconds = float64(utime+stime) / userHZ
+	if rss > 0 {
+		ps.RSSBytes = uint64(rss) * uint64(pageSize)
+	}
+	return ps, nil
+}
+
+// FindDescendant finds the first process below pid (or pid itself) with the command name supplied - the jailer is
+// started through sudo and execs firecracker so the firecracker process is somewhere underneath it
+func FindDescendant(pid int, comm string) (*ProcStat, error) {
+	entries, err := ioutil.ReadDir(ProcRoot)
+	if err != nil {
+		return nil, err
+	}
+	children := map[int][]*ProcStat{}
+	var root *ProcStat
+	for _, entry := range entries {
+		entryPid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		ps, err := ReadProcStat(entryPid)
+		if err != nil {
+			//the process may have gone away while we were looking
+			continue
+		}
+		if ps.Pid == pid {
+			root = ps
+		}
+		children[ps.PPid] = append(children[ps.PPid], ps)
+	}
+	if root == nil {
+		return nil, errors.New("Unable to find process " + strconv.Itoa(pid))
+	}
+	queue := []*ProcStat{root}
+	for len(queue) > 0 {
+		ps := queue[0]
+		queue = queue[1:]
+		if ps.Comm == comm {
+			return ps, nil
+		}
+		queue = append(queue, children[ps.Pid]...)
+	}
+	return nil, errors.New("Unable to find " + comm + " process under " + strconv.Itoa(pid))
+}
+
+// FindThreads returns the thread ids of a process by thread name - firecracker names its vcpu threads fc_vcpu N
+func FindThreads(pid int) (map[string]int, error) {
+	taskPath := filepath.Join(ProcRoot, strconv.Itoa(pid), "task")
+	entries, err := ioutil.ReadDir(taskPath)
+	if err != nil {
+		return nil, err
+	}
+	threads := map[string]int{}
+	for _, entry := range entries {
+		tid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		comm, err := ioutil.ReadFile(filepath.Join(taskPath, entry.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		threads[strings.TrimSpace(string(comm))] = tid
+	}
+	return threads, nil
+}
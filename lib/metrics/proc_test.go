@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recorded from a running firecracker process
+const recordedStat = "41388 (fc_vcpu 0) S 41371 41371 41371 0 -1 1077936448 26212 0 0 0 1520 388 0 0 20 0 3 0 5563824 1118208000 32148 18446744073709551615 1 1 0 0 0 0 0 4096 0 0 0 0 -1 5 0 0 0 0 0 0 0 0 0 0 0 0 0\n"
+
+func TestParseProcStat(t *testing.T) {
+	ps, err := ParseProcStat(recordedStat, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Pid != 41388 || ps.PPid != 41371 {
+		t.Errorf("unexpected pids %d and %d", ps.Pid, ps.PPid)
+	}
+	if ps.Comm != "fc_vcpu 0" {
+		t.Errorf("expected the command name to keep its space got %q", ps.Comm)
+	}
+	if ps.CPUSeconds != 19.08 {
+		t.Errorf("expected 19.08 cpu seconds got %v", ps.CPUSeconds)
+	}
+	if ps.RSSBytes != 32148*4096 {
+		t.Errorf("unexpected rss %d", ps.RSSBytes)
+	}
+	if _, err := ParseProcStat("41388 (firecracker S 1", 4096); err == nil {
+		t.Error("expected a truncated stat to fail")
+	}
+}
+
+func TestFindDescendant(t *testing.T) {
+	root, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	oldRoot := ProcRoot
+	ProcRoot = root
+	defer func() { ProcRoot = oldRoot }()
+	stats := map[string]string{
+		"1":   "1 (init) S 0 1 1 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		"100": "100 (sudo) S 1 100 100 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		"101": "101 (firecracker) S 100 100 100 0 -1 0 0 0 0 0 250 50 0 0 20 0 1 0 1 1 10 0 0 0",
+		"200": "200 (firecracker) S 1 200 200 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+	}
+	for pid, stat := range stats {
+		os.MkdirAll(filepath.Join(root, pid), 0755)
+		if err := ioutil.WriteFile(filepath.Join(root, pid, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ps, err := FindDescendant(100, "firecracker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Pid != 101 || ps.CPUSeconds != 3 {
+		t.Errorf("expected the firecracker process under sudo got %d", ps.Pid)
+	}
+	if _, err := FindDescendant(101, "sudo"); err == nil {
+		t.Error("expected parents not to be searched")
+	}
+}
+
+func TestFindThreads(t *testing.T) {
+	root, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	oldRoot := ProcRoot
+	ProcRoot = root
+	defer func() { ProcRoot = oldRoot }()
+	for tid, comm := range map[string]string{"101": "firecracker\n", "102": "fc_api\n", "103": "fc_vcpu 0\n", "104": "fc_vcpu 1\n"} {
+		os.MkdirAll(filepath.Join(root, "101", "task", tid), 0755)
+		if err := ioutil.WriteFile(filepath.Join(root, "101", "task", tid, "comm"), []byte(comm), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	threads, err := FindThreads(101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if threads["fc_vcpu 0"] != 103 || threads["fc_vcpu 1"] != 104 || len(threads) != 4 {
+		t.Errorf("unexpected threads %v", threads)
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const Namespace = "promethium"
+
+// Registry holds everything published on the daemons /metrics endpoint - the collectors for the host and
+// instances are registered by the daemon once the VmmManager is up
+var Registry = prometheus.NewRegistry()
+
+var (
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of API requests by method, route and status code.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"method", "route", "code"})
+	apiRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "api",
+		Name:      "request_errors_total",
+		Help:      "API requests that returned a 4xx or 5xx status by method, route and status code.",
+	}, []string{"method", "route", "code"})
+)
+
+func init() {
+	Registry.MustRegister(apiRequestDuration, apiRequestErrors)
+	Registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	Registry.MustRegister(prometheus.NewGoCollector())
+}
+
+// Handler serves the metrics in the registry in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+func (sr *statusRecorder) Flush() {
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// InstrumentHandler records the latency and errors of requests to next - route is used to label requests by the
+// matched route rather than the raw path so ids dont create a new series per instance
+func InstrumentHandler(next http.Handler, route func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		name := route(r)
+		code := strconv.Itoa(rec.status)
+		apiRequestDuration.WithLabelValues(r.Method, name, code).Observe(time.Since(started).Seconds())
+		if rec.status >= 400 {
+			apiRequestErrors.WithLabelValues(r.Method, name, code).Inc()
+		}
+	})
+}
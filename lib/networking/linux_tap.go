@@ -3,7 +3,7 @@ package networking
 import (
 	"fmt"
 
-	"github.com/768bit/vutils"
+	"github.com/768bit/promethium/lib/privhelper"
 	"github.com/milosgajdos83/tenus"
 )
 
@@ -61,9 +61,7 @@ func (iface *LinuxTapInterface) init() error {
 
 	dl, err := tenus.NewLinkFrom(iface.interfaceName)
 	if err != nil {
-		cmd := vutils.Exec.CreateAsyncCommand("ip", false, "tuntap", "add", "dev", iface.interfaceName, "mode", "tap").Sudo()
-		err := cmd.StartAndWait()
-		if err != nil {
+		if err := privhelper.Default.CreateTap(iface.interfaceName); err != nil {
 			return err
 		}
 		dl, err = tenus.NewLinkFrom(iface.interfaceName)
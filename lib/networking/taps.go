@@ -0,0 +1,50 @@
+package networking
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/768bit/promethium/lib/privhelper"
+)
+
+// TapDevice is a tap device on one of the bridges of the manager
+type TapDevice struct {
+	Name   string
+	Bridge string
+	// Attached is set when a process has the tap open - the kernel only brings up the carrier of a tap then
+	Attached bool
+}
+
+// Taps lists the tap devices on the bridges of the manager from sysfs
+func (mgr *Manager) Taps() ([]*TapDevice, error) {
+	infos, err := ioutil.ReadDir(privhelper.SysClassNet)
+	if err != nil {
+		return nil, err
+	}
+	taps := []*TapDevice{}
+	for _, info := range infos {
+		dir := filepath.Join(privhelper.SysClassNet, info.Name())
+		//only tun and tap devices have tun_flags
+		if _, err := os.Stat(filepath.Join(dir, "tun_flags")); err != nil {
+			continue
+		}
+		master, err := os.Readlink(filepath.Join(dir, "master"))
+		if err != nil {
+			continue
+		}
+		bridge := filepath.Base(master)
+		if _, ok := mgr.bridges[bridge]; !ok {
+			continue
+		}
+		//reading the carrier of an interface that is down fails - it isnt attached either way
+		carrier, _ := ioutil.ReadFile(filepath.Join(dir, "carrier"))
+		taps = append(taps, &TapDevice{
+			Name:     info.Name(),
+			Bridge:   bridge,
+			Attached: strings.TrimSpace(string(carrier)) == "1",
+		})
+	}
+	return taps, nil
+}
@@ -0,0 +1,65 @@
+package networking
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/768bit/promethium/lib/privhelper"
+)
+
+func writeInterface(t *testing.T, root string, name string, tap bool, master string, carrier string) {
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if tap {
+		if err := ioutil.WriteFile(filepath.Join(dir, "tun_flags"), []byte("0x1002\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if master != "" {
+		if err := os.Symlink(filepath.Join("..", master), filepath.Join(dir, "master")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if carrier != "" {
+		if err := ioutil.WriteFile(filepath.Join(dir, "carrier"), []byte(carrier+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTaps(t *testing.T) {
+	root, err := ioutil.TempDir("", "prmnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	previous := privhelper.SysClassNet
+	privhelper.SysClassNet = root
+	defer func() { privhelper.SysClassNet = previous }()
+
+	writeInterface(t, root, "br0", false, "", "1")
+	writeInterface(t, root, "eth0", false, "br0", "1")
+	writeInterface(t, root, "vm10", true, "br0", "1")
+	writeInterface(t, root, "vm20", true, "br0", "0")
+	writeInterface(t, root, "vm30", true, "", "")
+	writeInterface(t, root, "vm40", true, "docker0", "1")
+
+	mgr := &Manager{bridges: map[string]NetworkBridge{"br0": nil}}
+	taps, err := mgr.Taps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taps) != 2 {
+		t.Fatalf("expected the 2 taps on br0, got %d", len(taps))
+	}
+	if taps[0].Name != "vm10" || taps[0].Bridge != "br0" || !taps[0].Attached {
+		t.Errorf("unexpected tap %+v", taps[0])
+	}
+	if taps[1].Name != "vm20" || taps[1].Attached {
+		t.Errorf("unexpected tap %+v", taps[1])
+	}
+}
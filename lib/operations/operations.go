@@ -0,0 +1,309 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/go-openapi/strfmt"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// DefaultRetention is how long a finished operation is kept so its result can still be fetched
+const DefaultRetention = time.Hour
+
+var (
+	ErrNotFound = errors.New("Unable to find operation with that id")
+	ErrFinished = errors.New("The operation has already finished")
+	ErrClosed   = errors.New("The daemon is exiting so the operation wasnt started")
+)
+
+// Func is the work of an operation - it should give up when the context of the operation is done and report its
+// progress as it goes. What it returns is the result of the operation.
+type Func func(op *Operation) (interface{}, error)
+
+// Operation is a long running task that was started by a request and carries on after it has returned
+type Operation struct {
+	id          string
+	kind        string
+	target      string
+	description string
+	createdAt   time.Time
+
+	lock        sync.Mutex
+	status      string
+	progress    int
+	message     string
+	result      interface{}
+	err         error
+	completedAt time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// State is a copy of where an operation is at
+type State struct {
+	ID          string
+	Kind        string
+	Target      string
+	Description string
+	Status      string
+	Progress    int
+	Message     string
+	Result      interface{}
+	Err         error
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+func (op *Operation) ID() string {
+	return op.id
+}
+
+// Context is done when the operation is cancelled - work that can be run without an operation passes a nil one
+func (op *Operation) Context() context.Context {
+	if op == nil {
+		return context.Background()
+	}
+	return op.ctx
+}
+
+// SetProgress records how far through the operation is as a percentage along with what it is doing
+func (op *Operation) SetProgress(progress int, message string) {
+	if op == nil {
+		return
+	}
+	if progress < 0 {
+		progress = 0
+	} else if progress > 100 {
+		progress = 100
+	}
+	op.lock.Lock()
+	defer op.lock.Unlock()
+	op.progress = progress
+	if message != "" {
+		op.message = message
+	}
+}
+
+// SetMessage records what the operation is doing without moving its progress - for a step that cant tell how far
+// through it is
+func (op *Operation) SetMessage(message string) {
+	if op == nil {
+		return
+	}
+	op.lock.Lock()
+	defer op.lock.Unlock()
+	op.message = message
+}
+
+func (op *Operation) State() *State {
+	op.lock.Lock()
+	defer op.lock.Unlock()
+	return &State{
+		ID:          op.id,
+		Kind:        op.kind,
+		Target:      op.target,
+		Description: op.description,
+		Status:      op.status,
+		Progress:    op.progress,
+		Message:     op.message,
+		Result:      op.result,
+		Err:         op.err,
+		CreatedAt:   op.createdAt,
+		CompletedAt: op.completedAt,
+	}
+}
+
+// Finished is whether the operation has completed, failed or been cancelled
+func (op *Operation) Finished() bool {
+	select {
+	case <-op.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the operation has finished and returns its result
+func (op *Operation) Wait() (interface{}, error) {
+	<-op.done
+	op.lock.Lock()
+	defer op.lock.Unlock()
+	return op.result, op.err
+}
+
+// Cancel asks the operation to stop - it is up to the work of the operation to notice so it may still complete
+func (op *Operation) Cancel() error {
+	if op.Finished() {
+		return ErrFinished
+	}
+	op.cancel()
+	return nil
+}
+
+func (op *Operation) run(fn Func) {
+	result, err := fn(op)
+	op.lock.Lock()
+	op.completedAt = time.Now()
+	if err == nil {
+		op.status = StatusCompleted
+		op.progress = 100
+		op.result = result
+	} else if op.ctx.Err() != nil {
+		op.status = StatusCancelled
+		op.err = err
+	} else {
+		op.status = StatusFailed
+		op.err = err
+	}
+	op.lock.Unlock()
+	op.cancel()
+	close(op.done)
+}
+
+// Manager runs operations and keeps them around for retention after they finish
+type Manager struct {
+	lock       sync.RWMutex
+	operations map[string]*Operation
+	retention  time.Duration
+	//closed once the daemon is exiting - operations started after it are cancelled without running
+	closed bool
+}
+
+func NewManager(retention time.Duration) *Manager {
+	return &Manager{
+		operations: map[string]*Operation{},
+		retention:  retention,
+	}
+}
+
+// Start runs fn in the background as an operation of kind on target
+func (mgr *Manager) Start(kind string, target string, description string, fn Func) *Operation {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &Operation{
+		id:          uuid.NewV4().String(),
+		kind:        kind,
+		target:      target,
+		description: description,
+		createdAt:   time.Now(),
+		status:      StatusRunning,
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+	mgr.lock.Lock()
+	mgr.prune()
+	mgr.operations[op.id] = op
+	closed := mgr.closed
+	mgr.lock.Unlock()
+	if closed {
+		cancel()
+		fn = func(op *Operation) (interface{}, error) {
+			return nil, ErrClosed
+		}
+	}
+	go op.run(fn)
+	return op
+}
+
+func (mgr *Manager) Get(id string) (*Operation, error) {
+	mgr.lock.RLock()
+	defer mgr.lock.RUnlock()
+	if op, ok := mgr.operations[id]; ok {
+		return op, nil
+	}
+	return nil, ErrNotFound
+}
+
+// List returns the operations oldest first
+func (mgr *Manager) List() []*Operation {
+	mgr.lock.Lock()
+	mgr.prune()
+	list := make([]*Operation, 0, len(mgr.operations))
+	for _, op := range mgr.operations {
+		list = append(list, op)
+	}
+	mgr.lock.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].createdAt.Before(list[j].createdAt)
+	})
+	return list
+}
+
+func (mgr *Manager) Cancel(id string) error {
+	op, err := mgr.Get(id)
+	if err != nil {
+		return err
+	}
+	return op.Cancel()
+}
+
+// CancelAll cancels every running operation and waits up to timeout for them to stop - it is used when the daemon
+// exits so no more operations are run after it
+func (mgr *Manager) CancelAll(timeout time.Duration) {
+	mgr.lock.Lock()
+	mgr.closed = true
+	mgr.lock.Unlock()
+	deadline := time.After(timeout)
+	for _, op := range mgr.List() {
+		op.Cancel()
+	}
+	for _, op := range mgr.List() {
+		select {
+		case <-op.done:
+		case <-deadline:
+			return
+		}
+	}
+}
+
+// prune drops the operations that finished longer than retention ago - the lock must be held
+func (mgr *Manager) prune() {
+	for id, op := range mgr.operations {
+		if !op.Finished() {
+			continue
+		}
+		op.lock.Lock()
+		expired := time.Since(op.completedAt) > mgr.retention
+		op.lock.Unlock()
+		if expired {
+			delete(mgr.operations, id)
+		}
+	}
+}
+
+func (op *Operation) GetModel() *models.Operation {
+	state := op.State()
+	model := &models.Operation{
+		ID:          state.ID,
+		Kind:        state.Kind,
+		Target:      state.Target,
+		Description: state.Description,
+		Status:      state.Status,
+		Completed:   state.Status != StatusRunning,
+		Progress:    int32(state.Progress),
+		Message:     state.Message,
+		Result:      state.Result,
+		CreatedAt:   strfmt.DateTime(state.CreatedAt),
+	}
+	if !state.CompletedAt.IsZero() {
+		model.CompletedAt = strfmt.DateTime(state.CompletedAt)
+	}
+	if state.Err != nil {
+		model.Error = state.Err.Error()
+	}
+	return model
+}
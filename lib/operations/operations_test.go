@@ -0,0 +1,148 @@
+package operations
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestOperationCompletes(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	release := make(chan struct{})
+	op := mgr.Start("test", "target", "Testing", func(op *Operation) (interface{}, error) {
+		op.SetProgress(40, "Half way")
+		<-release
+		return "result", nil
+	})
+	if got, err := mgr.Get(op.ID()); err != nil || got != op {
+		t.Fatalf("expected to get the operation back, got %v %v", got, err)
+	}
+	for op.State().Progress != 40 {
+		time.Sleep(time.Millisecond)
+	}
+	if state := op.State(); state.Status != StatusRunning || state.Message != "Half way" {
+		t.Errorf("unexpected state %+v", state)
+	}
+	close(release)
+	result, err := op.Wait()
+	if err != nil || result != "result" {
+		t.Fatalf("unexpected result %v %v", result, err)
+	}
+	state := op.State()
+	if state.Status != StatusCompleted || state.Progress != 100 || state.CompletedAt.IsZero() {
+		t.Errorf("unexpected state %+v", state)
+	}
+	if err := op.Cancel(); err != ErrFinished {
+		t.Errorf("expected a finished operation to refuse to cancel, got %v", err)
+	}
+}
+
+func TestOperationFails(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	op := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		return nil, errors.New("broken")
+	})
+	if _, err := op.Wait(); err == nil || err.Error() != "broken" {
+		t.Fatalf("expected the error of the operation, got %v", err)
+	}
+	if state := op.State(); state.Status != StatusFailed {
+		t.Errorf("expected the operation to have failed, got %s", state.Status)
+	}
+}
+
+func TestOperationCancel(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	op := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		rdr := &Reader{Op: op, Reader: bytes.NewReader(make([]byte, 1<<20)), Size: 1 << 20, To: 100}
+		<-op.Context().Done()
+		_, err := ioutil.ReadAll(rdr)
+		return nil, err
+	})
+	if err := mgr.Cancel(op.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := op.Wait(); err == nil {
+		t.Fatal("expected the read of a cancelled operation to fail")
+	}
+	if state := op.State(); state.Status != StatusCancelled {
+		t.Errorf("expected the operation to be cancelled, got %s", state.Status)
+	}
+	if err := mgr.Cancel("missing"); err != ErrNotFound {
+		t.Errorf("expected a missing operation to not be found, got %v", err)
+	}
+}
+
+func TestCancelAllClosesManager(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	running := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		<-op.Context().Done()
+		return nil, op.Context().Err()
+	})
+	mgr.CancelAll(time.Second)
+	if state := running.State(); state.Status != StatusCancelled {
+		t.Errorf("expected the running operation to be cancelled, got %s", state.Status)
+	}
+	ran := false
+	late := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		ran = true
+		return nil, nil
+	})
+	if _, err := late.Wait(); err != ErrClosed {
+		t.Errorf("expected an operation started after the manager closed to be refused, got %v", err)
+	}
+	if ran || late.State().Status != StatusCancelled {
+		t.Errorf("expected the operation to be cancelled without running, it is %s", late.State().Status)
+	}
+}
+
+func TestReaderProgress(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	op := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		rdr := &Reader{Op: op, Reader: bytes.NewReader(make([]byte, 1000)), Size: 1000, From: 10, To: 60}
+		buf := make([]byte, 500)
+		if _, err := rdr.Read(buf); err != nil {
+			return nil, err
+		}
+		return op.State().Progress, nil
+	})
+	progress, err := op.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress != 35 {
+		t.Errorf("expected half of the copy to be 35%%, got %v", progress)
+	}
+}
+
+func TestSetMessage(t *testing.T) {
+	mgr := NewManager(DefaultRetention)
+	op := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		op.SetProgress(60, "Writing disk")
+		op.SetMessage("Resizing disk")
+		return op.State(), nil
+	})
+	result, err := op.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := result.(*State); state.Progress != 60 || state.Message != "Resizing disk" {
+		t.Errorf("expected the message to change without the progress, got %d %q", state.Progress, state.Message)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	mgr := NewManager(0)
+	op := mgr.Start("test", "", "Testing", func(op *Operation) (interface{}, error) {
+		return nil, nil
+	})
+	op.Wait()
+	time.Sleep(time.Millisecond)
+	if len(mgr.List()) != 0 {
+		t.Error("expected the finished operation to be pruned")
+	}
+	if _, err := mgr.Get(op.ID()); err != ErrNotFound {
+		t.Errorf("expected the pruned operation to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package operations
+
+import (
+	"io"
+)
+
+// Reader is a stream copied by an operation - reads fail once the operation is cancelled and the progress of the
+// operation moves from From to To as the copy gets through Size bytes
+type Reader struct {
+	Op     *Operation
+	Reader io.Reader
+	Size   int64
+	From   int
+	To     int
+	// Position is how far through Size the copy is when that isnt the bytes read - like the offset in a compressed
+	// file the stream is extracted from
+	Position func() int64
+
+	read int64
+}
+
+func (rdr *Reader) Read(p []byte) (int, error) {
+	if err := rdr.Op.Context().Err(); err != nil {
+		return 0, err
+	}
+	n, err := rdr.Reader.Read(p)
+	rdr.read += int64(n)
+	if rdr.Size > 0 {
+		position := rdr.read
+		if rdr.Position != nil {
+			position = rdr.Position()
+		}
+		if position > rdr.Size {
+			position = rdr.Size
+		}
+		rdr.Op.SetProgress(rdr.From+int(int64(rdr.To-rdr.From)*position/rdr.Size), "")
+	}
+	return n, err
+}
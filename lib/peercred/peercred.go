@@ -0,0 +1,45 @@
+package peercred
+
+import (
+	"errors"
+	"net"
+	"os/user"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// Get returns the credentials of the process on the other end of a unix socket connection
+func Get(conn net.Conn) (*unix.Ucred, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, errors.New("Peer credentials are only available for unix socket connections")
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cred, credErr
+}
+
+// Username returns the name of the user on the other end of a unix socket connection - the uid is used when
+// the user cant be looked up
+func Username(conn net.Conn) (string, error) {
+	cred, err := Get(conn)
+	if err != nil {
+		return "", err
+	}
+	uid := strconv.Itoa(int(cred.Uid))
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username, nil
+	}
+	return "uid:" + uid, nil
+}
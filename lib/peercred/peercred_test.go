@@ -0,0 +1,58 @@
+package peercred
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peercred")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	listener, err := net.Listen("unix", filepath.Join(dir, "test.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := net.Dial("unix", filepath.Join(dir, "test.sock"))
+		if err == nil {
+			defer conn.Close()
+			conn.Read(make([]byte, 1))
+		}
+	}()
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	cred, err := Get(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(cred.Uid) != os.Getuid() || int(cred.Pid) != os.Getpid() {
+		t.Errorf("expected our own credentials got %+v", cred)
+	}
+	if _, err := Username(conn); err != nil {
+		t.Error(err)
+	}
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.Close()
+	go net.Dial("tcp", tcp.Addr().String())
+	tcpConn, err := tcp.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcpConn.Close()
+	if _, err := Get(tcpConn); err == nil {
+		t.Error("expected tcp connections to be rejected")
+	}
+}
@@ -0,0 +1,218 @@
+package privhelper
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// Client asks the helper listening on a socket to perform the operations
+type Client struct {
+	socketPath string
+}
+
+func NewClient(socketPath string) *Client {
+	return &Client{
+		socketPath: socketPath,
+	}
+}
+
+func (c *Client) dial() (*net.UnixConn, error) {
+	return net.DialUnix("unixpacket", nil, &net.UnixAddr{Name: c.socketPath, Net: "unixpacket"})
+}
+
+// Ping checks the helper is there and will accept connections from us
+func (c *Client) Ping() error {
+	return c.do(&Request{Op: OpPing})
+}
+
+// Version is the BinaryVersion of the helper
+func (c *Client) Version() (string, error) {
+	resp, err := c.request(&Request{Op: OpPing})
+	if err != nil {
+		return "", err
+	}
+	return resp.Version, nil
+}
+
+func (c *Client) do(req *Request) error {
+	_, err := c.request(req)
+	return err
+}
+
+func (c *Client) request(req *Request) (*Response, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := writePacket(conn, req); err != nil {
+		return nil, err
+	}
+	var resp Response
+	files, err := readPacket(conn, &resp)
+	if err != nil {
+		return nil, err
+	}
+	closeFiles(files)
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
+
+func (c *Client) Chown(uid int, gid int, recursive bool, paths ...string) error {
+	return c.do(&Request{Op: OpChown, UID: uid, GID: gid, Recursive: recursive, Paths: paths})
+}
+
+func (c *Client) Chmod(mode os.FileMode, recursive bool, paths ...string) error {
+	return c.do(&Request{Op: OpChmod, Mode: uint32(mode), Recursive: recursive, Paths: paths})
+}
+
+func (c *Client) NbdConnect(device string, format string, path string) error {
+	return c.do(&Request{Op: OpNbdConnect, Device: device, Format: format, Path: path})
+}
+
+func (c *Client) NbdDisconnect(device string) error {
+	return c.do(&Request{Op: OpNbdDisconnect, Device: device})
+}
+
+func (c *Client) Partprobe(device string) error {
+	return c.do(&Request{Op: OpPartprobe, Device: device})
+}
+
+func (c *Client) QemuImgConvert(source string, dest string, format string) error {
+	return c.do(&Request{Op: OpQemuImgConvert, Source: source, Dest: dest, Format: format})
+}
+
+func (c *Client) CreateTap(name string) error {
+	return c.do(&Request{Op: OpCreateTap, Name: name})
+}
+
+func (c *Client) DeleteTap(name string) error {
+	return c.do(&Request{Op: OpDeleteTap, Name: name})
+}
+
+func (c *Client) CgroupMkdir(path string) error {
+	return c.do(&Request{Op: OpCgroupMkdir, Path: path})
+}
+
+func (c *Client) CgroupRmdir(path string) error {
+	return c.do(&Request{Op: OpCgroupRmdir, Path: path})
+}
+
+func (c *Client) CgroupWrite(path string, value string) error {
+	return c.do(&Request{Op: OpCgroupWrite, Path: path, Value: value})
+}
+
+func (c *Client) StartJailer(spec *JailerSpec) (Process, error) {
+	return c.jailer(&Request{Op: OpStartJailer, Jailer: spec})
+}
+
+func (c *Client) AttachJailer(spec *JailerSpec, pid int) (Process, error) {
+	return c.jailer(&Request{Op: OpAttachJailer, Jailer: spec, Pid: pid})
+}
+
+// jailer sends a request for a jailer - the connection is kept for signalling it and for its exit status
+func (c *Client) jailer(req *Request) (Process, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	if err := writePacket(conn, req); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var resp Response
+	files, err := readPacket(conn, &resp)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.Error != "" {
+		closeFiles(files)
+		conn.Close()
+		return nil, errors.New(resp.Error)
+	}
+	proc := &remoteProcess{
+		conn: conn,
+		pid:  resp.Pid,
+		done: make(chan struct{}),
+	}
+	//the stdio is only there when the helper has it - it doesnt for a jailer whose helper went without handing it over
+	if len(files) == 2 {
+		proc.console = files[0]
+		proc.stderr = files[1]
+	} else {
+		closeFiles(files)
+	}
+	go proc.waitExit()
+	return proc, nil
+}
+
+// remoteProcess is a jailer started by the helper - the exit status is sent on the connection it was started on
+type remoteProcess struct {
+	conn    *net.UnixConn
+	pid     int
+	lock    sync.Mutex
+	done    chan struct{}
+	status  *ExitStatus
+	err     error
+	console *os.File
+	stderr  *os.File
+}
+
+func (proc *remoteProcess) waitExit() {
+	defer proc.conn.Close()
+	var resp Response
+	_, err := readPacket(proc.conn, &resp)
+	if err != nil || resp.Exit == nil {
+		proc.err = ErrConnectionLost
+		proc.status = &ExitStatus{Code: -1}
+	} else {
+		proc.status = resp.Exit
+		if !resp.Exit.Success() {
+			proc.err = errors.New(resp.Exit.String())
+		}
+	}
+	close(proc.done)
+}
+
+func (proc *remoteProcess) Pid() int {
+	return proc.pid
+}
+
+func (proc *remoteProcess) Signal(sig syscall.Signal) error {
+	select {
+	case <-proc.done:
+		return errors.New("The process has already exited")
+	default:
+	}
+	proc.lock.Lock()
+	defer proc.lock.Unlock()
+	return writePacket(proc.conn, &Request{Op: OpSignal, Signal: int(sig)})
+}
+
+func (proc *remoteProcess) Wait() error {
+	<-proc.done
+	return proc.err
+}
+
+func (proc *remoteProcess) ExitStatus() *ExitStatus {
+	select {
+	case <-proc.done:
+		return proc.status
+	default:
+		return nil
+	}
+}
+
+func (proc *remoteProcess) Console() *os.File {
+	return proc.console
+}
+
+func (proc *remoteProcess) Stderr() *os.File {
+	return proc.stderr
+}
@@ -0,0 +1,673 @@
+package privhelper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/768bit/promethium/lib/pty"
+)
+
+// DefaultCgroupRoot is where the cgroup hierarchy is mounted
+const DefaultCgroupRoot = "/sys/fs/cgroup"
+
+// SysClassNet is where the network interfaces are listed
+var SysClassNet = "/sys/class/net"
+
+var (
+	nbdDeviceRx = regexp.MustCompile(`^/dev/nbd[0-9]+$`)
+	nbdPartRx   = regexp.MustCompile(`^/dev/nbd[0-9]+(p[0-9]+)?$`)
+	formatRx    = regexp.MustCompile(`^[a-z0-9]+$`)
+	tapNameRx   = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+	jailerIDRx  = regexp.MustCompile(`^[a-zA-Z0-9-]{1,64}$`)
+	//the values of the limits are numbers or max, cpu.max has a period after its quota
+	cgroupValueRx = regexp.MustCompile(`^(max|[0-9]+)( [0-9]+)?$`)
+)
+
+// the cgroup control files that can be written
+var cgroupFiles = map[string]bool{
+	"cgroup.subtree_control": true,
+	"cgroup.procs":           true,
+	"cgroup.threads":         true,
+	"cgroup.type":            true,
+	"cpuset.cpus":            true,
+	"cpuset.mems":            true,
+	"cpu.weight":             true,
+	"cpu.max":                true,
+	"memory.max":             true,
+	"io.max":                 true,
+	"pids.max":               true,
+}
+
+// the control files the jailer can be asked to write for a vm
+var jailerCgroupFiles = map[string]bool{
+	"cpu.weight": true,
+	"cpu.max":    true,
+	"memory.max": true,
+	"pids.max":   true,
+}
+
+// binaryOwner is who the binaries the helper runs have to belong to - nobody else can be able to change them
+var binaryOwner = 0
+
+// rootOwner is whose files the helper wont change or give to the commands it runs - the daemon could otherwise use it
+// to change the binaries that are run as root
+var rootOwner = 0
+
+// with cgroup v1 the firecracker cgroups are below these
+var cgroupV1Controllers = map[string]bool{
+	"cpu":         true,
+	"cpuacct":     true,
+	"cpu,cpuacct": true,
+	"cpuset":      true,
+	"memory":      true,
+	"blkio":       true,
+	"pids":        true,
+}
+
+// Local performs the operations in process - it is what the helper uses and what the daemon uses when it is run as
+// root without a helper
+type Local struct {
+	AppRoot    string
+	CgroupRoot string
+}
+
+func NewLocal(appRoot string, cgroupRoot string) *Local {
+	return &Local{
+		AppRoot:    appRoot,
+		CgroupRoot: cgroupRoot,
+	}
+}
+
+// within checks path stays under root once the symlinks in it are resolved - the last element is not resolved so
+// it is the link itself which is used. It returns the resolved path and where it is relative to root.
+func within(root string, path string) (string, string, error) {
+	if root == "" {
+		return "", "", errors.New("There is no root to check paths against")
+	}
+	if !filepath.IsAbs(path) {
+		return "", "", fmt.Errorf("%s is not an absolute path", path)
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", "", err
+	}
+	path = filepath.Clean(path)
+	resolved := path
+	if path != "/" {
+		dir, err := resolveExisting(filepath.Dir(path))
+		if err != nil {
+			return "", "", err
+		}
+		resolved = filepath.Join(dir, filepath.Base(path))
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", "", fmt.Errorf("%s is outside of %s", path, root)
+	}
+	return resolved, rel, nil
+}
+
+// resolveExisting resolves the symlinks in the part of path which exists - the rest is added back as it is
+func resolveExisting(path string) (string, error) {
+	rest := ""
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(path), rest)
+		path = parent
+	}
+}
+
+func (l *Local) appPath(path string) (string, error) {
+	resolved, _, err := within(l.AppRoot, path)
+	return resolved, err
+}
+
+// appFile is appPath for paths that are opened by the commands they are given to - a link as the last element is
+// followed so it cant point them outside of the AppRoot
+func (l *Local) appFile(path string) (string, error) {
+	resolved, err := l.appPath(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Lstat(resolved); os.IsNotExist(err) {
+		return resolved, nil
+	} else if err != nil {
+		return "", err
+	} else if info.Mode()&os.ModeSymlink == 0 {
+		return resolved, nil
+	}
+	target, err := filepath.EvalSymlinks(resolved)
+	if err != nil {
+		return "", fmt.Errorf("%s is a link that cant be followed: %s", path, err.Error())
+	}
+	return l.appPath(target)
+}
+
+// inBin is whether a resolved path is in AppRoot/bin where the binaries run as root are installed
+func (l *Local) inBin(resolved string) bool {
+	_, rel, err := within(l.AppRoot, resolved)
+	return err == nil && (rel == "bin" || strings.HasPrefix(rel, "bin/"))
+}
+
+// changeable checks a resolved path isnt in AppRoot/bin or owned by root - a path that isnt there yet can be used
+func (l *Local) changeable(resolved string, info os.FileInfo) error {
+	if l.inBin(resolved) {
+		return fmt.Errorf("%s is a binary that cant be changed", resolved)
+	}
+	if info == nil {
+		var err error
+		if info, err = os.Lstat(resolved); os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || int(stat.Uid) == rootOwner {
+		return fmt.Errorf("%s is owned by root", resolved)
+	}
+	return nil
+}
+
+// changeableFile is appFile for paths that are also checked with changeable
+func (l *Local) changeableFile(path string) (string, error) {
+	resolved, err := l.appFile(path)
+	if err != nil {
+		return "", err
+	}
+	return resolved, l.changeable(resolved, nil)
+}
+
+// device checks a device is an nbd device or an image under the AppRoot
+func (l *Local) device(device string) (string, error) {
+	if nbdPartRx.MatchString(device) {
+		return device, nil
+	}
+	return l.appFile(device)
+}
+
+// cgroupPath checks path is in the firecracker part of the hierarchy - control files of the root and firecracker
+// cgroups can be written so controllers can be enabled for the cgroups of vms
+func (l *Local) cgroupPath(path string, file bool) (string, error) {
+	resolved, rel, err := within(l.CgroupRoot, path)
+	if err != nil {
+		return "", err
+	}
+	dir := rel
+	if file {
+		name := filepath.Base(rel)
+		if !cgroupFiles[name] {
+			return "", fmt.Errorf("%s is not a cgroup file that can be written", name)
+		}
+		dir = filepath.Dir(rel)
+		if name == "cgroup.subtree_control" && dir == "." {
+			return resolved, nil
+		}
+	}
+	parts := strings.Split(dir, string(filepath.Separator))
+	if len(parts) > 1 && cgroupV1Controllers[parts[0]] {
+		parts = parts[1:]
+	}
+	if parts[0] == "firecracker" {
+		return resolved, nil
+	}
+	return "", fmt.Errorf("%s is not a cgroup of a vm", path)
+}
+
+func run(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %s: %s", name, err.Error(), strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// walk calls fn for path and when recursive everything below it - symlinks are passed to fn but not followed
+func walk(path string, recursive bool, fn func(path string, info os.FileInfo) error) error {
+	if !recursive {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	}
+	return filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	})
+}
+
+func (l *Local) Chown(uid int, gid int, recursive bool, paths ...string) error {
+	if uid == 0 || gid == 0 {
+		return errors.New("Nothing can be given to root")
+	}
+	for _, path := range paths {
+		resolved := path
+		if !nbdPartRx.MatchString(path) {
+			var err error
+			if resolved, err = l.appPath(path); err != nil {
+				return err
+			}
+		} else if recursive {
+			return errors.New("Devices cant be changed recursively")
+		}
+		err := walk(resolved, recursive, func(path string, info os.FileInfo) error {
+			//the jail folders made by the jailer belong to root so only the binaries are left alone
+			if l.inBin(path) {
+				return fmt.Errorf("%s is a binary that cant be changed", path)
+			}
+			return os.Lchown(path, uid, gid)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) Chmod(mode os.FileMode, recursive bool, paths ...string) error {
+	if mode&^(os.ModePerm|os.ModeSticky) != 0 {
+		return fmt.Errorf("Mode %s cant be set", mode)
+	}
+	for _, path := range paths {
+		resolved, err := l.appPath(path)
+		if err != nil {
+			return err
+		}
+		err = walk(resolved, recursive, func(path string, info os.FileInfo) error {
+			//chmod follows links - they are left alone
+			if info.Mode()&os.ModeSymlink != 0 {
+				return nil
+			}
+			if err := l.changeable(path, info); err != nil {
+				return err
+			}
+			return os.Chmod(path, mode)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) NbdConnect(device string, format string, path string) error {
+	if !nbdDeviceRx.MatchString(device) {
+		return fmt.Errorf("%s is not an nbd device", device)
+	}
+	if !formatRx.MatchString(format) {
+		return fmt.Errorf("%s is not an image format", format)
+	}
+	resolved, err := l.changeableFile(path)
+	if err != nil {
+		return err
+	}
+	return run("qemu-nbd", "-c", device, "-f", format, resolved)
+}
+
+func (l *Local) NbdDisconnect(device string) error {
+	if !nbdDeviceRx.MatchString(device) {
+		return fmt.Errorf("%s is not an nbd device", device)
+	}
+	return run("qemu-nbd", "-d", device)
+}
+
+func (l *Local) Partprobe(device string) error {
+	resolved, err := l.device(device)
+	if err != nil {
+		return err
+	}
+	return run("partprobe", resolved)
+}
+
+func (l *Local) QemuImgConvert(source string, dest string, format string) error {
+	if !formatRx.MatchString(format) {
+		return fmt.Errorf("%s is not an image format", format)
+	}
+	resolvedSource, err := l.changeableFile(source)
+	if err != nil {
+		return err
+	}
+	resolvedDest, err := l.changeableFile(dest)
+	if err != nil {
+		return err
+	}
+	return run("qemu-img", "convert", "-O", format, resolvedSource, resolvedDest)
+}
+
+func (l *Local) CreateTap(name string) error {
+	if !tapNameRx.MatchString(name) {
+		return fmt.Errorf("%s is not a valid interface name", name)
+	}
+	return run("ip", "tuntap", "add", "dev", name, "mode", "tap")
+}
+
+func (l *Local) DeleteTap(name string) error {
+	if !tapNameRx.MatchString(name) {
+		return fmt.Errorf("%s is not a valid interface name", name)
+	}
+	//only tun and tap devices have tun_flags
+	if _, err := os.Stat(filepath.Join(SysClassNet, name, "tun_flags")); err != nil {
+		return fmt.Errorf("%s is not a tap device", name)
+	}
+	return run("ip", "tuntap", "del", "dev", name, "mode", "tap")
+}
+
+func (l *Local) CgroupMkdir(path string) error {
+	resolved, err := l.cgroupPath(path, false)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(resolved, 0755)
+}
+
+func (l *Local) CgroupRmdir(path string) error {
+	resolved, err := l.cgroupPath(path, false)
+	if err != nil {
+		return err
+	}
+	return syscall.Rmdir(resolved)
+}
+
+func (l *Local) CgroupWrite(path string, value string) error {
+	resolved, err := l.cgroupPath(path, true)
+	if err != nil {
+		return err
+	}
+	//control files only take a single write so it is done directly rather than through ioutil.WriteFile
+	file, err := os.OpenFile(resolved, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(value + "\n"); err != nil {
+		return fmt.Errorf("Unable to write %s to %s: %s", value, path, err.Error())
+	}
+	return nil
+}
+
+func (l *Local) validateJailer(spec *JailerSpec) (*JailerSpec, error) {
+	if spec == nil {
+		return nil, errors.New("No jailer was given")
+	}
+	if !jailerIDRx.MatchString(spec.ID) {
+		return nil, fmt.Errorf("%s is not a valid vm id", spec.ID)
+	}
+	if spec.UID <= 0 || spec.GID <= 0 {
+		return nil, errors.New("Firecracker cant be run as root")
+	}
+	if spec.Node < 0 {
+		return nil, fmt.Errorf("%d is not a valid numa node", spec.Node)
+	}
+	if err := validateJailerCgroups(spec); err != nil {
+		return nil, err
+	}
+	checked := *spec
+	var err error
+	for _, binary := range []*string{&checked.Jailer, &checked.ExecFile} {
+		if *binary, err = l.appFile(*binary); err != nil {
+			return nil, err
+		}
+		if err := checkBinary(*binary); err != nil {
+			return nil, err
+		}
+	}
+	if checked.ChrootBaseDir, err = l.appPath(checked.ChrootBaseDir); err != nil {
+		return nil, err
+	}
+	return &checked, nil
+}
+
+// checkBinary checks a binary run as root is a file that only root can change - it and the folder it is in have to
+// belong to root and not be writable by anyone else
+func checkBinary(path string) error {
+	for _, checked := range []string{path, filepath.Dir(path)} {
+		info, err := os.Lstat(checked)
+		if err != nil {
+			return err
+		}
+		if stat, ok := info.Sys().(*syscall.Stat_t); !ok || int(stat.Uid) != binaryOwner {
+			return fmt.Errorf("%s is not owned by root", checked)
+		}
+		if info.Mode().Perm()&0022 != 0 {
+			return fmt.Errorf("%s can be written by users other than root", checked)
+		}
+	}
+	if info, err := os.Lstat(path); err != nil {
+		return err
+	} else if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a file", path)
+	}
+	return nil
+}
+
+// validateJailerCgroups checks the jailer only puts the vm in a cgroup below firecracker and only writes the limits a
+// vm is given to it
+func validateJailerCgroups(spec *JailerSpec) error {
+	if spec.CgroupVersion != 0 && spec.CgroupVersion != 1 && spec.CgroupVersion != 2 {
+		return fmt.Errorf("%d is not a valid cgroup version", spec.CgroupVersion)
+	}
+	if spec.ParentCgroup != "" && spec.ParentCgroup != "firecracker" {
+		return fmt.Errorf("%s is not the cgroup of vms", spec.ParentCgroup)
+	}
+	for _, cgroup := range spec.Cgroups {
+		parts := strings.SplitN(cgroup, "=", 2)
+		if len(parts) != 2 || !jailerCgroupFiles[parts[0]] {
+			return fmt.Errorf("%s is not a cgroup limit the jailer can set", cgroup)
+		}
+		if !cgroupValueRx.MatchString(parts[1]) {
+			return fmt.Errorf("%s is not a valid value for %s", parts[1], parts[0])
+		}
+	}
+	return nil
+}
+
+func (l *Local) StartJailer(spec *JailerSpec) (Process, error) {
+	checked, err := l.validateJailer(spec)
+	if err != nil {
+		return nil, err
+	}
+	//the serial console is on a pty so guest programs get a real terminal which can be resized - stderr stays a pipe
+	//as it only carries the firecracker log
+	ptm, pts, err := pty.Open()
+	if err != nil {
+		return nil, err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		ptm.Close()
+		pts.Close()
+		return nil, err
+	}
+	cmd := exec.Command(checked.Jailer, checked.args()...)
+	cmd.Stdin = pts
+	cmd.Stdout = pts
+	cmd.Stderr = errW
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:  true,
+		Setctty: true,
+	}
+	err = cmd.Start()
+	//the jailer has its own copies of the slave and the pipe now - ours have to go so reads see them close when it
+	//exits
+	pts.Close()
+	errW.Close()
+	if err != nil {
+		ptm.Close()
+		errR.Close()
+		return nil, err
+	}
+	proc := newLocalProcess(cmd)
+	proc.console = ptm
+	proc.stderr = errR
+	return proc, nil
+}
+
+// AttachJailer adopts a jailer that isnt our child - the jailer execs firecracker so the process has to be chrooted
+// in to the jail of the vm for it to be the one we want
+func (l *Local) AttachJailer(spec *JailerSpec, pid int) (Process, error) {
+	checked, err := l.validateJailer(spec)
+	if err != nil {
+		return nil, err
+	}
+	if pid <= 0 {
+		return nil, fmt.Errorf("%d is not a valid pid", pid)
+	}
+	root, err := os.Readlink(fmt.Sprintf("/proc/%d/root", pid))
+	if err != nil {
+		return nil, fmt.Errorf("Process %d isnt running: %s", pid, err.Error())
+	}
+	jail := filepath.Join(checked.ChrootBaseDir, filepath.Base(checked.ExecFile), checked.ID, "root")
+	if root != jail {
+		return nil, fmt.Errorf("Process %d is not the jailer of %s", pid, checked.ID)
+	}
+	return newAdoptedProcess(pid), nil
+}
+
+// adoptJailer picks up a jailer handed over by another helper along with its stdio - that helper started it or
+// checked it was the jailer when it attached to it so it is only checked to be running
+func (l *Local) adoptJailer(spec *JailerSpec, pid int, console *os.File, stderr *os.File) (Process, error) {
+	if _, err := l.validateJailer(spec); err != nil {
+		return nil, err
+	}
+	if pid <= 0 {
+		return nil, fmt.Errorf("%d is not a valid pid", pid)
+	}
+	if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+		return nil, fmt.Errorf("Process %d isnt running", pid)
+	}
+	proc := newAdoptedProcess(pid)
+	proc.console = console
+	proc.stderr = stderr
+	return proc, nil
+}
+
+type localProcess struct {
+	cmd     *exec.Cmd
+	done    chan struct{}
+	err     error
+	status  *ExitStatus
+	console *os.File
+	stderr  *os.File
+}
+
+func newLocalProcess(cmd *exec.Cmd) *localProcess {
+	proc := &localProcess{
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+	go func() {
+		proc.err = cmd.Wait()
+		proc.status = exitStatusFromState(cmd.ProcessState)
+		close(proc.done)
+	}()
+	return proc
+}
+
+func (proc *localProcess) Pid() int {
+	return proc.cmd.Process.Pid
+}
+
+func (proc *localProcess) Signal(sig syscall.Signal) error {
+	return proc.cmd.Process.Signal(sig)
+}
+
+func (proc *localProcess) Wait() error {
+	<-proc.done
+	return proc.err
+}
+
+func (proc *localProcess) ExitStatus() *ExitStatus {
+	select {
+	case <-proc.done:
+		return proc.status
+	default:
+		return nil
+	}
+}
+
+func (proc *localProcess) Console() *os.File {
+	return proc.console
+}
+
+func (proc *localProcess) Stderr() *os.File {
+	return proc.stderr
+}
+
+// ErrExitUnknown is given when an adopted process exits - it isnt our child so how it exited cant be known
+var ErrExitUnknown = errors.New("The process exited while it was detached so its exit status isnt known")
+
+// adoptedProcess is a process that isnt our child - it cant be waited for so it is checked for until it has gone. It
+// only has stdio when it was handed over by another helper.
+type adoptedProcess struct {
+	pid     int
+	done    chan struct{}
+	status  *ExitStatus
+	console *os.File
+	stderr  *os.File
+}
+
+func newAdoptedProcess(pid int) *adoptedProcess {
+	proc := &adoptedProcess{
+		pid:  pid,
+		done: make(chan struct{}),
+	}
+	go func() {
+		for syscall.Kill(pid, 0) != syscall.ESRCH {
+			time.Sleep(time.Second)
+		}
+		proc.status = &ExitStatus{Code: -1}
+		close(proc.done)
+	}()
+	return proc
+}
+
+func (proc *adoptedProcess) Pid() int {
+	return proc.pid
+}
+
+func (proc *adoptedProcess) Signal(sig syscall.Signal) error {
+	if proc.ExitStatus() != nil {
+		return errors.New("The process has already exited")
+	}
+	return syscall.Kill(proc.pid, sig)
+}
+
+func (proc *adoptedProcess) Wait() error {
+	<-proc.done
+	return ErrExitUnknown
+}
+
+func (proc *adoptedProcess) ExitStatus() *ExitStatus {
+	select {
+	case <-proc.done:
+		return proc.status
+	default:
+		return nil
+	}
+}
+
+func (proc *adoptedProcess) Console() *os.File {
+	return proc.console
+}
+
+func (proc *adoptedProcess) Stderr() *os.File {
+	return proc.stderr
+}
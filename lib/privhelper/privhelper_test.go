@@ -0,0 +1,561 @@
+package privhelper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func init() {
+	//the binaries run by the tests belong to whoever runs them
+	binaryOwner = os.Getuid()
+}
+
+func tempRoot(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "privhelper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the temp dir can be behind a symlink
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestWithin(t *testing.T) {
+	root := tempRoot(t)
+	defer os.RemoveAll(root)
+	outside := tempRoot(t)
+	defer os.RemoveAll(outside)
+	if err := os.MkdirAll(filepath.Join(root, "instances"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	for path, ok := range map[string]bool{
+		root: true,
+		filepath.Join(root, "instances", "vm", "disk"): true,
+		filepath.Join(root, "escape"):                  true,
+		filepath.Join(root, "escape", "file"):          false,
+		filepath.Join(root, "..", "file"):              false,
+		filepath.Join(outside, "file"):                 false,
+		"instances/vm":                                 false,
+	} {
+		_, _, err := within(root, path)
+		if ok && err != nil {
+			t.Errorf("expected %s to be allowed: %s", path, err.Error())
+		} else if !ok && err == nil {
+			t.Errorf("expected %s to be rejected", path)
+		}
+	}
+	if _, _, err := within("", root); err == nil {
+		t.Error("expected paths to be rejected without a root")
+	}
+}
+
+func TestCgroupPath(t *testing.T) {
+	root := tempRoot(t)
+	defer os.RemoveAll(root)
+	local := NewLocal("", root)
+	for path, ok := range map[string]bool{
+		"cgroup.subtree_control":              true,
+		"cgroup.procs":                        false,
+		"firecracker/cgroup.subtree_control":  true,
+		"firecracker/vm/cpu.max":              true,
+		"firecracker/vm/vcpu0/cgroup.threads": true,
+		"cpuset/firecracker/vm/cpuset.cpus":   true,
+		"firecracker/vm/memory.swap.max":      false,
+		"system.slice/cpu.max":                false,
+	} {
+		_, err := local.cgroupPath(filepath.Join(root, path), true)
+		if ok && err != nil {
+			t.Errorf("expected write to %s to be allowed: %s", path, err.Error())
+		} else if !ok && err == nil {
+			t.Errorf("expected write to %s to be rejected", path)
+		}
+	}
+	for path, ok := range map[string]bool{
+		"firecracker":                 true,
+		"firecracker/vm/vcpu0":        true,
+		"cpuset/firecracker/vm/vcpu1": true,
+		"system.slice":                false,
+		"system.slice/firecracker/vm": false,
+	} {
+		_, err := local.cgroupPath(filepath.Join(root, path), false)
+		if ok && err != nil {
+			t.Errorf("expected cgroup %s to be allowed: %s", path, err.Error())
+		} else if !ok && err == nil {
+			t.Errorf("expected cgroup %s to be rejected", path)
+		}
+	}
+	if err := local.CgroupMkdir(filepath.Join(root, "firecracker", "vm")); err != nil {
+		t.Fatal(err)
+	}
+	if err := local.CgroupRmdir(filepath.Join(root, "firecracker", "vm")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	local := NewLocal(tempRoot(t), DefaultCgroupRoot)
+	defer os.RemoveAll(local.AppRoot)
+	if err := local.NbdConnect("/dev/sda", "qcow2", filepath.Join(local.AppRoot, "disk.qcow2")); err == nil {
+		t.Error("expected a device which isnt an nbd device to be rejected")
+	}
+	if err := local.NbdConnect("/dev/nbd0", "qcow2 -k", filepath.Join(local.AppRoot, "disk.qcow2")); err == nil {
+		t.Error("expected an invalid format to be rejected")
+	}
+	if err := local.NbdConnect("/dev/nbd0", "qcow2", "/etc/shadow"); err == nil {
+		t.Error("expected an image outside of the app root to be rejected")
+	}
+	if err := local.QemuImgConvert(filepath.Join(local.AppRoot, "disk.qcow2"), "/etc/passwd", "raw"); err == nil {
+		t.Error("expected a destination outside of the app root to be rejected")
+	}
+	if err := local.CreateTap("tap0; reboot"); err == nil {
+		t.Error("expected an invalid interface name to be rejected")
+	}
+	if err := local.DeleteTap("lo; reboot"); err == nil {
+		t.Error("expected an invalid interface name to be rejected")
+	}
+	if err := local.DeleteTap("lo"); err == nil {
+		t.Error("expected an interface which isnt a tap to be rejected")
+	}
+	if err := local.Chown(1000, 1000, true, "/dev/nbd0"); err == nil {
+		t.Error("expected a recursive chown of a device to be rejected")
+	}
+	if err := local.Chown(0, -1, false, local.AppRoot); err == nil {
+		t.Error("expected giving a path to root to be rejected")
+	}
+	if err := local.Chown(-1, 0, false, local.AppRoot); err == nil {
+		t.Error("expected giving a path to the root group to be rejected")
+	}
+	if err := local.Chmod(os.ModeSetuid|0755, false, local.AppRoot); err == nil {
+		t.Error("expected setuid to be rejected")
+	}
+	if err := local.Chmod(os.ModeSetgid|0755, false, local.AppRoot); err == nil {
+		t.Error("expected setgid to be rejected")
+	}
+
+	//links are followed to where the commands would open
+	link := filepath.Join(local.AppRoot, "disk.raw")
+	if err := os.Symlink("/etc/shadow", link); err != nil {
+		t.Fatal(err)
+	}
+	if err := local.NbdConnect("/dev/nbd0", "raw", link); err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Errorf("expected an image linked to outside of the app root to be rejected, got %v", err)
+	}
+	if err := local.QemuImgConvert(filepath.Join(local.AppRoot, "disk.qcow2"), link, "raw"); err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Errorf("expected a destination linked to outside of the app root to be rejected, got %v", err)
+	}
+	dangling := filepath.Join(local.AppRoot, "dangling.raw")
+	if err := os.Symlink("/nonexistent/disk.raw", dangling); err != nil {
+		t.Fatal(err)
+	}
+	if err := local.QemuImgConvert(filepath.Join(local.AppRoot, "disk.qcow2"), dangling, "raw"); err == nil {
+		t.Error("expected a destination linked to somewhere that isnt there to be rejected")
+	}
+	if err := os.MkdirAll(filepath.Join(local.AppRoot, "instances"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(local.AppRoot, "instances", "disk.raw"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	inside := filepath.Join(local.AppRoot, "inside.raw")
+	if err := os.Symlink(filepath.Join(local.AppRoot, "instances", "disk.raw"), inside); err != nil {
+		t.Fatal(err)
+	}
+	if resolved, err := local.appFile(inside); err != nil || resolved != filepath.Join(local.AppRoot, "instances", "disk.raw") {
+		t.Errorf("expected a link that stays in the app root to be followed, got %s %v", resolved, err)
+	}
+}
+
+func writeJailer(t *testing.T, root string, script string) *JailerSpec {
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(bin, "jailer"), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(bin, "firecracker"), []byte{}, 0755); err != nil {
+		t.Fatal(err)
+	}
+	//the umask can leave them writable by the group
+	for _, path := range []string{bin, filepath.Join(bin, "jailer"), filepath.Join(bin, "firecracker")} {
+		if err := os.Chmod(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &JailerSpec{
+		Jailer:        filepath.Join(bin, "jailer"),
+		ID:            "vm-1",
+		ExecFile:      filepath.Join(bin, "firecracker"),
+		ChrootBaseDir: root,
+		UID:           1000,
+		GID:           1000,
+	}
+}
+
+func TestValidateJailer(t *testing.T) {
+	local := NewLocal(tempRoot(t), DefaultCgroupRoot)
+	defer os.RemoveAll(local.AppRoot)
+	spec := writeJailer(t, local.AppRoot, "exit 0")
+	if _, err := local.validateJailer(spec); err != nil {
+		t.Fatal(err)
+	}
+	limited := *spec
+	limited.CgroupVersion, limited.ParentCgroup = 2, "firecracker"
+	limited.Cgroups = []string{"cpu.weight=100", "cpu.max=max 100000", "memory.max=335544320", "pids.max=max"}
+	if _, err := local.validateJailer(&limited); err != nil {
+		t.Fatal(err)
+	}
+	args := strings.Join(limited.args(), " ")
+	if !strings.HasSuffix(args, "--cgroup-version 2 --parent-cgroup firecracker --cgroup cpu.weight=100 --cgroup cpu.max=max 100000 --cgroup memory.max=335544320 --cgroup pids.max=max") {
+		t.Errorf("expected the jailer to be given the cgroups, got %s", args)
+	}
+	for name, change := range map[string]func(spec *JailerSpec){
+		"id":             func(spec *JailerSpec) { spec.ID = "../vm" },
+		"root":           func(spec *JailerSpec) { spec.UID = 0 },
+		"jailer":         func(spec *JailerSpec) { spec.Jailer = "/bin/sh" },
+		"exec":           func(spec *JailerSpec) { spec.ExecFile = filepath.Join(local.AppRoot, "bin") },
+		"chroot":         func(spec *JailerSpec) { spec.ChrootBaseDir = "/" },
+		"cgroup version": func(spec *JailerSpec) { spec.CgroupVersion = 3 },
+		"parent cgroup":  func(spec *JailerSpec) { spec.ParentCgroup = "../system.slice" },
+		"cgroup file":    func(spec *JailerSpec) { spec.Cgroups = []string{"cgroup.procs=1"} },
+		"cgroup value":   func(spec *JailerSpec) { spec.Cgroups = []string{"pids.max=1\nmax"} },
+	} {
+		changed := *spec
+		change(&changed)
+		if _, err := local.validateJailer(&changed); err == nil {
+			t.Errorf("expected the jailer to be rejected with a bad %s", name)
+		}
+	}
+
+	//the binaries are run as root so they cant be ones the daemon could have changed
+	outside := tempRoot(t)
+	defer os.RemoveAll(outside)
+	changes := map[string]func(spec *JailerSpec) error{
+		"a writable jailer":       func(spec *JailerSpec) error { return os.Chmod(spec.Jailer, 0757) },
+		"a group writable exec":   func(spec *JailerSpec) error { return os.Chmod(spec.ExecFile, 0775) },
+		"a writable bin folder":   func(spec *JailerSpec) error { return os.Chmod(filepath.Dir(spec.Jailer), 0777) },
+		"a jailer linked outside": func(spec *JailerSpec) error { return replaceWithLink(spec.Jailer, filepath.Join(outside, "jailer")) },
+	}
+	if os.Getuid() == 0 {
+		changes["a jailer root doesnt own"] = func(spec *JailerSpec) error { return os.Chown(spec.Jailer, 1000, 1000) }
+		changes["a bin folder root doesnt own"] = func(spec *JailerSpec) error { return os.Chown(filepath.Dir(spec.Jailer), 1000, 1000) }
+	}
+	for name, change := range changes {
+		changed := NewLocal(tempRoot(t), DefaultCgroupRoot)
+		spec := writeJailer(t, changed.AppRoot, "exit 0")
+		if err := change(spec); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := changed.validateJailer(spec); err == nil {
+			t.Errorf("expected the jailer to be rejected with %s", name)
+		}
+		os.RemoveAll(changed.AppRoot)
+	}
+}
+
+// replaceWithLink replaces path with a link to a copy of it at target
+func replaceWithLink(path string, target string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(target, data, 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	return os.Symlink(target, path)
+}
+
+func startServer(t *testing.T, allowedUID int) (*Client, *Local, func()) {
+	local := NewLocal(tempRoot(t), DefaultCgroupRoot)
+	server := NewServer(local, allowedUID)
+	socketPath := filepath.Join(local.AppRoot, "privhelper.sock")
+	if err := server.Listen(socketPath, -1); err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve()
+	return NewClient(socketPath), local, func() {
+		server.Close()
+		os.RemoveAll(local.AppRoot)
+	}
+}
+
+func TestClient(t *testing.T) {
+	client, local, stop := startServer(t, os.Getuid())
+	defer stop()
+	if err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(local.AppRoot, "file")
+	if err := ioutil.WriteFile(path, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	//the helper leaves files that belong to root alone
+	if os.Getuid() == rootOwner {
+		if err := client.Chmod(0640, false, path); err == nil || !strings.Contains(err.Error(), "owned by root") {
+			t.Errorf("expected a file owned by root to be rejected, got %v", err)
+		}
+		if err := os.Chown(path, 1000, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.Chmod(0640, false, path); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0640 {
+		t.Errorf("expected mode 0640 got %#o", info.Mode().Perm())
+	}
+	if err := client.Chown(-1, -1, true, local.AppRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	//the jailer is run as root so the daemon cant be allowed to make it writable
+	spec := writeJailer(t, local.AppRoot, "exit 0")
+	if err := client.Chmod(0777, false, spec.Jailer); err == nil || !strings.Contains(err.Error(), "cant be changed") {
+		t.Errorf("expected a chmod of the jailer to be rejected, got %v", err)
+	}
+	if err := client.Chmod(0777, true, local.AppRoot); err == nil {
+		t.Error("expected a recursive chmod reaching the jailer to be rejected")
+	}
+	if info, err := os.Stat(spec.Jailer); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0755 {
+		t.Errorf("expected the jailer to be left as 0755 got %#o", info.Mode().Perm())
+	}
+	if err := client.Chown(1000, 1000, false, spec.Jailer); err == nil {
+		t.Error("expected a chown of the jailer to be rejected")
+	}
+	if err := client.QemuImgConvert(path, spec.Jailer, "raw"); err == nil {
+		t.Error("expected a conversion over the jailer to be rejected")
+	}
+	if err := client.NbdConnect("/dev/nbd0", "raw", spec.ExecFile); err == nil {
+		t.Error("expected firecracker to be rejected as an image")
+	}
+	link := filepath.Join(local.AppRoot, "jailer.raw")
+	if err := os.Symlink(spec.Jailer, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.QemuImgConvert(path, link, "raw"); err == nil {
+		t.Error("expected a conversion over a link to the jailer to be rejected")
+	}
+	if err := client.Chmod(0644, false, "/etc/passwd"); err == nil {
+		t.Error("expected a path outside of the app root to be rejected")
+	} else if !strings.Contains(err.Error(), "outside") {
+		t.Errorf("unexpected error %s", err.Error())
+	}
+}
+
+func TestClientNotAllowed(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("root is always allowed")
+	}
+	client, _, stop := startServer(t, os.Getuid()+1)
+	defer stop()
+	if err := client.Ping(); err == nil {
+		t.Error("expected the connection to be rejected")
+	}
+}
+
+func TestStartJailer(t *testing.T) {
+	client, local, stop := startServer(t, os.Getuid())
+	defer stop()
+	spec := writeJailer(t, local.AppRoot, `echo "$@" >&2; exit 3`)
+	proc, err := client.StartJailer(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proc.Pid() == 0 {
+		t.Error("expected the pid of the jailer")
+	}
+	if proc.Console() == nil || proc.Stderr() == nil {
+		t.Fatal("expected the stdio of the jailer")
+	}
+	defer proc.Console().Close()
+	stderr, _ := ioutil.ReadAll(proc.Stderr())
+	proc.Stderr().Close()
+	if !strings.Contains(string(stderr), "--id vm-1") || !strings.Contains(string(stderr), "--uid 1000") {
+		t.Errorf("unexpected jailer arguments %q", string(stderr))
+	}
+	if err := proc.Wait(); err == nil {
+		t.Error("expected an error for the exit status")
+	}
+	if status := proc.ExitStatus(); status == nil || status.Code != 3 {
+		t.Errorf("expected exit status 3 got %v", status)
+	}
+}
+
+func TestSignalJailer(t *testing.T) {
+	client, local, stop := startServer(t, os.Getuid())
+	defer stop()
+	spec := writeJailer(t, local.AppRoot, "exec sleep 30")
+	proc, err := client.StartJailer(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proc.Console().Close()
+	defer proc.Stderr().Close()
+	if proc.ExitStatus() != nil {
+		t.Error("expected no exit status while the jailer is running")
+	}
+	if _, err := client.StartJailer(spec); err == nil {
+		t.Error("expected a second jailer for the same id to be rejected")
+	}
+	if err := proc.Signal(syscall.SIGKILL); err != nil {
+		t.Fatal(err)
+	}
+	proc.Wait()
+	if status := proc.ExitStatus(); status == nil || status.Signal != "killed" {
+		t.Errorf("expected the jailer to be killed got %v", status)
+	}
+}
+
+func readUntil(t *testing.T, file *os.File, want string) {
+	file.SetReadDeadline(time.Now().Add(5 * time.Second))
+	got := ""
+	buf := make([]byte, 256)
+	for !strings.Contains(got, want) {
+		n, err := file.Read(buf)
+		if err != nil {
+			t.Fatalf("expected %q got %q: %s", want, got, err.Error())
+		}
+		got += string(buf[:n])
+	}
+}
+
+func TestAttachJailer(t *testing.T) {
+	client, local, stop := startServer(t, os.Getuid())
+	defer stop()
+	spec := writeJailer(t, local.AppRoot, `while read line; do echo "got $line" >&2; done`)
+	proc, err := client.StartJailer(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proc.Console().WriteString("one\n")
+	readUntil(t, proc.Stderr(), "got one")
+	//the daemon going away leaves the jailer running
+	proc.(*remoteProcess).conn.Close()
+	proc.Wait()
+	proc.Console().Close()
+	proc.Stderr().Close()
+	if _, err := client.AttachJailer(spec, proc.Pid()+1); err == nil {
+		t.Error("expected attaching with the wrong pid to be rejected")
+	}
+	attached, err := client.AttachJailer(spec, proc.Pid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attached.Console() == nil || attached.Stderr() == nil {
+		t.Fatal("expected the stdio of the jailer")
+	}
+	defer attached.Console().Close()
+	defer attached.Stderr().Close()
+	attached.Console().WriteString("two\n")
+	readUntil(t, attached.Stderr(), "got two")
+	if err := attached.Signal(syscall.SIGKILL); err != nil {
+		t.Fatal(err)
+	}
+	attached.Wait()
+	if status := attached.ExitStatus(); status == nil || status.Signal != "killed" {
+		t.Errorf("expected the jailer to be killed got %v", status)
+	}
+}
+
+func TestAdoptJailer(t *testing.T) {
+	local := NewLocal(tempRoot(t), DefaultCgroupRoot)
+	defer os.RemoveAll(local.AppRoot)
+	spec := writeJailer(t, local.AppRoot, "exit 0")
+	if _, err := local.AttachJailer(spec, os.Getpid()); err == nil {
+		t.Error("expected a process outside of the jail to be rejected")
+	}
+}
+
+func TestVersion(t *testing.T) {
+	client, _, stop := startServer(t, os.Getuid())
+	defer stop()
+	version, err := client.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version == "" || version != BinaryVersion() {
+		t.Errorf("expected the helper to give the version of its build %q, got %q", BinaryVersion(), version)
+	}
+}
+
+func TestTakeOver(t *testing.T) {
+	client, local, stop := startServer(t, os.Getuid())
+	defer stop()
+	spec := writeJailer(t, local.AppRoot, `while read line; do echo "got $line" >&2; done`)
+	proc, err := client.StartJailer(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proc.Console().WriteString("one\n")
+	readUntil(t, proc.Stderr(), "got one")
+	proc.Console().Close()
+	proc.Stderr().Close()
+
+	//a helper of a new build takes over while the daemon is attached
+	socketPath := filepath.Join(local.AppRoot, "privhelper.sock")
+	replacement := NewServer(local, os.Getuid())
+	taken, err := replacement.TakeOver(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taken != 1 {
+		t.Fatalf("expected the jailer to be taken over, %d were", taken)
+	}
+	if err := proc.Wait(); err != ErrConnectionLost {
+		t.Errorf("expected the daemon to lose its connection to the old helper, got %v", err)
+	}
+	if err := client.Ping(); err == nil {
+		t.Error("expected the old helper to stop listening")
+	}
+	if err := replacement.Listen(socketPath, -1); err != nil {
+		t.Fatal(err)
+	}
+	go replacement.Serve()
+	defer replacement.Close()
+
+	//the jailer kept its stdio so the daemon can use it through the new helper
+	attached, err := client.AttachJailer(spec, proc.Pid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attached.Console() == nil || attached.Stderr() == nil {
+		t.Fatal("expected the stdio of the jailer to be handed over")
+	}
+	defer attached.Console().Close()
+	defer attached.Stderr().Close()
+	attached.Console().WriteString("two\n")
+	readUntil(t, attached.Stderr(), "got two")
+	if err := attached.Signal(syscall.SIGKILL); err != nil {
+		t.Fatal(err)
+	}
+	if err := attached.Wait(); err == nil {
+		t.Error("expected an error for the exit of the jailer")
+	}
+}
+
+func TestTakeOverWithoutHelper(t *testing.T) {
+	root := tempRoot(t)
+	defer os.RemoveAll(root)
+	taken, err := NewServer(NewLocal(root, DefaultCgroupRoot), os.Getuid()).TakeOver(filepath.Join(root, "privhelper.sock"))
+	if err != nil || taken != 0 {
+		t.Errorf("expected nothing to be taken over without a helper, got %d %v", taken, err)
+	}
+}
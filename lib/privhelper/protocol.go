@@ -0,0 +1,241 @@
+// Package privhelper is the privileged helper the daemon uses for the few operations that need root once it has
+// dropped its privileges - chown, chmod, qemu-nbd, qemu-img, partprobe, creating taps, the cgroups of vms and
+// starting the jailer.
+//
+// The helper runs as root and listens on a unix seqpacket socket. Only the daemon user and root may connect which is
+// checked with the peer credentials of the connection. Every connection carries a single request which is a JSON
+// packet answered with a JSON packet - the only exceptions are starting and attaching to a jailer where the connection
+// stays open to send signals to the jailer and to receive its exit status.
+//
+// The helper creates the pty and stderr pipe of a jailer itself and passes the master and the read end back as file
+// descriptors. It keeps its own copies so the jailer survives the daemon going away - its output is drained until
+// a daemon attaches to it again.
+//
+// Pinging the helper gives the version of the build it was started from. A daemon that finds a helper from another
+// build starts a new one which asks the old one to hand over - the old helper sends the jailers it looks after along
+// with their pty masters and stderr pipes and exits. The masters are never closed so the jailers never see a hangup.
+//
+// Nothing is run through a shell and every path is checked to stay under the AppRoot of the daemon (or the cgroup
+// hierarchy for cgroup operations) before anything is done with it.
+package privhelper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// packets larger than this are rejected - requests are small
+const maxPacketSize = 64 * 1024
+
+type Op string
+
+const (
+	OpPing           Op = "ping"
+	OpChown          Op = "chown"
+	OpChmod          Op = "chmod"
+	OpNbdConnect     Op = "nbd-connect"
+	OpNbdDisconnect  Op = "nbd-disconnect"
+	OpPartprobe      Op = "partprobe"
+	OpQemuImgConvert Op = "qemu-img-convert"
+	OpCreateTap      Op = "create-tap"
+	OpDeleteTap      Op = "delete-tap"
+	OpCgroupMkdir    Op = "cgroup-mkdir"
+	OpCgroupRmdir    Op = "cgroup-rmdir"
+	OpCgroupWrite    Op = "cgroup-write"
+	OpStartJailer    Op = "start-jailer"
+	OpAttachJailer   Op = "attach-jailer"
+	OpSignal         Op = "signal"
+	OpHandOver       Op = "hand-over"
+)
+
+// Request is the first packet sent on a connection - signal requests are only sent on the connection of a jailer
+type Request struct {
+	Op        Op          `json:"op"`
+	Paths     []string    `json:"paths,omitempty"`     //chown and chmod
+	UID       int         `json:"uid,omitempty"`       //chown - -1 leaves it as it is
+	GID       int         `json:"gid,omitempty"`       //chown - -1 leaves it as it is
+	Mode      uint32      `json:"mode,omitempty"`      //chmod
+	Recursive bool        `json:"recursive,omitempty"` //chown and chmod
+	Device    string      `json:"device,omitempty"`    //nbd and partprobe
+	Format    string      `json:"format,omitempty"`    //nbd-connect and qemu-img-convert
+	Path      string      `json:"path,omitempty"`      //nbd-connect and cgroup operations
+	Source    string      `json:"source,omitempty"`    //qemu-img-convert
+	Dest      string      `json:"dest,omitempty"`      //qemu-img-convert
+	Name      string      `json:"name,omitempty"`      //create-tap
+	Value     string      `json:"value,omitempty"`     //cgroup-write
+	Jailer    *JailerSpec `json:"jailer,omitempty"`    //start-jailer and attach-jailer
+	Pid       int         `json:"pid,omitempty"`       //attach-jailer
+	Signal    int         `json:"signal,omitempty"`    //signal
+}
+
+// Response answers a request - for a jailer the first response carries the pid (and its stdio) and the last its exit
+// status
+type Response struct {
+	Error   string      `json:"error,omitempty"`
+	Pid     int         `json:"pid,omitempty"`
+	Exit    *ExitStatus `json:"exit,omitempty"`
+	Version string      `json:"version,omitempty"` //ping
+	Jailer  *JailerSpec `json:"jailer,omitempty"`  //hand-over
+}
+
+// JailerSpec is how a jailer is started - it maps on to the arguments of the jailer. Cgroups are control file values
+// ("cpu.weight=100") the jailer writes to the cgroup of the vm below ParentCgroup before it execs firecracker.
+type JailerSpec struct {
+	Jailer        string   `json:"jailer"`
+	ID            string   `json:"id"`
+	Node          int      `json:"node"`
+	ExecFile      string   `json:"execFile"`
+	ChrootBaseDir string   `json:"chrootBaseDir"`
+	UID           int      `json:"uid"`
+	GID           int      `json:"gid"`
+	CgroupVersion int      `json:"cgroupVersion,omitempty"`
+	ParentCgroup  string   `json:"parentCgroup,omitempty"`
+	Cgroups       []string `json:"cgroups,omitempty"`
+}
+
+func (spec *JailerSpec) args() []string {
+	args := []string{
+		"--id", spec.ID,
+		"--node", fmt.Sprintf("%d", spec.Node),
+		"--exec-file", spec.ExecFile,
+		"--chroot-base-dir", spec.ChrootBaseDir,
+		"--uid", fmt.Sprintf("%d", spec.UID),
+		"--gid", fmt.Sprintf("%d", spec.GID),
+	}
+	if spec.CgroupVersion != 0 {
+		args = append(args, "--cgroup-version", fmt.Sprintf("%d", spec.CgroupVersion))
+	}
+	if spec.ParentCgroup != "" {
+		args = append(args, "--parent-cgroup", spec.ParentCgroup)
+	}
+	for _, cgroup := range spec.Cgroups {
+		args = append(args, "--cgroup", cgroup)
+	}
+	return args
+}
+
+// BinaryVersion identifies the build that is running - it is the device, inode and modification time of the
+// executable so it changes whenever promethium is replaced. It is empty when the executable cant be found.
+func BinaryVersion() string {
+	info, err := os.Stat("/proc/self/exe")
+	if err != nil {
+		return ""
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d-%d", stat.Dev, stat.Ino, info.ModTime().UnixNano())
+}
+
+// ExitStatus is how a process exited - Signal is set when it was killed by one
+type ExitStatus struct {
+	Code   int    `json:"code"`
+	Signal string `json:"signal,omitempty"`
+}
+
+func (status *ExitStatus) Success() bool {
+	return status.Code == 0 && status.Signal == ""
+}
+
+// String matches what os.ProcessState gives
+func (status *ExitStatus) String() string {
+	if status.Signal != "" {
+		return "signal: " + status.Signal
+	}
+	return fmt.Sprintf("exit status %d", status.Code)
+}
+
+func exitStatusFromState(state *os.ProcessState) *ExitStatus {
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return &ExitStatus{Code: -1, Signal: ws.Signal().String()}
+	}
+	return &ExitStatus{Code: state.ExitCode()}
+}
+
+// ErrConnectionLost is given for the exit of a jailer when the connection to the helper went before it exited
+var ErrConnectionLost = errors.New("The connection to the privileged helper was lost")
+
+func writePacket(conn *net.UnixConn, v interface{}, files ...*os.File) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var oob []byte
+	if len(files) > 0 {
+		fds := make([]int, len(files))
+		for i, file := range files {
+			//Fd would put the file in to blocking mode which stops read deadlines working on the copies we keep
+			raw, err := file.SyscallConn()
+			if err != nil {
+				return err
+			}
+			raw.Control(func(fd uintptr) {
+				fds[i] = int(fd)
+			})
+		}
+		oob = unix.UnixRights(fds...)
+	}
+	_, _, err = conn.WriteMsgUnix(data, oob, nil)
+	return err
+}
+
+// readPacket reads a packet in to v - any file descriptors sent with it are returned as files
+func readPacket(conn *net.UnixConn, v interface{}) ([]*os.File, error) {
+	buf := make([]byte, maxPacketSize)
+	oob := make([]byte, unix.CmsgSpace(4*4))
+	n, oobn, flags, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	files, err := parseRights(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		closeFiles(files)
+		return nil, errors.New("Empty packet")
+	}
+	if flags&(unix.MSG_TRUNC|unix.MSG_CTRUNC) != 0 {
+		closeFiles(files)
+		return nil, errors.New("Packet too large")
+	}
+	if err := json.Unmarshal(buf[:n], v); err != nil {
+		closeFiles(files)
+		return nil, err
+	}
+	return files, nil
+}
+
+func parseRights(oob []byte) ([]*os.File, error) {
+	if len(oob) == 0 {
+		return nil, nil
+	}
+	msgs, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	files := []*os.File{}
+	for _, msg := range msgs {
+		fds, err := unix.ParseUnixRights(&msg)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			files = append(files, os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd)))
+		}
+	}
+	return files, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, file := range files {
+		file.Close()
+	}
+}
@@ -0,0 +1,60 @@
+package privhelper
+
+import (
+	"os"
+	"syscall"
+)
+
+// Runner performs the privileged operations - Local does them itself and Client asks the helper to
+type Runner interface {
+	// Chown changes the owner of paths - -1 leaves the uid or gid as it is. Symlinks are not followed and nothing
+	// can be given to root.
+	Chown(uid int, gid int, recursive bool, paths ...string) error
+	// Chmod changes the mode of paths - setuid and setgid cant be set and nothing in AppRoot/bin or owned by root can
+	// be changed. Chown leaves AppRoot/bin alone as well.
+	Chmod(mode os.FileMode, recursive bool, paths ...string) error
+	// NbdConnect connects an image to an nbd device - like QemuImgConvert the images cant be in AppRoot/bin or be
+	// owned by root
+	NbdConnect(device string, format string, path string) error
+	NbdDisconnect(device string) error
+	Partprobe(device string) error
+	QemuImgConvert(source string, dest string, format string) error
+	CreateTap(name string) error
+	// DeleteTap removes a tap device - it only removes taps so it cant be used to take down other interfaces
+	DeleteTap(name string) error
+	CgroupMkdir(path string) error
+	CgroupRmdir(path string) error
+	CgroupWrite(path string, value string) error
+	// StartJailer starts a jailer with a pty as its stdin and stdout and a pipe as its stderr
+	StartJailer(spec *JailerSpec) (Process, error)
+	// AttachJailer picks up a jailer that was started for spec before the daemon restarted - pid has to be the pid
+	// it was started with
+	AttachJailer(spec *JailerSpec, pid int) (Process, error)
+}
+
+// Process is a jailer started by a Runner
+type Process interface {
+	Pid() int
+	Signal(sig syscall.Signal) error
+	// Wait waits for the process to exit - it returns an error when it didnt exit successfully
+	Wait() error
+	// ExitStatus is nil until the process has exited
+	ExitStatus() *ExitStatus
+	// Console is the master of the pty of the jailer and Stderr the read end of its stderr - they are nil when the
+	// jailer was started by a helper that has since gone as its stdio went with it. They are closed by the caller.
+	Console() *os.File
+	Stderr() *os.File
+}
+
+// Default is the runner used by the daemon - it is replaced with a Client when the daemon has started the helper
+// and dropped its privileges. Until then nothing is allowed as there is no AppRoot to check paths against.
+var Default Runner = NewLocal("", DefaultCgroupRoot)
+
+// Owner is the uid to give Chown for paths that should belong to uid - nothing can be given to root so when the
+// daemon is root the owner is left as it is
+func Owner(uid int) int {
+	if uid == 0 {
+		return -1
+	}
+	return uid
+}
@@ -0,0 +1,441 @@
+package privhelper
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/768bit/promethium/lib/peercred"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server serves the operations of a Local to the daemon user
+type Server struct {
+	local      *Local
+	allowedUID int
+	version    string
+	listener   *net.UnixListener
+	closed     bool
+	lock       sync.Mutex
+	sessions   map[string]*jailerSession
+}
+
+func NewServer(local *Local, allowedUID int) *Server {
+	return &Server{
+		local:      local,
+		allowedUID: allowedUID,
+		version:    BinaryVersion(),
+		sessions:   map[string]*jailerSession{},
+	}
+}
+
+// TakeOver asks the helper listening on socketPath to hand over its jailers and waits for it to stop listening - it
+// is done before Listen so the jailers of running vms keep their stdio when the helper is replaced. It gives how many
+// jailers were taken over, which is none when there isnt a helper listening.
+func (s *Server) TakeOver(socketPath string) (int, error) {
+	conn, err := net.DialUnix("unixpacket", nil, &net.UnixAddr{Name: socketPath, Net: "unixpacket"})
+	if err != nil {
+		return 0, nil
+	}
+	defer conn.Close()
+	cred, err := peercred.Get(conn)
+	if err != nil {
+		return 0, err
+	}
+	if cred.Uid != 0 && int(cred.Uid) != os.Geteuid() {
+		return 0, fmt.Errorf("The helper on %s is run by uid %d", socketPath, cred.Uid)
+	}
+	if err := writePacket(conn, &Request{Op: OpHandOver}); err != nil {
+		return 0, err
+	}
+	taken := 0
+	for {
+		//the old helper closes the connection once it has stopped listening
+		var resp Response
+		files, err := readPacket(conn, &resp)
+		if err != nil {
+			return taken, nil
+		}
+		if resp.Error != "" {
+			closeFiles(files)
+			return taken, errors.New(resp.Error)
+		}
+		if err := s.adopt(&resp, files); err != nil {
+			log.Warnf("Unable to take over the jailer with pid %d: %s", resp.Pid, err.Error())
+			closeFiles(files)
+			continue
+		}
+		taken++
+	}
+}
+
+// adopt looks after a jailer handed over by another helper - no daemon is attached to it yet so its output is drained
+func (s *Server) adopt(resp *Response, files []*os.File) error {
+	if resp.Jailer == nil {
+		return errors.New("No jailer was given")
+	}
+	var console, stderr *os.File
+	if len(files) == 2 {
+		console, stderr = files[0], files[1]
+	} else {
+		closeFiles(files)
+	}
+	proc, err := s.local.adoptJailer(resp.Jailer, resp.Pid, console, stderr)
+	if err != nil {
+		return err
+	}
+	log.Infof("Took over the jailer of %s with pid %d", resp.Jailer.ID, proc.Pid())
+	sess := &jailerSession{
+		id:   resp.Jailer.ID,
+		spec: resp.Jailer,
+		proc: proc,
+	}
+	s.lock.Lock()
+	s.sessions[sess.id] = sess
+	s.lock.Unlock()
+	sess.lock.Lock()
+	sess.startDraining()
+	sess.lock.Unlock()
+	go s.waitJailer(sess)
+	return nil
+}
+
+// handOver sends the jailers to the helper taking over and stops listening - the connections of attached daemons are
+// closed so they attach to the new helper
+func (s *Server) handOver(conn *net.UnixConn) {
+	defer conn.Close()
+	cred, err := peercred.Get(conn)
+	if err != nil || (cred.Uid != 0 && int(cred.Uid) != os.Geteuid()) {
+		writePacket(conn, &Response{Error: "Only another helper can take over"})
+		return
+	}
+	s.lock.Lock()
+	for id, sess := range s.sessions {
+		sess.lock.Lock()
+		if sess.exited {
+			sess.lock.Unlock()
+			continue
+		}
+		if sess.conn != nil {
+			sess.conn.Close()
+			sess.conn = nil
+		} else {
+			sess.stopDraining()
+		}
+		err := writePacket(conn, &Response{Pid: sess.proc.Pid(), Jailer: sess.spec}, sess.files()...)
+		if err != nil {
+			sess.startDraining()
+			sess.lock.Unlock()
+			log.Warnf("Unable to hand over the jailer of %s: %s", id, err.Error())
+			continue
+		}
+		//the new helper has its own copies of the stdio now
+		sess.handedOver = true
+		closeFiles(sess.files())
+		sess.lock.Unlock()
+		log.Infof("Handed over the jailer of %s", id)
+	}
+	s.lock.Unlock()
+	s.Close()
+}
+
+// Listen creates the socket - it is only usable by its owner and group which is set to gid so the daemon can connect.
+// A gid of -1 leaves the group as it is.
+func (s *Server) Listen(socketPath string, gid int) error {
+	os.Remove(socketPath)
+	listener, err := net.ListenUnix("unixpacket", &net.UnixAddr{Name: socketPath, Net: "unixpacket"})
+	if err != nil {
+		return err
+	}
+	if err := os.Chown(socketPath, -1, gid); err != nil {
+		listener.Close()
+		return err
+	}
+	if err := os.Chmod(socketPath, 0660); err != nil {
+		listener.Close()
+		return err
+	}
+	s.listener = listener
+	return nil
+}
+
+func (s *Server) Serve() error {
+	if s.listener == nil {
+		return errors.New("The helper isnt listening")
+	}
+	for {
+		conn, err := s.listener.AcceptUnix()
+		if err != nil {
+			s.lock.Lock()
+			closed := s.closed
+			s.lock.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) Close() error {
+	if s.listener == nil {
+		return nil
+	}
+	//a helper taking over closes it too
+	s.lock.Lock()
+	s.closed = true
+	s.lock.Unlock()
+	return s.listener.Close()
+}
+
+func (s *Server) authorise(conn *net.UnixConn) error {
+	cred, err := peercred.Get(conn)
+	if err != nil {
+		return err
+	}
+	if cred.Uid != 0 && int(cred.Uid) != s.allowedUID {
+		return fmt.Errorf("uid %d is not allowed to use the privileged helper", cred.Uid)
+	}
+	return nil
+}
+
+func (s *Server) handle(conn *net.UnixConn) {
+	var req Request
+	if err := s.authorise(conn); err != nil {
+		log.Warnf("Rejected privileged helper connection: %s", err.Error())
+		conn.Close()
+		return
+	}
+	files, err := readPacket(conn, &req)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	closeFiles(files)
+	if req.Op == OpPing {
+		defer conn.Close()
+		writePacket(conn, &Response{Version: s.version})
+		return
+	}
+	if req.Op == OpHandOver {
+		s.handOver(conn)
+		return
+	}
+	if req.Op == OpStartJailer || req.Op == OpAttachJailer {
+		sess, err := s.jailerSession(&req)
+		if err != nil {
+			log.Warnf("Privileged helper %s failed: %s", req.Op, err.Error())
+			writePacket(conn, &Response{Error: err.Error()})
+			conn.Close()
+			return
+		}
+		sess.attach(conn)
+		return
+	}
+	defer conn.Close()
+	if err := s.do(&req); err != nil {
+		log.Warnf("Privileged helper %s failed: %s", req.Op, err.Error())
+		writePacket(conn, &Response{Error: err.Error()})
+		return
+	}
+	writePacket(conn, &Response{})
+}
+
+func (s *Server) do(req *Request) error {
+	switch req.Op {
+	case OpChown:
+		return s.local.Chown(req.UID, req.GID, req.Recursive, req.Paths...)
+	case OpChmod:
+		return s.local.Chmod(os.FileMode(req.Mode), req.Recursive, req.Paths...)
+	case OpNbdConnect:
+		return s.local.NbdConnect(req.Device, req.Format, req.Path)
+	case OpNbdDisconnect:
+		return s.local.NbdDisconnect(req.Device)
+	case OpPartprobe:
+		return s.local.Partprobe(req.Device)
+	case OpQemuImgConvert:
+		return s.local.QemuImgConvert(req.Source, req.Dest, req.Format)
+	case OpCreateTap:
+		return s.local.CreateTap(req.Name)
+	case OpDeleteTap:
+		return s.local.DeleteTap(req.Name)
+	case OpCgroupMkdir:
+		return s.local.CgroupMkdir(req.Path)
+	case OpCgroupRmdir:
+		return s.local.CgroupRmdir(req.Path)
+	case OpCgroupWrite:
+		return s.local.CgroupWrite(req.Path, req.Value)
+	default:
+		return fmt.Errorf("Unknown operation %s", req.Op)
+	}
+}
+
+// jailerSession starts a jailer or finds the one to attach to - a jailer started by a helper before this one is
+// adopted without its stdio
+func (s *Server) jailerSession(req *Request) (*jailerSession, error) {
+	if req.Jailer == nil {
+		return nil, errors.New("No jailer was given")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	sess, ok := s.sessions[req.Jailer.ID]
+	var proc Process
+	var err error
+	if req.Op == OpStartJailer {
+		if ok {
+			return nil, fmt.Errorf("The jailer of %s is already running", req.Jailer.ID)
+		}
+		if proc, err = s.local.StartJailer(req.Jailer); err != nil {
+			return nil, err
+		}
+		log.Infof("Started jailer for %s with pid %d", req.Jailer.ID, proc.Pid())
+	} else if ok {
+		if sess.proc.Pid() != req.Pid {
+			return nil, fmt.Errorf("The jailer of %s is running as %d not %d", req.Jailer.ID, sess.proc.Pid(), req.Pid)
+		}
+		return sess, nil
+	} else {
+		if proc, err = s.local.AttachJailer(req.Jailer, req.Pid); err != nil {
+			return nil, err
+		}
+		log.Infof("Adopted jailer for %s with pid %d", req.Jailer.ID, proc.Pid())
+	}
+	sess = &jailerSession{
+		id:   req.Jailer.ID,
+		spec: req.Jailer,
+		proc: proc,
+	}
+	s.sessions[sess.id] = sess
+	go s.waitJailer(sess)
+	return sess, nil
+}
+
+// waitJailer sends the exit status of the jailer to the daemon attached to it and forgets it
+func (s *Server) waitJailer(sess *jailerSession) {
+	sess.proc.Wait()
+	s.lock.Lock()
+	if s.sessions[sess.id] == sess {
+		delete(s.sessions, sess.id)
+	}
+	s.lock.Unlock()
+	sess.lock.Lock()
+	defer sess.lock.Unlock()
+	sess.exited = true
+	if sess.handedOver {
+		return
+	}
+	if sess.conn != nil {
+		writePacket(sess.conn, &Response{Exit: sess.proc.ExitStatus()})
+		sess.conn.Close()
+		sess.conn = nil
+	}
+	closeFiles(sess.files())
+}
+
+// jailerSession is a jailer the helper looks after - it outlives the connection of the daemon that started it so a
+// daemon can attach to it again after a restart
+type jailerSession struct {
+	id       string
+	spec     *JailerSpec
+	proc     Process
+	lock     sync.Mutex
+	conn     *net.UnixConn
+	exited   bool
+	draining sync.WaitGroup
+	//the jailer has been handed over to another helper which looks after it from now on
+	handedOver bool
+}
+
+func (sess *jailerSession) files() []*os.File {
+	files := []*os.File{}
+	for _, file := range []*os.File{sess.proc.Console(), sess.proc.Stderr()} {
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// attach sends the pid and stdio of the jailer on conn and then keeps it for signalling the jailer until the daemon
+// goes away - only one daemon is attached at a time
+func (sess *jailerSession) attach(conn *net.UnixConn) {
+	sess.lock.Lock()
+	if sess.exited {
+		sess.lock.Unlock()
+		writePacket(conn, &Response{Error: fmt.Sprintf("The jailer of %s has exited", sess.id)})
+		conn.Close()
+		return
+	}
+	if sess.handedOver {
+		sess.lock.Unlock()
+		writePacket(conn, &Response{Error: fmt.Sprintf("The jailer of %s has been handed over to another helper", sess.id)})
+		conn.Close()
+		return
+	}
+	if sess.conn != nil {
+		sess.conn.Close()
+		sess.conn = nil
+	} else {
+		sess.stopDraining()
+	}
+	if err := writePacket(conn, &Response{Pid: sess.proc.Pid()}, sess.files()...); err != nil {
+		sess.startDraining()
+		sess.lock.Unlock()
+		conn.Close()
+		return
+	}
+	sess.conn = conn
+	sess.lock.Unlock()
+	for {
+		var req Request
+		files, err := readPacket(conn, &req)
+		closeFiles(files)
+		if err != nil {
+			break
+		}
+		if req.Op == OpSignal {
+			sess.proc.Signal(syscall.Signal(req.Signal))
+		}
+	}
+	sess.lock.Lock()
+	defer sess.lock.Unlock()
+	if sess.conn == conn {
+		log.Infof("Daemon detached from the jailer of %s", sess.id)
+		sess.conn = nil
+		conn.Close()
+		sess.startDraining()
+	}
+}
+
+// startDraining reads the output of the jailer while no daemon is attached so it doesnt block on a full pty or pipe
+func (sess *jailerSession) startDraining() {
+	for _, file := range sess.files() {
+		sess.draining.Add(1)
+		go func(file *os.File) {
+			defer sess.draining.Done()
+			buf := make([]byte, 4096)
+			for {
+				if _, err := file.Read(buf); err != nil {
+					return
+				}
+			}
+		}(file)
+	}
+}
+
+// stopDraining interrupts the reads of startDraining so the output goes to the daemon attaching
+func (sess *jailerSession) stopDraining() {
+	files := sess.files()
+	for _, file := range files {
+		file.SetReadDeadline(time.Now())
+	}
+	sess.draining.Wait()
+	for _, file := range files {
+		file.SetReadDeadline(time.Time{})
+	}
+}
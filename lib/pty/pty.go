@@ -0,0 +1,98 @@
+package pty
+
+import (
+	"os"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// Open allocates a new pseudo terminal pair - the slave end is put in raw mode so the output of whatever runs on it
+// is passed through untouched
+func Open() (*os.File, *os.File, error) {
+	ptm, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var index int
+	err = control(ptm, func(fd int) error {
+		if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+			return err
+		}
+		index, err = unix.IoctlGetInt(fd, unix.TIOCGPTN)
+		return err
+	})
+	if err != nil {
+		ptm.Close()
+		return nil, nil, err
+	}
+	pts, err := os.OpenFile("/dev/pts/"+strconv.Itoa(index), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		ptm.Close()
+		return nil, nil, err
+	}
+	if err := MakeRaw(pts); err != nil {
+		ptm.Close()
+		pts.Close()
+		return nil, nil, err
+	}
+	return ptm, pts, nil
+}
+
+// control runs fn with the descriptor of tty - Fd isnt used as it puts the file in to blocking mode which stops read
+// deadlines working on it (and on any copies of it passed to other processes)
+func control(tty *os.File, fn func(fd int) error) error {
+	raw, err := tty.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var fnErr error
+	if err := raw.Control(func(fd uintptr) {
+		fnErr = fn(int(fd))
+	}); err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// MakeRaw puts a terminal in raw mode (as cfmakeraw does)
+func MakeRaw(tty *os.File) error {
+	return control(tty, func(fd int) error {
+		termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+		if err != nil {
+			return err
+		}
+		termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+		termios.Oflag &^= unix.OPOST
+		termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+		termios.Cflag &^= unix.CSIZE | unix.PARENB
+		termios.Cflag |= unix.CS8
+		termios.Cc[unix.VMIN] = 1
+		termios.Cc[unix.VTIME] = 0
+		return unix.IoctlSetTermios(fd, unix.TCSETS, termios)
+	})
+}
+
+// Setsize sets the window size of a terminal - processes on it are sent SIGWINCH
+func Setsize(tty *os.File, cols int, rows int) error {
+	return control(tty, func(fd int) error {
+		return unix.IoctlSetWinsize(fd, unix.TIOCSWINSZ, &unix.Winsize{
+			Col: uint16(cols),
+			Row: uint16(rows),
+		})
+	})
+}
+
+// Getsize returns the window size of a terminal as columns and rows
+func Getsize(tty *os.File) (int, int, error) {
+	var ws *unix.Winsize
+	err := control(tty, func(fd int) error {
+		var err error
+		ws, err = unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
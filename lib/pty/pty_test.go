@@ -0,0 +1,36 @@
+package pty
+
+import (
+	"testing"
+)
+
+func TestOpenAndResize(t *testing.T) {
+	ptm, pts, err := Open()
+	if err != nil {
+		t.Skip("Unable to allocate a pty: " + err.Error())
+	}
+	defer ptm.Close()
+	defer pts.Close()
+	if err := Setsize(ptm, 132, 43); err != nil {
+		t.Fatal(err)
+	}
+	cols, rows, err := Getsize(pts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cols != 132 || rows != 43 {
+		t.Errorf("expected the slave to see the new size got %dx%d", cols, rows)
+	}
+	//raw mode means what is written to the master isnt echoed or translated
+	if _, err := ptm.Write([]byte("hi\r")); err != nil {
+		t.Fatal(err)
+	}
+	buff := make([]byte, 16)
+	n, err := pts.Read(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buff[:n]) != "hi\r" {
+		t.Errorf("expected input to be passed through untouched got %q", buff[:n])
+	}
+}
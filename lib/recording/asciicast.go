@@ -0,0 +1,249 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	OutputEvent = "o"
+	InputEvent  = "i"
+	ResizeEvent = "r"
+)
+
+// Header is the first line of an asciicast v2 file - vm_id and user are our own additions which players ignore
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	VmID      string            `json:"vm_id,omitempty"`
+	User      string            `json:"user,omitempty"`
+}
+
+// Event is a single line after the header: [time, type, data]
+type Event struct {
+	Time float64
+	Type string
+	Data string
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Time, e.Type, e.Data})
+}
+
+func (e *Event) UnmarshalJSON(b []byte) error {
+	raw := []interface{}{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return errors.New("An asciicast event must have 3 elements")
+	}
+	ts, ok := raw[0].(float64)
+	if !ok {
+		return errors.New("The time of an asciicast event must be a number")
+	}
+	kind, ok := raw[1].(string)
+	if !ok {
+		return errors.New("The type of an asciicast event must be a string")
+	}
+	data, ok := raw[2].(string)
+	if !ok {
+		return errors.New("The data of an asciicast event must be a string")
+	}
+	e.Time, e.Type, e.Data = ts, kind, data
+	return nil
+}
+
+// Recorder writes a console session to an asciicast v2 file as it happens
+type Recorder struct {
+	lock    sync.Mutex
+	fd      *os.File
+	writer  *bufio.Writer
+	started time.Time
+	//incomplete utf-8 sequences held until the rest arrives, by event type
+	partial map[string][]byte
+}
+
+func Create(path string, header Header) (*Recorder, error) {
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	header.Version = 2
+	if header.Timestamp == 0 {
+		header.Timestamp = started.Unix()
+	}
+	rec := &Recorder{
+		fd:      fd,
+		writer:  bufio.NewWriter(fd),
+		started: started,
+		partial: map[string][]byte{},
+	}
+	if err := rec.writeLine(header); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (rec *Recorder) writeLine(value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, err := rec.writer.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	//flushed on every event so a recording is complete up to the point the daemon died
+	return rec.writer.Flush()
+}
+
+func (rec *Recorder) record(kind string, p []byte) error {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	if rec.fd == nil {
+		return os.ErrClosed
+	}
+	data, rest := splitUTF8(append(rec.partial[kind], p...))
+	rec.partial[kind] = rest
+	if len(data) == 0 {
+		return nil
+	}
+	return rec.writeLine(Event{
+		Time: time.Since(rec.started).Seconds(),
+		Type: kind,
+		Data: string(data),
+	})
+}
+
+func (rec *Recorder) Output(p []byte) error {
+	return rec.record(OutputEvent, p)
+}
+
+func (rec *Recorder) Input(p []byte) error {
+	return rec.record(InputEvent, p)
+}
+
+func (rec *Recorder) Resize(width int, height int) error {
+	return rec.record(ResizeEvent, []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+func (rec *Recorder) Close() error {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	if rec.fd == nil {
+		return nil
+	}
+	rec.writer.Flush()
+	err := rec.fd.Close()
+	rec.fd = nil
+	return err
+}
+
+// splitUTF8 holds back an incomplete utf-8 sequence at the end of p - output is read in chunks which can split a
+// character and asciicast data has to be valid utf-8
+func splitUTF8(p []byte) ([]byte, []byte) {
+	for back := 1; back <= utf8.UTFMax && back <= len(p); back++ {
+		index := len(p) - back
+		if !utf8.RuneStart(p[index]) {
+			continue
+		}
+		if !utf8.FullRune(p[index:]) {
+			return p[:index], append([]byte(nil), p[index:]...)
+		}
+		break
+	}
+	return p, nil
+}
+
+// Parse reads an asciicast v2 recording
+func Parse(rdr io.Reader) (*Header, []Event, error) {
+	scanner := bufio.NewScanner(rdr)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("The recording is empty")
+	}
+	header, err := parseHeader(scanner.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	events := []Event{}
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		event := Event{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+	return header, events, scanner.Err()
+}
+
+// ReadHeader reads only the header line of an asciicast v2 recording
+func ReadHeader(rdr io.Reader) (*Header, error) {
+	line, err := bufio.NewReader(rdr).ReadBytes('\n')
+	if err == io.EOF && len(line) == 0 {
+		return nil, errors.New("The recording is empty")
+	} else if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return parseHeader(line)
+}
+
+func parseHeader(line []byte) (*Header, error) {
+	header := &Header{}
+	if err := json.Unmarshal(line, header); err != nil {
+		return nil, err
+	}
+	if header.Version != 2 {
+		return nil, fmt.Errorf("Unsupported asciicast version %d", header.Version)
+	}
+	return header, nil
+}
+
+// Play writes the output of a recording to out with the original timing divided by speed - pauses are capped at
+// maxWait when it is set
+func Play(events []Event, out io.Writer, speed float64, maxWait time.Duration, stop <-chan struct{}) error {
+	if speed <= 0 {
+		speed = 1
+	}
+	last := 0.0
+	for _, event := range events {
+		wait := time.Duration((event.Time - last) / speed * float64(time.Second))
+		if maxWait > 0 && wait > maxWait {
+			wait = maxWait
+		}
+		last = event.Time
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-stop:
+				return nil
+			}
+		}
+		if event.Type != OutputEvent {
+			continue
+		}
+		if _, err := io.WriteString(out, event.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
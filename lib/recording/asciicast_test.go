@@ -0,0 +1,189 @@
+package recording
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeConsole struct {
+	output io.Reader
+	input  bytes.Buffer
+}
+
+func (fc *fakeConsole) Read(p []byte) (int, error) {
+	return fc.output.Read(p)
+}
+
+func (fc *fakeConsole) Write(p []byte) (int, error) {
+	return fc.input.Write(p)
+}
+
+func (fc *fakeConsole) Close() error {
+	return nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	root, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	store, err := NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, rec, err := store.New("0f1d1e2a-7b7c-4b8e-9a35-2a7d1c3c9e10", "alice", "test console", 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the euro sign is split across two reads
+	euro := []byte("€")
+	console := &fakeConsole{output: io.MultiReader(strings.NewReader("login: "), bytes.NewReader(euro[:1]), bytes.NewReader(append(euro[1:], '\n')))}
+	wrapped := Wrap(console, rec)
+	buff := make([]byte, 16)
+	output := ""
+	for {
+		n, err := wrapped.Read(buff)
+		output += string(buff[:n])
+		if err != nil {
+			break
+		}
+	}
+	wrapped.Write([]byte("root\r"))
+	wrapped.Close()
+	if console.input.String() != "root\r" {
+		t.Errorf("expected input to be passed to the console got %q", console.input.String())
+	}
+
+	recordings, err := store.List("0f1d1e2a-7b7c-4b8e-9a35-2a7d1c3c9e10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) != 1 || recordings[0].ID != id || recordings[0].User != "alice" || recordings[0].Width != 80 {
+		t.Fatalf("unexpected recordings %+v", recordings)
+	}
+	if others, _ := store.List("another-vm"); len(others) != 0 {
+		t.Error("expected recordings to be filtered by vm")
+	}
+	if _, err := store.Get("../../etc/passwd"); err == nil {
+		t.Error("expected an invalid id to be rejected")
+	}
+	found, err := store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := store.Open(found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	header, events, err := Parse(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.VmID != "0f1d1e2a-7b7c-4b8e-9a35-2a7d1c3c9e10" {
+		t.Errorf("unexpected header %+v", header)
+	}
+	kinds := ""
+	for _, event := range events {
+		kinds += event.Type
+	}
+	if kinds != "ooi" {
+		t.Errorf("expected the split character to be held back until it was complete got events %q", kinds)
+	}
+	replayed := &bytes.Buffer{}
+	if err := Play(events, replayed, 1000, time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	if replayed.String() != output || output != "login: €\n" {
+		t.Errorf("expected the output to be replayed got %q from %q", replayed.String(), output)
+	}
+}
+
+func TestParseRejectsOtherVersions(t *testing.T) {
+	if _, _, err := Parse(strings.NewReader(`{"version": 1, "width": 80, "height": 24}` + "\n")); err == nil {
+		t.Error("expected version 1 recordings to be rejected")
+	}
+	if _, _, err := Parse(strings.NewReader(`{"version": 2}` + "\n" + `[0.5, "o"]` + "\n")); err == nil {
+		t.Error("expected a malformed event to be rejected")
+	}
+}
+
+func TestResizeIsRecorded(t *testing.T) {
+	root, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	store, err := NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, rec, err := store.New("0f1d1e2a-7b7c-4b8e-9a35-2a7d1c3c9e10", "alice", "test console", 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped := Wrap(&fakeConsole{output: strings.NewReader("")}, rec)
+	if err := wrapped.(interface{ Resize(int, int) error }).Resize(132, 43); err != nil {
+		t.Fatal(err)
+	}
+	wrapped.Close()
+	found, err := store.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := store.Open(found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	_, events, err := Parse(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Type != ResizeEvent || events[0].Data != "132x43" {
+		t.Errorf("expected a resize event got %+v", events)
+	}
+}
+
+func TestListReadsHeaderAndLastEvent(t *testing.T) {
+	root, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	store, err := NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the events in between arent read when listing so one that doesnt decode doesnt hide the recording
+	cast := `{"version": 2, "width": 80, "height": 24, "timestamp": 1500000000, "vm_id": "vm"}` + "\n" +
+		`[0.5, "o", "login: "]` + "\n" +
+		`not an event` + "\n" +
+		`[2.25, "i", "root\r"]` + "\n"
+	if err := ioutil.WriteFile(root+"/0f1d1e2a-7b7c-4b8e-9a35-2a7d1c3c9e10.cast", []byte(cast), 0640); err != nil {
+		t.Fatal(err)
+	}
+	empty := `{"version": 2, "width": 80, "height": 24, "timestamp": 1400000000, "vm_id": "vm"}` + "\n"
+	if err := ioutil.WriteFile(root+"/5d3c2b1a-7b7c-4b8e-9a35-2a7d1c3c9e10.cast", []byte(empty), 0640); err != nil {
+		t.Fatal(err)
+	}
+	recordings, err := store.List("vm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) != 2 {
+		t.Fatalf("expected both recordings to be listed got %+v", recordings)
+	}
+	if recordings[0].Duration != 2.25 || recordings[0].Width != 80 {
+		t.Errorf("expected the duration to be the time of the last event got %+v", recordings[0])
+	}
+	if recordings[1].Duration != 0 {
+		t.Errorf("expected a recording without events to have no duration got %+v", recordings[1])
+	}
+}
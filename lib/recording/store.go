@@ -0,0 +1,200 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const castExtension = ".cast"
+
+// tailSize is how much of the end of a recording is read to find the time of its last event
+const tailSize = 64 * 1024
+
+var ErrInvalidID = errors.New("Invalid recording id")
+
+// Recording describes a recording kept in the store
+type Recording struct {
+	ID        string
+	Path      string
+	VmID      string
+	User      string
+	Title     string
+	Width     int
+	Height    int
+	StartedAt time.Time
+	Duration  float64
+	Size      int64
+}
+
+// Store keeps console recordings in a single directory named by their id
+type Store struct {
+	root string
+}
+
+func NewStore(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0750); err != nil {
+		return nil, err
+	}
+	return &Store{root: root}, nil
+}
+
+// New starts recording a console session
+func (st *Store) New(vmID string, user string, title string, width int, height int) (string, *Recorder, error) {
+	id := uuid.NewV4().String()
+	rec, err := Create(filepath.Join(st.root, id+castExtension), Header{
+		Width:  width,
+		Height: height,
+		Title:  title,
+		VmID:   vmID,
+		User:   user,
+		Env: map[string]string{
+			"TERM": "xterm",
+		},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return id, rec, nil
+}
+
+// List returns the recordings of a vm (or every recording when vmID is empty) newest first
+func (st *Store) List(vmID string) ([]*Recording, error) {
+	files, err := filepath.Glob(filepath.Join(st.root, "*"+castExtension))
+	if err != nil {
+		return nil, err
+	}
+	recordings := []*Recording{}
+	for _, file := range files {
+		rec, err := st.load(file)
+		if err != nil {
+			//a recording that cant be read shouldnt hide the rest
+			continue
+		}
+		if vmID != "" && rec.VmID != vmID {
+			continue
+		}
+		recordings = append(recordings, rec)
+	}
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].StartedAt.After(recordings[j].StartedAt)
+	})
+	return recordings, nil
+}
+
+func (st *Store) Get(id string) (*Recording, error) {
+	if _, err := uuid.FromString(id); err != nil {
+		return nil, ErrInvalidID
+	}
+	path := filepath.Join(st.root, id+castExtension)
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.New("Unable to find recording with id " + id)
+	}
+	return st.load(path)
+}
+
+// Open returns a reader for the asciicast file of a recording
+func (st *Store) Open(rec *Recording) (io.ReadCloser, error) {
+	return os.Open(rec.Path)
+}
+
+func (st *Store) load(path string) (*Recording, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	//only the header and the last event are read - listing shouldnt parse every event of every recording
+	header, err := ReadHeader(fd)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Recording{
+		ID:        strings.TrimSuffix(filepath.Base(path), castExtension),
+		Path:      path,
+		VmID:      header.VmID,
+		User:      header.User,
+		Title:     header.Title,
+		Width:     header.Width,
+		Height:    header.Height,
+		StartedAt: time.Unix(header.Timestamp, 0),
+		Size:      info.Size(),
+	}
+	if last, err := lastEvent(fd, info.Size()); err == nil {
+		rec.Duration = last.Time
+	}
+	return rec, nil
+}
+
+// lastEvent decodes the last line of a recording from its tail - a recording without events has its header as the
+// last line which doesnt decode as an event
+func lastEvent(fd *os.File, size int64) (*Event, error) {
+	offset := size - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, size-offset)
+	if _, err := fd.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	lines := bytes.Split(bytes.TrimRight(tail, "\n"), []byte("\n"))
+	event := &Event{}
+	if err := json.Unmarshal(lines[len(lines)-1], event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Wrap records everything read from a console as output and everything written to it as input - closing it
+// closes the recording too
+func Wrap(console io.ReadWriteCloser, rec *Recorder) io.ReadWriteCloser {
+	return &recordedConsole{console: console, rec: rec}
+}
+
+type recordedConsole struct {
+	console io.ReadWriteCloser
+	rec     *Recorder
+}
+
+func (rc *recordedConsole) Read(p []byte) (int, error) {
+	n, err := rc.console.Read(p)
+	if n > 0 {
+		rc.rec.Output(p[:n])
+	}
+	return n, err
+}
+
+func (rc *recordedConsole) Write(p []byte) (int, error) {
+	n, err := rc.console.Write(p)
+	if n > 0 {
+		rc.rec.Input(p[:n])
+	}
+	return n, err
+}
+
+// Resize records the new size and passes it on when the console can be resized
+func (rc *recordedConsole) Resize(width int, height int) error {
+	rc.rec.Resize(width, height)
+	if resizer, ok := rc.console.(interface{ Resize(int, int) error }); ok {
+		return resizer.Resize(width, height)
+	}
+	return nil
+}
+
+func (rc *recordedConsole) Close() error {
+	err := rc.console.Close()
+	rc.rec.Close()
+	return err
+}
@@ -0,0 +1,300 @@
+package sshgateway
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/768bit/promethium/lib/config"
+	"golang.org/x/crypto/ssh"
+)
+
+// ConsoleOpener attaches an operator to the console of the vm named - the console is closed when the session ends
+type ConsoleOpener func(vm string, user string, cols int, rows int) (io.ReadWriteCloser, error)
+
+// consoleResizer matches consoles that can change the size of their terminal
+type consoleResizer interface {
+	Resize(cols int, rows int) error
+}
+
+const operatorExtension = "promethium-operator"
+
+// Gateway is an ssh server where the ssh user is the vm to attach to and operators log in with their own keys
+type Gateway struct {
+	config *ssh.ServerConfig
+	open   ConsoleOpener
+
+	lock     sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]bool
+	closed   bool
+}
+
+// NewGateway creates a gateway that lets in the operators given - keyed by name
+func NewGateway(hostKey ssh.Signer, operators map[string][]ssh.PublicKey, open ConsoleOpener) *Gateway {
+	gw := &Gateway{
+		open:  open,
+		conns: map[net.Conn]bool{},
+	}
+	gw.config = &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			marshalled := key.Marshal()
+			for name, keys := range operators {
+				for _, authorized := range keys {
+					if bytes.Equal(authorized.Marshal(), marshalled) {
+						return &ssh.Permissions{Extensions: map[string]string{operatorExtension: name}}, nil
+					}
+				}
+			}
+			return nil, fmt.Errorf("Unknown public key for %s", meta.User())
+		},
+	}
+	gw.config.AddHostKey(hostKey)
+	return gw
+}
+
+// Serve accepts connections until the listener is closed
+func (gw *Gateway) Serve(listener net.Listener) error {
+	gw.lock.Lock()
+	if gw.closed {
+		gw.lock.Unlock()
+		return errors.New("The ssh gateway has been closed")
+	}
+	gw.listener = listener
+	gw.lock.Unlock()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			gw.lock.Lock()
+			closed := gw.closed
+			gw.lock.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go gw.handleConn(conn)
+	}
+}
+
+// ListenAndServe listens on the address given and serves the gateway there
+func (gw *Gateway) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return gw.Serve(listener)
+}
+
+// Close stops accepting connections and disconnects every session
+func (gw *Gateway) Close() error {
+	gw.lock.Lock()
+	defer gw.lock.Unlock()
+	gw.closed = true
+	for conn := range gw.conns {
+		conn.Close()
+	}
+	if gw.listener != nil {
+		return gw.listener.Close()
+	}
+	return nil
+}
+
+func (gw *Gateway) handleConn(conn net.Conn) {
+	gw.lock.Lock()
+	gw.conns[conn] = true
+	gw.lock.Unlock()
+	defer func() {
+		gw.lock.Lock()
+		delete(gw.conns, conn)
+		gw.lock.Unlock()
+		conn.Close()
+	}()
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, gw.config)
+	if err != nil {
+		log.Printf("SSH handshake with %s failed: %s", conn.RemoteAddr(), err.Error())
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+	operator := sshConn.Permissions.Extensions[operatorExtension]
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "Only sessions are supported")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go gw.handleSession(sshConn.User(), operator, channel, channelRequests)
+	}
+}
+
+type ptyRequest struct {
+	Term     string
+	Cols     uint32
+	Rows     uint32
+	Width    uint32
+	Height   uint32
+	Modelist string
+}
+
+type windowChangeRequest struct {
+	Cols   uint32
+	Rows   uint32
+	Width  uint32
+	Height uint32
+}
+
+// handleSession waits for the client to ask for a shell then joins the session to the console of the vm - the
+// session ends when either side goes away
+func (gw *Gateway) handleSession(vm string, operator string, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	cols, rows := 80, 24
+	var console io.ReadWriteCloser
+	done := make(chan struct{})
+	for {
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				if console != nil {
+					console.Close()
+				}
+				return
+			}
+			switch req.Type {
+			case "pty-req":
+				ptyReq := ptyRequest{}
+				if err := ssh.Unmarshal(req.Payload, &ptyReq); err == nil && ptyReq.Cols > 0 && ptyReq.Rows > 0 {
+					cols, rows = int(ptyReq.Cols), int(ptyReq.Rows)
+				}
+				req.Reply(true, nil)
+			case "window-change":
+				change := windowChangeRequest{}
+				if err := ssh.Unmarshal(req.Payload, &change); err != nil {
+					continue
+				}
+				cols, rows = int(change.Cols), int(change.Rows)
+				if resizer, ok := console.(consoleResizer); ok {
+					resizer.Resize(cols, rows)
+				}
+			case "shell":
+				if console != nil {
+					req.Reply(false, nil)
+					continue
+				}
+				var err error
+				console, err = gw.open(vm, operator, cols, rows)
+				if err != nil {
+					req.Reply(true, nil)
+					fmt.Fprintf(channel.Stderr(), "Unable to attach to the console of %s: %s\r\n", vm, err.Error())
+					sendExitStatus(channel, 1)
+					return
+				}
+				req.Reply(true, nil)
+				log.Printf("%s attached to the console of %s over ssh", operator, vm)
+				go func() {
+					io.Copy(channel, console)
+					close(done)
+				}()
+				go io.Copy(console, channel)
+			default:
+				//exec, subsystems, env and forwarding arent supported - the console is the only thing on offer
+				req.Reply(false, nil)
+			}
+		case <-done:
+			//the console went away - most likely the vm stopped
+			console.Close()
+			sendExitStatus(channel, 0)
+			return
+		}
+	}
+}
+
+func sendExitStatus(channel ssh.Channel, status uint32) {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, status)
+	channel.SendRequest("exit-status", false, payload)
+}
+
+// LoadHostKey reads the host key of the gateway generating a new one if it doesnt exist yet
+func LoadHostKey(path string) (ssh.Signer, error) {
+	if pemBytes, err := ioutil.ReadFile(path); err == nil {
+		return ssh.ParsePrivateKey(pemBytes)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	if err := ioutil.WriteFile(path, pemBytes, 0600); err != nil {
+		return nil, err
+	}
+	return ssh.NewSignerFromKey(key)
+}
+
+// LoadOperators reads the authorized keys of every operator in the config
+func LoadOperators(users []*config.SSHUserConfig) (map[string][]ssh.PublicKey, error) {
+	operators := map[string][]ssh.PublicKey{}
+	for _, user := range users {
+		if user == nil || user.Name == "" {
+			continue
+		}
+		authorized := []byte{}
+		for _, line := range user.AuthorizedKeys {
+			authorized = append(authorized, []byte(line+"\n")...)
+		}
+		if user.AuthorizedKeysFile != "" {
+			fileBytes, err := ioutil.ReadFile(user.AuthorizedKeysFile)
+			if err != nil {
+				return nil, err
+			}
+			authorized = append(authorized, fileBytes...)
+		}
+		keys, err := parseAuthorizedKeys(authorized)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the authorized keys of %s: %s", user.Name, err.Error())
+		}
+		operators[user.Name] = append(operators[user.Name], keys...)
+	}
+	return operators, nil
+}
+
+func parseAuthorizedKeys(authorized []byte) ([]ssh.PublicKey, error) {
+	keys := []ssh.PublicKey{}
+	for _, line := range bytes.Split(authorized, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,234 @@
+package sshgateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"golang.org/x/crypto/ssh"
+)
+
+type fakeConsole struct {
+	lock    sync.Mutex
+	output  *io.PipeReader
+	guest   *io.PipeWriter
+	input   chan []byte
+	resizes [][2]int
+}
+
+func newFakeConsole() *fakeConsole {
+	rd, wr := io.Pipe()
+	return &fakeConsole{output: rd, guest: wr, input: make(chan []byte, 16)}
+}
+
+func (fc *fakeConsole) Read(p []byte) (int, error) {
+	return fc.output.Read(p)
+}
+
+func (fc *fakeConsole) Write(p []byte) (int, error) {
+	fc.input <- append([]byte(nil), p...)
+	return len(p), nil
+}
+
+func (fc *fakeConsole) Close() error {
+	return fc.output.Close()
+}
+
+func (fc *fakeConsole) Resize(cols int, rows int) error {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.resizes = append(fc.resizes, [2]int{cols, rows})
+	return nil
+}
+
+func (fc *fakeConsole) lastSize() [2]int {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	if len(fc.resizes) == 0 {
+		return [2]int{}
+	}
+	return fc.resizes[len(fc.resizes)-1]
+}
+
+func newSigner(t *testing.T) ssh.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+type openedConsole struct {
+	vm, user   string
+	cols, rows int
+}
+
+func startGateway(t *testing.T, operator ssh.Signer, console *fakeConsole) (string, chan openedConsole, func()) {
+	opened := make(chan openedConsole, 1)
+	gw := NewGateway(newSigner(t), map[string][]ssh.PublicKey{"alice": {operator.PublicKey()}}, func(vm string, user string, cols int, rows int) (io.ReadWriteCloser, error) {
+		if vm != "web-1" {
+			return nil, errors.New("Unable to find instance with that id or name")
+		}
+		opened <- openedConsole{vm, user, cols, rows}
+		console.Resize(cols, rows)
+		return console, nil
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go gw.Serve(listener)
+	return listener.Addr().String(), opened, func() { gw.Close() }
+}
+
+func dial(address string, vm string, signer ssh.Signer) (*ssh.Client, error) {
+	return ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            vm,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         5 * time.Second,
+	})
+}
+
+func TestConsoleSession(t *testing.T) {
+	operator := newSigner(t)
+	console := newFakeConsole()
+	address, opened, stop := startGateway(t, operator, console)
+	defer stop()
+
+	client, err := dial(address, "web-1", operator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	stdin, _ := session.StdinPipe()
+	stdout, _ := session.StdoutPipe()
+	if err := session.RequestPty("xterm", 40, 120, ssh.TerminalModes{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case o := <-opened:
+		if o.user != "alice" || o.cols != 120 || o.rows != 40 {
+			t.Errorf("expected the console to be opened for alice at 120x40 got %+v", o)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("console was never opened")
+	}
+
+	go console.guest.Write([]byte("login: "))
+	buff := make([]byte, 7)
+	if _, err := io.ReadFull(stdout, buff); err != nil || string(buff) != "login: " {
+		t.Fatalf("expected the console output over ssh got %q %v", buff, err)
+	}
+	stdin.Write([]byte("root\r"))
+	select {
+	case in := <-console.input:
+		if string(in) != "root\r" {
+			t.Errorf("expected the input to reach the console got %q", in)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("input never reached the console")
+	}
+
+	if err := session.WindowChange(50, 160); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for console.lastSize() != [2]int{160, 50} {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the console to be resized to 160x50 got %v", console.lastSize())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	//the vm going away ends the session
+	console.guest.Close()
+	if err := session.Wait(); err != nil {
+		t.Errorf("expected a clean exit when the console closes got %v", err)
+	}
+}
+
+func TestUnknownVmAndKeys(t *testing.T) {
+	operator := newSigner(t)
+	address, _, stop := startGateway(t, operator, newFakeConsole())
+	defer stop()
+
+	if _, err := dial(address, "web-1", newSigner(t)); err == nil {
+		t.Error("expected an unknown key to be refused")
+	}
+
+	client, err := dial(address, "missing", operator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Wait(); err == nil {
+		t.Error("expected the session to fail for a vm that doesnt exist")
+	}
+}
+
+func TestLoadHostKeyAndOperators(t *testing.T) {
+	root, err := ioutil.TempDir("", "sshgateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	path := filepath.Join(root, "ssh", "host_key")
+	generated, err := LoadHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(generated.PublicKey().Marshal()) != string(loaded.PublicKey().Marshal()) {
+		t.Error("expected the generated host key to be kept")
+	}
+
+	keysFile := filepath.Join(root, "authorized_keys")
+	ioutil.WriteFile(keysFile, []byte("# bob's laptop\n"+string(ssh.MarshalAuthorizedKey(newSigner(t).PublicKey()))), 0600)
+	operators, err := LoadOperators([]*config.SSHUserConfig{
+		{Name: "alice", AuthorizedKeys: []string{string(ssh.MarshalAuthorizedKey(generated.PublicKey()))}},
+		{Name: "bob", AuthorizedKeysFile: keysFile},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(operators["alice"]) != 1 || len(operators["bob"]) != 1 {
+		t.Errorf("expected a key for alice and bob got %v", operators)
+	}
+	if _, err := LoadOperators([]*config.SSHUserConfig{{Name: "eve", AuthorizedKeys: []string{"not a key"}}}); err == nil {
+		t.Error("expected an invalid key to be rejected")
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/768bit/promethium/lib/common"
 	"github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/operations"
 	"github.com/768bit/vutils"
 )
 
@@ -17,15 +19,16 @@ import (
 //and uses the location /opt/promethium/storage/default-local
 
 type LocalFileStorage struct {
-	id            string
-	sm            *StorageManager
-	rootFolder    string
-	imagesFolder  string
-	imagesEnabled bool
-	disksFolder   string
-	disksEnabled  bool
-	kernelsFolder string
-	imagesCache   map[string]string
+	id              string
+	sm              *StorageManager
+	rootFolder      string
+	imagesFolder    string
+	imagesEnabled   bool
+	disksFolder     string
+	disksEnabled    bool
+	kernelsFolder   string
+	snapshotsFolder string
+	imagesCache     map[string]string
 }
 
 func getRootFolderFromConfig(config map[string]interface{}) (string, error) {
@@ -51,15 +54,17 @@ func LoadLocalFileStorage(sm *StorageManager, id string, config map[string]inter
 	imagesFolder := filepath.Join(rootFolder, "images")
 	disksFolder := filepath.Join(rootFolder, "disks")
 	kernelsFolder := filepath.Join(rootFolder, "kernels")
+	snapshotsFolder := filepath.Join(rootFolder, "snapshots")
 	//create the instance...
 	lfs := &LocalFileStorage{
-		id:            id,
-		sm:            sm,
-		rootFolder:    rootFolder,
-		disksFolder:   disksFolder,
-		imagesFolder:  imagesFolder,
-		imagesCache:   map[string]string{},
-		kernelsFolder: kernelsFolder,
+		id:              id,
+		sm:              sm,
+		rootFolder:      rootFolder,
+		disksFolder:     disksFolder,
+		imagesFolder:    imagesFolder,
+		imagesCache:     map[string]string{},
+		kernelsFolder:   kernelsFolder,
+		snapshotsFolder: snapshotsFolder,
 	}
 	if !vutils.Files.CheckPathExists(disksFolder) {
 		lfs.disksEnabled = false
@@ -86,6 +91,8 @@ func InitLocalFileStorage(id string, config map[string]interface{}) error {
 	vutils.Files.CreateDirIfNotExist(disksFolder)
 	kernelsFolder := filepath.Join(rootFolder, "kernels")
 	vutils.Files.CreateDirIfNotExist(kernelsFolder)
+	snapshotsFolder := filepath.Join(rootFolder, "snapshots")
+	vutils.Files.CreateDirIfNotExist(snapshotsFolder)
 	return nil
 }
 
@@ -260,6 +267,14 @@ func (lfs *LocalFileStorage) LookupPath(path string) (string, bool, error) {
 			return "", false, errors.New("The supplied path is invalid")
 		}
 		return filepath.Join(lfs.disksFolder, splitPath[1], splitPath[2]), false, nil
+	case "snapshots":
+		if !lfs.disksEnabled {
+			return "", false, errors.New("This storage target is not enabled for disk/kernel storage")
+		}
+		if len(splitPath) != 4 {
+			return "", false, errors.New("The supplied path is invalid")
+		}
+		return filepath.Join(lfs.snapshotsFolder, splitPath[1], splitPath[2], splitPath[3]), false, nil
 	}
 	return "", false, nil
 }
@@ -275,7 +290,7 @@ func (lfs *LocalFileStorage) WriteKernel(id string, source io.Reader) (string, e
 	_, err = io.Copy(f, source)
 	return newKernelPath, err
 }
-func (lfs *LocalFileStorage) WriteRootDisk(id string, source io.Reader, newSize int64, sourceIsRaw bool, growPart bool) (string, error) {
+func (lfs *LocalFileStorage) WriteRootDisk(op *operations.Operation, id string, source io.Reader, newSize int64, sourceIsRaw bool, growPart bool) (string, error) {
 	//output the qcow2 image somewhere (it came from an image), unless its raw, in which case we just pump it to dest...
 	newDiskPath := filepath.Join(lfs.disksFolder, id, "root.img")
 	vutils.Files.CreateDirIfNotExist(filepath.Join(lfs.disksFolder, id))
@@ -292,10 +307,10 @@ func (lfs *LocalFileStorage) WriteRootDisk(id string, source io.Reader, newSize
 			return newDiskPath, err
 		}
 		//
-		err = resizeRawImage(newDiskPath, newSize, growPart)
+		err = resizeRawImage(op, newDiskPath, newSize, growPart)
 		return newDiskPath, err
 	} else {
-		err := writeAndConvertQcow2(newDiskPath, source, newSize, false, growPart)
+		err := writeAndConvertQcow2(op, newDiskPath, source, newSize, false, growPart)
 		return newDiskPath, err
 	}
 	return "", nil
@@ -307,7 +322,75 @@ func (lfs *LocalFileStorage) WriteCloudInit(id string, source io.Reader) (string
 	return "", nil
 }
 
-func resizeRawImage(path string, newSize int64, growPart bool) error {
+func (lfs *LocalFileStorage) SnapshotPath(id string, snapshotID string) (string, error) {
+	//snapshots live with the disks so a vmm and its snapshots are always in the same target
+	if !lfs.disksEnabled {
+		return "", errors.New("This storage target is not enabled for disk/kernel storage")
+	}
+	snapshotPath := filepath.Join(lfs.snapshotsFolder, id, snapshotID)
+	if err := os.MkdirAll(snapshotPath, 0755); err != nil {
+		return "", err
+	}
+	return snapshotPath, nil
+}
+
+func (lfs *LocalFileStorage) DeleteSnapshot(id string, snapshotID string) error {
+	if id == "" || snapshotID == "" {
+		return errors.New("The supplied snapshot is invalid")
+	}
+	err := os.RemoveAll(filepath.Join(lfs.snapshotsFolder, id, snapshotID))
+	if err != nil {
+		return err
+	}
+	//tidy up the vmm folder if that was the last snapshot
+	os.Remove(filepath.Join(lfs.snapshotsFolder, id))
+	return nil
+}
+
+// DiskIDs returns the ids of the vmms that have disks in the storage target
+func (lfs *LocalFileStorage) DiskIDs() ([]string, error) {
+	if !lfs.disksEnabled {
+		return []string{}, nil
+	}
+	infos, err := ioutil.ReadDir(lfs.disksFolder)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, info := range infos {
+		if info.IsDir() {
+			ids = append(ids, info.Name())
+		}
+	}
+	return ids, nil
+}
+
+// DeleteDisks removes the disks, kernel and snapshots of a vmm
+func (lfs *LocalFileStorage) DeleteDisks(id string) error {
+	if id == "" || strings.ContainsAny(id, "/.") {
+		return errors.New("The supplied vmm id is invalid")
+	}
+	if err := os.RemoveAll(filepath.Join(lfs.disksFolder, id)); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(lfs.kernelsFolder, id+".elf")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(lfs.snapshotsFolder, id))
+}
+
+// Capacity returns the total and available bytes on the filesystem the storage target lives on
+func (lfs *LocalFileStorage) Capacity() (uint64, uint64, error) {
+	stat := syscall.Statfs_t{}
+	if err := syscall.Statfs(lfs.rootFolder, &stat); err != nil {
+		return 0, 0, err
+	}
+	return stat.Blocks * uint64(stat.Bsize), stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// resizeRawImage grows the disk to newSize as a step of op - the resize cant report how far through it is so only
+// the message of op moves
+func resizeRawImage(op *operations.Operation, path string, newSize int64, growPart bool) error {
 	qcimg, err := images.LoadQemuImage(path)
 	if err != nil {
 		return err
@@ -317,11 +400,16 @@ func resizeRawImage(path string, newSize int64, growPart bool) error {
 		//err
 	} else if nsu > qcimg.VirtualSize() {
 		//resize/expand
+		op.SetMessage("Resizing disk")
+		if err := op.Context().Err(); err != nil {
+			return err
+		}
 		err := qcimg.Resize(nsu)
 		if err != nil {
 			return err
 		}
 		if growPart {
+			op.SetMessage("Growing partition")
 			err = qcimg.GrowFullPart()
 			if err != nil {
 				println(err.Error())
@@ -331,7 +419,7 @@ func resizeRawImage(path string, newSize int64, growPart bool) error {
 	return nil
 }
 
-func writeAndConvertQcow2(outpath string, source io.Reader, newSize int64, destIsDevice bool, growPart bool) error {
+func writeAndConvertQcow2(op *operations.Operation, outpath string, source io.Reader, newSize int64, destIsDevice bool, growPart bool) error {
 	//create a temporary file path...
 	tdir, err := ioutil.TempDir("", "prmextract")
 	if err != nil {
@@ -346,7 +434,7 @@ func writeAndConvertQcow2(outpath string, source io.Reader, newSize int64, destI
 	}
 	_, err = io.Copy(f, source)
 	if err != nil {
-		err = f.Close()
+		f.Close()
 		return err
 	}
 	err = f.Close()
@@ -364,11 +452,16 @@ func writeAndConvertQcow2(outpath string, source io.Reader, newSize int64, destI
 		return errors.New("Size is smaller")
 	} else if nsu > qcimg.VirtualSize() {
 		//resize/expand
+		op.SetMessage("Resizing disk")
+		if err := op.Context().Err(); err != nil {
+			return err
+		}
 		err := qcimg.Resize(nsu)
 		if err != nil {
 			return err
 		}
 		if growPart {
+			op.SetMessage("Growing partition")
 			err = qcimg.Connect()
 			if err != nil {
 				return err
@@ -381,6 +474,12 @@ func writeAndConvertQcow2(outpath string, source io.Reader, newSize int64, destI
 	}
 
 	//now we can use qemu-convert to target what we need to...
+	op.SetMessage("Converting disk")
+	if err := op.Context().Err(); err != nil {
+		//the temp disk is removed on the way out so it cant be left connected
+		qcimg.Disconnect()
+		return err
+	}
 	if destIsDevice {
 		err := qcimg.ConvertImgRawDevice(outpath)
 		if err != nil {
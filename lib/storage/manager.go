@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/768bit/promethium/lib/common"
@@ -23,6 +25,7 @@ type StorageManager struct {
 	imagesCachePath string
 	imagesCache     map[string]*images.ImageCacheFile
 	imagesHashMap   map[string]string
+	exitLock        sync.Mutex
 	exiting         bool
 }
 
@@ -70,7 +73,7 @@ func (sm *StorageManager) init(configs []*config.StorageConfig, uid int, gid int
 		sm.GetImages()
 		go func() {
 			for {
-				if sm.exiting {
+				if sm.isExiting() {
 					return
 				}
 				time.Sleep(10 * time.Second)
@@ -85,8 +88,16 @@ func (sm *StorageManager) init(configs []*config.StorageConfig, uid int, gid int
 
 }
 
+func (sm *StorageManager) isExiting() bool {
+	sm.exitLock.Lock()
+	defer sm.exitLock.Unlock()
+	return sm.exiting
+}
+
 func (sm *StorageManager) Dispose() {
+	sm.exitLock.Lock()
 	sm.exiting = true
+	sm.exitLock.Unlock()
 	images.QemuNbd.Dispose()
 	time.Sleep(1 * time.Second)
 	sm.writeImagesCache()
@@ -177,6 +188,16 @@ func (sm *StorageManager) GetStorage(name string) (common.StorageDriver, error)
 	}
 }
 
+// Targets returns the ids of the loaded storage targets in order
+func (sm *StorageManager) Targets() []string {
+	ids := []string{}
+	for id := range sm.targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func (sm *StorageManager) GetImages() []common.Image {
 
 	imagesList := []common.Image{}
@@ -254,6 +275,15 @@ func (sm *StorageManager) ResolveStorageURI(uri string) (string, bool, error) {
 	return "", false, nil
 }
 
+// GetStorageForURI returns the storage target a storage uri points into
+func (sm *StorageManager) GetStorageForURI(uri string) (common.StorageDriver, error) {
+	outUrl, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	return sm.GetStorage(outUrl.Host)
+}
+
 func (sm *StorageManager) runDirectoryWatch() {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
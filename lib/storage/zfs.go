@@ -8,6 +8,7 @@ import (
 
 	"github.com/768bit/promethium/lib/common"
 	"github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/operations"
 	"github.com/768bit/vutils"
 	"github.com/gobuffalo/envy"
 	gozfs "github.com/mistifyio/go-zfs"
@@ -174,7 +175,7 @@ func (zfs *ZfsStorage) CreateDiskFromImage(id string, img common.Image, size uin
 func (zfs *ZfsStorage) WriteKernel(id string, source io.Reader) (string, error) {
 	return "", nil
 }
-func (zfs *ZfsStorage) WriteRootDisk(id string, source io.Reader, sourceIsRaw bool, growPart bool) (string, error) {
+func (zfs *ZfsStorage) WriteRootDisk(op *operations.Operation, id string, source io.Reader, sourceIsRaw bool, growPart bool) (string, error) {
 	return "", nil
 }
 func (zfs *ZfsStorage) WriteAdditionalDisk(id string, index int, source io.Reader, sourceIsRaw bool, growPart bool) (string, error) {
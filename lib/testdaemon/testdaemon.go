@@ -0,0 +1,146 @@
+// Package testdaemon runs the VmmManager and the REST API against a temporary AppRoot so the daemon, api and cli can
+// be tested end to end. It is meant to be used with mock vmms as it doesnt need root, kvm or firecracker. The
+// VmmManager and api use package level state so only one Daemon can be running at a time.
+package testdaemon
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	client "github.com/768bit/promethium/api/client"
+	"github.com/768bit/promethium/api/restapi"
+	"github.com/768bit/promethium/api/restapi/operations"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/networking"
+	"github.com/768bit/promethium/lib/vmm"
+	"github.com/768bit/vutils"
+	"github.com/go-openapi/loads"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// StorageName is the local file storage target every test daemon has
+const StorageName = "default-local"
+
+// Daemon is a running test daemon - the api is served over http on localhost
+type Daemon struct {
+	AppRoot string
+	Config  *config.PromethiumDaemonConfig
+	Manager *vmm.VmmManager
+	Server  *httptest.Server
+	Client  *client.Promethium
+	Host    string
+	Port    int
+}
+
+// Start sets up appRoot and starts a daemon on it - the vmm configs given are written into the instances folder
+// first so they are loaded (and started if they autostart) just like on a real daemon
+func Start(appRoot string, instances ...*config.VmmConfig) (*Daemon, error) {
+	pdc, err := NewConfig(appRoot)
+	if err != nil {
+		return nil, err
+	}
+	storageDir := filepath.Join(appRoot, "storage", StorageName)
+	for _, dir := range []string{"disks", "images", "kernels", "snapshots"} {
+		if err := os.MkdirAll(filepath.Join(storageDir, dir), 0750); err != nil {
+			return nil, err
+		}
+	}
+	instancesDir := filepath.Join(appRoot, "instances")
+	if err := os.MkdirAll(instancesDir, 0750); err != nil {
+		return nil, err
+	}
+	for _, cfg := range instances {
+		if cfg.ID == "" {
+			return nil, errors.New("Vmm configs need an id")
+		}
+		if err, _ := vutils.Config.SaveConfigToFile("", filepath.Join(instancesDir, cfg.ID+".json"), cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	mgr, err := vmm.NewVmmManager(pdc)
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.Start(); err != nil {
+		return nil, err
+	}
+
+	swaggerSpec, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
+	if err != nil {
+		mgr.Kill()
+		return nil, err
+	}
+	server := restapi.NewServer(operations.NewServerAPI(swaggerSpec))
+	restapi.SetManager(mgr)
+	server.ConfigureAPI()
+	httpServer := httptest.NewServer(server.GetHandler())
+
+	host, portStr, err := net.SplitHostPort(httpServer.Listener.Addr().String())
+	if err != nil {
+		httpServer.Close()
+		mgr.Kill()
+		return nil, err
+	}
+	port, _ := strconv.Atoi(portStr)
+	transport := httptransport.New(fmt.Sprintf("%s:%d", host, port), "", []string{"http"})
+	return &Daemon{
+		AppRoot: appRoot,
+		Config:  pdc,
+		Manager: mgr,
+		Server:  httpServer,
+		Client:  client.New(transport, strfmt.Default),
+		Host:    host,
+		Port:    port,
+	}, nil
+}
+
+// NewConfig is the daemon config of a test daemon - it runs as the current user and has a single local file
+// storage target and no networks
+func NewConfig(appRoot string) (*config.PromethiumDaemonConfig, error) {
+	current, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	group, err := user.LookupGroupId(current.Gid)
+	if err != nil {
+		return nil, err
+	}
+	return &config.PromethiumDaemonConfig{
+		NodeID:   "test-node",
+		Clusters: []*config.ClusterConfig{},
+		Storage: []*config.StorageConfig{
+			{
+				ID:     StorageName,
+				Driver: "local-file",
+				Config: map[string]interface{}{
+					"rootFolder": filepath.Join(appRoot, "storage", StorageName),
+				},
+			},
+		},
+		Networks:  []*networking.NetworkConfig{},
+		AppRoot:   appRoot,
+		User:      current.Username,
+		Group:     group.Name,
+		JailUser:  current.Username,
+		JailGroup: group.Name,
+	}, nil
+}
+
+// ConsoleURL is the websocket url consoles are attached through
+func (d *Daemon) ConsoleURL() string {
+	return fmt.Sprintf("ws://%s:%d/consolews", d.Host, d.Port)
+}
+
+// Close stops the api and kills every vmm
+func (d *Daemon) Close() error {
+	d.Server.Close()
+	return d.Manager.Kill()
+}
@@ -0,0 +1,86 @@
+package vmm
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/agent"
+)
+
+// how long to wait for the guest to accept a connection to the agent
+const agentDialTimeout = 5 * time.Second
+
+// how long the agent has to accept a shutdown before falling back to ctrl+alt+del
+const agentShutdownTimeout = 2 * time.Second
+
+var ErrVmmNotRunning = errors.New("The VM isnt running")
+
+func (vmm *Vmm) agentPort() uint32 {
+	if vmm.config.Vsock != nil && vmm.config.Vsock.AgentPort != 0 {
+		return vmm.config.Vsock.AgentPort
+	}
+	return agent.DefaultPort
+}
+
+// Agent returns a client for the guest agent listening on the vsock of the vmm
+func (vmm *Vmm) Agent() (*agent.Client, error) {
+	if vmm.config.Vsock == nil {
+		return nil, ErrVsockNotConfigured
+	}
+	if vmm.instance == nil || vmm.Status() != "Running" {
+		return nil, ErrVmmNotRunning
+	}
+	port := vmm.agentPort()
+	return agent.NewClient(func() (net.Conn, error) {
+		return vmm.DialVsock(port, agentDialTimeout)
+	}), nil
+}
+
+// agentShutdown asks the guest agent to shut the guest down
+func (vmm *Vmm) agentShutdown() error {
+	client, err := vmm.Agent()
+	if err != nil {
+		return err
+	}
+	client.Timeout = agentShutdownTimeout
+	return client.Shutdown()
+}
+
+// SetShutdownRequest sets how the guest is asked to shut down - ctrl+alt+del is sent when it is nil or fails
+func (fcp *FireCrackerProcess) SetShutdownRequest(requestShutdown func() error) {
+	fcp.requestShutdown = requestShutdown
+}
+
+// GetAgentInfoModel asks the guest agent about itself and the network interfaces of the guest
+func (vmm *Vmm) GetAgentInfoModel() (*models.VMAgentInfo, error) {
+	client, err := vmm.Agent()
+	if err != nil {
+		return nil, err
+	}
+	ping, err := client.Ping()
+	if err != nil {
+		return nil, err
+	}
+	netInfo, err := client.NetInfo()
+	if err != nil {
+		return nil, err
+	}
+	info := &models.VMAgentInfo{
+		Version:    ping.Version,
+		Hostname:   ping.Hostname,
+		Uptime:     ping.Uptime,
+		Interfaces: []*models.VMAgentInterface{},
+	}
+	for _, iface := range netInfo.Interfaces {
+		info.Interfaces = append(info.Interfaces, &models.VMAgentInterface{
+			Name:      iface.Name,
+			Mac:       iface.MAC,
+			Mtu:       int64(iface.MTU),
+			Up:        iface.Up,
+			Addresses: iface.Addresses,
+		})
+	}
+	return info, nil
+}
@@ -0,0 +1,196 @@
+package vmm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/768bit/firecracker-go-sdk"
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/vutils"
+)
+
+const AddBalloonHandlerName = "fcinit.AddBalloon"
+
+// balloon statistics are recorded as gauges with this prefix - the balloon group of the metrics fifo holds counters
+const balloonStatsPrefix = "balloon_stats."
+
+var ErrBalloonNotConfigured = errors.New("The VM doesnt have a balloon device")
+
+// balloonHandler adds the balloon device - the sdk doesnt know about balloons so this runs after the vsock handler
+func (fcp *FireCrackerProcess) balloonHandler() firecracker.Handler {
+	return firecracker.Handler{
+		Name: AddBalloonHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			balloon := fcp.balloonConfig()
+			if balloon == nil {
+				return nil
+			}
+			return newFirecrackerAPI(fcp.socketPath).PutBalloon(balloon.AmountMiB, balloon.DeflateOnOOM, balloon.StatsInterval)
+		},
+	}
+}
+
+// SetBalloon gives the vm a balloon device the next time it starts - nil removes it. The process keeps its own copy
+// so the config of the vmm can be changed without holding the balloon lock
+func (fcp *FireCrackerProcess) SetBalloon(balloon *config.VmmBalloonConfig) {
+	fcp.balloonLock.Lock()
+	defer fcp.balloonLock.Unlock()
+	if balloon == nil {
+		fcp.balloon = nil
+		return
+	}
+	copied := *balloon
+	fcp.balloon = &copied
+}
+
+// balloonConfig returns a copy of the balloon the vm is given, nil if it doesnt have one
+func (fcp *FireCrackerProcess) balloonConfig() *config.VmmBalloonConfig {
+	fcp.balloonLock.Lock()
+	defer fcp.balloonLock.Unlock()
+	if fcp.balloon == nil {
+		return nil
+	}
+	copied := *fcp.balloon
+	return &copied
+}
+
+// UpdateBalloon changes the size of the balloon - a stopped vm starts with the new size. The balloon lock is held
+// throughout so a vm that is starting is given either the old size or the new one and never misses the change
+func (fcp *FireCrackerProcess) UpdateBalloon(amountMiB int64) error {
+	fcp.balloonLock.Lock()
+	defer fcp.balloonLock.Unlock()
+	if fcp.balloon == nil {
+		return ErrBalloonNotConfigured
+	}
+	if started, _ := fcp.runState(); started {
+		if err := newFirecrackerAPI(fcp.socketPath).PatchBalloon(amountMiB); err != nil {
+			return err
+		}
+	}
+	fcp.balloon.AmountMiB = amountMiB
+	return nil
+}
+
+// pollBalloonStatistics records the balloon statistics as gauges while this run of the vm is up
+func (fcp *FireCrackerProcess) pollBalloonStatistics() {
+	balloon := fcp.balloonConfig()
+	if balloon == nil || balloon.StatsInterval <= 0 {
+		return
+	}
+	fcp.lock.Lock()
+	startedAt := fcp.lastStartedAt
+	fcp.lock.Unlock()
+	ticker := time.NewTicker(time.Duration(balloon.StatsInterval) * fcp.balloonStatsUnit)
+	defer ticker.Stop()
+	for range ticker.C {
+		fcp.lock.Lock()
+		started, paused := fcp.isStarted && fcp.lastStartedAt == startedAt, fcp.isPaused
+		fcp.lock.Unlock()
+		if !started {
+			return
+		}
+		if paused {
+			continue
+		}
+		stats, err := newFirecrackerAPI(fcp.socketPath).GetBalloonStatistics()
+		if err != nil {
+			fcp.logger.Debugf("Unable to get balloon statistics: %s", err.Error())
+			continue
+		}
+		gauges := make(map[string]float64, len(stats))
+		for name, value := range stats {
+			gauges[balloonStatsPrefix+name] = value
+		}
+		fcp.metrics.SetGauges(gauges)
+	}
+}
+
+// BalloonMiB returns the target and actual size of the balloon - until the guest reports statistics the balloon is
+// taken to be at its target
+func (vmm *Vmm) BalloonMiB() (int64, int64) {
+	target, ok := vmm.balloonTarget()
+	if !ok {
+		return 0, 0
+	}
+	if vmm.instance == nil || vmm.Status() != "Running" {
+		return target, 0
+	}
+	if snap := vmm.instance.Metrics(); snap != nil {
+		if actual, ok := snap.Gauges[balloonStatsPrefix+"actual_mib"]; ok {
+			return target, int64(actual)
+		}
+	}
+	return target, target
+}
+
+// balloonTarget returns the size the balloon of the vmm is set to and whether it has one
+func (vmm *Vmm) balloonTarget() (int64, bool) {
+	vmm.balloonLock.RLock()
+	defer vmm.balloonLock.RUnlock()
+	if vmm.config.Balloon == nil {
+		return 0, false
+	}
+	return vmm.config.Balloon.AmountMiB, true
+}
+
+// MemoryUsage returns the memory given to running vmms and how much of it is inflated into balloons and so can be
+// reclaimed by the host, in MiB
+func (vmmMgr *VmmManager) MemoryUsage() (int64, int64) {
+	allocated, reclaimable := int64(0), int64(0)
+	for _, vmm := range vmmMgr.List(true) {
+		if vmm.instance == nil || vmm.Status() != "Running" {
+			continue
+		}
+		allocated += vmm.config.Memory
+		_, actual := vmm.BalloonMiB()
+		reclaimable += actual
+	}
+	return allocated, reclaimable
+}
+
+// SetBalloonTarget inflates or deflates the balloon of the vmm and saves the new size to its config
+func (vmm *Vmm) SetBalloonTarget(amountMiB int64) error {
+	vmm.balloonLock.RLock()
+	if vmm.config.Balloon == nil {
+		vmm.balloonLock.RUnlock()
+		return ErrBalloonNotConfigured
+	}
+	balloon := *vmm.config.Balloon
+	vmm.balloonLock.RUnlock()
+	balloon.AmountMiB = amountMiB
+	if err := balloon.Validate(vmm.config.Memory); err != nil {
+		return err
+	}
+	if vmm.instance == nil {
+		return errors.New("Unable to change the balloon as instance isnt setup")
+	}
+	done, err := vmm.beginOp("update balloon")
+	if err != nil {
+		return err
+	}
+	defer done()
+	if err := vmm.instance.UpdateBalloon(amountMiB); err != nil {
+		return err
+	}
+	vmm.balloonLock.Lock()
+	defer vmm.balloonLock.Unlock()
+	vmm.config.Balloon.AmountMiB = amountMiB
+	err, _ = vutils.Config.SaveConfigToFile("", vmm.configPath, vmm.config)
+	return err
+}
+
+// GetBalloonModel returns the balloon of the vmm for the api
+func (vmm *Vmm) GetBalloonModel() (*models.VMBalloon, error) {
+	if vmm.config.Balloon == nil {
+		return nil, ErrBalloonNotConfigured
+	}
+	target, actual := vmm.BalloonMiB()
+	return &models.VMBalloon{
+		AmountMib:     target,
+		ActualMib:     actual,
+		DeflateOnOom:  vmm.config.Balloon.DeflateOnOOM,
+		StatsInterval: vmm.config.Balloon.StatsInterval,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package vmm
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/vutils"
+)
+
+// balloonGauge returns a balloon statistic of the process - -1 until the statistics have been polled
+func balloonGauge(fcp *FireCrackerProcess, name string) float64 {
+	if value, ok := fcp.Metrics().Gauges[balloonStatsPrefix+name]; ok {
+		return value
+	}
+	return -1
+}
+
+func TestFakeProcessUpdateBalloon(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.UpdateBalloon(32); err != ErrBalloonNotConfigured {
+		t.Errorf("expected a vm without a balloon to not be resized, got %v", err)
+	}
+	balloon := &config.VmmBalloonConfig{AmountMiB: 32, DeflateOnOOM: true}
+	fcp.SetBalloon(balloon)
+	//the process has its own copy so the vmm config can change without it
+	balloon.AmountMiB = 128
+
+	//a stopped vm is started with the new size
+	if err := fcp.UpdateBalloon(48); err != nil {
+		t.Fatal(err)
+	}
+	if hasRequest(fc, "PATCH /balloon") {
+		t.Error("expected firecracker to not be asked to resize a balloon of a vm that isnt started")
+	}
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	fc.lock.Lock()
+	if fc.balloon["amount_mib"] != float64(48) || fc.balloon["deflate_on_oom"] != true {
+		t.Errorf("expected the vm to be started with the new balloon size, got %v", fc.balloon)
+	}
+	fc.lock.Unlock()
+
+	if err := fcp.UpdateBalloon(64); err != nil {
+		t.Fatal(err)
+	}
+	fc.lock.Lock()
+	if fc.balloon["amount_mib"] != float64(64) {
+		t.Errorf("expected the balloon of the running vm to be resized, got %v", fc.balloon)
+	}
+	fc.lock.Unlock()
+	if current := fcp.balloonConfig(); current.AmountMiB != 64 {
+		t.Errorf("expected the new size to be kept for the next start, got %d", current.AmountMiB)
+	}
+}
+
+func TestFakeProcessBalloonStatistics(t *testing.T) {
+	fcp, _, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	fcp.balloonStatsUnit = 10 * time.Millisecond
+	fcp.SetBalloon(&config.VmmBalloonConfig{AmountMiB: 32, StatsInterval: 1})
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the balloon statistics to be polled", func() bool { return balloonGauge(fcp, "actual_mib") == 32 })
+
+	//resizing while the statistics are polled
+	wg := sync.WaitGroup{}
+	for _, amount := range []int64{40, 48, 56, 64} {
+		wg.Add(1)
+		go func(amount int64) {
+			defer wg.Done()
+			if err := fcp.UpdateBalloon(amount); err != nil {
+				t.Error(err)
+			}
+		}(amount)
+	}
+	wg.Wait()
+	final := float64(fcp.balloonConfig().AmountMiB)
+	waitFor(t, "the statistics to show the new size", func() bool { return balloonGauge(fcp, "target_mib") == final })
+
+	//the statistics stop being polled with the run of the vm they were started for
+	if err := fcp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	polls := len(fcp.Metrics().Gauges)
+	if err := fcp.UpdateBalloon(16); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if balloonGauge(fcp, "target_mib") != final || len(fcp.Metrics().Gauges) != polls {
+		t.Errorf("expected the statistics to not be polled once the vm stopped, got %v", fcp.Metrics().Gauges)
+	}
+}
+
+func TestBalloonMemoryUsage(t *testing.T) {
+	fcp, _, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	fcp.balloonStatsUnit = 10 * time.Millisecond
+	mgr, mgrCleanup := newTestManager(t)
+	defer mgrCleanup()
+	cfg := &config.VmmConfig{
+		ID:      "balloon",
+		Name:    "balloon",
+		Memory:  256,
+		Balloon: &config.VmmBalloonConfig{AmountMiB: 32, StatsInterval: 1},
+	}
+	vmm := newVmm(mgr, cfg.ID, filepath.Join(mgr.instanceConfigRootPath, cfg.ID+".json"), cfg)
+	fcp.SetBalloon(cfg.Balloon)
+	vmm.instance = fcp
+	mgr.addInstance(vmm)
+
+	if allocated, reclaimable := mgr.MemoryUsage(); allocated != 0 || reclaimable != 0 {
+		t.Errorf("expected a stopped vm to not use any memory, got %d %d", allocated, reclaimable)
+	}
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return vmm.Status() == "Running" })
+	waitFor(t, "the balloon to be reported", func() bool {
+		allocated, reclaimable := mgr.MemoryUsage()
+		return allocated == 256 && reclaimable == 32
+	})
+
+	if err := vmm.SetBalloonTarget(256); err == nil {
+		t.Error("expected a balloon as big as the vm to be refused")
+	}
+	if err := vmm.SetBalloonTarget(96); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the bigger balloon to be reported", func() bool {
+		_, reclaimable := mgr.MemoryUsage()
+		return reclaimable == 96
+	})
+	if target, actual := vmm.BalloonMiB(); target != 96 || actual != 96 {
+		t.Errorf("expected the balloon to be at its new target, it is %d of %d", actual, target)
+	}
+	saved := &config.VmmConfig{}
+	if err := vutils.Config.LoadConfigFromFile(vmm.configPath, saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Balloon == nil || saved.Balloon.AmountMiB != 96 {
+		t.Errorf("expected the new target to be saved, got %+v", saved.Balloon)
+	}
+}
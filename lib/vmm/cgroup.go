@@ -0,0 +1,281 @@
+package vmm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/768bit/firecracker-go-sdk"
+	models "github.com/768bit/firecracker-go-sdk/client/models"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/768bit/vutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// CgroupRoot is where the cgroup hierarchy is mounted
+var CgroupRoot = "/sys/fs/cgroup"
+
+// vmCgroup is the cgroup the jailer puts the firecracker process of a vm in - with cgroup v1 there is one per
+// controller under firecracker/<id>, with cgroup v2 it is a single firecracker/<id> cgroup
+type vmCgroup struct {
+	id      string
+	unified bool
+}
+
+func newVmCgroup(id string) *vmCgroup {
+	return &vmCgroup{
+		id:      id,
+		unified: vutils.Files.CheckPathExists(filepath.Join(CgroupRoot, "cgroup.controllers")),
+	}
+}
+
+func (cg *vmCgroup) dir(controller string, children ...string) string {
+	parts := []string{CgroupRoot}
+	if !cg.unified {
+		parts = append(parts, controller)
+	}
+	parts = append(parts, "firecracker", cg.id)
+	return filepath.Join(append(parts, children...)...)
+}
+
+func (cg *vmCgroup) read(controller string, file string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(cg.dir(controller), file))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (cg *vmCgroup) write(controller string, file string, value string) error {
+	return writeCgroupFile(filepath.Join(cg.dir(controller), file), value)
+}
+
+// prepare creates the firecracker cgroup and enables the controllers given for the cgroups of vms below it - the
+// jailer only does this itself for cgroup v1
+func (cg *vmCgroup) prepare(controllers ...string) error {
+	if !cg.unified {
+		return nil
+	}
+	enable := make([]string, len(controllers))
+	for i, controller := range controllers {
+		enable[i] = "+" + controller
+	}
+	if err := writeCgroupFile(filepath.Join(CgroupRoot, "cgroup.subtree_control"), strings.Join(enable, " ")); err != nil {
+		return err
+	}
+	parent := filepath.Join(CgroupRoot, "firecracker")
+	if err := makeCgroup(parent); err != nil {
+		return err
+	}
+	return writeCgroupFile(filepath.Join(parent, "cgroup.subtree_control"), strings.Join(enable, " "))
+}
+
+// attach puts pid in the cgroup of the vm and enables the controllers it needs - the jailer only does this itself
+// for cgroup v1
+func (cg *vmCgroup) attach(pid int, controllers ...string) error {
+	if !cg.unified {
+		return nil
+	}
+	if err := cg.prepare(controllers...); err != nil {
+		return err
+	}
+	if err := makeCgroup(cg.dir("")); err != nil {
+		return err
+	}
+	return writeCgroupFile(filepath.Join(cg.dir(""), "cgroup.procs"), strconv.Itoa(pid))
+}
+
+// pinThreads pins each thread to one of cpus in turn through a cpuset cgroup per thread below the cgroup of the vm
+func (cg *vmCgroup) pinThreads(tids []int, cpus []int, node int) error {
+	mems := strconv.Itoa(node)
+	if cg.unified {
+		if err := writeCgroupFile(filepath.Join(cg.dir("cpuset"), "cgroup.subtree_control"), "+cpuset"); err != nil {
+			return err
+		}
+	}
+	if err := writeCgroupFile(filepath.Join(cg.dir("cpuset"), "cpuset.mems"), mems); err != nil {
+		return err
+	}
+	if err := writeCgroupFile(filepath.Join(cg.dir("cpuset"), "cpuset.cpus"), config.FormatCPUList(cpus)); err != nil {
+		return err
+	}
+	for i, tid := range tids {
+		child := cg.dir("cpuset", fmt.Sprintf("vcpu%d", i))
+		if err := makeCgroup(child); err != nil {
+			return err
+		}
+		tasks := "tasks"
+		if cg.unified {
+			if err := writeCgroupFile(filepath.Join(child, "cgroup.type"), "threaded"); err != nil {
+				return err
+			}
+			tasks = "cgroup.threads"
+		}
+		if err := writeCgroupFile(filepath.Join(child, "cpuset.mems"), mems); err != nil {
+			return err
+		}
+		if err := writeCgroupFile(filepath.Join(child, "cpuset.cpus"), strconv.Itoa(cpus[i%len(cpus)])); err != nil {
+			return err
+		}
+		if err := writeCgroupFile(filepath.Join(child, tasks), strconv.Itoa(tid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeThreadCgroups removes the per thread cgroups left from the last run of the vm - the cpus of the vm cant be
+// changed while they are there
+func (cg *vmCgroup) removeThreadCgroups() {
+	children, _ := filepath.Glob(cg.dir("cpuset", "vcpu*"))
+	for _, child := range children {
+		privhelper.Default.CgroupRmdir(child)
+	}
+}
+
+// remove removes the cgroups of the vm once firecracker is gone - with cgroup v1 the jailer makes one for each
+// controller it is given so all of them are looked for
+func (cg *vmCgroup) remove() {
+	cg.removeThreadCgroups()
+	dirs := []string{cg.dir("")}
+	if !cg.unified {
+		dirs, _ = filepath.Glob(filepath.Join(CgroupRoot, "*", "firecracker", cg.id))
+	}
+	for _, dir := range dirs {
+		if vutils.Files.CheckPathExists(dir) {
+			privhelper.Default.CgroupRmdir(dir)
+		}
+	}
+}
+
+func makeCgroup(path string) error {
+	if vutils.Files.CheckPathExists(path) {
+		return nil
+	}
+	return privhelper.Default.CgroupMkdir(path)
+}
+
+// writeCgroupFile writes a value to a cgroup control file through the privileged helper as the hierarchy is owned
+// by root
+func writeCgroupFile(path string, value string) error {
+	return privhelper.Default.CgroupWrite(path, value)
+}
+
+// firecrackerPid returns the pid of the firecracker process the jailer execs - the process tree is only searched
+// the first time it is needed for each jailer
+func (fcp *FireCrackerProcess) firecrackerPid() (int, error) {
+	pid := fcp.Pid()
+	if pid == 0 {
+		return 0, ErrVmmNotRunning
+	}
+	fcp.lock.Lock()
+	if fcp.firecrackerProcOf == pid {
+		cached := fcp.firecrackerProcPid
+		fcp.lock.Unlock()
+		return cached, nil
+	}
+	fcp.lock.Unlock()
+	ps, err := metrics.FindDescendant(pid, "firecracker")
+	if err != nil {
+		return 0, err
+	}
+	fcp.lock.Lock()
+	fcp.firecrackerProcPid, fcp.firecrackerProcOf = ps.Pid, pid
+	fcp.lock.Unlock()
+	return ps.Pid, nil
+}
+
+// ProcessStat is the resource usage of the firecracker process
+func (fcp *FireCrackerProcess) ProcessStat() (*metrics.ProcStat, error) {
+	pid, err := fcp.firecrackerPid()
+	if err != nil {
+		return nil, err
+	}
+	return metrics.ReadProcStat(pid)
+}
+
+// pinVcpus pins the vcpu threads of the firecracker process to the cpu set of the vm - it runs once the vm has
+// started as that is when firecracker creates the threads
+func (fcp *FireCrackerProcess) pinVcpus() error {
+	if fcp.cpu == nil {
+		return nil
+	}
+	cpus, err := fcp.cpu.Pinning()
+	if err != nil {
+		return fmt.Errorf("Unable to pin vcpus of %s: %s", fcp.id, err.Error())
+	} else if len(cpus) == 0 {
+		return nil
+	}
+	pid, err := fcp.firecrackerPid()
+	if err != nil {
+		return fmt.Errorf("Unable to pin vcpus of %s: %s", fcp.id, err.Error())
+	}
+	threads, err := metrics.FindThreads(pid)
+	if err != nil {
+		return fmt.Errorf("Unable to pin vcpus of %s: %s", fcp.id, err.Error())
+	}
+	tids := []int{}
+	for i := int64(0); i < fcp.cpus; i++ {
+		tid, ok := threads[fmt.Sprintf("fc_vcpu %d", i)]
+		if !ok {
+			return fmt.Errorf("Unable to pin vcpus of %s: vcpu %d thread not found", fcp.id, i)
+		}
+		tids = append(tids, tid)
+	}
+	cg := newVmCgroup(fcp.id)
+	if err := cg.attach(pid, "cpuset"); err != nil {
+		return fmt.Errorf("Unable to pin vcpus of %s: %s", fcp.id, err.Error())
+	}
+	if err := cg.pinThreads(tids, cpus, fcp.cpu.NumaNode); err != nil {
+		return fmt.Errorf("Unable to pin vcpus of %s: %s", fcp.id, err.Error())
+	}
+	log.Printf("Pinned vcpus of %s to cpus %s\n", fcp.id, config.FormatCPUList(cpus))
+	return nil
+}
+
+// setupCgroup applies the resource limits and vcpu pinning of the vm once firecracker has created its vcpu threads -
+// the jailer has already set the limits it can before it exec'd firecracker so this adds the io limits and catches a
+// jailer that was waiting to be used when the limits changed
+func (fcp *FireCrackerProcess) setupCgroup() error {
+	if resources := fcp.currentResources(); resources != nil {
+		if err := fcp.applyResources(resources); err != nil {
+			return fmt.Errorf("Unable to apply resource limits to %s: %s", fcp.id, err.Error())
+		}
+	}
+	return fcp.pinVcpus()
+}
+
+// machineConfiguration is the machine config for firecracker from the cpu config of the vm
+func (fcp *FireCrackerProcess) machineConfiguration() models.MachineConfiguration {
+	cfg := models.MachineConfiguration{
+		VcpuCount:  firecracker.Int64(fcp.cpus),
+		MemSizeMib: firecracker.Int64(fcp.memory),
+		HtEnabled:  firecracker.Bool(false),
+	}
+	if fcp.cpu != nil {
+		cfg.HtEnabled = firecracker.Bool(fcp.cpu.SMT)
+		cfg.CPUTemplate = models.CPUTemplate(fcp.cpu.CPUTemplate)
+	}
+	return cfg
+}
+
+// numaNode is the node the jailer keeps the vm on
+func (fcp *FireCrackerProcess) numaNode() int {
+	if fcp.cpu == nil {
+		return 0
+	}
+	return fcp.cpu.NumaNode
+}
+
+// ValidateCPU checks the cpu config of a vm can be satisfied by this host
+func ValidateCPU(cpu *config.VmmCPUConfig, vcpus int64) error {
+	host, err := config.ReadHostCPUTopology()
+	if err != nil {
+		return err
+	}
+	return cpu.Validate(vcpus, host)
+}
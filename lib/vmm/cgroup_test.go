@@ -0,0 +1,179 @@
+package vmm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/768bit/promethium/lib/privhelper"
+)
+
+// newTestCgroupRoot points the cgroups of vms at a temporary hierarchy that the fake runner writes to - unified makes
+// it a cgroup v2 hierarchy
+func newTestCgroupRoot(t *testing.T, unified bool) (*fakeRunner, func()) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unified {
+		if err := ioutil.WriteFile(filepath.Join(dir, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644); err != nil {
+			os.RemoveAll(dir)
+			t.Fatal(err)
+		}
+	}
+	cgroupRoot, runner := CgroupRoot, privhelper.Default
+	fake := &fakeRunner{cgroupRoot: dir}
+	CgroupRoot, privhelper.Default = dir, fake
+	return fake, func() {
+		CgroupRoot, privhelper.Default = cgroupRoot, runner
+		os.RemoveAll(dir)
+	}
+}
+
+// readCgroupFile reads a control file relative to the cgroup root
+func readCgroupFile(t *testing.T, file string) string {
+	data, err := ioutil.ReadFile(filepath.Join(CgroupRoot, file))
+	if err != nil {
+		t.Fatalf("expected %s to be written: %v", file, err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// fakeFirecrackerThreads makes a proc tree with a firecracker process below the fake jailer that has a thread for
+// each vcpu given
+func fakeFirecrackerThreads(t *testing.T, jailerPid int, vcpus int) (int, func()) {
+	procRoot, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRoot := metrics.ProcRoot
+	metrics.ProcRoot = procRoot
+	cleanup := func() {
+		metrics.ProcRoot = oldRoot
+		os.RemoveAll(procRoot)
+	}
+	jailer, firecracker := strconv.Itoa(jailerPid), 999999
+	files := map[string]string{
+		filepath.Join(jailer, "stat"):                      jailer + " (jailer) S 1 1 1 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		filepath.Join("999999", "stat"):                    "999999 (firecracker) S " + jailer + " 1 1 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		filepath.Join("999999", "task", "999999", "comm"):  "firecracker\n",
+		filepath.Join("999999", "task", "1000000", "comm"): "fc_api\n",
+	}
+	for i := 0; i < vcpus; i++ {
+		files[filepath.Join("999999", "task", strconv.Itoa(1000001+i), "comm")] = "fc_vcpu " + strconv.Itoa(i) + "\n"
+	}
+	for path, contents := range files {
+		os.MkdirAll(filepath.Dir(filepath.Join(procRoot, path)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(procRoot, path), []byte(contents), 0644); err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+	}
+	return firecracker, cleanup
+}
+
+func TestPinThreads(t *testing.T) {
+	_, cleanup := newTestCgroupRoot(t, true)
+	defer cleanup()
+	cg := newVmCgroup("vm")
+	if err := cg.pinThreads([]int{11, 12, 13}, []int{2, 3}, 1); err == nil {
+		t.Error("expected pinning to fail when the cgroup of the vm isnt there")
+	}
+	os.MkdirAll(cg.dir("cpuset"), 0755)
+	if err := cg.pinThreads([]int{11, 12, 13}, []int{2, 3}, 1); err != nil {
+		t.Fatal(err)
+	}
+	for file, want := range map[string]string{
+		"firecracker/vm/cgroup.subtree_control": "+cpuset",
+		"firecracker/vm/cpuset.mems":            "1",
+		"firecracker/vm/cpuset.cpus":            "2-3",
+		"firecracker/vm/vcpu0/cgroup.type":      "threaded",
+		"firecracker/vm/vcpu0/cpuset.mems":      "1",
+		"firecracker/vm/vcpu0/cpuset.cpus":      "2",
+		"firecracker/vm/vcpu0/cgroup.threads":   "11",
+		"firecracker/vm/vcpu1/cpuset.cpus":      "3",
+		"firecracker/vm/vcpu1/cgroup.threads":   "12",
+		//there are more threads than cpus so they go round again
+		"firecracker/vm/vcpu2/cpuset.cpus":    "2",
+		"firecracker/vm/vcpu2/cgroup.threads": "13",
+	} {
+		if got := readCgroupFile(t, file); got != want {
+			t.Errorf("expected %s to be %q, got %q", file, want, got)
+		}
+	}
+
+	//the thread cgroups are removed before the next run so the cpus of the vm can change
+	cg.removeThreadCgroups()
+	if children, _ := filepath.Glob(cg.dir("cpuset", "vcpu*")); len(children) != 0 {
+		t.Errorf("expected the thread cgroups to be removed, got %v", children)
+	}
+}
+
+func TestPinThreadsV1(t *testing.T) {
+	_, cleanup := newTestCgroupRoot(t, false)
+	defer cleanup()
+	cg := newVmCgroup("vm")
+	os.MkdirAll(cg.dir("cpuset"), 0755)
+	if err := cg.pinThreads([]int{11, 12}, []int{4, 5}, 0); err != nil {
+		t.Fatal(err)
+	}
+	for file, want := range map[string]string{
+		"cpuset/firecracker/vm/cpuset.cpus":       "4-5",
+		"cpuset/firecracker/vm/vcpu0/cpuset.cpus": "4",
+		"cpuset/firecracker/vm/vcpu0/tasks":       "11",
+		"cpuset/firecracker/vm/vcpu1/cpuset.cpus": "5",
+		"cpuset/firecracker/vm/vcpu1/tasks":       "12",
+	} {
+		if got := readCgroupFile(t, file); got != want {
+			t.Errorf("expected %s to be %q, got %q", file, want, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(CgroupRoot, "cpuset/firecracker/vm/vcpu0/cgroup.type")); err == nil {
+		t.Error("expected threaded cgroups to only be used with cgroup v2")
+	}
+}
+
+func TestFakeProcessPinVcpus(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	firecracker, procCleanup := fakeFirecrackerThreads(t, fcp.Pid(), int(fcp.cpus))
+	defer procCleanup()
+	fcp.cpu = &config.VmmCPUConfig{CPUSet: "6,7"}
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if procs := runner.writesTo("firecracker/fake/cgroup.procs"); len(procs) != 1 || procs[0] != strconv.Itoa(firecracker) {
+		t.Errorf("expected firecracker to be moved to the cgroup of the vm, got %v", procs)
+	}
+	if got := readCgroupFile(t, "firecracker/fake/vcpu1/cgroup.threads"); got != "1000002" {
+		t.Errorf("expected the second vcpu thread to be pinned, got %q", got)
+	}
+	if got := readCgroupFile(t, "firecracker/fake/vcpu1/cpuset.cpus"); got != "7" {
+		t.Errorf("expected the second vcpu to be pinned to the second cpu, got %q", got)
+	}
+}
+
+func TestFakeProcessPinVcpusFailure(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	//firecracker only has the first of its vcpu threads
+	_, procCleanup := fakeFirecrackerThreads(t, fcp.Pid(), 1)
+	defer procCleanup()
+	fcp.cpu = &config.VmmCPUConfig{CPUSet: "6,7"}
+	fc := runner.latest()
+	err := fcp.Start()
+	if err == nil || !strings.Contains(err.Error(), "Unable to pin vcpus") {
+		t.Fatalf("expected the start to fail when the vcpus cant be pinned, got %v", err)
+	}
+	if started, _ := fcp.runState(); started {
+		t.Error("expected the vm to not be left running")
+	}
+	if fc.getExitStatus() == nil {
+		t.Error("expected firecracker to be stopped")
+	}
+}
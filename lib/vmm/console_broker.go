@@ -0,0 +1,240 @@
+package vmm
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// DefaultConsoleScrollback is how much recent console output is kept to replay to clients when they attach
+const DefaultConsoleScrollback = 64 * 1024
+
+// how many chunks of output can be waiting for a client before it is considered too slow and detached
+const consoleClientBacklog = 256
+
+var (
+	ErrConsoleReadOnly     = errors.New("Console is attached read only")
+	ErrConsoleNotConnected = errors.New("Console is not connected to a running VM")
+	ErrConsoleTooSlow      = errors.New("Console client was detached as it wasnt keeping up with the output")
+)
+
+// ConsoleBroker owns the serial console of a vmm - output is kept in a bounded scrollback buffer and sent to every
+// attached client while input from any client that isnt read only is passed on to the vmm
+type ConsoleBroker struct {
+	lock       sync.Mutex
+	scrollback []byte
+	start      int
+	length     int
+	input      io.Writer
+	resize     func(cols int, rows int) error
+	clients    map[*ConsoleClient]bool
+}
+
+// ConsoleResizer is implemented by consoles that can change the size of the terminal they are attached to
+type ConsoleResizer interface {
+	Resize(cols int, rows int) error
+}
+
+func NewConsoleBroker(scrollbackSize int) *ConsoleBroker {
+	if scrollbackSize <= 0 {
+		scrollbackSize = DefaultConsoleScrollback
+	}
+	return &ConsoleBroker{
+		scrollback: make([]byte, scrollbackSize),
+		clients:    map[*ConsoleClient]bool{},
+	}
+}
+
+// SetInput connects the broker to the input of the console - nil disconnects it
+func (cb *ConsoleBroker) SetInput(input io.Writer) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.input = input
+}
+
+// SetResizer connects the broker to whatever changes the size of the console terminal - nil disconnects it
+func (cb *ConsoleBroker) SetResizer(resize func(cols int, rows int) error) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.resize = resize
+}
+
+// Write takes output from the console - it is added to the scrollback and sent to every client
+func (cb *ConsoleBroker) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.appendScrollback(p)
+	for client := range cb.clients {
+		chunk := make([]byte, len(p))
+		copy(chunk, p)
+		select {
+		case client.output <- chunk:
+		default:
+			cb.detach(client, ErrConsoleTooSlow)
+		}
+	}
+	return len(p), nil
+}
+
+func (cb *ConsoleBroker) appendScrollback(p []byte) {
+	size := len(cb.scrollback)
+	if len(p) >= size {
+		copy(cb.scrollback, p[len(p)-size:])
+		cb.start = 0
+		cb.length = size
+		return
+	}
+	end := (cb.start + cb.length) % size
+	n := copy(cb.scrollback[end:], p)
+	copy(cb.scrollback, p[n:])
+	cb.length += len(p)
+	if cb.length > size {
+		cb.start = (cb.start + cb.length - size) % size
+		cb.length = size
+	}
+}
+
+// Scrollback returns a copy of the output currently held in the scrollback buffer
+func (cb *ConsoleBroker) Scrollback() []byte {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.scrollbackLocked()
+}
+
+func (cb *ConsoleBroker) scrollbackLocked() []byte {
+	out := make([]byte, cb.length)
+	n := copy(out, cb.scrollback[cb.start:])
+	if n < cb.length {
+		copy(out[n:], cb.scrollback[:cb.length-n])
+	}
+	return out
+}
+
+// Attach adds a client to the console - when replay is set the client first reads back the scrollback. Read only
+// clients see the output but cant send input
+func (cb *ConsoleBroker) Attach(readOnly bool, replay bool) *ConsoleClient {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	client := &ConsoleClient{
+		broker:   cb,
+		readOnly: readOnly,
+		output:   make(chan []byte, consoleClientBacklog),
+		closed:   make(chan struct{}),
+	}
+	if replay {
+		client.pending = cb.scrollbackLocked()
+	}
+	cb.clients[client] = true
+	return client
+}
+
+// DetachAll disconnects every client - they read EOF once they have read what was sent to them
+func (cb *ConsoleBroker) DetachAll() {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	for client := range cb.clients {
+		cb.detach(client, io.EOF)
+	}
+}
+
+// Clients returns the number of attached clients
+func (cb *ConsoleBroker) Clients() int {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return len(cb.clients)
+}
+
+func (cb *ConsoleBroker) detach(client *ConsoleClient, reason error) {
+	if _, ok := cb.clients[client]; !ok {
+		return
+	}
+	delete(cb.clients, client)
+	client.err = reason
+	close(client.output)
+}
+
+func (cb *ConsoleBroker) writeInput(p []byte) (int, error) {
+	cb.lock.Lock()
+	input := cb.input
+	cb.lock.Unlock()
+	if input == nil {
+		return 0, ErrConsoleNotConnected
+	}
+	return input.Write(p)
+}
+
+func (cb *ConsoleBroker) resizeTerminal(cols int, rows int) error {
+	cb.lock.Lock()
+	resize := cb.resize
+	cb.lock.Unlock()
+	if resize == nil {
+		return ErrConsoleNotConnected
+	}
+	return resize(cols, rows)
+}
+
+// ConsoleClient is a single attachment to a console broker
+type ConsoleClient struct {
+	broker    *ConsoleBroker
+	readOnly  bool
+	output    chan []byte
+	pending   []byte
+	err       error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (cc *ConsoleClient) ReadOnly() bool {
+	return cc.readOnly
+}
+
+func (cc *ConsoleClient) Read(p []byte) (int, error) {
+	for len(cc.pending) == 0 {
+		select {
+		case chunk, ok := <-cc.output:
+			if !ok {
+				//err is set before the channel is closed
+				return 0, cc.err
+			}
+			cc.pending = chunk
+		case <-cc.closed:
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, cc.pending)
+	cc.pending = cc.pending[n:]
+	return n, nil
+}
+
+func (cc *ConsoleClient) Write(p []byte) (int, error) {
+	if cc.readOnly {
+		return 0, ErrConsoleReadOnly
+	}
+	select {
+	case <-cc.closed:
+		return 0, io.ErrClosedPipe
+	default:
+	}
+	return cc.broker.writeInput(p)
+}
+
+// Resize changes the size of the console terminal - with several clients attached the last one to resize wins
+func (cc *ConsoleClient) Resize(cols int, rows int) error {
+	if cc.readOnly {
+		return ErrConsoleReadOnly
+	}
+	return cc.broker.resizeTerminal(cols, rows)
+}
+
+func (cc *ConsoleClient) Close() error {
+	cc.closeOnce.Do(func() {
+		close(cc.closed)
+		cc.broker.lock.Lock()
+		cc.broker.detach(cc, io.EOF)
+		cc.broker.lock.Unlock()
+	})
+	return nil
+}
@@ -0,0 +1,141 @@
+package vmm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+// readChunk reads exactly len(want) bytes from the client and checks they are what was expected
+func readChunk(t *testing.T, client *ConsoleClient, want string) {
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(client, got); err != nil {
+		t.Fatalf("expected to read %q: %v", want, err)
+	}
+	if string(got) != want {
+		t.Fatalf("expected to read %q, got %q", want, got)
+	}
+}
+
+func TestConsoleBrokerScrollbackWraparound(t *testing.T) {
+	cb := NewConsoleBroker(8)
+	for _, step := range []struct {
+		write string
+		want  string
+	}{
+		{"abc", "abc"},
+		{"defgh", "abcdefgh"},
+		//the oldest output is dropped once the buffer is full
+		{"ij", "cdefghij"},
+		{"klmno", "hijklmno"},
+		//a write bigger than the buffer only keeps its tail
+		{"0123456789", "23456789"},
+		{"x", "3456789x"},
+	} {
+		cb.Write([]byte(step.write))
+		if got := string(cb.Scrollback()); got != step.want {
+			t.Errorf("after writing %q expected the scrollback to be %q, got %q", step.write, step.want, got)
+		}
+	}
+}
+
+func TestConsoleBrokerReplay(t *testing.T) {
+	cb := NewConsoleBroker(DefaultConsoleScrollback)
+	cb.Write([]byte("boot log\n"))
+	replayed := cb.Attach(true, true)
+	defer replayed.Close()
+	live := cb.Attach(true, false)
+	defer live.Close()
+	cb.Write([]byte("login: "))
+
+	readChunk(t, replayed, "boot log\nlogin: ")
+	//a client that doesnt ask for the scrollback only sees what is written after it attached
+	readChunk(t, live, "login: ")
+
+	//the replay is of the scrollback so only the newest output is replayed once it has wrapped
+	small := NewConsoleBroker(4)
+	small.Write([]byte("abcdef"))
+	wrapped := small.Attach(true, true)
+	defer wrapped.Close()
+	small.Write([]byte("g"))
+	readChunk(t, wrapped, "cdefg")
+}
+
+func TestConsoleBrokerFanOut(t *testing.T) {
+	cb := NewConsoleBroker(DefaultConsoleScrollback)
+	fast := []*ConsoleClient{cb.Attach(false, false), cb.Attach(true, false)}
+	for _, client := range fast {
+		defer client.Close()
+	}
+	slow := cb.Attach(true, false)
+	defer slow.Close()
+	gone := cb.Attach(false, false)
+	gone.Close()
+	if cb.Clients() != 3 {
+		t.Fatalf("expected the closed client to be detached, %d are attached", cb.Clients())
+	}
+
+	//the slow client never reads so it is detached once its backlog is full - the others keep getting everything
+	for i := 0; i <= consoleClientBacklog; i++ {
+		chunk := fmt.Sprintf("line %d\n", i)
+		if n, err := cb.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("expected the write to not be held up by the clients, got %d %v", n, err)
+		}
+		for _, client := range fast {
+			readChunk(t, client, chunk)
+		}
+	}
+	if cb.Clients() != 2 {
+		t.Errorf("expected the slow client to be detached, %d are attached", cb.Clients())
+	}
+	//it still gets what it was sent before it is told why it was detached
+	backlog, err := ioutil.ReadAll(slow)
+	if err != ErrConsoleTooSlow {
+		t.Errorf("expected the slow client to be told it was too slow, got %v", err)
+	}
+	if !bytes.HasPrefix(backlog, []byte("line 0\n")) || !bytes.HasSuffix(backlog, []byte(fmt.Sprintf("line %d\n", consoleClientBacklog-1))) {
+		t.Errorf("expected the slow client to read its backlog, got %d bytes", len(backlog))
+	}
+	if _, err := gone.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("expected the closed client to read EOF, got %v", err)
+	}
+
+	cb.Write([]byte("after"))
+	for _, client := range fast {
+		readChunk(t, client, "after")
+	}
+	cb.DetachAll()
+	for _, client := range fast {
+		if _, err := client.Read(make([]byte, 1)); err != io.EOF {
+			t.Errorf("expected the detached clients to read EOF, got %v", err)
+		}
+	}
+}
+
+func TestConsoleBrokerInput(t *testing.T) {
+	cb := NewConsoleBroker(DefaultConsoleScrollback)
+	writer := cb.Attach(false, false)
+	defer writer.Close()
+	reader := cb.Attach(true, false)
+	defer reader.Close()
+	if _, err := writer.Write([]byte("ls\n")); err != ErrConsoleNotConnected {
+		t.Errorf("expected input to fail without a vm behind the console, got %v", err)
+	}
+	input := &bytes.Buffer{}
+	cb.SetInput(input)
+	if _, err := writer.Write([]byte("ls\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Write([]byte("rm -rf /\n")); err != ErrConsoleReadOnly {
+		t.Errorf("expected a read only client to not send input, got %v", err)
+	}
+	if input.String() != "ls\n" {
+		t.Errorf("expected only the input of the writer to reach the vm, got %q", input.String())
+	}
+	writer.Close()
+	if _, err := writer.Write([]byte("ls\n")); err != io.ErrClosedPipe {
+		t.Errorf("expected a closed client to not send input, got %v", err)
+	}
+}
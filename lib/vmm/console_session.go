@@ -0,0 +1,52 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/768bit/promethium/lib/recording"
+)
+
+// Find looks an instance up by its id or failing that its name
+func (vmmMgr *VmmManager) Find(idOrName string) (*Vmm, error) {
+	if vmm, err := vmmMgr.Get(idOrName); err == nil {
+		return vmm, nil
+	}
+	for _, vmm := range vmmMgr.instanceList() {
+		if vmm != nil && vmm.Name() == idOrName {
+			return vmm, nil
+		}
+	}
+	return nil, errors.New("Unable to find instance with that id or name")
+}
+
+// OpenConsole attaches a user to the console of an instance - interactive sessions are recorded and the terminal is
+// sized for the user. This is shared by every way of reaching a console so they all behave the same
+func (vmmMgr *VmmManager) OpenConsole(vmm *Vmm, user string, readOnly bool, cols int, rows int) (io.ReadWriteCloser, error) {
+	console, err := vmm.Console(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly {
+		return console, nil
+	}
+	if cols <= 0 || rows <= 0 {
+		cols, rows = 80, 24
+	}
+	//a session isnt refused if recording fails
+	title := fmt.Sprintf("%s console (%s)", vmm.Name(), user)
+	if recID, rec, err := vmmMgr.recordings.New(vmm.ID(), user, title, cols, rows); err != nil {
+		log.Printf("Unable to record console session: %s", err.Error())
+	} else {
+		log.Printf("Recording console session %s for %s", recID, user)
+		console = recording.Wrap(console, rec)
+	}
+	if resizer, ok := console.(ConsoleResizer); ok {
+		if err := resizer.Resize(cols, rows); err != nil {
+			log.Printf("Unable to resize console: %s", err.Error())
+		}
+	}
+	return console, nil
+}
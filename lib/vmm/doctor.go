@@ -0,0 +1,303 @@
+package vmm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/networking"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ProblemOrphanedChroot = "orphaned-chroot"
+	ProblemOrphanedDisk   = "orphaned-disk"
+	ProblemMissingDisk    = "missing-disk"
+	ProblemStaleTap       = "stale-tap"
+	ProblemLeakedNbd      = "leaked-nbd"
+)
+
+// DoctorProblem is a resource left behind by a vmm that is gone or a vmm missing one of its resources - fix cleans
+// it up and is nil when that cant be done safely
+type DoctorProblem struct {
+	Kind        string
+	Resource    string
+	VmID        string
+	Description string
+	Fixed       bool
+	Err         error
+	fix         func() error
+}
+
+func (problem *DoctorProblem) Fixable() bool {
+	return problem.fix != nil
+}
+
+// DoctorReport is what a run of the doctor found
+type DoctorReport struct {
+	CheckedAt time.Time
+	Problems  []*DoctorProblem
+}
+
+// Doctor looks for the resources that are left behind when the daemon or a vmm doesnt exit cleanly and cleans up
+// the ones it can when fix is set
+func (vmmMgr *VmmManager) Doctor(fix bool) *DoctorReport {
+	report := &DoctorReport{
+		CheckedAt: time.Now(),
+		Problems:  []*DoctorProblem{},
+	}
+	checks := []func() ([]*DoctorProblem, error){
+		vmmMgr.checkOrphanedChroots,
+		vmmMgr.checkOrphanedDisks,
+		vmmMgr.checkMissingDisks,
+		vmmMgr.checkStaleTaps,
+		vmmMgr.checkLeakedNbd,
+	}
+	for _, check := range checks {
+		problems, err := check()
+		if err != nil {
+			log.Warnf("Doctor check failed: %s", err.Error())
+		}
+		report.Problems = append(report.Problems, problems...)
+	}
+	if fix {
+		for _, problem := range report.Problems {
+			if !problem.Fixable() {
+				continue
+			}
+			if problem.Err = problem.fix(); problem.Err == nil {
+				problem.Fixed = true
+			}
+		}
+	}
+	return report
+}
+
+// doctorOnStart reports what the doctor finds when the daemon starts - it is only cleaned up when the config says so
+func (vmmMgr *VmmManager) doctorOnStart() {
+	report := vmmMgr.Doctor(vmmMgr.config.FixLeaksOnStart)
+	for _, problem := range report.Problems {
+		if problem.Fixed {
+			log.Printf("Cleaned up %s %s: %s", problem.Kind, problem.Resource, problem.Description)
+		} else if problem.Err != nil {
+			log.Warnf("Unable to clean up %s %s: %s", problem.Kind, problem.Resource, problem.Err.Error())
+		} else {
+			log.Warnf("Found %s %s: %s", problem.Kind, problem.Resource, problem.Description)
+		}
+	}
+}
+
+func (vmmMgr *VmmManager) checkOrphanedChroots() ([]*DoctorProblem, error) {
+	_, _, orphanedDirs, err := vmmMgr.classifyInstanceDirs()
+	if err != nil {
+		return nil, err
+	}
+	problems := []*DoctorProblem{}
+	for _, dir := range orphanedDirs {
+		dir := dir
+		problem := &DoctorProblem{
+			Kind:        ProblemOrphanedChroot,
+			Resource:    dir,
+			VmID:        filepath.Base(dir),
+			Description: "Jailer chroot of a VM without a config",
+			fix: func() error {
+				return vmmMgr.removeOrphanedChroot(dir)
+			},
+		}
+		//a firecracker that is still running might be a vm someone cares about so it is left for them to stop
+		if pid, running := chrootFirecracker(dir); running {
+			problem.Description = fmt.Sprintf("Jailer chroot of a VM without a config - firecracker is still running as pid %d and has to be stopped before it can be removed", pid)
+			problem.fix = nil
+		}
+		problems = append(problems, problem)
+	}
+	return problems, nil
+}
+
+// chrootFirecracker is the pid of the firecracker left running in a chroot
+func chrootFirecracker(dir string) (int, bool) {
+	state, err := readRuntimeState(filepath.Join(dir, runtimeStateName))
+	if err != nil || !processRunning(state.Pid) {
+		return 0, false
+	}
+	return state.Pid, true
+}
+
+// removeOrphanedChroot removes a chroot and the cgroups of the vm - it is checked again that firecracker isnt running
+// in it as it could have been started since the check
+func (vmmMgr *VmmManager) removeOrphanedChroot(dir string) error {
+	id := filepath.Base(dir)
+	if pid, running := chrootFirecracker(dir); running {
+		return fmt.Errorf("Firecracker is running in %s as pid %d", dir, pid)
+	}
+	newVmCgroup(id).remove()
+	//the jailer creates the device folders as root
+	root := filepath.Join(dir, "root")
+	paths := []string{}
+	for _, jailDir := range jailDevDirs {
+		if _, err := os.Lstat(filepath.Join(root, jailDir)); err == nil {
+			paths = append(paths, filepath.Join(root, jailDir))
+		}
+	}
+	if len(paths) > 0 {
+		if err := privhelper.Default.Chown(privhelper.Owner(os.Getuid()), -1, false, paths...); err != nil {
+			return err
+		}
+	}
+	return os.RemoveAll(dir)
+}
+
+func processRunning(pid int) bool {
+	return pid > 0 && syscall.Kill(pid, 0) != syscall.ESRCH
+}
+
+func (vmmMgr *VmmManager) checkOrphanedDisks() ([]*DoctorProblem, error) {
+	problems := []*DoctorProblem{}
+	for _, targetID := range vmmMgr.Storage().Targets() {
+		target, err := vmmMgr.Storage().GetStorage(targetID)
+		if err != nil {
+			return problems, err
+		}
+		ids, err := target.DiskIDs()
+		if err != nil {
+			return problems, err
+		}
+		for _, id := range ids {
+			if vmmMgr.knownInstance(id) {
+				continue
+			}
+			id := id
+			problems = append(problems, &DoctorProblem{
+				Kind:        ProblemOrphanedDisk,
+				Resource:    target.GetURI() + "/disks/" + id,
+				VmID:        id,
+				Description: "Disks of a VM without a config",
+				fix: func() error {
+					//a vm being created with the id would have it marked before its disks were written
+					if vmmMgr.knownInstance(id) {
+						return fmt.Errorf("The disks of %s belong to a VM", id)
+					}
+					return target.DeleteDisks(id)
+				},
+			})
+		}
+	}
+	return problems, nil
+}
+
+// checkMissingDisks looks for vmms whose kernel or disks are gone - they cant be fixed as there is nothing to put
+// back
+func (vmmMgr *VmmManager) checkMissingDisks() ([]*DoctorProblem, error) {
+	problems := []*DoctorProblem{}
+	for _, vmm := range vmmMgr.allInstances() {
+		uris := []string{vmm.config.Kernel}
+		for _, dsk := range vmm.config.Disks {
+			uris = append(uris, dsk.StorageURI)
+		}
+		for _, uri := range uris {
+			if uri == "" {
+				continue
+			}
+			path, _, err := vmmMgr.Storage().ResolveStorageURI(uri)
+			if err == nil && path != "" {
+				_, err = os.Stat(path)
+			}
+			if err != nil {
+				problems = append(problems, &DoctorProblem{
+					Kind:        ProblemMissingDisk,
+					Resource:    uri,
+					VmID:        vmm.id,
+					Description: fmt.Sprintf("Disk of %s is missing: %s", vmm.config.Name, err.Error()),
+				})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// checkStaleTaps looks for taps on our bridges that dont belong to a vmm. Taps in use are left alone.
+func (vmmMgr *VmmManager) checkStaleTaps() ([]*DoctorProblem, error) {
+	taps, err := vmmMgr.networks.Taps()
+	if err != nil {
+		return nil, err
+	}
+	return vmmMgr.staleTaps(taps), nil
+}
+
+// staleTaps are the taps that arent attached and arent named after a vmm - they are only reported as a tap cant be
+// told apart from one of a stopped vmm or one that promethium didnt create so they have to be removed by hand
+func (vmmMgr *VmmManager) staleTaps(taps []*networking.TapDevice) []*DoctorProblem {
+	problems := []*DoctorProblem{}
+	for _, tap := range taps {
+		if tap.Attached || vmmMgr.tapOwner(tap.Name) != "" {
+			continue
+		}
+		problems = append(problems, &DoctorProblem{
+			Kind:        ProblemStaleTap,
+			Resource:    tap.Name,
+			Description: "Tap device on bridge " + tap.Bridge + " without a VM - it has to be removed by hand",
+		})
+	}
+	return problems
+}
+
+// tapIndexPattern is what follows the vmm id in the name of its taps - the index of the tap, after a dash for vlans
+var tapIndexPattern = regexp.MustCompile(`^-?\d+$`)
+
+// tapOwner is the vmm a tap is named after - the tap code names them with the id of the vmm followed by the index
+func (vmmMgr *VmmManager) tapOwner(name string) string {
+	for _, vmm := range vmmMgr.allInstances() {
+		if strings.HasPrefix(name, vmm.id) && tapIndexPattern.MatchString(name[len(vmm.id):]) {
+			return vmm.id
+		}
+	}
+	return ""
+}
+
+func (vmmMgr *VmmManager) checkLeakedNbd() ([]*DoctorProblem, error) {
+	problems := []*DoctorProblem{}
+	if images.QemuNbd == nil {
+		return problems, nil
+	}
+	for _, conn := range images.QemuNbd.Leaked(vmmMgr.appRootPath) {
+		device := conn.Device
+		problems = append(problems, &DoctorProblem{
+			Kind:        ProblemLeakedNbd,
+			Resource:    device,
+			Description: "Connected to " + conn.Image + " by a previous run of the daemon",
+			fix: func() error {
+				return privhelper.Default.NbdDisconnect(device)
+			},
+		})
+	}
+	return problems, nil
+}
+
+func GetDoctorReportModel(report *DoctorReport) *models.DoctorReport {
+	model := &models.DoctorReport{
+		CheckedAt: strfmt.DateTime(report.CheckedAt),
+		Problems:  make([]*models.DoctorProblem, len(report.Problems)),
+	}
+	for i, problem := range report.Problems {
+		model.Problems[i] = &models.DoctorProblem{
+			Kind:        problem.Kind,
+			Resource:    problem.Resource,
+			VMID:        problem.VmID,
+			Description: problem.Description,
+			Fixable:     problem.Fixable(),
+			Fixed:       problem.Fixed,
+		}
+		if problem.Err != nil {
+			model.Problems[i].Error = problem.Err.Error()
+		}
+	}
+	return model
+}
@@ -0,0 +1,113 @@
+package vmm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/768bit/promethium/lib/networking"
+)
+
+func TestDoctorOrphanedChroots(t *testing.T) {
+	_, cgroupCleanup := newTestCgroupRoot(t, true)
+	defer cgroupCleanup()
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	dir, err := ioutil.TempDir("", "firecracker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	mgr.fcInstanceRootPath = dir
+	addFakeVmm(mgr, "known")
+	for _, id := range []string{"known", "stale", "running"} {
+		os.MkdirAll(filepath.Join(dir, id, "root"), 0755)
+	}
+	//the test stands in for the firecracker left running in the chroot
+	state, _ := json.Marshal(&runtimeState{Pid: os.Getpid(), Started: true})
+	if err := ioutil.WriteFile(filepath.Join(dir, "running", runtimeStateName), state, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := mgr.checkOrphanedChroots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]*DoctorProblem{}
+	for _, problem := range problems {
+		found[problem.VmID] = problem
+	}
+	if len(found) != 2 || found["known"] != nil {
+		t.Fatalf("expected the chroots without a vm to be found, got %v", found)
+	}
+	if !found["stale"].Fixable() {
+		t.Error("expected a chroot nothing is running in to be fixable")
+	}
+	if found["running"].Fixable() {
+		t.Error("expected a chroot with firecracker running in it to be left alone")
+	}
+	if err := found["stale"].fix(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("expected the stale chroot to be removed, got %v", err)
+	}
+	//firecracker could have been started in it after the check
+	if err := mgr.removeOrphanedChroot(filepath.Join(dir, "running")); err == nil {
+		t.Error("expected a chroot with firecracker running in it to not be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "running", runtimeStateName)); err != nil {
+		t.Errorf("expected the chroot firecracker is running in to be kept: %v", err)
+	}
+}
+
+func TestDoctorVmsBeingCreated(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	done := mgr.beginCreate("new")
+	//its disks are written before it has a config so they arent left behind
+	if !mgr.knownInstance("new") {
+		t.Error("expected a vm being created to be known")
+	}
+	addFakeVmm(mgr, "new")
+	done()
+	if !mgr.knownInstance("new") {
+		t.Error("expected the vm to be known once it was created")
+	}
+	mgr.beginCreate("failed")()
+	if mgr.knownInstance("failed") {
+		t.Error("expected a vm that wasnt created to not be known")
+	}
+}
+
+func TestDoctorStaleTaps(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	//vmm ids are uuids which are longer than an interface name can be so their taps cant be told apart
+	id := "0b5a6a2e-5d0f-4c1b-9e57-2f4c3d8a1b6e"
+	addFakeVmm(mgr, id)
+	addFakeVmm(mgr, "vm1")
+	taps := []*networking.TapDevice{
+		{Name: id[:14] + "0", Bridge: "br0"},
+		{Name: "tap0", Bridge: "br0"},
+		{Name: "vm10", Bridge: "br0"},
+		{Name: "vm1-2", Bridge: "br0"},
+		{Name: "vm1x", Bridge: "br0"},
+		{Name: "vm20", Bridge: "br0", Attached: true},
+	}
+	problems := mgr.staleTaps(taps)
+	found := map[string]*DoctorProblem{}
+	for _, problem := range problems {
+		found[problem.Resource] = problem
+	}
+	if len(found) != 3 || found[id[:14]+"0"] == nil || found["tap0"] == nil || found["vm1x"] == nil {
+		t.Fatalf("expected the taps not named after a vm to be found, got %v", found)
+	}
+	for name, problem := range found {
+		if problem.Fixable() {
+			t.Errorf("expected tap %s to be left to be removed by hand", name)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package vmm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/768bit/promethium/lib/privhelper"
+)
+
+const (
+	fakeUninitialized = "Uninitialized"
+	fakeRunning       = "Running"
+	fakePaused        = "Paused"
+)
+
+// fakeFirecracker serves the firecracker api on the socket in a jail - it keeps the config it is given and moves
+// between the states firecracker does so FireCrackerProcess can be tested without kvm
+type fakeFirecracker struct {
+	id         string
+	socketPath string
+	server     *http.Server
+
+	//how the guest behaves - set before the process is started
+	ignoreShutdown bool          //the guest never acts on ctrl+alt+del so shutdowns time out
+	shutdownDelay  time.Duration //how long the guest takes to power off after ctrl+alt+del
+	failStart      string        //InstanceStart is refused with this fault
+
+	lock              sync.Mutex
+	state             string
+	requests          []string
+	machineConfig     map[string]interface{}
+	bootSource        map[string]interface{}
+	logger            map[string]interface{}
+	vsock             map[string]interface{}
+	balloon           map[string]interface{}
+	drives            map[string]map[string]interface{}
+	networkInterfaces map[string]map[string]interface{}
+	actions           []string
+
+	exited     chan struct{}
+	exitStatus *privhelper.ExitStatus
+}
+
+func newFakeFirecracker(id string, socketPath string) *fakeFirecracker {
+	return &fakeFirecracker{
+		id:                id,
+		socketPath:        socketPath,
+		state:             fakeUninitialized,
+		drives:            map[string]map[string]interface{}{},
+		networkInterfaces: map[string]map[string]interface{}{},
+		exited:            make(chan struct{}),
+	}
+}
+
+// listen creates the api socket - like firecracker it is there by the time the jailer has been started
+func (fc *fakeFirecracker) listen() error {
+	os.Remove(fc.socketPath)
+	listener, err := net.Listen("unix", fc.socketPath)
+	if err != nil {
+		return err
+	}
+	fc.server = &http.Server{Handler: fc}
+	go fc.server.Serve(listener)
+	return nil
+}
+
+// exit stops firecracker - the exit status is set before the socket goes so a failed poll always sees it
+func (fc *fakeFirecracker) exit(status *privhelper.ExitStatus) {
+	fc.lock.Lock()
+	if fc.exitStatus != nil {
+		fc.lock.Unlock()
+		return
+	}
+	fc.exitStatus = status
+	fc.lock.Unlock()
+	fc.server.Close()
+	os.Remove(fc.socketPath)
+	close(fc.exited)
+}
+
+// crash makes firecracker exit as if it had failed
+func (fc *fakeFirecracker) crash(code int) {
+	fc.exit(&privhelper.ExitStatus{Code: code})
+}
+
+func (fc *fakeFirecracker) getState() string {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	return fc.state
+}
+
+func (fc *fakeFirecracker) getExitStatus() *privhelper.ExitStatus {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	return fc.exitStatus
+}
+
+func (fc *fakeFirecracker) getRequests() []string {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	return append([]string{}, fc.requests...)
+}
+
+func (fc *fakeFirecracker) getActions() []string {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	return append([]string{}, fc.actions...)
+}
+
+func (fc *fakeFirecracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := map[string]interface{}{}
+	if r.ContentLength != 0 && r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeFakeFault(w, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.requests = append(fc.requests, r.Method+" "+r.URL.Path)
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/":
+		writeFakeJSON(w, map[string]interface{}{
+			"id":          fc.id,
+			"state":       fc.state,
+			"vmm_version": "0.0.0",
+		})
+	case r.Method == http.MethodGet && r.URL.Path == "/machine-config":
+		//the sdk reads the config back once it has put it
+		writeFakeJSON(w, fc.machineConfig)
+	case r.Method == http.MethodPut && r.URL.Path == "/actions":
+		fc.action(w, body)
+	case r.Method == http.MethodPut && r.URL.Path == "/snapshot/create":
+		fc.createSnapshot(w, body)
+	case r.Method == http.MethodPut && r.URL.Path == "/snapshot/load":
+		fc.loadSnapshot(w, body)
+	case r.Method == http.MethodPatch && r.URL.Path == "/vm":
+		fc.patchVM(w, body)
+	case r.Method == http.MethodGet && r.URL.Path == "/balloon/statistics":
+		if fc.balloon == nil {
+			writeFakeFault(w, "No balloon device found.")
+			return
+		}
+		//the guest gives up the memory as soon as it is asked to
+		amount, _ := fc.balloon["amount_mib"].(float64)
+		writeFakeJSON(w, map[string]interface{}{
+			"target_pages": amount * 256,
+			"actual_pages": amount * 256,
+			"target_mib":   amount,
+			"actual_mib":   amount,
+		})
+	case r.Method == http.MethodPatch && r.URL.Path == "/balloon":
+		//unlike the rest of the config the balloon can be resized once the vm is running
+		if fc.balloon == nil {
+			writeFakeFault(w, "No balloon device found.")
+			return
+		}
+		fc.balloon["amount_mib"] = body["amount_mib"]
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		//everything else configures the vm so it is only allowed before it is started
+		if fc.state != fakeUninitialized {
+			writeFakeFault(w, "The requested operation is not supported after starting the microVM.")
+			return
+		}
+		switch {
+		case r.URL.Path == "/machine-config":
+			fc.machineConfig = body
+		case r.URL.Path == "/boot-source":
+			fc.bootSource = body
+		case r.URL.Path == "/logger":
+			fc.logger = body
+		case r.URL.Path == "/vsock":
+			fc.vsock = body
+		case r.URL.Path == "/balloon":
+			fc.balloon = body
+		case len(parts) == 2 && parts[0] == "drives":
+			fc.drives[parts[1]] = body
+		case len(parts) == 2 && parts[0] == "network-interfaces":
+			fc.networkInterfaces[parts[1]] = body
+		default:
+			writeFakeFault(w, "Invalid request method and/or path: "+r.Method+" "+r.URL.Path)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeFault(w, "Invalid request method and/or path: "+r.Method+" "+r.URL.Path)
+	}
+}
+
+// action handles the actions firecracker takes - fc.lock is held
+func (fc *fakeFirecracker) action(w http.ResponseWriter, body map[string]interface{}) {
+	actionType, _ := body["action_type"].(string)
+	fc.actions = append(fc.actions, actionType)
+	switch actionType {
+	case "InstanceStart":
+		if fc.state != fakeUninitialized {
+			writeFakeFault(w, "The microVM is already running.")
+			return
+		} else if fc.bootSource == nil {
+			writeFakeFault(w, "Cannot start microvm without kernel configuration.")
+			return
+		} else if fc.failStart != "" {
+			writeFakeFault(w, fc.failStart)
+			return
+		}
+		fc.state = fakeRunning
+	case "SendCtrlAltDel":
+		if fc.state == fakeUninitialized {
+			writeFakeFault(w, "The microVM is not running.")
+			return
+		}
+		if !fc.ignoreShutdown {
+			//the guest powers off and firecracker exits cleanly
+			go func() {
+				time.Sleep(fc.shutdownDelay)
+				fc.exit(&privhelper.ExitStatus{Code: 0})
+			}()
+		}
+	case "FlushMetrics":
+	default:
+		writeFakeFault(w, "Unknown action: "+actionType)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// patchVM pauses and resumes the vm - fc.lock is held
+func (fc *fakeFirecracker) patchVM(w http.ResponseWriter, body map[string]interface{}) {
+	if fc.state == fakeUninitialized {
+		writeFakeFault(w, "The microVM is not running.")
+		return
+	}
+	switch body["state"] {
+	case "Paused":
+		fc.state = fakePaused
+	case "Resumed":
+		fc.state = fakeRunning
+	default:
+		writeFakeFault(w, fmt.Sprintf("Invalid vm state: %v", body["state"]))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fakeSnapshot is what the fake writes as the device state of a snapshot - the memory file just names the vm
+type fakeSnapshot struct {
+	MachineConfig map[string]interface{}            `json:"machine_config"`
+	Drives        map[string]map[string]interface{} `json:"drives"`
+}
+
+// createSnapshot writes the snapshot files into the jail like firecracker does - fc.lock is held
+func (fc *fakeFirecracker) createSnapshot(w http.ResponseWriter, body map[string]interface{}) {
+	if fc.state != fakePaused {
+		writeFakeFault(w, "The microVM must be paused before a snapshot is created.")
+		return
+	}
+	state, err := json.Marshal(&fakeSnapshot{MachineConfig: fc.machineConfig, Drives: fc.drives})
+	if err != nil {
+		writeFakeFault(w, err.Error())
+		return
+	}
+	root := filepath.Dir(fc.socketPath)
+	statePath, _ := body["snapshot_path"].(string)
+	memPath, _ := body["mem_file_path"].(string)
+	if err := ioutil.WriteFile(filepath.Join(root, statePath), state, 0600); err != nil {
+		writeFakeFault(w, err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, memPath), []byte("memory of "+fc.id), 0600); err != nil {
+		writeFakeFault(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadSnapshot restores the config in the snapshot files in the jail - the vm is left paused - fc.lock is held
+func (fc *fakeFirecracker) loadSnapshot(w http.ResponseWriter, body map[string]interface{}) {
+	if fc.state != fakeUninitialized {
+		writeFakeFault(w, "Loading a microVM snapshot not allowed after configuring boot-specific resources.")
+		return
+	}
+	root := filepath.Dir(fc.socketPath)
+	statePath, _ := body["snapshot_path"].(string)
+	memPath, _ := body["mem_file_path"].(string)
+	if _, err := os.Stat(filepath.Join(root, memPath)); err != nil {
+		writeFakeFault(w, "Cannot load snapshot memory: "+err.Error())
+		return
+	}
+	state, err := ioutil.ReadFile(filepath.Join(root, statePath))
+	if err != nil {
+		writeFakeFault(w, "Cannot load snapshot state: "+err.Error())
+		return
+	}
+	snap := &fakeSnapshot{}
+	if err := json.Unmarshal(state, snap); err != nil {
+		writeFakeFault(w, "Cannot load snapshot state: "+err.Error())
+		return
+	}
+	fc.machineConfig = snap.MachineConfig
+	fc.drives = snap.Drives
+	fc.state = fakePaused
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeFakeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeFakeFault(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(&firecrackerAPIError{FaultMessage: msg})
+}
+
+// fakeJailerProcess is the jailer of a fakeFirecracker - it runs until firecracker exits or it is signalled
+type fakeJailerProcess struct {
+	fc *fakeFirecracker
+}
+
+func (proc *fakeJailerProcess) Pid() int {
+	return os.Getpid()
+}
+
+func (proc *fakeJailerProcess) Signal(sig syscall.Signal) error {
+	if proc.fc.getExitStatus() != nil {
+		return errors.New("The process has already exited")
+	}
+	proc.fc.exit(&privhelper.ExitStatus{Code: -1, Signal: sig.String()})
+	return nil
+}
+
+func (proc *fakeJailerProcess) Wait() error {
+	<-proc.fc.exited
+	if status := proc.fc.getExitStatus(); !status.Success() {
+		return errors.New(status.String())
+	}
+	return nil
+}
+
+func (proc *fakeJailerProcess) ExitStatus() *privhelper.ExitStatus {
+	return proc.fc.getExitStatus()
+}
+
+func (proc *fakeJailerProcess) Console() *os.File {
+	return nil
+}
+
+func (proc *fakeJailerProcess) Stderr() *os.File {
+	return nil
+}
+
+// fakeRunner starts a fakeFirecracker in place of the jailer - the privileged operations do nothing apart from the
+// cgroup ones below cgroupRoot which are done on the files there and recorded
+type fakeRunner struct {
+	//configure is called with each firecracker before its socket is created
+	configure func(fc *fakeFirecracker)
+	//a temporary directory standing in for the cgroup hierarchy
+	cgroupRoot string
+
+	lock         sync.Mutex
+	started      []*fakeFirecracker
+	specs        []*privhelper.JailerSpec
+	cgroupWrites map[string][]string
+}
+
+func (r *fakeRunner) StartJailer(spec *privhelper.JailerSpec) (privhelper.Process, error) {
+	if err := r.jailCgroup(spec); err != nil {
+		return nil, err
+	}
+	socketPath := filepath.Join(spec.ChrootBaseDir, filepath.Base(spec.ExecFile), spec.ID, "root", "api.socket")
+	fc := newFakeFirecracker(spec.ID, socketPath)
+	if r.configure != nil {
+		r.configure(fc)
+	}
+	if err := fc.listen(); err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	r.started = append(r.started, fc)
+	r.specs = append(r.specs, spec)
+	r.lock.Unlock()
+	return &fakeJailerProcess{fc: fc}, nil
+}
+
+// jailCgroup does what the jailer does with the cgroups it is given - it makes the cgroup of the vm below the parent
+// cgroup, which has to be there, and writes the limits to it
+func (r *fakeRunner) jailCgroup(spec *privhelper.JailerSpec) error {
+	if spec.ParentCgroup == "" {
+		return nil
+	}
+	parent := filepath.Join(r.cgroupRoot, spec.ParentCgroup)
+	if _, err := os.Stat(parent); err != nil {
+		return fmt.Errorf("Jailer couldnt find the parent cgroup: %s", err.Error())
+	}
+	if err := r.CgroupMkdir(filepath.Join(parent, spec.ID)); err != nil {
+		return err
+	}
+	for _, cgroup := range spec.Cgroups {
+		parts := strings.SplitN(cgroup, "=", 2)
+		if err := r.CgroupWrite(filepath.Join(parent, spec.ID, parts[0]), parts[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestSpec is the spec of the jailer that was started last
+func (r *fakeRunner) latestSpec() *privhelper.JailerSpec {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.specs) == 0 {
+		return nil
+	}
+	return r.specs[len(r.specs)-1]
+}
+
+// AttachJailer picks up the firecracker of the vm that is still running - the fake jailers all have the pid of the
+// test
+func (r *fakeRunner) AttachJailer(spec *privhelper.JailerSpec, pid int) (privhelper.Process, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := len(r.started) - 1; i >= 0; i-- {
+		fc := r.started[i]
+		if fc.id == spec.ID && pid == os.Getpid() && fc.getExitStatus() == nil {
+			return &fakeJailerProcess{fc: fc}, nil
+		}
+	}
+	return nil, fmt.Errorf("Process %d is not the jailer of %s", pid, spec.ID)
+}
+
+// latest is the firecracker that was started last
+func (r *fakeRunner) latest() *fakeFirecracker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.started) == 0 {
+		return nil
+	}
+	return r.started[len(r.started)-1]
+}
+
+func (r *fakeRunner) startCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.started)
+}
+
+func (r *fakeRunner) Chown(uid int, gid int, recursive bool, paths ...string) error { return nil }
+func (r *fakeRunner) Chmod(mode os.FileMode, recursive bool, paths ...string) error { return nil }
+func (r *fakeRunner) NbdConnect(device string, format string, path string) error    { return nil }
+func (r *fakeRunner) NbdDisconnect(device string) error                             { return nil }
+func (r *fakeRunner) Partprobe(device string) error                                 { return nil }
+func (r *fakeRunner) QemuImgConvert(source string, dest string, format string) error {
+	return errors.New("qemu-img is not available to the fake runner")
+}
+func (r *fakeRunner) CreateTap(name string) error { return nil }
+func (r *fakeRunner) DeleteTap(name string) error { return nil }
+
+// inCgroupRoot is whether a cgroup operation is done by the fake - nothing outside of its cgroup root is touched
+func (r *fakeRunner) inCgroupRoot(path string) bool {
+	return r.cgroupRoot != "" && strings.HasPrefix(path, r.cgroupRoot+"/")
+}
+
+func (r *fakeRunner) CgroupMkdir(path string) error {
+	if !r.inCgroupRoot(path) {
+		return nil
+	}
+	return os.MkdirAll(path, 0755)
+}
+
+func (r *fakeRunner) CgroupRmdir(path string) error {
+	if !r.inCgroupRoot(path) {
+		return nil
+	}
+	return os.RemoveAll(path)
+}
+
+// CgroupWrite writes the value to the control file - like cgroupfs it fails if the cgroup isnt there
+func (r *fakeRunner) CgroupWrite(path string, value string) error {
+	if !r.inCgroupRoot(path) {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cgroupWrites == nil {
+		r.cgroupWrites = map[string][]string{}
+	}
+	r.cgroupWrites[path] = append(r.cgroupWrites[path], value)
+	return ioutil.WriteFile(path, []byte(value+"\n"), 0644)
+}
+
+// writesTo returns the values written to a control file relative to the cgroup root
+func (r *fakeRunner) writesTo(file string) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.cgroupWrites[filepath.Join(r.cgroupRoot, file)]...)
+}
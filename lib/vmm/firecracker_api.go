@@ -0,0 +1,149 @@
+package vmm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+// firecrackerAPI is a minimal client for the parts of the firecracker API that the go sdk doesnt cover yet
+// (pausing, snapshots, hybrid vsock and the balloon) - it talks to the same unix socket as the sdk client.
+type firecrackerAPI struct {
+	socketPath string
+	client     *http.Client
+}
+
+func newFirecrackerAPI(socketPath string) *firecrackerAPI {
+	return &firecrackerAPI{
+		socketPath: socketPath,
+		client: &http.Client{
+			Timeout: 5 * time.Minute, //snapshots of large guests can take a while to write out
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+type firecrackerAPIError struct {
+	FaultMessage string `json:"fault_message"`
+}
+
+func (api *firecrackerAPI) do(method string, path string, body interface{}, result interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = b
+	}
+	req, err := http.NewRequest(method, "http://localhost"+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := api.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		apiErr := &firecrackerAPIError{}
+		if json.Unmarshal(respBody, apiErr) == nil && apiErr.FaultMessage != "" {
+			return errors.New("Firecracker API error: " + apiErr.FaultMessage)
+		}
+		return fmt.Errorf("Firecracker API error: %s %s returned %d", method, path, resp.StatusCode)
+	}
+	if result != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, result)
+	}
+	return nil
+}
+
+// PatchVMState sets the vm state to Paused or Resumed
+func (api *firecrackerAPI) PatchVMState(state string) error {
+	return api.do(http.MethodPatch, "/vm", map[string]string{"state": state}, nil)
+}
+
+// CreateSnapshot writes a full snapshot of a paused vm - paths are inside the jail
+func (api *firecrackerAPI) CreateSnapshot(statePath string, memPath string) error {
+	return api.do(http.MethodPut, "/snapshot/create", map[string]interface{}{
+		"snapshot_type": "Full",
+		"snapshot_path": statePath,
+		"mem_file_path": memPath,
+	}, nil)
+}
+
+// LoadSnapshot loads a snapshot into a freshly started firecracker that hasnt been configured yet
+func (api *firecrackerAPI) LoadSnapshot(statePath string, memPath string) error {
+	return api.do(http.MethodPut, "/snapshot/load", map[string]interface{}{
+		"snapshot_path": statePath,
+		"mem_file_path": memPath,
+	}, nil)
+}
+
+// PutVsock adds a hybrid vsock device - guest ports are reached through the unix socket at udsPath in the jail
+func (api *firecrackerAPI) PutVsock(cid uint32, udsPath string) error {
+	return api.do(http.MethodPut, "/vsock", map[string]interface{}{
+		"vsock_id":  "vsock0",
+		"guest_cid": cid,
+		"uds_path":  udsPath,
+	}, nil)
+}
+
+// PutBalloon adds the balloon device - it has to be added before the vm boots
+func (api *firecrackerAPI) PutBalloon(amountMiB int64, deflateOnOOM bool, statsInterval int64) error {
+	return api.do(http.MethodPut, "/balloon", map[string]interface{}{
+		"amount_mib":               amountMiB,
+		"deflate_on_oom":           deflateOnOOM,
+		"stats_polling_interval_s": statsInterval,
+	}, nil)
+}
+
+// PatchBalloon changes the size the guest driver inflates the balloon to
+func (api *firecrackerAPI) PatchBalloon(amountMiB int64) error {
+	return api.do(http.MethodPatch, "/balloon", map[string]interface{}{
+		"amount_mib": amountMiB,
+	}, nil)
+}
+
+// GetBalloonStatistics returns the latest statistics reported by the guest balloon driver - values are in bytes
+// apart from the page, mib and fault counts
+func (api *firecrackerAPI) GetBalloonStatistics() (map[string]float64, error) {
+	stats := map[string]float64{}
+	if err := api.do(http.MethodGet, "/balloon/statistics", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// WaitForSocket waits for firecracker to start listening on its api socket
+func (api *firecrackerAPI) WaitForSocket(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		conn, err := net.Dial("unix", api.socketPath)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.New("Timed out waiting for firecracker api socket: " + err.Error())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,212 @@
+package vmm
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/768bit/firecracker-go-sdk"
+	models "github.com/768bit/firecracker-go-sdk/client/models"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/768bit/promethium/lib/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	logFifoName     = "log.fifo"
+	metricsFifoName = "metrics.fifo"
+)
+
+const (
+	SerialLogSource      = "serial"
+	FirecrackerLogSource = "firecracker"
+)
+
+// loggingHandler replaces the sdk logging handler which uses the same path on the host and in firecracker -
+// that doesnt work once firecracker is inside the jail
+func (fcp *FireCrackerProcess) loggingHandler() firecracker.Handler {
+	return firecracker.Handler{
+		Name: firecracker.BootstrapLoggingHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			if err := fcp.setupLogging(ctx); err != nil {
+				fcp.logger.Warnf("Unable to setup firecracker logging and metrics: %s. Continuing anyway.", err.Error())
+			}
+			return nil
+		},
+	}
+}
+
+// setupLogging creates the log and metrics fifos in the jail, starts reading from them and then tells
+// firecracker to write to them
+func (fcp *FireCrackerProcess) setupLogging(ctx context.Context) error {
+	fcp.closeFifos()
+	logPath := filepath.Join(fcp.chrootPath, logFifoName)
+	metricsPath := filepath.Join(fcp.chrootPath, metricsFifoName)
+	for _, path := range []string{logPath, metricsPath} {
+		os.Remove(path)
+		if err := syscall.Mkfifo(path, 0660); err != nil {
+			return err
+		}
+		//the umask would take away the write access of the jail group
+		if err := os.Chmod(path, 0660); err != nil {
+			return err
+		}
+	}
+	if err := fcp.openFifos(); err != nil {
+		return err
+	}
+
+	_, err := fcp.conn.PutLogger(ctx, &models.Logger{
+		LogFifo:       firecracker.String("/" + logFifoName),
+		MetricsFifo:   firecracker.String("/" + metricsFifoName),
+		Level:         firecracker.String("Warning"),
+		ShowLevel:     firecracker.Bool(true),
+		ShowLogOrigin: firecracker.Bool(false),
+		Options:       []string{},
+	})
+	return err
+}
+
+// openFifos starts reading from the fifos in the jail - firecracker keeps writing to them across a restart of the
+// daemon so they are only opened again when reattaching
+func (fcp *FireCrackerProcess) openFifos() error {
+	fcp.closeFifos()
+	//opened read/write so neither end blocks waiting for the other and we dont see EOF between flushes
+	logFifo, err := os.OpenFile(filepath.Join(fcp.chrootPath, logFifoName), os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	metricsFifo, err := os.OpenFile(filepath.Join(fcp.chrootPath, metricsFifoName), os.O_RDWR, 0)
+	if err != nil {
+		logFifo.Close()
+		return err
+	}
+	fcp.logFifo = logFifo
+	fcp.metricsFifo = metricsFifo
+	go fcp.readLogFifo(logFifo, fcp.logger)
+	go fcp.metrics.Consume(metricsFifo)
+	return nil
+}
+
+func (fcp *FireCrackerProcess) readLogFifo(logFifo *os.File, logger *log.Entry) {
+	logger = logger.WithField("source", "firecracker")
+	scanner := bufio.NewScanner(logFifo)
+	for scanner.Scan() {
+		logger.Warn(scanner.Text())
+		if fcp.firecrackerLog != nil {
+			fcp.firecrackerLog.WriteLine(scanner.Text())
+		}
+	}
+}
+
+func (fcp *FireCrackerProcess) closeFifos() {
+	if fcp.logFifo != nil {
+		fcp.logFifo.Close()
+		fcp.logFifo = nil
+	}
+	if fcp.metricsFifo != nil {
+		fcp.metricsFifo.Close()
+		fcp.metricsFifo = nil
+	}
+}
+
+// FlushMetrics asks firecracker to write its metrics to the fifo now instead of waiting for the next interval
+func (fcp *FireCrackerProcess) FlushMetrics() error {
+	if started, _ := fcp.runState(); !started || fcp.conn == nil {
+		return nil
+	}
+	action := models.InstanceActionInfoActionTypeFlushMetrics
+	_, err := fcp.conn.CreateSyncAction(context.Background(), &models.InstanceActionInfo{
+		ActionType: &action,
+	})
+	return err
+}
+
+func (fcp *FireCrackerProcess) Metrics() *metrics.VmmMetricsSnapshot {
+	return fcp.metrics.Snapshot()
+}
+
+// Pid returns the pid of the jailer process (started by the privileged helper) or 0 when it isnt running
+func (fcp *FireCrackerProcess) Pid() int {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	if !fcp.jailerProcRunning || fcp.jailerProc == nil {
+		return 0
+	}
+	return fcp.jailerProc.Pid()
+}
+
+// openLogs opens the serial and firecracker logs - they live next to the jail rather than in it so they are kept
+// when the jail is cleaned up
+func (fcp *FireCrackerProcess) openLogs() error {
+	serialLog, firecrackerLog, err := openInstanceLogs(fcp.id)
+	if err != nil {
+		return err
+	}
+	fcp.serialLog = serialLog
+	fcp.firecrackerLog = firecrackerLog
+	return nil
+}
+
+// openInstanceLogs opens the serial and firecracker logs of the vmm with the id given
+func openInstanceLogs(id string) (*logging.Log, *logging.Log, error) {
+	logsPath := filepath.Join(ROOT_PATH, "firecracker", id, "logs")
+	if err := os.MkdirAll(logsPath, 0750); err != nil {
+		return nil, nil, err
+	}
+	maxSize := LOGS_CONFIG.MaxSizeMB * 1024 * 1024
+	serialLog, err := logging.NewLog(SerialLogSource, filepath.Join(logsPath, "serial.log"), maxSize, LOGS_CONFIG.MaxFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+	firecrackerLog, err := logging.NewLog(FirecrackerLogSource, filepath.Join(logsPath, "firecracker.log"), maxSize, LOGS_CONFIG.MaxFiles)
+	if err != nil {
+		serialLog.Close()
+		return nil, nil, err
+	}
+	return serialLog, firecrackerLog, nil
+}
+
+// captureSerial reads the serial console for as long as firecracker runs so output isnt lost (or firecracker
+// blocked) when nobody is attached - attached clients are disconnected when firecracker exits
+func (fcp *FireCrackerProcess) captureSerial(outP io.Reader) {
+	buff := make([]byte, 4096)
+	for {
+		n, err := outP.Read(buff)
+		if n > 0 {
+			if fcp.serialLog != nil {
+				fcp.serialLog.Write(buff[:n])
+			}
+			fcp.console.Write(buff[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	fcp.console.SetInput(nil)
+	fcp.console.SetResizer(nil)
+	fcp.console.DetachAll()
+}
+
+func (fcp *FireCrackerProcess) captureStderr(errP io.Reader) {
+	scanner := bufio.NewScanner(errP)
+	for scanner.Scan() {
+		if fcp.firecrackerLog != nil {
+			fcp.firecrackerLog.WriteLine(scanner.Text())
+		}
+	}
+}
+
+// Log returns the log for the source given or nil if there isnt one
+func (fcp *FireCrackerProcess) Log(source string) *logging.Log {
+	switch source {
+	case SerialLogSource:
+		return fcp.serialLog
+	case FirecrackerLogSource:
+		return fcp.firecrackerLog
+	}
+	return nil
+}
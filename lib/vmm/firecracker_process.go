@@ -8,13 +8,18 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/768bit/firecracker-go-sdk"
 	models "github.com/768bit/firecracker-go-sdk/client/models"
 	"github.com/768bit/firecracker-go-sdk/client/operations"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/768bit/promethium/lib/pty"
 	"github.com/768bit/vutils"
 	"github.com/cloudius-systems/capstan/core"
 	"github.com/cloudius-systems/capstan/util"
@@ -22,6 +27,10 @@ import (
 )
 
 const UNKOWN_STATUS = "UNKNOWN_STATUS"
+const PAUSED_STATUS = "Paused"
+
+// how often firecracker is asked for the state of the instance once it has started
+const statusPollInterval = 2 * time.Second
 
 func NewFireCrackerProcess(id string, name string, cmd string, entryPoint string, cpus int64, memory int64, imageSize int64, autoStart bool) (*FireCrackerProcess, error) {
 	fcp := &FireCrackerProcess{
@@ -39,7 +48,7 @@ func NewFireCrackerProcess(id string, name string, cmd string, entryPoint string
 	return fcp.init()
 }
 
-func NewFireCrackerProcessImg(id string, name string, boot string, cpus int64, memory int64, kernelPath string, driveImages []string, networkInterfaces []string, autoStart bool) (*FireCrackerProcess, error) {
+func NewFireCrackerProcessImg(id string, name string, boot string, cpus int64, memory int64, kernelPath string, driveImages []string, networkInterfaces []string, autoStart bool, restartPolicy *config.VmmRestartPolicy, cpu *config.VmmCPUConfig, resources *config.VmmResourcesConfig, jail JailIdentity) (*FireCrackerProcess, error) {
 	if networkInterfaces == nil {
 		networkInterfaces = []string{}
 	}
@@ -53,8 +62,13 @@ func NewFireCrackerProcessImg(id string, name string, boot string, cpus int64, m
 		imageList:         driveImages,
 		cmd:               boot,
 		autoStart:         autoStart,
+		restartPolicy:     restartPolicy,
 		networkInterfaces: networkInterfaces,
+		cpu:               cpu,
+		resources:         resources,
+		jail:              jail,
 	}
+	//the limits are given before init as it starts the jailer that puts firecracker in the cgroup with them
 	return fcp.init()
 }
 
@@ -71,14 +85,20 @@ type FireCrackerProcess struct {
 
 	memory int64
 	cpus   int64
+	cpu    *config.VmmCPUConfig
+	jail   JailIdentity
 
 	imageList         []string
 	networkInterfaces []string
 
-	jailerProc            *vutils.ExecAsyncCommand
-	jailerProcRunning     bool
+	jailerProc        privhelper.Process
+	jailerProcRunning bool
+	//the firecracker process found under the jailer - it is looked up again when the jailer changes
+	firecrackerProcPid    int
+	firecrackerProcOf     int
 	jailerBinaryPath      string
 	firecrackerBinaryPath string
+	firecrackerVersion    string
 	kernelPath            string
 	chrootPath            string
 	socketPath            string
@@ -93,18 +113,47 @@ type FireCrackerProcess struct {
 	Status                string
 	cloudInit             []byte
 
-	isPolling bool
-	exitChan  chan error
-	killChan  chan error
-	stateChan chan string
+	metrics     *metrics.VmmMetrics
+	logFifo     *os.File
+	metricsFifo *os.File
+
+	serialLog      *logging.Log
+	firecrackerLog *logging.Log
+	console        *ConsoleBroker
+	vsock          *config.VmmVsockConfig
+	resources      *config.VmmResourcesConfig
+	//guards the balloon - it is resized by the api while the statistics are polled
+	balloonLock      sync.Mutex
+	balloon          *config.VmmBalloonConfig
+	balloonStatsUnit time.Duration //the unit of the statistics interval of the balloon
+	//asks the guest to shut down before falling back to ctrl+alt+del
+	requestShutdown func() error
+	//runs the restarts of the restart policy as an operation of the vmm
+	runOperation func(op string, fn func() error) error
+
+	//guards the run state below - it is shared with the polling, exit and restart goroutines
+	lock         sync.Mutex
+	isPolling    bool
+	pollInterval time.Duration
+	exitChan     chan error
+	killChan     chan error
 
 	isRestarting   bool
 	isShuttingDown bool
 	isStopping     bool
 	isStarted      bool
+	isPaused       bool
 
 	procExitWaitChan chan error
 
+	restartPolicy  *config.VmmRestartPolicy
+	restartTimer   *time.Timer
+	stoppedByUser  bool //the vmm was stopped or shut down on purpose so its exit isnt a crash
+	crashCount     int64
+	restartCount   int64 //every restart made by the restart policy - unlike crashCount it is never reset
+	lastExitReason string
+	lastStartedAt  time.Time
+
 	err error
 	ctx context.Context
 
@@ -122,9 +171,25 @@ func (fcp *FireCrackerProcess) init() (*FireCrackerProcess, error) {
 	fcp.chrootPath = filepath.Join(ROOT_PATH, "firecracker", fcp.id, "root")
 	//os.RemoveAll(fcp.chrootPath)
 	fcp.socketPath = filepath.Join(fcp.chrootPath, "api.socket")
+	fcp.pollInterval = statusPollInterval
+	fcp.balloonStatsUnit = time.Second
 	fcp.procExitWaitChan = make(chan error)
 	fcp.exitChan = make(chan error)
 	fcp.killChan = make(chan error)
+	fcp.metrics = metrics.NewVmmMetrics()
+	fcp.console = NewConsoleBroker(DefaultConsoleScrollback)
+	if err := fcp.openLogs(); err != nil {
+		log.Warnf("Unable to open logs for %s: %s. Output will not be kept.", fcp.id, err.Error())
+	}
+	if fcp.restartPolicy == nil {
+		fcp.restartPolicy = (&config.VmmConfig{AutoStart: fcp.autoStart}).GetRestartPolicy()
+	}
+	if _, err := os.Stat(fcp.runtimeStatePath()); err == nil {
+		//firecracker was left running by a previous run of the daemon - the VmmManager reattaches to it once the
+		//vmm is set up
+		fcp.Status = UNKOWN_STATUS
+		return fcp, nil
+	}
 	fcp.cleanUp()
 	fcp.Status = UNKOWN_STATUS
 	if fcp.jailerProc == nil {
@@ -133,39 +198,173 @@ func (fcp *FireCrackerProcess) init() (*FireCrackerProcess, error) {
 			return nil, err
 		}
 	}
-	if fcp.autoStart {
-		if err := fcp.Start(); err != nil {
-			return fcp, err
-		}
-	}
+	//starting on boot is handled by the VmmManager so dependencies can be started in order
 	return fcp, nil
 }
 
+// Reattach picks up the firecracker left running by a previous run of the daemon - it is true when it was picked up.
+// When there isnt one (or it cant be used) the vmm is set up from scratch.
+func (fcp *FireCrackerProcess) Reattach() (bool, error) {
+	fcp.lock.Lock()
+	attached := fcp.jailerProc != nil
+	fcp.lock.Unlock()
+	if attached {
+		return false, nil
+	}
+	err := fcp.reattach()
+	if err == nil {
+		return true, nil
+	}
+	if !os.IsNotExist(err) {
+		log.Warnf("Unable to reattach to firecracker for %s: %s", fcp.id, err.Error())
+	}
+	fcp.cleanUp()
+	return false, fcp.startFirecrackerProcess()
+}
+
+func (fcp *FireCrackerProcess) reattach() error {
+	state, err := readRuntimeState(fcp.runtimeStatePath())
+	if err != nil {
+		return err
+	}
+	proc, err := privhelper.Default.AttachJailer(fcp.jailerSpec(), state.Pid)
+	if err != nil {
+		return err
+	}
+	fcp.logger = log.NewEntry(log.New())
+	fcp.conn = firecracker.NewClient(fcp.socketPath, fcp.logger, true)
+	if _, err := fcp.conn.GetInstanceDescription(); err != nil {
+		//firecracker is there but it cant be used without its api so it goes
+		proc.Signal(syscall.SIGKILL)
+		proc.Wait()
+		closeJailerStdio(proc)
+		return err
+	}
+	fcp.attachJailer(proc)
+	log.Printf("Reattached to firecracker %d for %s", state.Pid, fcp.id)
+	if !state.Started {
+		//it is waiting to be configured - Start carries on from here
+		return nil
+	}
+	fcp.ctx, fcp.cancelFunc = context.WithCancel(context.Background())
+	fcp.fcConfig = firecracker.Config{
+		SocketPath: fcp.socketPath,
+		MachineCfg: fcp.machineConfiguration(),
+	}
+	//the machine is only kept for shutdowns - firecracker is already configured
+	if m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn)); err != nil {
+		fcp.logger.Warnf("Unable to create the machine for %s: %s. It can only be stopped.", fcp.id, err.Error())
+	} else {
+		fcp.machine = m
+	}
+	if err := fcp.openFifos(); err != nil {
+		fcp.logger.Warnf("Unable to reopen firecracker logging and metrics: %s. Continuing anyway.", err.Error())
+	}
+	fcp.lock.Lock()
+	fcp.isStarted = true
+	fcp.isPaused = state.Paused
+	fcp.lastStartedAt = state.StartedAt
+	fcp.lock.Unlock()
+	fcp.beginPollingLoop()
+	return nil
+}
+
+// Detach stops looking after firecracker without stopping it so a restarted daemon can attach to it again
+func (fcp *FireCrackerProcess) Detach() error {
+	fcp.lock.Lock()
+	fcp.cancelRestart()
+	fcp.isPolling = false
+	exited := fcp.jailerProc == nil || fcp.jailerProc.ExitStatus() != nil
+	fcp.lock.Unlock()
+	if exited {
+		return nil
+	}
+	fcp.saveRuntimeState()
+	return nil
+}
+
 func (fcp *FireCrackerProcess) startFirecrackerProcess() error {
-	vutils.Files.CreateDirIfNotExist(fcp.chrootPath)
-	fcp.jailerProc = vutils.Exec.CreateAsyncCommand("sudo", false, fcp.jailerBinaryPath,
-		"--id", fcp.id,
-		"--node", "0",
-		"--exec-file", fcp.firecrackerBinaryPath,
-		"--uid", strconv.Itoa(os.Getuid()),
-		"--gid", strconv.Itoa(os.Getgid()),
-		"--chroot-base-dir", ROOT_PATH) // //.CaptureStdoutAndStdErr(false, false)
-	fcp.jailerProc.Proc.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-	}
-	e := fcp.jailerProc.Start()
-	if e == nil {
-		fmt.Println("Firecracker started")
-		fcp.jailerProcRunning = true
+	if err := fcp.prepareJailDir(); err != nil {
+		return err
+	}
+	cg := newVmCgroup(fcp.id)
+	cg.removeThreadCgroups()
+	if fcp.currentResources() != nil {
+		//the jailer puts firecracker in the cgroup of the vm before it execs it so the controllers have to be enabled
+		if err := cg.prepare(resourceControllers...); err != nil {
+			return fmt.Errorf("Unable to create the cgroup of %s: %s", fcp.id, err.Error())
+		}
+	}
+	proc, e := privhelper.Default.StartJailer(fcp.jailerSpec())
+	if e != nil {
+		fmt.Println("Error starting jailer/firecracker: " + e.Error())
+		return e
+	}
+	fmt.Println("Firecracker started")
+	fcp.attachJailer(proc)
+	fcp.saveRuntimeState()
+	return nil
+}
+
+func (fcp *FireCrackerProcess) jailerSpec() *privhelper.JailerSpec {
+	spec := &privhelper.JailerSpec{
+		Jailer:        fcp.jailerBinaryPath,
+		ID:            fcp.id,
+		Node:          fcp.numaNode(),
+		ExecFile:      fcp.firecrackerBinaryPath,
+		ChrootBaseDir: ROOT_PATH,
+		UID:           fcp.jail.UID,
+		GID:           fcp.jail.GID,
+	}
+	if resources := fcp.currentResources(); resources != nil {
+		spec.CgroupVersion = 2
+		spec.ParentCgroup = "firecracker"
+		spec.Cgroups = fcp.jailerCgroups(resources)
+	}
+	return spec
+}
+
+// attachJailer takes the output of the jailer and waits for it to exit - a jailer adopted without its stdio has no
+// serial console until it is started again
+func (fcp *FireCrackerProcess) attachJailer(proc privhelper.Process) {
+	fcp.lock.Lock()
+	fcp.jailerProc = proc
+	fcp.jailerProcRunning = true
+	fcp.lock.Unlock()
+	if ptm := proc.Console(); ptm != nil {
+		fcp.console.SetInput(ptm)
+		fcp.console.SetResizer(func(cols int, rows int) error {
+			return pty.Setsize(ptm, cols, rows)
+		})
 		go func() {
-			fcp.procExitWaitChan <- fcp.jailerProc.Wait()
-			fmt.Println("Firecracker exited")
-			fcp.jailerProcRunning = false
+			fcp.captureSerial(ptm)
+			ptm.Close()
+		}()
+	}
+	if errP := proc.Stderr(); errP != nil {
+		go func() {
+			fcp.captureStderr(errP)
+			errP.Close()
 		}()
-	} else {
-		fmt.Println("Error starting jailer/firecracker: " + e.Error())
 	}
-	return e
+	go func() {
+		err := proc.Wait()
+		fmt.Println("Firecracker exited")
+		fcp.lock.Lock()
+		if fcp.jailerProc == proc {
+			fcp.jailerProcRunning = false
+		}
+		fcp.lock.Unlock()
+		fcp.procExitWaitChan <- err
+	}()
+}
+
+func closeJailerStdio(proc privhelper.Process) {
+	for _, file := range []*os.File{proc.Console(), proc.Stderr()} {
+		if file != nil {
+			file.Close()
+		}
+	}
 }
 
 func (fcp *FireCrackerProcess) checkImage() {
@@ -173,11 +372,46 @@ func (fcp *FireCrackerProcess) checkImage() {
 }
 
 func (fcp *FireCrackerProcess) GetStatus() string {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
 	return fcp.Status
 }
 
+func (fcp *FireCrackerProcess) GetCrashCount() int64 {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.crashCount
+}
+
+func (fcp *FireCrackerProcess) GetRestartCount() int64 {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.restartCount
+}
+
+func (fcp *FireCrackerProcess) GetLastExitReason() string {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.lastExitReason
+}
+
+// runState returns whether the vmm is started and whether it is paused
+func (fcp *FireCrackerProcess) runState() (bool, bool) {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.isStarted, fcp.isPaused
+}
+
+// jailerRunning returns whether there is a firecracker for the vmm that hasnt exited
+func (fcp *FireCrackerProcess) jailerRunning() bool {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.jailerProcRunning && fcp.jailerProc != nil
+}
+
 func (fcp *FireCrackerProcess) Send(input string) error {
-	return fcp.jailerProc.Write([]byte(input))
+	_, err := fcp.console.writeInput([]byte(input))
+	return err
 }
 
 func (fcp *FireCrackerProcess) SetCloudInit(cloudConfig []byte) {
@@ -196,13 +430,12 @@ func (fcp *FireCrackerProcess) Build() error {
 	return nil
 }
 
-func (fcp *FireCrackerProcess) Console() (io.ReadCloser, io.ReadCloser, io.WriteCloser, error) {
-	if fcp.jailerProc == nil || fcp.Status != "Running" {
-		return nil, nil, nil, errors.New("Cannot connect to console of non running VM")
-	} else {
-		outP, errP, inP := fcp.jailerProc.GetPipes()
-		return outP, errP, inP, nil
+// Console attaches a client to the serial console broker - read only clients only see the output
+func (fcp *FireCrackerProcess) Console(readOnly bool) (io.ReadWriteCloser, error) {
+	if !fcp.jailerRunning() || fcp.GetStatus() != "Running" {
+		return nil, errors.New("Cannot connect to console of non running VM")
 	}
+	return fcp.console.Attach(readOnly, true), nil
 }
 
 func (fcp *FireCrackerProcess) runBuild() (*core.Image, *util.Repo, string, error) {
@@ -216,84 +449,150 @@ func (fcp *FireCrackerProcess) beginPollingLoop() {
 		fcp.exitChan <- err
 		return
 	}
+	fcp.lock.Lock()
 	fcp.isPolling = true
+	fcp.lock.Unlock()
+	go fcp.pollBalloonStatistics()
 	go func() {
 		for {
-			time.Sleep(time.Second * 2)
-			if !fcp.isPolling {
+			time.Sleep(fcp.pollInterval)
+			fcp.lock.Lock()
+			polling := fcp.isPolling
+			fcp.lock.Unlock()
+			if !polling {
 				return
 			}
 			//perform the polling..
 			err := fcp.pollStatus()
 			if err != nil {
+				fcp.lock.Lock()
+				paused := fcp.isPaused && fcp.jailerProcRunning
+				fcp.lock.Unlock()
+				if paused {
+					//a paused vm isnt a failed one - only give up if the process has actually gone
+					continue
+				}
 				fcp.exitChan <- err
 				return
 			}
 		}
 	}()
+	//the next run of the vmm has its own logger and context
+	logger, cancel := fcp.logger, fcp.cancelFunc
 	go func() {
-		//use our select switcher to check for changes in state...
-		for {
-			select {
-			case err := <-fcp.exitChan:
-				log.Println("Exit Chan triggered:", err)
-				if err != nil {
-					//there was an error in execution - lets break out of the loops and clean everything up..
-					//the error will also imply a change of state
-					fcp.err = err
-					fcp.logger.Debugf("Error when doing polling: %s", err.Error())
-					fcp.Status = "ERROR"
-				}
-				fcp.isPolling = false
-				if fcp.isShuttingDown {
-					//we are shutting down so lets just kill everything...
-					fcp.Stop()
-					return
-				}
-				if fcp.jailerProc != nil && fcp.jailerProc.Proc != nil && fcp.jailerProc.Proc.ProcessState != nil && fcp.jailerProc.Proc.ProcessState.Exited() {
-					//it has exited.. lets remake the process..
-					err := fcp.startFirecrackerProcess()
-					if err != nil {
-						fcp.logger.Debugf("Error when restarting firecracker: %s", err.Error())
-					} else {
-						if fcp.autoStart {
-							err = fcp.Start()
-							if err != nil {
-								fcp.logger.Debugf("Error when restarting vmm in autostart mode: %s", err.Error())
-							}
-						} else {
-							fcp.cancelFunc()
-						}
-					}
-				}
-				return
-				//break
-			case newStatus := <-fcp.stateChan:
-				if fcp.Status != newStatus {
-					//state has changed - process this...
-					log.Printf("Status Has changed %s -> %s\n", fcp.Status, newStatus)
-				}
-				//break
+		//wait for the polling to stop and work out why...
+		err := <-fcp.exitChan
+		log.Println("Exit Chan triggered:", err)
+		fcp.lock.Lock()
+		if err != nil && !fcp.stoppedByUser {
+			//there was an error in execution - lets break out of the loops and clean everything up..
+			//the error will also imply a change of state
+			fcp.err = err
+			logger.Debugf("Error when doing polling: %s", err.Error())
+			fcp.Status = "ERROR"
+		}
+		fcp.isPolling = false
+		if fcp.isShuttingDown {
+			fcp.lock.Unlock()
+			//we are shutting down so lets just kill everything...
+			fcp.Stop()
+			return
+		}
+		if fcp.jailerProc == nil || fcp.jailerProc.ExitStatus() == nil {
+			fcp.lock.Unlock()
+			return
+		}
+		//it has exited.. record why and lets remake the process if the policy says it comes back up..
+		failed := fcp.recordExit(fcp.jailerProc.ExitStatus(), err)
+		restart := fcp.restartPolicy.ShouldRestart(failed, fcp.stoppedByUser, fcp.crashCount)
+		if !restart && !fcp.stoppedByUser {
+			logger.Warnf("Not restarting vmm %s (policy %s, crashes %d): %s", fcp.id, fcp.restartPolicy.Policy, fcp.crashCount, fcp.lastExitReason)
+		}
+		fcp.lock.Unlock()
+		if !restart {
+			cancel()
+		} else if err := fcp.startFirecrackerProcess(); err != nil {
+			logger.Debugf("Error when restarting firecracker: %s", err.Error())
+		} else {
+			fcp.lock.Lock()
+			//the vmm may have been stopped while firecracker was being remade
+			if !fcp.stoppedByUser {
+				fcp.scheduleRestart()
 			}
+			fcp.lock.Unlock()
 		}
 	}()
 }
 
+// recordExit marks the vmm as no longer started and tracks the crash count and exit reason for an unexpected exit -
+// returns true if the exit was a failure. An exit after the vmm was stopped by the user isnt counted as a crash.
+// The lock must be held.
+func (fcp *FireCrackerProcess) recordExit(state *privhelper.ExitStatus, pollErr error) bool {
+	fcp.isStarted = false
+	fcp.isPaused = false
+	fcp.jailerProcRunning = false
+	if fcp.stoppedByUser {
+		return false
+	}
+	failed := !state.Success()
+	fcp.lastExitReason = state.String()
+	if pollErr != nil {
+		fcp.lastExitReason = fmt.Sprintf("%s (%s)", fcp.lastExitReason, pollErr.Error())
+	}
+	//if we were up for longer than the reset window this is a fresh run of crashes
+	resetWindow := time.Duration(fcp.restartPolicy.ResetWindow) * time.Second
+	if !fcp.lastStartedAt.IsZero() && time.Since(fcp.lastStartedAt) > resetWindow {
+		fcp.crashCount = 0
+	}
+	fcp.crashCount++
+	return failed
+}
+
+// scheduleRestart starts the vmm again after the backoff delay for the current crash count - the lock must be held
+func (fcp *FireCrackerProcess) scheduleRestart() {
+	delay := fcp.restartPolicy.BackoffDelay(fcp.crashCount)
+	fcp.logger.Warnf("Restarting vmm %s in %s (policy %s, crashes %d): %s", fcp.id, delay, fcp.restartPolicy.Policy, fcp.crashCount, fcp.lastExitReason)
+	fcp.cancelRestart()
+	fcp.restartCount++
+	fcp.restartTimer = time.AfterFunc(delay, func() {
+		fcp.lock.Lock()
+		fcp.restartTimer = nil
+		fcp.lock.Unlock()
+		start := fcp.Start
+		if fcp.runOperation != nil {
+			start = func() error {
+				return fcp.runOperation("restart", fcp.Start)
+			}
+		}
+		if err := start(); err != nil {
+			fcp.logger.Debugf("Error when restarting vmm with restart policy: %s", err.Error())
+		}
+	})
+}
+
+// cancelRestart stops a restart of the restart policy that is waiting - the lock must be held
+func (fcp *FireCrackerProcess) cancelRestart() {
+	if fcp.restartTimer != nil {
+		fcp.restartTimer.Stop()
+		fcp.restartTimer = nil
+	}
+}
+
 func (fcp *FireCrackerProcess) cleanUp() {
 	//clean up firecracker and the jailer - lets tear everything down...
+	fcp.closeFifos()
+	fcp.releaseJail()
+	fcp.removeRuntimeState()
 	os.RemoveAll(fcp.chrootPath)
 	// os.Remove(fcp.fcConfig.SocketPath)
 	// os.RemoveAll(filepath.Join(fcp.chrootPath, "dev"))
+	fcp.lock.Lock()
 	fcp.isStarted = false
 	fcp.isRestarting = false
 	fcp.isShuttingDown = false
+	fcp.isPaused = false
 	fcp.Status = UNKOWN_STATUS
-	if fcp.stateChan != nil {
-		close(fcp.stateChan)
-		fcp.stateChan = make(chan string)
-	} else {
-		fcp.stateChan = make(chan string)
-	}
+	fcp.lock.Unlock()
 	if fcp.procExitWaitChan == nil {
 		fcp.procExitWaitChan = make(chan error)
 	}
@@ -304,14 +603,23 @@ func (fcp *FireCrackerProcess) pollStatus() error {
 	if err != nil {
 		return err
 	}
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
 	fcp.statusResp = res
 	status := *(fcp.statusResp.Payload.State)
+	if fcp.isPaused {
+		status = PAUSED_STATUS
+	}
 	fcp.logger.Warnf("Status %s", status)
-	if fcp.Status == UNKOWN_STATUS {
-		fcp.Status = status
-	} else {
-		fcp.stateChan <- status
+	if !fcp.isStarted {
+		//the vmm was stopped while it was being polled
+		return nil
+	}
+	if fcp.Status != UNKOWN_STATUS && fcp.Status != status {
+		//state has changed - process this...
+		log.Printf("Status Has changed %s -> %s\n", fcp.Status, status)
 	}
+	fcp.Status = status
 	return nil
 }
 
@@ -319,13 +627,18 @@ func (fcp *FireCrackerProcess) Start() error {
 
 	//if the vmm is already started we dont need to do anything - but lets also check its not currently exited either
 
-	if !fcp.jailerProcRunning || fcp.jailerProc == nil {
+	fcp.lock.Lock()
+	fcp.cancelRestart()
+	fcp.stoppedByUser = false
+	fcp.lock.Unlock()
+
+	if !fcp.jailerRunning() {
 		//fcp.cleanUp()
 		err := fcp.startFirecrackerProcess()
 		if err != nil {
 			return err
 		}
-	} else if fcp.isStarted {
+	} else if started, _ := fcp.runState(); started {
 		return errors.New("VMM already started")
 	}
 	if fcp.isOsv {
@@ -337,46 +650,9 @@ func (fcp *FireCrackerProcess) Start() error {
 	fcp.ctx, fcp.cancelFunc = context.WithCancel(context.Background())
 	log.Println("CTX:", fcp.ctx.Err())
 
-	driveList := make([]models.Drive, len(fcp.imageList))
-
-	for index, img := range fcp.imageList {
-		driveName := fmt.Sprintf("drive%d", index)
-		if index == 0 {
-			driveName = "rootfs"
-		}
-		destPath := filepath.Join(fcp.chrootPath, driveName+".img")
-		if !vutils.Files.PathExists(destPath) {
-			//make the link
-			err := os.Link(img, destPath)
-			if err != nil {
-				return err
-			}
-		}
-		driveList[index] = models.Drive{
-			DriveID:      firecracker.String(driveName),
-			PathOnHost:   firecracker.String("/" + driveName + ".img"),
-			IsRootDevice: firecracker.Bool(false),
-			IsReadOnly:   firecracker.Bool(false),
-		}
-		if index == 0 {
-			driveList[index].IsRootDevice = firecracker.Bool(true)
-		}
-	}
-
-	if fcp.cloudInit != nil && len(fcp.cloudInit) > 0 {
-		destPath := filepath.Join(fcp.chrootPath, "cloud-init.img")
-		if !vutils.Files.PathExists(destPath) {
-			err := ioutil.WriteFile(destPath, fcp.cloudInit, 0660)
-			if err != nil {
-				return err
-			}
-		}
-		driveList = append(driveList, models.Drive{
-			DriveID:      firecracker.String("cloud_init"),
-			PathOnHost:   firecracker.String("/cloud-init.img"),
-			IsRootDevice: firecracker.Bool(false),
-			IsReadOnly:   firecracker.Bool(false),
-		})
+	driveList, err := fcp.linkDrives()
+	if err != nil {
+		return err
 	}
 
 	ifaceList := make([]firecracker.NetworkInterface, len(fcp.networkInterfaces))
@@ -400,32 +676,18 @@ func (fcp *FireCrackerProcess) Start() error {
 		KernelArgs:        fcp.cmd,
 		Drives:            driveList,
 		NetworkInterfaces: ifaceList,
-		//LogLevel:          "Debug",
-		//LogFifo: filepath.Join(fcp.chrootPath, "log"),
-		//MetricsFifo:filepath.Join(fcp.chrootPath, "metrics"),
-		MachineCfg: models.MachineConfiguration{
-			VcpuCount:  firecracker.Int64(fcp.cpus),
-			MemSizeMib: firecracker.Int64(fcp.memory),
-			HtEnabled:  firecracker.Bool(false),
-		},
+		//logging and metrics fifos are created in the jail by the logging handler
+		MachineCfg: fcp.machineConfiguration(),
 		//Debug: true,
 	}
 
-	//err := ioutil.WriteFile(filepath.Join(fcp.chrootPath, "log"), []byte{}, 0666)
-	//if err != nil {
-	//  return err
-	//}
-	//err = ioutil.WriteFile(filepath.Join(fcp.chrootPath, "metrics"), []byte{}, 0666)
-	//if err != nil {
-	//  return err
-	//}
 	log.Println("Creating machine")
-	m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn), firecracker.WithProcessRunner(fcp.jailerProc.Proc))
+	m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn))
 	if err != nil {
 		return err
 	}
 
-	m.Handlers.FcInit = FCHandlerList
+	m.Handlers.FcInit = fcp.initHandlers()
 	m.Handlers.Validation = m.Handlers.Validation.Clear()
 	kpath := filepath.Join(fcp.chrootPath, "kernel.elf")
 	//create hard links for resources...
@@ -440,17 +702,20 @@ func (fcp *FireCrackerProcess) Start() error {
 
 	fcp.machine = m
 
-	err = chown(fcp.chrootPath)
+	err = fcp.prepareJailFiles()
 	if err != nil {
 		return err
 	}
 
 	if err := m.Start(fcp.ctx); err != nil {
-		fcp.isStarted = false
 		return err
 	}
-	fcp.isStarted = true
-	fcp.Status = UNKOWN_STATUS
+	fcp.markStarted()
+	//a vm that cant be given its limits or kept to its cpus isnt left running without them
+	if err := fcp.setupCgroup(); err != nil {
+		fcp.Stop()
+		return err
+	}
 	fcp.beginPollingLoop() //while we wait we will begin polling for status...
 
 	log.Println("Started machine")
@@ -459,6 +724,66 @@ func (fcp *FireCrackerProcess) Start() error {
 
 }
 
+// markStarted records that a run of the vmm has begun and saves it so a restarted daemon can reattach to it - the
+// status is filled in by the first poll
+func (fcp *FireCrackerProcess) markStarted() {
+	fcp.lock.Lock()
+	fcp.isStarted = true
+	fcp.isPaused = false
+	fcp.lastStartedAt = time.Now()
+	fcp.Status = UNKOWN_STATUS
+	fcp.lock.Unlock()
+	fcp.saveRuntimeState()
+}
+
+// linkDrives links the drive images into the jail - the paths inside the jail have to stay the same between
+// boots so snapshots taken of the vmm can be loaded again
+func (fcp *FireCrackerProcess) linkDrives() ([]models.Drive, error) {
+	driveList := make([]models.Drive, len(fcp.imageList))
+
+	for index, img := range fcp.imageList {
+		driveName := fmt.Sprintf("drive%d", index)
+		if index == 0 {
+			driveName = "rootfs"
+		}
+		destPath := filepath.Join(fcp.chrootPath, driveName+".img")
+		if !vutils.Files.PathExists(destPath) {
+			//make the link
+			err := os.Link(img, destPath)
+			if err != nil {
+				return nil, err
+			}
+		}
+		driveList[index] = models.Drive{
+			DriveID:      firecracker.String(driveName),
+			PathOnHost:   firecracker.String("/" + driveName + ".img"),
+			IsRootDevice: firecracker.Bool(false),
+			IsReadOnly:   firecracker.Bool(false),
+		}
+		if index == 0 {
+			driveList[index].IsRootDevice = firecracker.Bool(true)
+		}
+	}
+
+	if fcp.cloudInit != nil && len(fcp.cloudInit) > 0 {
+		destPath := filepath.Join(fcp.chrootPath, "cloud-init.img")
+		if !vutils.Files.PathExists(destPath) {
+			err := ioutil.WriteFile(destPath, fcp.cloudInit, 0660)
+			if err != nil {
+				return nil, err
+			}
+		}
+		driveList = append(driveList, models.Drive{
+			DriveID:      firecracker.String("cloud_init"),
+			PathOnHost:   firecracker.String("/cloud-init.img"),
+			IsRootDevice: firecracker.Bool(false),
+			IsReadOnly:   firecracker.Bool(false),
+		})
+	}
+
+	return driveList, nil
+}
+
 func (fcp *FireCrackerProcess) startOsv() error {
 
 	osvRelease, err := getCapstanDevPath()
@@ -488,19 +813,15 @@ func (fcp *FireCrackerProcess) startOsv() error {
 		KernelImagePath: "/kernel.elf",
 		KernelArgs:      "--power-off-on-abort --nopci --verbose " + fcp.cmd,
 		Drives:          []models.Drive{db},
-		MachineCfg: models.MachineConfiguration{
-			VcpuCount:  firecracker.Int64(fcp.cpus),
-			MemSizeMib: firecracker.Int64(fcp.memory),
-			HtEnabled:  firecracker.Bool(false),
-		},
+		MachineCfg:      fcp.machineConfiguration(),
 	}
 	log.Println("Creating machine")
-	m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn), firecracker.WithProcessRunner(fcp.jailerProc.Proc))
+	m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn))
 	if err != nil {
 		return err
 	}
 
-	m.Handlers.FcInit = FCHandlerList
+	m.Handlers.FcInit = fcp.initHandlers()
 	m.Handlers.Validation = m.Handlers.Validation.Clear()
 	kpath := filepath.Join(fcp.chrootPath, "kernel.elf")
 	//create hard links for resources...
@@ -522,12 +843,20 @@ func (fcp *FireCrackerProcess) startOsv() error {
 
 	fcp.machine = m
 
+	err = fcp.prepareJailFiles()
+	if err != nil {
+		return err
+	}
+
 	if err := m.Start(fcp.ctx); err != nil {
-		fcp.isStarted = false
 		return err
 	}
-	fcp.isStarted = true
-	fcp.Status = UNKOWN_STATUS
+	fcp.markStarted()
+	//a vm that cant be given its limits or kept to its cpus isnt left running without them
+	if err := fcp.setupCgroup(); err != nil {
+		fcp.Stop()
+		return err
+	}
 	fcp.beginPollingLoop() //while we wait we will begin polling for status...
 
 	return nil
@@ -538,7 +867,10 @@ func (fcp *FireCrackerProcess) Wait() error {
 	for {
 		select {
 		case waitErr := <-fcp.procExitWaitChan:
-			if !fcp.isShuttingDown && !fcp.isRestarting {
+			fcp.lock.Lock()
+			expected := fcp.isShuttingDown || fcp.isRestarting
+			fcp.lock.Unlock()
+			if !expected {
 				return waitErr
 			}
 		}
@@ -562,14 +894,19 @@ func (fcp *FireCrackerProcess) terminateInitialise() error {
 }
 
 func (fcp *FireCrackerProcess) Stop() error {
+	fcp.lock.Lock()
 	fcp.isStopping = true
-	if fcp.jailerProc != nil && fcp.jailerProc.Proc != nil && fcp.jailerProc.Proc.Process != nil {
+	fcp.stoppedByUser = true
+	fcp.cancelRestart()
+	if fcp.jailerProc != nil && fcp.jailerProc.ExitStatus() == nil {
 		fmt.Println("direct kill")
-		fcp.jailerProc.Proc.Process.Signal(syscall.SIGKILL)
+		fcp.jailerProc.Signal(syscall.SIGKILL)
 		fcp.jailerProcRunning = false
 		fmt.Println("signalled")
 	}
-	if fcp.isShuttingDown {
+	shuttingDown := fcp.isShuttingDown
+	fcp.lock.Unlock()
+	if shuttingDown {
 		fcp.cleanUp()
 		fmt.Println("cleaned up")
 		go func() {
@@ -582,7 +919,9 @@ func (fcp *FireCrackerProcess) Stop() error {
 		fcp.cleanUp()
 		fmt.Println("cleaned up")
 	}
+	fcp.lock.Lock()
 	fcp.isStopping = false
+	fcp.lock.Unlock()
 	// a forced termination
 	return nil
 
@@ -593,13 +932,25 @@ func (fcp *FireCrackerProcess) Shutdown() error {
 }
 
 func (fcp *FireCrackerProcess) ShutdownTimeout(timeout time.Duration) error {
+	fcp.lock.Lock()
 	if !fcp.isStarted {
+		fcp.lock.Unlock()
 		fmt.Println("running direct kill")
 		return fcp.Stop()
 	} else if fcp.isShuttingDown {
+		fcp.lock.Unlock()
 		return errors.New("Already shutting down")
 	}
 	fcp.isShuttingDown = true
+	fcp.stoppedByUser = true
+	paused := fcp.isPaused
+	fcp.lock.Unlock()
+	if paused {
+		//a paused guest cant respond to ctrl+alt+del
+		if err := fcp.Resume(); err != nil {
+			fcp.logger.Warnf("Unable to resume VM before shutdown: %s", err.Error())
+		}
+	}
 	// a graceful shutdown..
 	// if fcp.ctx == nil {
 	// 	fmt.Println("Current Context is null")
@@ -609,9 +960,12 @@ func (fcp *FireCrackerProcess) ShutdownTimeout(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	e := fcp.machine.Shutdown(ctx)
-	if e != nil {
-		return e
+	if fcp.requestShutdown == nil || fcp.requestShutdown() != nil {
+		e := fcp.machine.Shutdown(ctx)
+		if e != nil {
+			fcp.setShuttingDown(false)
+			return e
+		}
 	}
 	doneChan := make(chan bool)
 	go func() {
@@ -621,26 +975,66 @@ func (fcp *FireCrackerProcess) ShutdownTimeout(timeout time.Duration) error {
 				fmt.Println("overslept")
 			case <-ctx.Done():
 				fmt.Println(ctx.Err()) // prints "context deadline exceeded"
-				fcp.isShuttingDown = false
+				fcp.setShuttingDown(false)
 				fcp.Stop()
 				doneChan <- true
 				return
 			case <-fcp.killChan:
-				fcp.isShuttingDown = false
+				fcp.setShuttingDown(false)
 				doneChan <- true
 				return
 			}
 		}
 	}()
 	_ = <-doneChan
-	fcp.isShuttingDown = false
+	fcp.setShuttingDown(false)
 	return nil //fcp.Wait()
 
 }
 
+func (fcp *FireCrackerProcess) setShuttingDown(shuttingDown bool) {
+	fcp.lock.Lock()
+	fcp.isShuttingDown = shuttingDown
+	fcp.lock.Unlock()
+}
+
+func (fcp *FireCrackerProcess) Pause() error {
+	if started, paused := fcp.runState(); !started {
+		return errors.New("Cannot pause a VM that isnt running")
+	} else if paused {
+		return errors.New("VM is already paused")
+	}
+	if err := newFirecrackerAPI(fcp.socketPath).PatchVMState("Paused"); err != nil {
+		return err
+	}
+	fcp.lock.Lock()
+	fcp.isPaused = true
+	fcp.Status = PAUSED_STATUS
+	fcp.lock.Unlock()
+	fcp.saveRuntimeState()
+	return nil
+}
+
+func (fcp *FireCrackerProcess) Resume() error {
+	if started, paused := fcp.runState(); !started {
+		return errors.New("Cannot resume a VM that isnt running")
+	} else if !paused {
+		return errors.New("VM is not paused")
+	}
+	if err := newFirecrackerAPI(fcp.socketPath).PatchVMState("Resumed"); err != nil {
+		return err
+	}
+	fcp.lock.Lock()
+	fcp.isPaused = false
+	fcp.Status = UNKOWN_STATUS //the next poll will fill this in
+	fcp.lock.Unlock()
+	fcp.saveRuntimeState()
+	return nil
+}
+
 func (fcp *FireCrackerProcess) Restart() error {
 
-	fcp.isRestarting = true
+	fcp.setRestarting(true)
 
 	// a graceful shutdown..
 	e := fcp.Shutdown()
@@ -654,14 +1048,14 @@ func (fcp *FireCrackerProcess) Restart() error {
 	// 	}
 	// 	e = fcp.machine.Start(fcp.ctx)
 	// }()
-	fcp.isRestarting = false
+	fcp.setRestarting(false)
 	return fcp.Start()
 
 }
 
 func (fcp *FireCrackerProcess) RestartTimeout(timeout time.Duration) error {
 
-	fcp.isRestarting = true
+	fcp.setRestarting(true)
 
 	// a graceful shutdown..
 	e := fcp.ShutdownTimeout(timeout)
@@ -675,14 +1069,14 @@ func (fcp *FireCrackerProcess) RestartTimeout(timeout time.Duration) error {
 	// 	}
 	// 	e = fcp.machine.Start(fcp.ctx)
 	// }()
-	fcp.isRestarting = false
+	fcp.setRestarting(false)
 	return fcp.Start()
 
 }
 
 func (fcp *FireCrackerProcess) Reset() error {
 
-	fcp.isRestarting = true
+	fcp.setRestarting(true)
 
 	// a graceful shutdown..
 	e := fcp.Stop()
@@ -696,11 +1090,17 @@ func (fcp *FireCrackerProcess) Reset() error {
 	// 	}
 	// 	e = fcp.machine.Start(fcp.ctx)
 	// }()
-	fcp.isRestarting = false
+	fcp.setRestarting(false)
 	return fcp.Start()
 
 }
 
+func (fcp *FireCrackerProcess) setRestarting(restarting bool) {
+	fcp.lock.Lock()
+	fcp.isRestarting = restarting
+	fcp.lock.Unlock()
+}
+
 func (fcp *FireCrackerProcess) Terminate() error {
 
 	// forcfully remove all items associated with the firecracker process (a full cleanup)
@@ -716,11 +1116,12 @@ func (fcp *FireCrackerProcess) Destroy() error {
 }
 
 func qemuConvertImgRaw(src string, dest string) error {
-	return vutils.Exec.CreateAsyncCommand("qemu-img", false, "convert", "-O", "raw", src, dest).Sudo().BindToStdoutAndStdErr().StartAndWait()
+	return privhelper.Default.QemuImgConvert(src, dest, "raw")
 }
 
-func chown(path string) error {
-	return vutils.Exec.CreateAsyncCommand("chown", false, "-R", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()), path).Sudo().BindToStdoutAndStdErr().StartAndWait()
+// initHandlers are the handlers that configure firecracker before the vm boots
+func (fcp *FireCrackerProcess) initHandlers() firecracker.HandlerList {
+	return FCHandlerList.Swap(fcp.loggingHandler()).Swap(fcp.vsockHandler()).Append(fcp.balloonHandler())
 }
 
 var FCHandlerList = firecracker.HandlerList{}.Append(
@@ -2,14 +2,21 @@ package vmm
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/768bit/promethium/api/models"
 	"github.com/768bit/promethium/lib/cloudconfig"
+	"github.com/768bit/promethium/lib/config"
 	"github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/metrics"
 	"github.com/768bit/promethium/lib/networking"
+	"github.com/768bit/promethium/lib/privhelper"
 )
 
 func TestProcessCreate(t *testing.T) {
@@ -35,7 +42,7 @@ func TestProcessCreateImg(t *testing.T) {
 		return
 	}
 	bootParams := strings.TrimSpace(string(ba))
-	fcp, err := NewFireCrackerProcessImg("TESTER", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false)
+	fcp, err := NewFireCrackerProcessImg("TESTER", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false, nil, nil, nil, JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
 	if err != nil {
 		t.Errorf("Error creating new process: %s", err.Error())
 		return
@@ -61,7 +68,7 @@ func TestProcessCreateImg2(t *testing.T) {
 		return
 	}
 	bootParams := strings.TrimSpace(string(ba))
-	fcp, err := NewFireCrackerProcessImg("TESTER2", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false)
+	fcp, err := NewFireCrackerProcessImg("TESTER2", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false, nil, nil, nil, JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
 	if err != nil {
 		t.Errorf("Error creating new process: %s", err.Error())
 		return
@@ -90,7 +97,7 @@ func TestProcessCreateImg3(t *testing.T) {
 		return
 	}
 	bootParams := strings.TrimSpace(string(ba))
-	fcp, err := NewFireCrackerProcessImg("TESTER3", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false)
+	fcp, err := NewFireCrackerProcessImg("TESTER3", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, nil, false, nil, nil, nil, JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
 	if err != nil {
 		t.Errorf("Error creating new process: %s", err.Error())
 		return
@@ -139,7 +146,7 @@ func TestProcessCreateImg4(t *testing.T) {
 		t.Errorf("Error creating linux tap interface %s", err.Error())
 		return
 	}
-	fcp, err := NewFireCrackerProcessImg("TESTER4", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, []string{iface.GetId()}, false)
+	fcp, err := NewFireCrackerProcessImg("TESTER4", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, []string{iface.GetId()}, false, nil, nil, nil, JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
 	if err != nil {
 		t.Errorf("Error creating new process: %s", err.Error())
 		return
@@ -205,16 +212,18 @@ func TestProcessCreateImg5(t *testing.T) {
 		},
 	}
 	udatas := &cloudconfig.UserData{
-		PackageUpdate:  true,
-		PackageUpgrade: true,
-		Users: []*cloudconfig.UserDataUserConfig{
-			{
-				Name: "craig",
-				SshAuthorisedKeys: []string{
-					"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDdi3LLLHu7ZFUG5PDAlwDQgMYHbG+vbjBGMwVr6E3foeIiVaa5EFQa/nWTb1f86DV2aOV2fmSj36QKWho84QcbwV67d/WTtGlPYHfeMEffdRPFx32dEC9CH3XxtZmMNDWDi/IgE8ZdEiF8EFbzbXuHwG2Et/606jP549tsyUSnfrDp+uZaAxFSLkHwDitm2Heoc1ur+rTo3PrkkF7Z6GZDE/vJs+k/TpRuEhUAaTOgLzX0met7iyJcP7/sQkR/F1keUC+s2/sFeFvATLWNVkyOZYvulYQUdk4ObnR51V1sXRD9AVfy7f6PYj5bNHt4mXN0PsSfSe6uLDjIPknYR3LF craig@skylaker",
+		CloudInitUserData: &models.CloudInitUserData{
+			PackageUpdate:  true,
+			PackageUpgrade: true,
+			Users: []*models.CloudInitUserDataUser{
+				{
+					Name: "craig",
+					SSHAuthorisedKeys: []string{
+						"ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDdi3LLLHu7ZFUG5PDAlwDQgMYHbG+vbjBGMwVr6E3foeIiVaa5EFQa/nWTb1f86DV2aOV2fmSj36QKWho84QcbwV67d/WTtGlPYHfeMEffdRPFx32dEC9CH3XxtZmMNDWDi/IgE8ZdEiF8EFbzbXuHwG2Et/606jP549tsyUSnfrDp+uZaAxFSLkHwDitm2Heoc1ur+rTo3PrkkF7Z6GZDE/vJs+k/TpRuEhUAaTOgLzX0met7iyJcP7/sQkR/F1keUC+s2/sFeFvATLWNVkyOZYvulYQUdk4ObnR51V1sXRD9AVfy7f6PYj5bNHt4mXN0PsSfSe6uLDjIPknYR3LF craig@skylaker",
+					},
+					Sudo:  "ALL=(ALL) NOPASSWD:ALL",
+					Shell: "/bin/bash",
 				},
-				Sudo:  "ALL=(ALL) NOPASSWD:ALL",
-				Shell: "/bin/bash",
 			},
 		},
 	}
@@ -228,7 +237,7 @@ func TestProcessCreateImg5(t *testing.T) {
 		t.Errorf("Error creating linux tap interface %s", err.Error())
 		return
 	}
-	fcp, err := NewFireCrackerProcessImg("TESTER5", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, []string{iface.GetId()}, false)
+	fcp, err := NewFireCrackerProcessImg("TESTER5", "test4", bootParams, 1, 512, kernelPath, []string{imagePath}, []string{iface.GetId()}, false, nil, nil, nil, JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
 	if err != nil {
 		t.Errorf("Error creating new process: %s", err.Error())
 		return
@@ -252,3 +261,500 @@ func TestProcessCreateImg5(t *testing.T) {
 	//}()
 	fcp.Wait()
 }
+
+// newFakeFireCrackerProcess sets up a FireCrackerProcess with a kernel, a root drive and a tap that starts a
+// fakeFirecracker in place of the jailer
+func newFakeFireCrackerProcess(t *testing.T, restartPolicy *config.VmmRestartPolicy, configure func(fc *fakeFirecracker)) (*FireCrackerProcess, *fakeRunner, func()) {
+	return newLimitedFakeFireCrackerProcess(t, restartPolicy, configure, nil)
+}
+
+// newLimitedFakeFireCrackerProcess is newFakeFireCrackerProcess for a vm made with resource limits
+func newLimitedFakeFireCrackerProcess(t *testing.T, restartPolicy *config.VmmRestartPolicy, configure func(fc *fakeFirecracker), resources *config.VmmResourcesConfig) (*FireCrackerProcess, *fakeRunner, func()) {
+	dir, err := ioutil.TempDir("", "fc-process")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPath, runner, cgroupRoot := ROOT_PATH, privhelper.Default, CgroupRoot
+	fake := &fakeRunner{configure: configure, cgroupRoot: filepath.Join(dir, "cgroup")}
+	ROOT_PATH, privhelper.Default, CgroupRoot = dir, fake, fake.cgroupRoot
+	restore := func() {
+		ROOT_PATH, privhelper.Default, CgroupRoot = rootPath, runner, cgroupRoot
+		os.RemoveAll(dir)
+	}
+	for _, path := range []string{
+		filepath.Join(dir, "bin", "firecracker"),
+		filepath.Join(dir, "bin", "jailer"),
+		filepath.Join(dir, "kernel.elf"),
+		filepath.Join(dir, "root.img"),
+		//a cgroup v2 hierarchy
+		filepath.Join(fake.cgroupRoot, "cgroup.controllers"),
+	} {
+		os.MkdirAll(filepath.Dir(path), 0750)
+		if err := ioutil.WriteFile(path, []byte{}, 0750); err != nil {
+			restore()
+			t.Fatal(err)
+		}
+	}
+	fcp, err := NewFireCrackerProcessImg("fake", "fake", "console=ttyS0", 2, 256, filepath.Join(dir, "kernel.elf"),
+		[]string{filepath.Join(dir, "root.img")}, []string{"tap-fake"}, false, restartPolicy, nil, resources,
+		JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
+	if err != nil {
+		restore()
+		t.Fatal(err)
+	}
+	fcp.pollInterval = 10 * time.Millisecond
+	return fcp, fake, func() {
+		fcp.Stop()
+		restore()
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFakeProcessStart(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	if runner.startCount() != 1 {
+		t.Fatalf("expected firecracker to be started with the process, it was started %d times", runner.startCount())
+	}
+	fc := runner.latest()
+	if fc.getState() != fakeUninitialized {
+		t.Fatalf("expected firecracker to wait to be configured, it is %q", fc.getState())
+	}
+	fcp.SetVsock(&config.VmmVsockConfig{CID: 3})
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fcp.Start(); err == nil {
+		t.Error("expected a second start to fail")
+	}
+	if runner.startCount() != 1 {
+		t.Errorf("expected the jailer that was waiting to be used, %d were started", runner.startCount())
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	if fc.machineConfig["vcpu_count"] != float64(2) || fc.machineConfig["mem_size_mib"] != float64(256) {
+		t.Errorf("unexpected machine config %v", fc.machineConfig)
+	}
+	if fc.bootSource["kernel_image_path"] != "/kernel.elf" || fc.bootSource["boot_args"] != "console=ttyS0" {
+		t.Errorf("unexpected boot source %v", fc.bootSource)
+	}
+	if root := fc.drives["rootfs"]; root == nil || root["path_on_host"] != "/rootfs.img" || root["is_root_device"] != true {
+		t.Errorf("expected the root drive to be attached from the jail, got %v", fc.drives)
+	}
+	if eth := fc.networkInterfaces["eth0"]; eth == nil || eth["host_dev_name"] != "tap-fake" {
+		t.Errorf("expected the tap to be attached, got %v", fc.networkInterfaces)
+	}
+	if fc.vsock["guest_cid"] != float64(3) || fc.vsock["uds_path"] != "/"+config.DefaultVsockUDSPath {
+		t.Errorf("unexpected vsock %v", fc.vsock)
+	}
+	if fc.logger == nil {
+		t.Error("expected the logger to be configured")
+	}
+	if len(fc.actions) != 1 || fc.actions[0] != "InstanceStart" {
+		t.Errorf("expected the instance to be started once everything was configured, got %v", fc.actions)
+	}
+	last := fc.requests[len(fc.requests)-1]
+	if last != "GET /" {
+		t.Errorf("expected the instance to be polled once started, the last request was %s", last)
+	}
+	for _, path := range []string{"kernel.elf", "rootfs.img"} {
+		if _, err := os.Stat(filepath.Join(fcp.chrootPath, path)); err != nil {
+			t.Errorf("expected %s to be in the jail: %v", path, err)
+		}
+	}
+}
+
+func TestFakeProcessStartRefused(t *testing.T) {
+	fcp, _, cleanup := newFakeFireCrackerProcess(t, nil, func(fc *fakeFirecracker) {
+		fc.failStart = "Cannot create vm: KVM is not available"
+	})
+	defer cleanup()
+	err := fcp.Start()
+	if err == nil || !strings.Contains(err.Error(), "KVM is not available") {
+		t.Fatalf("expected the fault to be returned, got %v", err)
+	}
+	if started, _ := fcp.runState(); started {
+		t.Error("expected the vm to not be started")
+	}
+}
+
+func TestFakeProcessPolling(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, &config.VmmRestartPolicy{Policy: config.RestartPolicyNo}, nil)
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	polls := len(fc.getRequests())
+	waitFor(t, "the vm to be polled", func() bool { return len(fc.getRequests()) > polls+2 })
+
+	if err := fcp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if fc.getState() != fakePaused || fcp.GetStatus() != PAUSED_STATUS {
+		t.Errorf("expected the vm to be paused, firecracker is %q and the vm %q", fc.getState(), fcp.GetStatus())
+	}
+	if err := fcp.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running again", func() bool { return fcp.GetStatus() == "Running" })
+
+	//firecracker going away is picked up by the next poll
+	fc.crash(1)
+	waitFor(t, "the exit to be recorded", func() bool { return fcp.GetCrashCount() == 1 })
+	if fcp.GetStatus() != "ERROR" || !strings.HasPrefix(fcp.GetLastExitReason(), "exit status 1") {
+		t.Errorf("unexpected status %q and exit reason %q", fcp.GetStatus(), fcp.GetLastExitReason())
+	}
+	//the policy says the vm isnt started again so no new firecracker is started for it
+	time.Sleep(50 * time.Millisecond)
+	if runner.startCount() != 1 {
+		t.Errorf("expected the vm to not be restarted, firecracker was started %d times", runner.startCount())
+	}
+}
+
+func TestFakeProcessShutdown(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, func(fc *fakeFirecracker) {
+		fc.shutdownDelay = 20 * time.Millisecond
+	})
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	start := time.Now()
+	if err := fcp.ShutdownTimeout(5 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("expected the shutdown to finish when the guest powered off, it took %s", took)
+	}
+	if actions := fc.getActions(); actions[len(actions)-1] != "SendCtrlAltDel" {
+		t.Errorf("expected the guest to be sent ctrl+alt+del, got %v", actions)
+	}
+	if status := fc.getExitStatus(); status == nil || !status.Success() {
+		t.Errorf("expected firecracker to exit cleanly, got %v", status)
+	}
+	if started, _ := fcp.runState(); started || fcp.GetStatus() != UNKOWN_STATUS {
+		t.Errorf("expected the vm to be stopped, it is %q", fcp.GetStatus())
+	}
+}
+
+func TestFakeProcessShutdownTimeout(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, func(fc *fakeFirecracker) {
+		fc.ignoreShutdown = true
+	})
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	start := time.Now()
+	if err := fcp.ShutdownTimeout(100 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took < 100*time.Millisecond {
+		t.Errorf("expected the shutdown to wait for the timeout, it took %s", took)
+	}
+	if status := fc.getExitStatus(); status == nil || status.Signal != syscall.SIGKILL.String() {
+		t.Errorf("expected firecracker to be killed after the timeout, got %v", status)
+	}
+	if started, _ := fcp.runState(); started {
+		t.Error("expected the vm to be stopped")
+	}
+}
+
+func TestFakeProcessRestart(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, &config.VmmRestartPolicy{
+		Policy:      config.RestartPolicyOnFailure,
+		MaxRetries:  1,
+		ResetWindow: 600,
+	}, nil)
+	defer cleanup()
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- fcp.Wait()
+	}()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+
+	runner.latest().crash(1)
+	select {
+	case err := <-waitErr:
+		if err == nil || err.Error() != "exit status 1" {
+			t.Errorf("expected the crash to be returned from wait, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for firecracker to exit")
+	}
+	//the policy starts the vm again on a new firecracker straight away
+	waitFor(t, "the vm to be restarted", func() bool {
+		return runner.startCount() == 2 && runner.latest().getState() == fakeRunning
+	})
+	restarted := runner.latest()
+	restarted.lock.Lock()
+	if restarted.machineConfig == nil || restarted.bootSource == nil || len(restarted.drives) != 1 {
+		t.Errorf("expected the new firecracker to be configured again, got %v", restarted.requests)
+	}
+	restarted.lock.Unlock()
+	if fcp.GetCrashCount() != 1 {
+		t.Errorf("expected one crash to be recorded, got %d", fcp.GetCrashCount())
+	}
+
+	//the second crash is over the retries so it is left
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	restarted.crash(1)
+	waitFor(t, "the second crash to be recorded", func() bool { return fcp.GetCrashCount() == 2 })
+	time.Sleep(50 * time.Millisecond)
+	if runner.startCount() != 2 {
+		t.Errorf("expected the vm to not be restarted again, firecracker was started %d times", runner.startCount())
+	}
+	if fcp.GetStatus() != "ERROR" {
+		t.Errorf("expected the vm to be left crashed, it is %q", fcp.GetStatus())
+	}
+}
+
+func TestFakeProcessStopIsNotACrash(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, &config.VmmRestartPolicy{Policy: config.RestartPolicyAlways}, nil)
+	defer cleanup()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+
+	//a stop isnt brought back by the policy and can be started again
+	if err := fcp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if runner.startCount() != 1 {
+		t.Errorf("expected a stopped vm to not be restarted, firecracker was started %d times", runner.startCount())
+	}
+	if fcp.GetCrashCount() != 0 || fcp.GetStatus() == "ERROR" {
+		t.Errorf("expected a stop to not be a crash, got %d crashes and status %q", fcp.GetCrashCount(), fcp.GetStatus())
+	}
+	if err := fcp.Start(); err != nil {
+		t.Fatalf("expected a stopped vm to start again: %s", err.Error())
+	}
+	waitFor(t, "the vm to be running again", func() bool { return fcp.GetStatus() == "Running" })
+
+	//a crash after starting again is brought back
+	runner.latest().crash(1)
+	waitFor(t, "the crash to be recorded", func() bool { return fcp.GetCrashCount() == 1 })
+	waitFor(t, "the vm to be restarted", func() bool {
+		return runner.startCount() == 3 && runner.latest().getState() == fakeRunning
+	})
+}
+
+func TestFakeProcessPauseResume(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.Pause(); err == nil {
+		t.Error("expected a vm that isnt started to not be paused")
+	}
+	if err := fcp.Resume(); err == nil {
+		t.Error("expected a vm that isnt started to not be resumed")
+	}
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	if err := fcp.Resume(); err == nil {
+		t.Error("expected a running vm to not be resumed")
+	}
+
+	if err := fcp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fcp.Pause(); err == nil {
+		t.Error("expected a paused vm to not be paused again")
+	}
+	//polling a paused vm leaves it paused rather than treating it as failed
+	polls := len(fc.getRequests())
+	waitFor(t, "the paused vm to be polled", func() bool { return len(fc.getRequests()) > polls+2 })
+	if started, paused := fcp.runState(); !started || !paused || fcp.GetStatus() != PAUSED_STATUS {
+		t.Errorf("expected the vm to stay paused, it is %q", fcp.GetStatus())
+	}
+	if fcp.GetCrashCount() != 0 {
+		t.Error("expected a paused vm to not be counted as crashed")
+	}
+
+	if err := fcp.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running again", func() bool { return fcp.GetStatus() == "Running" })
+	if err := fcp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fcp.Pause(); err == nil {
+		t.Error("expected a stopped vm to not be paused")
+	}
+	if fc.getState() != fakeRunning {
+		t.Errorf("expected the failed transitions to not reach firecracker, it is %q", fc.getState())
+	}
+}
+
+func TestFakeProcessFirecrackerPidCached(t *testing.T) {
+	fcp, _, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	procRoot, err := ioutil.TempDir("", "proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(procRoot)
+	oldRoot := metrics.ProcRoot
+	metrics.ProcRoot = procRoot
+	defer func() { metrics.ProcRoot = oldRoot }()
+	//the fake jailer has the pid of the test
+	jailer := strconv.Itoa(fcp.Pid())
+	writeStats := func(stats map[string]string) {
+		for pid, stat := range stats {
+			os.MkdirAll(filepath.Join(procRoot, pid), 0755)
+			if err := ioutil.WriteFile(filepath.Join(procRoot, pid, "stat"), []byte(stat), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeStats(map[string]string{
+		jailer:   jailer + " (jailer) S 1 1 1 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		"999999": "999999 (firecracker) S " + jailer + " 1 1 0 -1 0 0 0 0 0 250 50 0 0 20 0 1 0 1 1 10 0 0 0",
+	})
+	ps, err := fcp.ProcessStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Pid != 999999 || ps.CPUSeconds != 3 {
+		t.Errorf("expected the firecracker process under the jailer, got %+v", ps)
+	}
+
+	//the process tree isnt searched again while the jailer is the same
+	writeStats(map[string]string{
+		"999998": "999998 (firecracker) S " + jailer + " 1 1 0 -1 0 0 0 0 0 1 1 0 0 20 0 1 0 1 1 10 0 0 0",
+		"999999": "999999 (firecracker) S 1 1 1 0 -1 0 0 0 0 0 500 100 0 0 20 0 1 0 1 1 10 0 0 0",
+	})
+	ps, err = fcp.ProcessStat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Pid != 999999 || ps.CPUSeconds != 6 {
+		t.Errorf("expected the cached firecracker process to be read again, got %+v", ps)
+	}
+}
+
+// restartedFakeProcess makes the process a restarted daemon would for the fake vm - it finds the runtime state of the
+// firecracker left running and waits to be reattached instead of starting a jailer
+func restartedFakeProcess(t *testing.T) *FireCrackerProcess {
+	fcp, err := NewFireCrackerProcessImg("fake", "fake", "console=ttyS0", 2, 256, filepath.Join(ROOT_PATH, "kernel.elf"),
+		[]string{filepath.Join(ROOT_PATH, "root.img")}, []string{"tap-fake"}, false, nil, nil, nil,
+		JailIdentity{UID: os.Getuid(), GID: os.Getgid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fcp.pollInterval = 10 * time.Millisecond
+	return fcp
+}
+
+func TestFakeProcessReattach(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	fc := runner.latest()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return fcp.GetStatus() == "Running" })
+	if err := fcp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	fcp.lock.Lock()
+	startedAt := fcp.lastStartedAt
+	fcp.lock.Unlock()
+	if err := fcp.Detach(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := readRuntimeState(fcp.runtimeStatePath())
+	if err != nil {
+		t.Fatalf("expected the runtime state to be saved when the daemon detached: %v", err)
+	}
+	if state.Pid != os.Getpid() || !state.Started || !state.Paused {
+		t.Errorf("unexpected runtime state %+v", state)
+	}
+
+	restarted := restartedFakeProcess(t)
+	defer restarted.Stop()
+	if runner.startCount() != 1 {
+		t.Fatalf("expected the restarted daemon to not start a jailer while firecracker is running, %d were", runner.startCount())
+	}
+	reattached, err := restarted.Reattach()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reattached {
+		t.Fatal("expected the firecracker left running to be reattached")
+	}
+	if runner.startCount() != 1 {
+		t.Errorf("expected the running firecracker to be used, %d were started", runner.startCount())
+	}
+	restarted.lock.Lock()
+	if !restarted.lastStartedAt.Equal(startedAt) {
+		t.Errorf("expected the start time to be kept, got %s not %s", restarted.lastStartedAt, startedAt)
+	}
+	restarted.lock.Unlock()
+	waitFor(t, "the reattached vm to be polled", func() bool { return restarted.GetStatus() == PAUSED_STATUS })
+	if err := restarted.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the reattached vm to run", func() bool { return fc.getState() == fakeRunning })
+
+	//the reattached firecracker is the one stopped
+	if err := restarted.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if fc.getExitStatus() == nil {
+		t.Error("expected the reattached firecracker to be stopped")
+	}
+}
+
+func TestFakeProcessReattachGone(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fcp.Detach(); err != nil {
+		t.Fatal(err)
+	}
+	//firecracker went while the daemon was down
+	runner.latest().exit(&privhelper.ExitStatus{Code: -1, Signal: "killed"})
+
+	restarted := restartedFakeProcess(t)
+	defer restarted.Stop()
+	reattached, err := restarted.Reattach()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reattached {
+		t.Error("expected a firecracker that has gone to not be reattached")
+	}
+	if runner.startCount() != 2 {
+		t.Errorf("expected a new jailer to be started in its place, %d were started", runner.startCount())
+	}
+	if started, _ := restarted.runState(); started {
+		t.Error("expected the vm to wait to be started again")
+	}
+}
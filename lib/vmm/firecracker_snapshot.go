@@ -0,0 +1,153 @@
+package vmm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/768bit/firecracker-go-sdk"
+	"github.com/768bit/vutils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	snapshotStateFile = "snapshot.state"
+	snapshotMemFile   = "snapshot.mem"
+)
+
+// Version returns the version reported by the firecracker binary in use
+func (fcp *FireCrackerProcess) Version() (string, error) {
+	if fcp.firecrackerVersion != "" {
+		return fcp.firecrackerVersion, nil
+	}
+	out, err := exec.Command(fcp.firecrackerBinaryPath, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	lines := strings.SplitN(strings.TrimSpace(string(out)), "\n", 2)
+	fcp.firecrackerVersion = strings.TrimPrefix(strings.TrimSpace(lines[0]), "Firecracker ")
+	return fcp.firecrackerVersion, nil
+}
+
+// CreateSnapshot snapshots the memory and device state of the paused vm into the files supplied
+func (fcp *FireCrackerProcess) CreateSnapshot(statePath string, memPath string) error {
+	if started, paused := fcp.runState(); !started {
+		return errors.New("Cannot snapshot a VM that isnt running")
+	} else if !paused {
+		return errors.New("VM must be paused before it can be snapshotted")
+	}
+	//firecracker can only see inside the jail so write the snapshot there and move it out after
+	jailState := filepath.Join(fcp.chrootPath, snapshotStateFile)
+	jailMem := filepath.Join(fcp.chrootPath, snapshotMemFile)
+	os.Remove(jailState)
+	os.Remove(jailMem)
+	if err := newFirecrackerAPI(fcp.socketPath).CreateSnapshot("/"+snapshotStateFile, "/"+snapshotMemFile); err != nil {
+		return err
+	}
+	//firecracker writes them as the jail user
+	if err := fcp.claimJailFiles(jailState, jailMem); err != nil {
+		return err
+	}
+	if err := moveFile(jailState, statePath); err != nil {
+		return err
+	}
+	return moveFile(jailMem, memPath)
+}
+
+// LoadSnapshot starts the vmm from a snapshot instead of booting the kernel - the drives are linked into the jail
+// at the same paths they had when the snapshot was taken
+func (fcp *FireCrackerProcess) LoadSnapshot(statePath string, memPath string) error {
+	fcp.lock.Lock()
+	fcp.cancelRestart()
+	fcp.lock.Unlock()
+	if fcp.isOsv {
+		return errors.New("Snapshots are not supported for OSv VMs")
+	}
+	if !fcp.jailerRunning() {
+		err := fcp.startFirecrackerProcess()
+		if err != nil {
+			return err
+		}
+	} else if started, _ := fcp.runState(); started {
+		return errors.New("VMM already started")
+	}
+
+	log.Println("Restoring snapshot for", fcp.id, "STATE:", statePath, "MEMORY:", memPath)
+	fcp.ctx, fcp.cancelFunc = context.WithCancel(context.Background())
+
+	driveList, err := fcp.linkDrives()
+	if err != nil {
+		return err
+	}
+	for src, dest := range map[string]string{statePath: snapshotStateFile, memPath: snapshotMemFile} {
+		destPath := filepath.Join(fcp.chrootPath, dest)
+		os.Remove(destPath)
+		if err := os.Link(src, destPath); err != nil {
+			//different filesystems - fall back to a copy
+			if err := vutils.Files.Copy(src, destPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	logger := log.New()
+	fcp.logger = log.NewEntry(logger)
+	fcp.conn = firecracker.NewClient(fcp.socketPath, fcp.logger, true)
+	fcp.fcConfig = firecracker.Config{
+		SocketPath: fcp.socketPath,
+		Drives:     driveList,
+		MachineCfg: fcp.machineConfiguration(),
+	}
+	//the machine isnt started as the snapshot replaces all of the configuration - it is kept for shutdowns
+	m, err := firecracker.NewMachine(fcp.ctx, fcp.fcConfig, firecracker.WithLogger(fcp.logger), firecracker.WithClient(fcp.conn))
+	if err != nil {
+		return err
+	}
+	fcp.machine = m
+
+	err = fcp.prepareJailFiles()
+	if err != nil {
+		return err
+	}
+
+	api := newFirecrackerAPI(fcp.socketPath)
+	if err := api.WaitForSocket(10 * time.Second); err != nil {
+		return err
+	}
+	if err := fcp.setupLogging(fcp.ctx); err != nil {
+		fcp.logger.Warnf("Unable to setup firecracker logging and metrics: %s. Continuing anyway.", err.Error())
+	}
+	if err := api.LoadSnapshot("/"+snapshotStateFile, "/"+snapshotMemFile); err != nil {
+		return err
+	}
+	//snapshots load paused
+	if err := api.PatchVMState("Resumed"); err != nil {
+		return err
+	}
+	fcp.markStarted()
+	if err := fcp.setupCgroup(); err != nil {
+		fcp.Stop()
+		return err
+	}
+	fcp.beginPollingLoop()
+
+	log.Println("Restored machine")
+
+	return nil
+}
+
+func moveFile(src string, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+	//the jail and the storage target may be on different filesystems
+	if err := vutils.Files.CopyRM(src, dest); err != nil {
+		return fmt.Errorf("Unable to move %s to %s: %s", src, dest, err.Error())
+	}
+	return nil
+}
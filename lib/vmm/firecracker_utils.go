@@ -2,9 +2,13 @@ package vmm
 
 import (
 	"errors"
+	"github.com/768bit/promethium/lib/assets"
 	"github.com/768bit/vutils"
 	"github.com/kardianos/osext"
+	"io"
+	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
 	"runtime"
 )
@@ -27,6 +31,49 @@ func getFirecrackerBinary() (string, string, error) {
 
 }
 
+// InstallEmbeddedBinaries puts the embedded firecracker and jailer in the bin folder of appRoot - it is done as root
+// before privileges are dropped as the helper only runs binaries that root owns
+func InstallEmbeddedBinaries(appRoot string) error {
+	binPath := filepath.Join(appRoot, "bin")
+	if err := os.MkdirAll(binPath, 0755); err != nil {
+		return err
+	}
+	for _, name := range []string{"firecracker", "jailer"} {
+		if err := installEmbeddedBinary(binPath, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installEmbeddedBinary writes the binary next to where it goes and renames it in to place so a binary that is being
+// run isnt written to
+func installEmbeddedBinary(binPath string, name string) error {
+	binary, err := assets.FireCrackerAssets.Open(name)
+	if err != nil {
+		//it isnt embedded in this build
+		return nil
+	}
+	defer binary.Close()
+	tmp, err := ioutil.TempFile(binPath, "."+name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0755); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(binPath, name))
+}
+
 func lookupEmbeddedBinary() (string, string, error) {
 	return checkBinariesExist(filepath.Join(ROOT_PATH, "bin"))
 }
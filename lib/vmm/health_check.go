@@ -0,0 +1,256 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+)
+
+const (
+	HealthNone      = "none"
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// HealthMonitor runs the configured health checks against a running vmm and applies the remediation
+// for a check once it has failed more than its threshold in a row.
+type HealthMonitor struct {
+	vmm    *Vmm
+	checks []*healthCheck
+
+	lock     sync.Mutex
+	stopChan chan bool
+	running  bool
+}
+
+type healthCheck struct {
+	config *config.VmmHealthCheckConfig
+
+	failures     int64
+	status       string
+	lastError    string
+	lastChecked  time.Time
+	runningSince time.Time
+}
+
+func NewHealthMonitor(vmm *Vmm, checks []*config.VmmHealthCheckConfig) (*HealthMonitor, error) {
+	hm := &HealthMonitor{
+		vmm:    vmm,
+		checks: []*healthCheck{},
+	}
+	for _, check := range checks {
+		if err := check.Validate(); err != nil {
+			return nil, err
+		}
+		hm.checks = append(hm.checks, &healthCheck{
+			config: check.WithDefaults(),
+			status: HealthStarting,
+		})
+	}
+	return hm, nil
+}
+
+func (hm *HealthMonitor) Start() {
+	if hm == nil {
+		return
+	}
+	hm.lock.Lock()
+	defer hm.lock.Unlock()
+	if hm.running || len(hm.checks) == 0 {
+		return
+	}
+	hm.running = true
+	hm.stopChan = make(chan bool)
+	for _, check := range hm.checks {
+		go hm.runCheck(check, hm.stopChan)
+	}
+}
+
+func (hm *HealthMonitor) Stop() {
+	if hm == nil {
+		return
+	}
+	hm.lock.Lock()
+	defer hm.lock.Unlock()
+	if !hm.running {
+		return
+	}
+	hm.running = false
+	close(hm.stopChan)
+}
+
+// Status is the combined status of all checks - any unhealthy check makes the vmm unhealthy
+func (hm *HealthMonitor) Status() (string, string) {
+	if hm == nil || len(hm.checks) == 0 {
+		return HealthNone, ""
+	}
+	hm.lock.Lock()
+	defer hm.lock.Unlock()
+	status := HealthHealthy
+	message := ""
+	for _, check := range hm.checks {
+		switch check.status {
+		case HealthUnhealthy:
+			return HealthUnhealthy, check.lastError
+		case HealthStarting:
+			status = HealthStarting
+		}
+		if check.lastError != "" && message == "" {
+			message = check.lastError
+		}
+	}
+	return status, message
+}
+
+func (hm *HealthMonitor) runCheck(check *healthCheck, stopChan chan bool) {
+	ticker := time.NewTicker(time.Duration(check.config.Interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			hm.evaluate(check)
+		}
+	}
+}
+
+func (hm *HealthMonitor) evaluate(check *healthCheck) {
+	if hm.vmm.instance == nil || hm.vmm.Status() != "Running" {
+		//nothing to check until the vmm is running again
+		hm.lock.Lock()
+		check.failures = 0
+		check.status = HealthStarting
+		check.runningSince = time.Time{}
+		hm.lock.Unlock()
+		return
+	}
+	err := hm.probe(check.config)
+	hm.lock.Lock()
+	check.lastChecked = time.Now()
+	if check.runningSince.IsZero() {
+		check.runningSince = check.lastChecked
+	}
+	if err == nil {
+		check.failures = 0
+		check.lastError = ""
+		check.status = HealthHealthy
+		hm.lock.Unlock()
+		return
+	}
+	check.lastError = err.Error()
+	startPeriod := time.Duration(check.config.StartPeriod) * time.Second
+	if time.Since(check.runningSince) < startPeriod {
+		hm.lock.Unlock()
+		return
+	}
+	check.failures++
+	if check.failures < check.config.Threshold {
+		hm.lock.Unlock()
+		return
+	}
+	becameUnhealthy := check.status != HealthUnhealthy
+	check.status = HealthUnhealthy
+	hm.lock.Unlock()
+	if becameUnhealthy {
+		log.Printf("VMM %s is unhealthy after %d failed %s checks: %s", hm.vmm.ID(), check.failures, check.config.Type, check.lastError)
+	}
+	hm.remediate(check)
+}
+
+func (hm *HealthMonitor) remediate(check *healthCheck) {
+	var err error
+	switch check.config.Remediation {
+	case config.HealthRemediationRestart:
+		log.Printf("Restarting unhealthy VMM %s", hm.vmm.ID())
+		err = hm.vmm.Restart()
+	case config.HealthRemediationReset:
+		log.Printf("Resetting unhealthy VMM %s", hm.vmm.ID())
+		err = hm.vmm.Reset()
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("Error remediating VMM %s: %s", hm.vmm.ID(), err.Error())
+		return
+	}
+	hm.lock.Lock()
+	check.failures = 0
+	check.status = HealthStarting
+	check.runningSince = time.Time{}
+	hm.lock.Unlock()
+}
+
+func (hm *HealthMonitor) probe(check *config.VmmHealthCheckConfig) error {
+	timeout := time.Duration(check.Timeout) * time.Second
+	switch check.Type {
+	case config.HealthCheckTCP:
+		host, err := hm.host(check)
+		if err != nil {
+			return err
+		}
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.FormatInt(check.Port, 10)), timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case config.HealthCheckHTTP:
+		host, err := hm.host(check)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{Timeout: timeout}
+		resp, err := client.Get(fmt.Sprintf("http://%s%s", net.JoinHostPort(host, strconv.FormatInt(check.Port, 10)), check.Path))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return errors.New("HTTP health check returned " + resp.Status)
+		}
+		return nil
+	case config.HealthCheckVsock:
+		conn, err := hm.vmm.DialVsock(uint32(check.Port), timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case config.HealthCheckAgent:
+		client, err := hm.vmm.Agent()
+		if err != nil {
+			return err
+		}
+		client.Timeout = timeout
+		_, err = client.Ping()
+		return err
+	}
+	return errors.New("Unknown health check type: " + string(check.Type))
+}
+
+// host resolves the address to check - either the configured host or the first static address of the vmm
+func (hm *HealthMonitor) host(check *config.VmmHealthCheckConfig) (string, error) {
+	if check.Host != "" {
+		return check.Host, nil
+	}
+	network := hm.vmm.config.Network
+	if network != nil {
+		for _, iface := range network.Interfaces {
+			if iface.Config == nil {
+				continue
+			}
+			for _, addr := range iface.Config.Addresses {
+				return strings.SplitN(addr, "/", 2)[0], nil
+			}
+		}
+	}
+	return "", errors.New("Unable to find an address to health check for " + hm.vmm.ID())
+}
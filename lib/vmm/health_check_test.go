@@ -0,0 +1,120 @@
+package vmm
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+)
+
+// newHealthCheckedVmm is a running mock vmm with a tcp health check against the port given
+func newHealthCheckedVmm(t *testing.T, port int, threshold int64, remediation config.VmmHealthRemediation) (*Vmm, *MockProcess, func()) {
+	mp, cleanup := newTestMockProcess(t, nil, nil)
+	if err := mp.Start(); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+	vmm := newVmm(nil, "health", "", &config.VmmConfig{ID: "health"})
+	vmm.instance = mp
+	health, err := NewHealthMonitor(vmm, []*config.VmmHealthCheckConfig{{
+		Type:        config.HealthCheckTCP,
+		Host:        "127.0.0.1",
+		Port:        int64(port),
+		Threshold:   threshold,
+		Remediation: remediation,
+	}})
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	vmm.health = health
+	return vmm, mp, cleanup
+}
+
+// closedPort is a port on localhost that nothing is listening on
+func closedPort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	return port
+}
+
+func TestHealthMonitorThreshold(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, portStr, _ := net.SplitHostPort(l.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	vmm, _, cleanup := newHealthCheckedVmm(t, port, 2, config.HealthRemediationNone)
+	defer cleanup()
+	check := vmm.health.checks[0]
+
+	if status, _ := vmm.Health(); status != HealthStarting {
+		t.Errorf("expected the vmm to be starting before it is checked, it is %s", status)
+	}
+	vmm.health.evaluate(check)
+	if status, _ := vmm.Health(); status != HealthHealthy {
+		t.Fatalf("expected the vmm to be healthy, it is %s", status)
+	}
+
+	//a failure under the threshold leaves it healthy but reports the failure
+	l.Close()
+	vmm.health.evaluate(check)
+	if status, message := vmm.Health(); status != HealthHealthy || message == "" {
+		t.Errorf("expected the vmm to stay healthy with the failure reported, it is %s %q", status, message)
+	}
+	vmm.health.evaluate(check)
+	if status, message := vmm.Health(); status != HealthUnhealthy || message == "" {
+		t.Errorf("expected the vmm to be unhealthy once the threshold is reached, it is %s %q", status, message)
+	}
+	//no remediation leaves it unhealthy
+	vmm.health.evaluate(check)
+	if status, _ := vmm.Health(); status != HealthUnhealthy {
+		t.Errorf("expected the vmm to stay unhealthy, it is %s", status)
+	}
+}
+
+func TestHealthMonitorNotRunning(t *testing.T) {
+	vmm, mp, cleanup := newHealthCheckedVmm(t, closedPort(t), 1, config.HealthRemediationReset)
+	defer cleanup()
+	if err := mp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	check := vmm.health.checks[0]
+	vmm.health.evaluate(check)
+	if status, _ := vmm.Health(); status != HealthStarting {
+		t.Errorf("expected a stopped vmm to not be checked, it is %s", status)
+	}
+	if mp.GetStatus() == "Running" {
+		t.Error("expected a stopped vmm to not be remediated")
+	}
+}
+
+func TestHealthMonitorRemediation(t *testing.T) {
+	for _, remediation := range []config.VmmHealthRemediation{config.HealthRemediationRestart, config.HealthRemediationReset} {
+		vmm, mp, cleanup := newHealthCheckedVmm(t, closedPort(t), 1, remediation)
+		check := vmm.health.checks[0]
+		vmm.health.evaluate(check)
+
+		mp.lock.Lock()
+		starts := mp.starts
+		mp.lock.Unlock()
+		if starts != 2 {
+			t.Errorf("%s: expected the unhealthy vmm to be started again, it was started %d times", remediation, starts)
+		}
+		if status, _ := vmm.Health(); status != HealthStarting {
+			t.Errorf("%s: expected the check to start over after remediation, it is %s", remediation, status)
+		}
+		if vmm.Operation() != "" {
+			t.Errorf("%s: expected the remediation to finish its operation, %q is still running", remediation, vmm.Operation())
+		}
+		cleanup()
+	}
+}
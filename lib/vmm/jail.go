@@ -0,0 +1,163 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/768bit/vutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// how long firecracker has to create its api socket once the jailer is started
+const jailSocketTimeout = 10 * time.Second
+
+// JailIdentity is the user and group firecracker runs as in its jail
+type JailIdentity struct {
+	UID int
+	GID int
+}
+
+// the directories the jailer creates in the jail as root for the device nodes
+var jailDevDirs = []string{"dev", filepath.Join("dev", "net")}
+
+// ensureJailUID gives the vmm its own uid from the jail uid range when there is one - the config is saved when it
+// changes
+func (vmmMgr *VmmManager) ensureJailUID(vmm *Vmm) error {
+	uidRange := vmmMgr.config.JailUIDRange
+	if uidRange == nil {
+		return nil
+	}
+	vmmMgr.allocLock.Lock()
+	defer vmmMgr.allocLock.Unlock()
+	used := map[int]bool{}
+	for _, other := range vmmMgr.instanceList() {
+		if other == nil || other.id == vmm.id || other.config == nil {
+			continue
+		}
+		used[other.config.JailUID] = true
+	}
+	uid := vmm.config.JailUID
+	if uidRange.Contains(uid) && !used[uid] {
+		return nil
+	}
+	if uid != 0 {
+		log.Printf("Jail uid %d of %s is in use or outside the range - allocating a new one", uid, vmm.id)
+	}
+	for candidate := uidRange.Start; candidate < uidRange.Start+uidRange.Size; candidate++ {
+		if !used[candidate] {
+			vmm.config.JailUID = candidate
+			err, _ := vutils.Config.SaveConfigToFile("", vmm.configPath, vmm.config)
+			return err
+		}
+	}
+	return fmt.Errorf("No jail uids are left in the range %d-%d", uidRange.Start, uidRange.Start+uidRange.Size-1)
+}
+
+// jailIdentity is who the firecracker process of the vmm runs as - its own uid when they are allocated from a range
+// and the jail user otherwise
+func (vmm *Vmm) jailIdentity() JailIdentity {
+	jail := JailIdentity{UID: vmm.mgr.jailUID, GID: vmm.mgr.jailGID}
+	if vmm.mgr.config.JailUIDRange != nil && vmm.config.JailUID != 0 {
+		jail.UID = vmm.config.JailUID
+	}
+	return jail
+}
+
+// claimJailFiles gives files in the jail to the daemon while keeping them in the jail group so firecracker can still
+// use them - only the paths supplied are changed
+func (fcp *FireCrackerProcess) claimJailFiles(paths ...string) error {
+	existing := []string{}
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err == nil {
+			existing = append(existing, path)
+		}
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	return privhelper.Default.Chown(privhelper.Owner(os.Getuid()), fcp.jail.GID, false, existing...)
+}
+
+// prepareJailDir creates the jail before the jailer starts - files created in it by firecracker take the jail group
+// from the setgid bit so the daemon and firecracker can both use them
+func (fcp *FireCrackerProcess) prepareJailDir() error {
+	if err := vutils.Files.CreateDirIfNotExist(fcp.chrootPath); err != nil {
+		return err
+	}
+	if err := fcp.claimJailFiles(fcp.chrootPath); err != nil {
+		return err
+	}
+	return os.Chmod(fcp.chrootPath, 0770|os.ModeSetgid)
+}
+
+// prepareJailFiles waits for the api socket and hands it to the daemon along with everything that has been put in
+// the jail for firecracker to use
+func (fcp *FireCrackerProcess) prepareJailFiles() error {
+	deadline := time.Now().Add(jailSocketTimeout)
+	for !vutils.Files.CheckPathExists(fcp.socketPath) {
+		if time.Now().After(deadline) {
+			return errors.New("Timed out waiting for firecracker to create its api socket")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	entries, err := ioutil.ReadDir(fcp.chrootPath)
+	if err != nil {
+		return err
+	}
+	paths := []string{}
+	files := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(fcp.chrootPath, entry.Name())
+		paths = append(paths, path)
+		if entry.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	if err := fcp.claimJailFiles(paths...); err != nil {
+		return err
+	}
+	for _, path := range files {
+		if err := os.Chmod(path, 0660); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseJail gives the directories the jailer created as root to the daemon so the jail can be removed
+func (fcp *FireCrackerProcess) releaseJail() {
+	paths := make([]string, len(jailDevDirs))
+	for i, dir := range jailDevDirs {
+		paths[i] = filepath.Join(fcp.chrootPath, dir)
+	}
+	if err := fcp.claimJailFiles(paths...); err != nil {
+		log.Warnf("Unable to take ownership of the jail of %s: %s", fcp.id, err.Error())
+	}
+}
+
+// resolveJailIdentity looks up the jail user and group of the daemon config
+func resolveJailIdentity(pdc *config.PromethiumDaemonConfig) (int, int, error) {
+	uid, err := config.GetUserId(pdc.JailUser)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Unable to find jail user %s: %s", pdc.JailUser, err.Error())
+	}
+	gid, err := config.GetGroupId(pdc.JailGroup)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Unable to find jail group %s: %s", pdc.JailGroup, err.Error())
+	}
+	if pdc.JailUIDRange != nil {
+		if err := pdc.JailUIDRange.Validate(); err != nil {
+			return 0, 0, err
+		}
+	}
+	return uid, gid, nil
+}
@@ -0,0 +1,101 @@
+package vmm
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/go-openapi/strfmt"
+)
+
+const AllLogSources = "all"
+
+func (vmm *Vmm) logs(source string) ([]*logging.Log, error) {
+	if vmm.instance == nil {
+		return nil, errors.New("Unable to get logs as instance isnt setup")
+	}
+	sources := []string{source}
+	if source == AllLogSources {
+		sources = []string{SerialLogSource, FirecrackerLogSource}
+	}
+	logs := []*logging.Log{}
+	for _, name := range sources {
+		l := vmm.instance.Log(name)
+		if l == nil {
+			return nil, errors.New("There is no " + name + " log for this instance")
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
+// Logs returns the lines written to a log (or all logs) at or after since - when tail is more than zero only that
+// many of the most recent lines are returned
+func (vmm *Vmm) Logs(source string, since time.Time, tail int) ([]logging.Entry, error) {
+	logs, err := vmm.logs(source)
+	if err != nil {
+		return nil, err
+	}
+	lists := [][]logging.Entry{}
+	for _, l := range logs {
+		entries, err := l.Read(since, tail)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, entries)
+	}
+	entries := logging.Merge(lists...)
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, nil
+}
+
+// FollowLogs sends every line written to a log (or all logs) from now on until the returned cancel func is called
+func (vmm *Vmm) FollowLogs(source string) (<-chan logging.Entry, func(), error) {
+	logs, err := vmm.logs(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan logging.Entry, 256)
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	subs := make([]chan logging.Entry, len(logs))
+	for index, l := range logs {
+		subs[index] = l.Subscribe()
+		wg.Add(1)
+		go func(ch chan logging.Entry) {
+			defer wg.Done()
+			for entry := range ch {
+				select {
+				case out <- entry:
+				case <-done:
+				}
+			}
+		}(subs[index])
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	once := sync.Once{}
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			for index, l := range logs {
+				l.Unsubscribe(subs[index])
+			}
+		})
+	}
+	return out, cancel, nil
+}
+
+func (vmm *Vmm) GetLogEntryModel(entry logging.Entry) *models.VMLogEntry {
+	return &models.VMLogEntry{
+		Time:   strfmt.DateTime(entry.Time),
+		Source: entry.Source,
+		Line:   entry.Line,
+	}
+}
@@ -0,0 +1,486 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/768bit/promethium/lib/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+// MockVersion is the firecracker version mock vmms claim to be so their snapshots can be restored
+const MockVersion = "0.0.0"
+
+const (
+	mockStoppedStatus = "Not started"
+	mockErrorStatus   = "ERROR"
+)
+
+// MockProcess is a VmmProcess that doesnt run a vm - it boots, shuts down, writes to its console and fails as its
+// mock config says so the daemon, api and cli can be exercised without kvm or firecracker
+type MockProcess struct {
+	id            string
+	mock          *config.VmmMockConfig
+	restartPolicy *config.VmmRestartPolicy
+	//runs the restarts of the restart policy as an operation of the vmm
+	runOperation func(op string, fn func() error) error
+
+	lock           sync.Mutex
+	status         string
+	isPaused       bool
+	isShuttingDown bool
+	starts         int64
+	crashCount     int64
+	restartCount   int64
+	lastExitReason string
+	lastStartedAt  time.Time
+	balloonMiB     int64
+	//closed when the current run of the vmm ends - nil when it isnt running
+	stopChan     chan struct{}
+	exitChan     chan error
+	restartTimer *time.Timer
+
+	console        *ConsoleBroker
+	metrics        *metrics.VmmMetrics
+	serialLog      *logging.Log
+	firecrackerLog *logging.Log
+}
+
+func NewMockProcess(id string, mock *config.VmmMockConfig, restartPolicy *config.VmmRestartPolicy) *MockProcess {
+	if mock == nil {
+		mock = &config.VmmMockConfig{}
+	}
+	mp := &MockProcess{
+		id:            id,
+		mock:          mock,
+		restartPolicy: restartPolicy,
+		status:        mockStoppedStatus,
+		exitChan:      make(chan error),
+		console:       NewConsoleBroker(DefaultConsoleScrollback),
+		metrics:       metrics.NewVmmMetrics(),
+	}
+	if mp.restartPolicy == nil {
+		mp.restartPolicy = (&config.VmmConfig{}).GetRestartPolicy()
+	}
+	serialLog, firecrackerLog, err := openInstanceLogs(id)
+	if err != nil {
+		log.Warnf("Unable to open logs for %s: %s. Output will not be kept.", id, err.Error())
+	} else {
+		mp.serialLog = serialLog
+		mp.firecrackerLog = firecrackerLog
+	}
+	return mp
+}
+
+// SetOperationRunner sets what the restarts of the restart policy are run through
+func (mp *MockProcess) SetOperationRunner(runOperation func(op string, fn func() error) error) {
+	mp.runOperation = runOperation
+}
+
+func (mp *MockProcess) GetStatus() string {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	return mp.status
+}
+
+func (mp *MockProcess) GetCrashCount() int64 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	return mp.crashCount
+}
+
+func (mp *MockProcess) GetRestartCount() int64 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	return mp.restartCount
+}
+
+func (mp *MockProcess) GetLastExitReason() string {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	return mp.lastExitReason
+}
+
+// Wait blocks until the vmm exits without being asked to
+func (mp *MockProcess) Wait() error {
+	return <-mp.exitChan
+}
+
+func (mp *MockProcess) Console(readOnly bool) (io.ReadWriteCloser, error) {
+	if mp.GetStatus() != "Running" {
+		return nil, errors.New("Cannot connect to console of non running VM")
+	}
+	return mp.console.Attach(readOnly, true), nil
+}
+
+func (mp *MockProcess) Start() error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.cancelRestart()
+	if mp.stopChan != nil {
+		return errors.New("VMM already started")
+	}
+	mp.starts++
+	if mp.mock.FailStart != "" && (mp.mock.FailStartCount == 0 || mp.starts <= mp.mock.FailStartCount) {
+		mp.status = mockStoppedStatus
+		mp.lastExitReason = mp.mock.FailStart
+		mp.logEvent("Mock vmm failed to start: " + mp.mock.FailStart)
+		return errors.New(mp.mock.FailStart)
+	}
+	mp.begin(true)
+	return nil
+}
+
+// begin starts a run of the vmm - when boot is false it is Running straight away as if it was loaded from a
+// snapshot. The lock must be held.
+func (mp *MockProcess) begin(boot bool) {
+	stopChan := make(chan struct{})
+	mp.stopChan = stopChan
+	mp.isPaused = false
+	mp.lastStartedAt = time.Now()
+	if boot {
+		mp.status = "Starting"
+		mp.logEvent("Mock vmm booting")
+	} else {
+		mp.logEvent("Mock vmm loaded from snapshot")
+		mp.running()
+	}
+	go mp.run(stopChan, boot)
+}
+
+// running marks the vmm as booted - the lock must be held
+func (mp *MockProcess) running() {
+	mp.status = "Running"
+	if mp.mock.Echo {
+		mp.console.SetInput(&mockConsoleInput{mp: mp})
+	}
+	mp.console.SetResizer(func(cols int, rows int) error {
+		return nil
+	})
+}
+
+// run plays out the boot of the vmm and then waits for it to be stopped or to crash
+func (mp *MockProcess) run(stopChan chan struct{}, boot bool) {
+	if boot {
+		lines := mp.mock.ConsoleOutput
+		delay := time.Duration(mp.mock.BootDelay) * time.Millisecond
+		step := delay
+		if len(lines) > 0 {
+			step = delay / time.Duration(len(lines))
+		}
+		for index := 0; index < len(lines) || index == 0; index++ {
+			if !mp.sleep(stopChan, step) {
+				return
+			}
+			if index < len(lines) {
+				mp.writeSerial([]byte(lines[index] + "\r\n"))
+			}
+		}
+		mp.lock.Lock()
+		if mp.stopChan != stopChan {
+			mp.lock.Unlock()
+			return
+		}
+		mp.logEvent("Mock vmm running")
+		mp.running()
+		mp.lock.Unlock()
+	}
+	if mp.mock.CrashAfter > 0 && mp.sleep(stopChan, time.Duration(mp.mock.CrashAfter)*time.Millisecond) {
+		mp.crash(stopChan)
+	}
+}
+
+// sleep waits for the delay given - it is false if the run of the vmm ended first
+func (mp *MockProcess) sleep(stopChan chan struct{}, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopChan:
+		return false
+	}
+}
+
+// crash ends the run of the vmm as a failure and brings it back if the restart policy says so
+func (mp *MockProcess) crash(stopChan chan struct{}) {
+	mp.lock.Lock()
+	if mp.stopChan != stopChan {
+		mp.lock.Unlock()
+		return
+	}
+	mp.end()
+	mp.status = mockErrorStatus
+	mp.lastExitReason = "Mock vmm crashed"
+	//if we were up for longer than the reset window this is a fresh run of crashes
+	resetWindow := time.Duration(mp.restartPolicy.ResetWindow) * time.Second
+	if time.Since(mp.lastStartedAt) > resetWindow {
+		mp.crashCount = 0
+	}
+	mp.crashCount++
+	mp.logEvent(mp.lastExitReason)
+	if mp.restartPolicy.ShouldRestart(true, false, mp.crashCount) {
+		mp.scheduleRestart()
+	}
+	mp.lock.Unlock()
+	select {
+	case mp.exitChan <- errors.New(mp.GetLastExitReason()):
+	default:
+	}
+}
+
+// scheduleRestart starts the vmm again after the backoff delay for the current crash count - the lock must be held
+func (mp *MockProcess) scheduleRestart() {
+	delay := mp.restartPolicy.BackoffDelay(mp.crashCount)
+	mp.restartCount++
+	mp.restartTimer = time.AfterFunc(delay, func() {
+		start := mp.Start
+		if mp.runOperation != nil {
+			start = func() error {
+				return mp.runOperation("restart", mp.Start)
+			}
+		}
+		if err := start(); err != nil {
+			log.Debugf("Error when restarting mock vmm with restart policy: %s", err.Error())
+		}
+	})
+}
+
+// cancelRestart stops a restart of the restart policy that is waiting - the lock must be held
+func (mp *MockProcess) cancelRestart() {
+	if mp.restartTimer != nil {
+		mp.restartTimer.Stop()
+		mp.restartTimer = nil
+	}
+}
+
+// end finishes the current run of the vmm and disconnects its console - the lock must be held
+func (mp *MockProcess) end() {
+	if mp.stopChan != nil {
+		close(mp.stopChan)
+		mp.stopChan = nil
+	}
+	mp.isPaused = false
+	mp.status = mockStoppedStatus
+	mp.console.SetInput(nil)
+	mp.console.SetResizer(nil)
+	mp.console.DetachAll()
+}
+
+func (mp *MockProcess) Stop() error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.cancelRestart()
+	if mp.stopChan != nil {
+		mp.logEvent("Mock vmm killed")
+	}
+	mp.end()
+	return nil
+}
+
+func (mp *MockProcess) Shutdown() error {
+	return mp.ShutdownTimeout(5 * time.Second)
+}
+
+// ShutdownTimeout asks the guest to shut down and kills it if it hasnt after timeout
+func (mp *MockProcess) ShutdownTimeout(timeout time.Duration) error {
+	mp.lock.Lock()
+	stopChan := mp.stopChan
+	if stopChan == nil || mp.status == "Starting" {
+		mp.lock.Unlock()
+		return mp.Stop()
+	} else if mp.isShuttingDown {
+		mp.lock.Unlock()
+		return errors.New("Already shutting down")
+	}
+	mp.isShuttingDown = true
+	mp.isPaused = false
+	mp.status = "Running"
+	mp.logEvent("Mock vmm asked to shut down")
+	mp.lock.Unlock()
+	defer func() {
+		mp.lock.Lock()
+		mp.isShuttingDown = false
+		mp.lock.Unlock()
+	}()
+	delay := time.Duration(mp.mock.ShutdownDelay) * time.Millisecond
+	if mp.mock.IgnoreShutdown || delay > timeout {
+		//the guest wont be done in time so it is killed once the timeout is up
+		if mp.sleep(stopChan, timeout) {
+			mp.lock.Lock()
+			mp.logEvent("Mock vmm didnt shut down in time")
+			mp.lock.Unlock()
+		}
+		return mp.Stop()
+	}
+	if mp.sleep(stopChan, delay) {
+		mp.writeSerial([]byte("reboot: Power down\r\n"))
+	}
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if mp.stopChan == stopChan {
+		mp.logEvent("Mock vmm shut down")
+		mp.end()
+	}
+	return nil
+}
+
+func (mp *MockProcess) Restart() error {
+	if err := mp.Shutdown(); err != nil {
+		return err
+	}
+	return mp.Start()
+}
+
+func (mp *MockProcess) Reset() error {
+	if err := mp.Stop(); err != nil {
+		return err
+	}
+	return mp.Start()
+}
+
+func (mp *MockProcess) Pause() error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if mp.isPaused {
+		return errors.New("VM is already paused")
+	} else if mp.status != "Running" {
+		return errors.New("Cannot pause a VM that isnt running")
+	}
+	mp.isPaused = true
+	mp.status = PAUSED_STATUS
+	return nil
+}
+
+func (mp *MockProcess) Resume() error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if mp.stopChan == nil {
+		return errors.New("Cannot resume a VM that isnt running")
+	} else if !mp.isPaused {
+		return errors.New("VM is not paused")
+	}
+	mp.isPaused = false
+	mp.status = "Running"
+	return nil
+}
+
+func (mp *MockProcess) Version() (string, error) {
+	return MockVersion, nil
+}
+
+// CreateSnapshot writes placeholder state and memory files for the paused vmm
+func (mp *MockProcess) CreateSnapshot(statePath string, memPath string) error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if mp.stopChan == nil {
+		return errors.New("Cannot snapshot a VM that isnt running")
+	} else if !mp.isPaused {
+		return errors.New("VM must be paused before it can be snapshotted")
+	}
+	if err := ioutil.WriteFile(statePath, []byte("mock vmm state of "+mp.id), 0640); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(memPath, []byte("mock vmm memory of "+mp.id), 0640)
+}
+
+// LoadSnapshot starts the vmm as if from the snapshot - it is Running straight away without booting
+func (mp *MockProcess) LoadSnapshot(statePath string, memPath string) error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.cancelRestart()
+	if mp.stopChan != nil {
+		return errors.New("VMM already started")
+	}
+	for _, path := range []string{statePath, memPath} {
+		if _, err := os.Stat(path); err != nil {
+			return err
+		}
+	}
+	mp.begin(false)
+	return nil
+}
+
+func (mp *MockProcess) FlushMetrics() error {
+	return nil
+}
+
+func (mp *MockProcess) Metrics() *metrics.VmmMetricsSnapshot {
+	return mp.metrics.Snapshot()
+}
+
+// UpdateBalloon records the target - the mock guest gives up the memory straight away
+func (mp *MockProcess) UpdateBalloon(amountMiB int64) error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.balloonMiB = amountMiB
+	return nil
+}
+
+func (mp *MockProcess) UpdateResources(resources *config.VmmResourcesConfig) error {
+	return nil
+}
+
+// Pid is always 0 as there is no process
+func (mp *MockProcess) Pid() int {
+	return 0
+}
+
+// ProcessStat is always unavailable as there is no process behind the mock
+func (mp *MockProcess) ProcessStat() (*metrics.ProcStat, error) {
+	return nil, ErrVmmNotRunning
+}
+
+// Log returns the log for the source given or nil if there isnt one - mock events are written to the firecracker log
+func (mp *MockProcess) Log(source string) *logging.Log {
+	switch source {
+	case SerialLogSource:
+		return mp.serialLog
+	case FirecrackerLogSource:
+		return mp.firecrackerLog
+	}
+	return nil
+}
+
+// Reattach never picks anything up as mock vmms dont outlive the daemon
+func (mp *MockProcess) Reattach() (bool, error) {
+	return false, nil
+}
+
+// Detach stops the vmm as there is nothing to leave running
+func (mp *MockProcess) Detach() error {
+	return mp.Stop()
+}
+
+func (mp *MockProcess) writeSerial(p []byte) {
+	if mp.serialLog != nil {
+		mp.serialLog.Write(p)
+	}
+	mp.console.Write(p)
+}
+
+func (mp *MockProcess) logEvent(event string) {
+	if mp.firecrackerLog != nil {
+		mp.firecrackerLog.WriteLine(fmt.Sprintf("[%s] %s", mp.id, event))
+	}
+}
+
+// mockConsoleInput writes the console input back to the console when the mock echoes
+type mockConsoleInput struct {
+	mp *MockProcess
+}
+
+func (mci *mockConsoleInput) Write(p []byte) (int, error) {
+	mci.mp.writeSerial(p)
+	return len(p), nil
+}
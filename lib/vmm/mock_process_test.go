@@ -0,0 +1,262 @@
+package vmm
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+)
+
+func newTestMockProcess(t *testing.T, mock *config.VmmMockConfig, policy *config.VmmRestartPolicy) (*MockProcess, func()) {
+	dir, err := ioutil.TempDir("", "mock-process")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPath := ROOT_PATH
+	ROOT_PATH = dir
+	mp := NewMockProcess("mock", mock, policy)
+	return mp, func() {
+		mp.Stop()
+		ROOT_PATH = rootPath
+		os.RemoveAll(dir)
+	}
+}
+
+func waitForMockStatus(t *testing.T, mp *MockProcess, status string, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for mp.GetStatus() != status {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the mock to be %q, it is %q", status, mp.GetStatus())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// readConsole reads from the console until it has seen want
+func readConsole(t *testing.T, console io.Reader, want string) string {
+	out := make(chan string)
+	go func() {
+		seen := []byte{}
+		buff := make([]byte, 256)
+		for !bytes.Contains(seen, []byte(want)) {
+			n, err := console.Read(buff)
+			seen = append(seen, buff[:n]...)
+			if err != nil {
+				break
+			}
+		}
+		out <- string(seen)
+	}()
+	select {
+	case seen := <-out:
+		if !strings.Contains(seen, want) {
+			t.Fatalf("expected the console to show %q, got %q", want, seen)
+		}
+		return seen
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %q on the console", want)
+	}
+	return ""
+}
+
+func TestMockProcessBoot(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{
+		BootDelay:     60,
+		ConsoleOutput: []string{"Booting", "login:"},
+		Echo:          true,
+	}, nil)
+	defer cleanup()
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if status := mp.GetStatus(); status != "Starting" {
+		t.Errorf("expected the mock to be booting, it is %q", status)
+	}
+	if _, err := mp.Console(false); err == nil {
+		t.Error("expected the console of a booting vm to be refused")
+	}
+	if err := mp.Start(); err == nil {
+		t.Error("expected a second start to fail")
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+	console, err := mp.Console(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer console.Close()
+	readConsole(t, console, "Booting\r\nlogin:\r\n")
+	if _, err := console.Write([]byte("root\n")); err != nil {
+		t.Fatal(err)
+	}
+	readConsole(t, console, "root\n")
+	if err := mp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if status := mp.GetStatus(); status != mockStoppedStatus {
+		t.Errorf("expected the mock to be stopped, it is %q", status)
+	}
+	if _, err := ioutil.ReadAll(console); err != nil {
+		t.Errorf("expected the console to be closed when the vm stops, got %v", err)
+	}
+}
+
+func TestMockProcessFailStart(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{FailStart: "no kvm", FailStartCount: 1}, nil)
+	defer cleanup()
+	if err := mp.Start(); err == nil || err.Error() != "no kvm" {
+		t.Fatalf("expected the first start to fail, got %v", err)
+	}
+	if mp.GetStatus() != mockStoppedStatus || mp.GetLastExitReason() != "no kvm" {
+		t.Errorf("unexpected status %q and exit reason %q", mp.GetStatus(), mp.GetLastExitReason())
+	}
+	if err := mp.Start(); err != nil {
+		t.Fatalf("expected the second start to work, got %v", err)
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+}
+
+func TestMockProcessShutdown(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{ShutdownDelay: 20}, nil)
+	defer cleanup()
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+	console, err := mp.Console(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer console.Close()
+	if err := mp.ShutdownTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if status := mp.GetStatus(); status != mockStoppedStatus {
+		t.Errorf("expected the mock to have shut down, it is %q", status)
+	}
+	readConsole(t, console, "reboot: Power down")
+}
+
+func TestMockProcessShutdownTimeout(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{IgnoreShutdown: true}, nil)
+	defer cleanup()
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+	start := time.Now()
+	if err := mp.ShutdownTimeout(50 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took < 50*time.Millisecond {
+		t.Errorf("expected the shutdown to wait for the timeout, it took %s", took)
+	}
+	if status := mp.GetStatus(); status != mockStoppedStatus {
+		t.Errorf("expected the mock to be killed after the timeout, it is %q", status)
+	}
+}
+
+func TestMockProcessCrashRestart(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{CrashAfter: 20}, &config.VmmRestartPolicy{
+		Policy:      config.RestartPolicyOnFailure,
+		MaxRetries:  1,
+		Backoff:     1,
+		MaxBackoff:  1,
+		ResetWindow: 600,
+	})
+	defer cleanup()
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- mp.Wait()
+	}()
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-waitErr:
+		if err == nil {
+			t.Error("expected the crash to be returned from wait")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the mock to crash")
+	}
+	if mp.GetCrashCount() != 1 || mp.GetLastExitReason() == "" {
+		t.Errorf("expected the crash to be recorded, got %d %q", mp.GetCrashCount(), mp.GetLastExitReason())
+	}
+	//the restart policy brings it back once and gives up after the second crash
+	deadline := time.Now().Add(3 * time.Second)
+	for mp.GetCrashCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the mock to be restarted and crash again")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(1200 * time.Millisecond)
+	if status := mp.GetStatus(); status != mockErrorStatus || mp.GetCrashCount() != 2 {
+		t.Errorf("expected the mock to be left crashed, it is %q after %d crashes", status, mp.GetCrashCount())
+	}
+}
+
+func TestMockProcessRestartCount(t *testing.T) {
+	//every crash is outside the reset window so the crash count starts over each time
+	mp, cleanup := newTestMockProcess(t, &config.VmmMockConfig{CrashAfter: 20}, &config.VmmRestartPolicy{
+		Policy: config.RestartPolicyAlways,
+	})
+	defer cleanup()
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for mp.GetRestartCount() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the mock to keep being restarted, it was restarted %d times", mp.GetRestartCount())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if crashes := mp.GetCrashCount(); crashes != 1 {
+		t.Errorf("expected the crash count to be reset by the reset window, it is %d", crashes)
+	}
+}
+
+func TestMockProcessPauseResume(t *testing.T) {
+	mp, cleanup := newTestMockProcess(t, nil, nil)
+	defer cleanup()
+	if err := mp.Pause(); err == nil {
+		t.Error("expected a mock that isnt started to not be paused")
+	}
+	if err := mp.Resume(); err == nil {
+		t.Error("expected a mock that isnt started to not be resumed")
+	}
+	if err := mp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitForMockStatus(t, mp, "Running", time.Second)
+	if err := mp.Resume(); err == nil {
+		t.Error("expected a running mock to not be resumed")
+	}
+	if err := mp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.Pause(); err == nil {
+		t.Error("expected a paused mock to not be paused again")
+	}
+	if status := mp.GetStatus(); status != PAUSED_STATUS {
+		t.Errorf("expected the mock to be paused, it is %q", status)
+	}
+	if err := mp.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	if status := mp.GetStatus(); status != "Running" {
+		t.Errorf("expected the mock to be running again, it is %q", status)
+	}
+	if err := mp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.Pause(); err == nil {
+		t.Error("expected a stopped mock to not be paused")
+	}
+}
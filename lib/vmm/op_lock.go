@@ -0,0 +1,136 @@
+package vmm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ConflictError is returned when an operation is started on a vmm while another one is still in progress on it
+type ConflictError struct {
+	VmID      string
+	Operation string
+	Running   string
+}
+
+func (err *ConflictError) Error() string {
+	running := err.Running
+	if running == "" {
+		running = "another operation"
+	}
+	return fmt.Sprintf("Unable to %s VM %s as %s is in progress", err.Operation, err.VmID, running)
+}
+
+// StateError is returned when an operation cant be applied to a vmm in the state it is in
+type StateError struct {
+	VmID      string
+	Operation string
+	Status    string
+}
+
+func (err *StateError) Error() string {
+	return fmt.Sprintf("Unable to %s VM %s as it is %s", err.Operation, err.VmID, err.Status)
+}
+
+// IsConflict is whether err was returned because the vmm was busy or in the wrong state for the operation
+func IsConflict(err error) bool {
+	switch err.(type) {
+	case *ConflictError, *StateError:
+		return true
+	}
+	return false
+}
+
+// opLock serialises the operations that change the state of a vmm - the slot holds a token while one is in progress
+// so callers can choose between failing straight away and waiting their turn
+type opLock struct {
+	slot chan struct{}
+	lock sync.Mutex
+	name string
+}
+
+func newOpLock() *opLock {
+	return &opLock{slot: make(chan struct{}, 1)}
+}
+
+// try starts op when nothing else is in progress - otherwise it returns what is
+func (ol *opLock) try(op string) (string, bool) {
+	select {
+	case ol.slot <- struct{}{}:
+		ol.set(op)
+		return "", true
+	default:
+		return ol.current(), false
+	}
+}
+
+// wait starts op once whatever is in progress has finished
+func (ol *opLock) wait(op string) {
+	ol.slot <- struct{}{}
+	ol.set(op)
+}
+
+func (ol *opLock) done() {
+	ol.set("")
+	<-ol.slot
+}
+
+func (ol *opLock) set(op string) {
+	ol.lock.Lock()
+	ol.name = op
+	ol.lock.Unlock()
+}
+
+func (ol *opLock) current() string {
+	ol.lock.Lock()
+	defer ol.lock.Unlock()
+	return ol.name
+}
+
+// beginOp starts an operation on the vmm - it fails with a ConflictError when another is in progress. The func
+// returned ends it.
+func (vmm *Vmm) beginOp(op string) (func(), error) {
+	if running, ok := vmm.ops.try(op); !ok {
+		return nil, &ConflictError{VmID: vmm.id, Operation: op, Running: running}
+	}
+	return vmm.ops.done, nil
+}
+
+// waitOp starts an operation on the vmm once the one in progress has finished - it is used when the daemon exits
+// as that cant be refused
+func (vmm *Vmm) waitOp(op string) func() {
+	vmm.ops.wait(op)
+	return vmm.ops.done
+}
+
+// Busy is the ConflictError op would fail with if it was started now - it lets a request that starts op in the
+// background be refused straight away
+func (vmm *Vmm) Busy(op string) error {
+	if running := vmm.ops.current(); running != "" {
+		return &ConflictError{VmID: vmm.id, Operation: op, Running: running}
+	}
+	return nil
+}
+
+// Operation is the operation in progress on the vmm - it is empty when there isnt one
+func (vmm *Vmm) Operation() string {
+	return vmm.ops.current()
+}
+
+// do runs an operation on the instance of the vmm when nothing else is in progress on it
+func (vmm *Vmm) do(op string, fn func() error) error {
+	if vmm.instance == nil {
+		return fmt.Errorf("Unable to %s as instance isnt setup", op)
+	}
+	done, err := vmm.beginOp(op)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return fn()
+}
+
+// SetOperationRunner is how the process runs the restarts of its restart policy - they happen on a timer so one that
+// fires while the vmm is being stopped or changed is dropped rather than racing it
+func (fcp *FireCrackerProcess) SetOperationRunner(runOperation func(op string, fn func() error) error) {
+	fcp.runOperation = runOperation
+}
@@ -0,0 +1,276 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/logging"
+	"github.com/768bit/promethium/lib/metrics"
+)
+
+// fakeProcess is a VmmProcess that only keeps its state - start blocks until release is closed so tests can hold
+// an operation open, and active counts the calls in progress so overlapping ones are caught
+type fakeProcess struct {
+	lock    sync.Mutex
+	status  string
+	release chan struct{}
+	entered chan struct{}
+	active  int32
+	overlap int32
+}
+
+func newFakeProcess() *fakeProcess {
+	release := make(chan struct{})
+	close(release)
+	return &fakeProcess{status: UNKOWN_STATUS, release: release, entered: make(chan struct{}, 16)}
+}
+
+func (fp *fakeProcess) enter() func() {
+	if atomic.AddInt32(&fp.active, 1) > 1 {
+		atomic.StoreInt32(&fp.overlap, 1)
+	}
+	return func() {
+		atomic.AddInt32(&fp.active, -1)
+	}
+}
+
+func (fp *fakeProcess) setStatus(status string) error {
+	defer fp.enter()()
+	fp.lock.Lock()
+	fp.status = status
+	fp.lock.Unlock()
+	return nil
+}
+
+func (fp *fakeProcess) GetStatus() string {
+	fp.lock.Lock()
+	defer fp.lock.Unlock()
+	return fp.status
+}
+
+func (fp *fakeProcess) Start() error {
+	defer fp.enter()()
+	select {
+	case fp.entered <- struct{}{}:
+	default:
+	}
+	<-fp.release
+	fp.lock.Lock()
+	fp.status = "Running"
+	fp.lock.Unlock()
+	return nil
+}
+
+func (fp *fakeProcess) GetCrashCount() int64      { return 0 }
+func (fp *fakeProcess) GetLastExitReason() string { return "" }
+func (fp *fakeProcess) Wait() error               { return nil }
+func (fp *fakeProcess) Console(readOnly bool) (io.ReadWriteCloser, error) {
+	return nil, errors.New("no console")
+}
+func (fp *fakeProcess) Stop() error     { return fp.setStatus(UNKOWN_STATUS) }
+func (fp *fakeProcess) Shutdown() error { return fp.setStatus(UNKOWN_STATUS) }
+func (fp *fakeProcess) ShutdownTimeout(timeout time.Duration) error {
+	return fp.setStatus(UNKOWN_STATUS)
+}
+func (fp *fakeProcess) Restart() error                                        { return fp.setStatus("Running") }
+func (fp *fakeProcess) Reset() error                                          { return fp.setStatus("Running") }
+func (fp *fakeProcess) Pause() error                                          { return fp.setStatus(PAUSED_STATUS) }
+func (fp *fakeProcess) Resume() error                                         { return fp.setStatus("Running") }
+func (fp *fakeProcess) Version() (string, error)                              { return "fake", nil }
+func (fp *fakeProcess) CreateSnapshot(statePath string, memPath string) error { return nil }
+func (fp *fakeProcess) LoadSnapshot(statePath string, memPath string) error   { return nil }
+func (fp *fakeProcess) FlushMetrics() error                                   { return nil }
+func (fp *fakeProcess) Metrics() *metrics.VmmMetricsSnapshot                  { return nil }
+func (fp *fakeProcess) UpdateBalloon(amountMiB int64) error                   { return nil }
+func (fp *fakeProcess) UpdateResources(resources *config.VmmResourcesConfig) error {
+	return nil
+}
+func (fp *fakeProcess) Pid() int               { return 0 }
+func (fp *fakeProcess) GetRestartCount() int64 { return 0 }
+func (fp *fakeProcess) ProcessStat() (*metrics.ProcStat, error) {
+	return nil, ErrVmmNotRunning
+}
+func (fp *fakeProcess) Log(source string) *logging.Log { return nil }
+func (fp *fakeProcess) Reattach() (bool, error)        { return false, nil }
+func (fp *fakeProcess) Detach() error                  { return fp.setStatus(UNKOWN_STATUS) }
+
+// newTestManager is a manager with no instances - its instance configs are saved to a temp dir removed by cleanup
+func newTestManager(t *testing.T) (*VmmManager, func()) {
+	configDir, err := ioutil.TempDir("", "promethium-op-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &VmmManager{
+		instances:              map[string]*Vmm{},
+		clusterInstances:       map[string]map[string]*Vmm{},
+		creating:               map[string]bool{},
+		instanceConfigRootPath: configDir,
+	}, func() {
+		os.RemoveAll(configDir)
+	}
+}
+
+func addFakeVmm(mgr *VmmManager, id string) (*Vmm, *fakeProcess) {
+	configPath := filepath.Join(mgr.instanceConfigRootPath, id+".json")
+	vmm := newVmm(mgr, id, configPath, &config.VmmConfig{ID: id, Name: "vm-" + id})
+	proc := newFakeProcess()
+	vmm.instance = proc
+	mgr.addInstance(vmm)
+	return vmm, proc
+}
+
+func TestOperationConflict(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	vmm, proc := addFakeVmm(mgr, "a")
+	proc.release = make(chan struct{})
+	started := make(chan error)
+	go func() {
+		started <- vmm.Start()
+	}()
+	<-proc.entered
+	if vmm.Operation() != "start" {
+		t.Errorf("expected start to be in progress, got %q", vmm.Operation())
+	}
+	err := vmm.Stop()
+	if !IsConflict(err) {
+		t.Fatalf("expected a conflict stopping a vm that is starting, got %v", err)
+	}
+	if err.(*ConflictError).Running != "start" {
+		t.Errorf("expected the conflict to name start, got %q", err.(*ConflictError).Running)
+	}
+	if _, err := vmm.CreateSnapshot(nil, "snap"); !IsConflict(err) {
+		t.Errorf("expected a conflict snapshotting a vm that is starting, got %v", err)
+	}
+	close(proc.release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+	if vmm.Operation() != "" {
+		t.Errorf("expected no operation in progress, got %q", vmm.Operation())
+	}
+	if err := vmm.Stop(); err != nil {
+		t.Errorf("expected stop to succeed once start finished, got %v", err)
+	}
+}
+
+func TestOperationsOnDifferentVmsRunInParallel(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	release := make(chan struct{})
+	procs := []*fakeProcess{}
+	vmms := []*Vmm{}
+	for i := 0; i < 3; i++ {
+		vmm, proc := addFakeVmm(mgr, fmt.Sprintf("vm%d", i))
+		proc.release = release
+		vmms = append(vmms, vmm)
+		procs = append(procs, proc)
+	}
+	errs := make(chan error, len(vmms))
+	for _, vmm := range vmms {
+		go func(vmm *Vmm) {
+			errs <- vmm.Start()
+		}(vmm)
+	}
+	//every start has to be in progress at once before any of them is let go
+	for _, proc := range procs {
+		select {
+		case <-proc.entered:
+		case <-time.After(5 * time.Second):
+			t.Fatal("starts of different vms were serialised")
+		}
+	}
+	close(release)
+	for range vmms {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestKillWaitsForOperation(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	vmm, proc := addFakeVmm(mgr, "a")
+	proc.release = make(chan struct{})
+	go vmm.Start()
+	<-proc.entered
+	killed := make(chan error)
+	go func() {
+		killed <- vmm.Kill()
+	}()
+	select {
+	case <-killed:
+		t.Fatal("kill did not wait for the start in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(proc.release)
+	if err := <-killed; err != nil {
+		t.Fatal(err)
+	}
+	if proc.GetStatus() != UNKOWN_STATUS {
+		t.Errorf("expected the vm to be stopped after kill, got %s", proc.GetStatus())
+	}
+	if atomic.LoadInt32(&proc.overlap) != 0 {
+		t.Error("kill ran while start was in progress")
+	}
+}
+
+func TestConcurrentManagerAccess(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	procs := map[string]*fakeProcess{}
+	for i := 0; i < 4; i++ {
+		_, proc := addFakeVmm(mgr, fmt.Sprintf("vm%d", i))
+		procs[fmt.Sprintf("vm%d", i)] = proc
+	}
+	var wg sync.WaitGroup
+	//keep adding vmms while the others are looked up and operated on
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 4; i < 40; i++ {
+			addFakeVmm(mgr, fmt.Sprintf("vm%d", i))
+		}
+	}()
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprintf("vm%d", (worker+i)%4)
+				vmm, err := mgr.Get(id)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				ops := []func() error{vmm.Start, vmm.Stop, vmm.Pause, vmm.Resume, vmm.Restart, vmm.Reset}
+				if err := ops[(worker+i)%len(ops)](); err != nil && !IsConflict(err) {
+					t.Error(err)
+				}
+				mgr.List(true)
+				mgr.Find("vm-" + id)
+				vmm.Status()
+				mgr.knownInstance(id)
+			}
+		}(worker)
+	}
+	wg.Wait()
+	for id, proc := range procs {
+		if atomic.LoadInt32(&proc.overlap) != 0 {
+			t.Errorf("operations on %s overlapped", id)
+		}
+	}
+	if len(mgr.List(true)) != 40 {
+		t.Errorf("expected 40 vmms, got %d", len(mgr.List(true)))
+	}
+}
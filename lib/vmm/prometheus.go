@@ -0,0 +1,147 @@
+package vmm
+
+import (
+	"strings"
+
+	"github.com/768bit/promethium/lib/images"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var vmLabels = []string{"vm_id", "vm_name"}
+
+func newDesc(name string, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(metrics.Namespace, "", name), help, labels, nil)
+}
+
+var (
+	vmsDesc              = newDesc("vms", "Number of instances by state.", "state")
+	storageCapacityDesc  = newDesc("storage_capacity_bytes", "Size of the filesystem backing a storage target.", "target")
+	storageAvailableDesc = newDesc("storage_available_bytes", "Free space on the filesystem backing a storage target.", "target")
+	imagesDesc           = newDesc("images", "Number of images in a storage target.", "target")
+	nbdUsedDesc          = newDesc("nbd_devices_used", "Number of nbd devices with an image connected.")
+	nbdTotalDesc         = newDesc("nbd_devices", "Number of nbd devices available for connecting images.")
+	memAllocatedDesc     = newDesc("memory_allocated_bytes", "Memory given to running instances.")
+	memReclaimableDesc   = newDesc("memory_reclaimable_bytes", "Memory of running instances inflated into balloons and returned to the host.")
+
+	vmUpDesc         = newDesc("vm_up", "Whether the instance is running.", vmLabels...)
+	vmVcpusDesc      = newDesc("vm_vcpus", "Number of vcpus configured for the instance.", vmLabels...)
+	vmMemoryDesc     = newDesc("vm_memory_bytes", "Memory configured for the instance.", vmLabels...)
+	vmRestartsDesc   = newDesc("vm_restarts_total", "Number of times the instance has crashed and been restarted.", vmLabels...)
+	vmCPUDesc        = newDesc("vm_cpu_seconds_total", "CPU time used by the firecracker process of the instance.", vmLabels...)
+	vmRSSDesc        = newDesc("vm_resident_memory_bytes", "Resident memory of the firecracker process of the instance.", vmLabels...)
+	vmBalloonDesc    = newDesc("vm_balloon_bytes", "Memory inflated into the balloon of the instance.", vmLabels...)
+	vmBlockBytesDesc = newDesc("vm_block_bytes_total", "Bytes read from and written to the block devices of the instance.", append(vmLabels, "direction")...)
+	vmBlockOpsDesc   = newDesc("vm_block_ops_total", "Read and write requests to the block devices of the instance.", append(vmLabels, "direction")...)
+	vmNetBytesDesc   = newDesc("vm_network_bytes_total", "Bytes received and transmitted by the network interfaces of the instance.", append(vmLabels, "direction")...)
+	vmNetPacketsDesc = newDesc("vm_network_packets_total", "Packets received and transmitted by the network interfaces of the instance.", append(vmLabels, "direction")...)
+)
+
+// the firecracker counters that make up each of the per vm i/o series
+var vmCounterSeries = []struct {
+	desc      *prometheus.Desc
+	direction string
+	counter   string
+}{
+	{vmBlockBytesDesc, "read", "block.read_bytes"},
+	{vmBlockBytesDesc, "write", "block.write_bytes"},
+	{vmBlockOpsDesc, "read", "block.read_count"},
+	{vmBlockOpsDesc, "write", "block.write_count"},
+	{vmNetBytesDesc, "rx", "net.rx_bytes_count"},
+	{vmNetBytesDesc, "tx", "net.tx_bytes_count"},
+	{vmNetPacketsDesc, "rx", "net.rx_packets_count"},
+	{vmNetPacketsDesc, "tx", "net.tx_packets_count"},
+}
+
+// Collector publishes the state of the host and its instances to prometheus - everything is read when the
+// metrics are scraped so nothing is kept between scrapes
+type Collector struct {
+	mgr *VmmManager
+}
+
+func NewCollector(mgr *VmmManager) *Collector {
+	return &Collector{mgr: mgr}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{vmsDesc, storageCapacityDesc, storageAvailableDesc, imagesDesc, nbdUsedDesc, nbdTotalDesc,
+		memAllocatedDesc, memReclaimableDesc, vmUpDesc, vmVcpusDesc, vmMemoryDesc, vmRestartsDesc, vmCPUDesc, vmRSSDesc, vmBalloonDesc,
+		vmBlockBytesDesc, vmBlockOpsDesc, vmNetBytesDesc, vmNetPacketsDesc} {
+		ch <- desc
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.collectStorage(ch)
+	if images.QemuNbd != nil {
+		used, total := images.QemuNbd.Usage()
+		ch <- prometheus.MustNewConstMetric(nbdUsedDesc, prometheus.GaugeValue, float64(used))
+		ch <- prometheus.MustNewConstMetric(nbdTotalDesc, prometheus.GaugeValue, float64(total))
+	}
+	allocated, reclaimable := c.mgr.MemoryUsage()
+	ch <- prometheus.MustNewConstMetric(memAllocatedDesc, prometheus.GaugeValue, float64(allocated*1024*1024))
+	ch <- prometheus.MustNewConstMetric(memReclaimableDesc, prometheus.GaugeValue, float64(reclaimable*1024*1024))
+	states := map[string]int{}
+	for _, vmm := range c.mgr.List(true) {
+		if vmm.instance == nil {
+			states["unknown"]++
+			continue
+		}
+		status := vmm.Status()
+		states[strings.ToLower(status)]++
+		c.collectVmm(ch, vmm, status)
+	}
+	for state, count := range states {
+		ch <- prometheus.MustNewConstMetric(vmsDesc, prometheus.GaugeValue, float64(count), state)
+	}
+}
+
+func (c *Collector) collectStorage(ch chan<- prometheus.Metric) {
+	sm := c.mgr.Storage()
+	if sm == nil {
+		return
+	}
+	for _, id := range sm.Targets() {
+		target, err := sm.GetStorage(id)
+		if err != nil {
+			continue
+		}
+		if total, available, err := target.Capacity(); err == nil {
+			ch <- prometheus.MustNewConstMetric(storageCapacityDesc, prometheus.GaugeValue, float64(total), id)
+			ch <- prometheus.MustNewConstMetric(storageAvailableDesc, prometheus.GaugeValue, float64(available), id)
+		}
+		if imgs, err := target.GetImages(); err == nil {
+			ch <- prometheus.MustNewConstMetric(imagesDesc, prometheus.GaugeValue, float64(len(imgs)), id)
+		}
+	}
+}
+
+func (c *Collector) collectVmm(ch chan<- prometheus.Metric, vmm *Vmm, status string) {
+	labels := []string{vmm.id, vmm.config.Name}
+	up := 0.0
+	if status == "Running" {
+		up = 1
+	}
+	ch <- prometheus.MustNewConstMetric(vmUpDesc, prometheus.GaugeValue, up, labels...)
+	ch <- prometheus.MustNewConstMetric(vmVcpusDesc, prometheus.GaugeValue, float64(vmm.config.Cpus), labels...)
+	ch <- prometheus.MustNewConstMetric(vmMemoryDesc, prometheus.GaugeValue, float64(vmm.config.Memory*1024*1024), labels...)
+	ch <- prometheus.MustNewConstMetric(vmRestartsDesc, prometheus.CounterValue, float64(vmm.instance.GetRestartCount()), labels...)
+
+	if ps, err := vmm.instance.ProcessStat(); err == nil {
+		ch <- prometheus.MustNewConstMetric(vmCPUDesc, prometheus.CounterValue, ps.CPUSeconds, labels...)
+		ch <- prometheus.MustNewConstMetric(vmRSSDesc, prometheus.GaugeValue, float64(ps.RSSBytes), labels...)
+	}
+
+	if vmm.config.Balloon != nil {
+		_, actual := vmm.BalloonMiB()
+		ch <- prometheus.MustNewConstMetric(vmBalloonDesc, prometheus.GaugeValue, float64(actual*1024*1024), labels...)
+	}
+
+	snap := vmm.instance.Metrics()
+	if snap == nil || snap.Samples == 0 {
+		return
+	}
+	for _, series := range vmCounterSeries {
+		ch <- prometheus.MustNewConstMetric(series.desc, prometheus.CounterValue, snap.Counters[series.counter], append(labels, series.direction)...)
+	}
+}
@@ -0,0 +1,38 @@
+package vmm
+
+import (
+	"io/ioutil"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/recording"
+	"github.com/go-openapi/strfmt"
+)
+
+// GetRecordingModel describes a console recording - the asciicast itself is only included when withCast is set
+// as it can be large
+func GetRecordingModel(store *recording.Store, rec *recording.Recording, withCast bool) (*models.ConsoleRecording, error) {
+	model := &models.ConsoleRecording{
+		ID:        strfmt.UUID4(rec.ID),
+		VMID:      strfmt.UUID4(rec.VmID),
+		User:      rec.User,
+		Title:     rec.Title,
+		Width:     int64(rec.Width),
+		Height:    int64(rec.Height),
+		StartedAt: strfmt.DateTime(rec.StartedAt),
+		Duration:  rec.Duration,
+		Size:      rec.Size,
+	}
+	if withCast {
+		fd, err := store.Open(rec)
+		if err != nil {
+			return nil, err
+		}
+		defer fd.Close()
+		cast, err := ioutil.ReadAll(fd)
+		if err != nil {
+			return nil, err
+		}
+		model.Cast = string(cast)
+	}
+	return model, nil
+}
@@ -0,0 +1,290 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/vutils"
+	"golang.org/x/sys/unix"
+)
+
+// the controllers resource limits are set through
+var resourceControllers = []string{"cpu", "memory", "io", "pids"}
+
+var ErrCgroupV2Required = errors.New("Resource limits need the cgroup v2 hierarchy")
+
+var deviceNumberPattern = regexp.MustCompile(`^\d+:\d+$`)
+
+// ValidateResources checks the resource limits of a vm can be applied on this host
+func ValidateResources(resources *config.VmmResourcesConfig) error {
+	if err := resources.Validate(); err != nil {
+		return err
+	}
+	if !newVmCgroup("").unified {
+		return ErrCgroupV2Required
+	}
+	available, err := ioutil.ReadFile(filepath.Join(CgroupRoot, "cgroup.controllers"))
+	if err != nil {
+		return err
+	}
+	for _, controller := range resourceControllers {
+		if !strings.Contains(" "+strings.TrimSpace(string(available))+" ", " "+controller+" ") {
+			return fmt.Errorf("The %s cgroup controller isnt available on this host", controller)
+		}
+	}
+	for _, limit := range resources.IOMax {
+		if limit.Device == "" {
+			continue
+		}
+		if _, err := blockDevice(limit.Device); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockDevice returns the major:minor number of the disk behind a block device or the file system holding a file -
+// io.max only takes whole disks so partitions are resolved to their disk
+func blockDevice(path string) (string, error) {
+	if deviceNumberPattern.MatchString(path) {
+		return path, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", errors.New("Unable to find the device of " + path)
+	}
+	dev := uint64(stat.Dev)
+	if info.Mode()&os.ModeDevice != 0 {
+		dev = uint64(stat.Rdev)
+	}
+	number := fmt.Sprintf("%d:%d", unix.Major(dev), unix.Minor(dev))
+	sysPath, err := filepath.EvalSymlinks(filepath.Join(config.SysfsRoot, "dev", "block", number))
+	if err != nil {
+		return "", fmt.Errorf("%s isnt on a block device", path)
+	}
+	if vutils.Files.CheckPathExists(filepath.Join(sysPath, "partition")) {
+		disk, err := ioutil.ReadFile(filepath.Join(filepath.Dir(sysPath), "dev"))
+		if err != nil {
+			return "", err
+		}
+		number = strings.TrimSpace(string(disk))
+	}
+	return number, nil
+}
+
+// SetResources sets the resource limits applied when the vm next starts
+func (fcp *FireCrackerProcess) SetResources(resources *config.VmmResourcesConfig) {
+	fcp.lock.Lock()
+	fcp.resources = resources
+	fcp.lock.Unlock()
+}
+
+// currentResources is the resource limits of the vm - they are read by the restart goroutine while the api changes them
+func (fcp *FireCrackerProcess) currentResources() *config.VmmResourcesConfig {
+	fcp.lock.Lock()
+	defer fcp.lock.Unlock()
+	return fcp.resources
+}
+
+// UpdateResources changes the resource limits - a running vm has them applied straight away and nil lifts them
+func (fcp *FireCrackerProcess) UpdateResources(resources *config.VmmResourcesConfig) error {
+	fcp.SetResources(resources)
+	if started, _ := fcp.runState(); !started {
+		return nil
+	}
+	if resources == nil {
+		resources = &config.VmmResourcesConfig{}
+	}
+	return fcp.applyResources(resources)
+}
+
+// applyResources writes the resource limits to the cgroup of the firecracker process
+func (fcp *FireCrackerProcess) applyResources(resources *config.VmmResourcesConfig) error {
+	pid, err := fcp.firecrackerPid()
+	if err != nil {
+		return err
+	}
+	cg := newVmCgroup(fcp.id)
+	if !cg.unified {
+		return ErrCgroupV2Required
+	}
+	if err := cg.attach(pid, resourceControllers...); err != nil {
+		return err
+	}
+	values := [][]string{
+		{"cpu", "cpu.weight", resources.CPUWeightValue()},
+		{"cpu", "cpu.max", resources.CPUMaxValue()},
+		{"memory", "memory.max", resources.MemoryMaxValue(fcp.memory)},
+		{"pids", "pids.max", resources.PidsMaxValue()},
+	}
+	for _, value := range values {
+		if err := cg.write(value[0], value[1], value[2]); err != nil {
+			return err
+		}
+	}
+	limits, err := fcp.ioLimits(resources)
+	if err != nil {
+		return err
+	}
+	//devices that were limited before and arent anymore are set back to unlimited
+	current, _ := cg.read("io", "io.max")
+	for _, line := range strings.Split(current, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := limits[fields[0]]; !ok {
+			limits[fields[0]] = (&config.VmmIOLimit{}).Value()
+		}
+	}
+	for device, limit := range limits {
+		if err := cg.write("io", "io.max", device+" "+limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jailerCgroups are the limits the jailer writes to the cgroup of the vm before it execs firecracker - io.max is per
+// device so it is only written once firecracker has started
+func (fcp *FireCrackerProcess) jailerCgroups(resources *config.VmmResourcesConfig) []string {
+	return []string{
+		"cpu.weight=" + resources.CPUWeightValue(),
+		"cpu.max=" + resources.CPUMaxValue(),
+		"memory.max=" + resources.MemoryMaxValue(fcp.memory),
+		"pids.max=" + resources.PidsMaxValue(),
+	}
+}
+
+// ioLimits maps the devices backing the disks of the vm to their io.max limits
+func (fcp *FireCrackerProcess) ioLimits(resources *config.VmmResourcesConfig) (map[string]string, error) {
+	limits := map[string]string{}
+	for _, limit := range resources.IOMax {
+		paths := fcp.imageList
+		if limit.Device != "" {
+			paths = []string{limit.Device}
+		}
+		for _, path := range paths {
+			device, err := blockDevice(path)
+			if err != nil {
+				return nil, err
+			}
+			limits[device] = limit.Value()
+		}
+	}
+	return limits, nil
+}
+
+// SetResources validates and applies new resource limits to the vmm and saves them to its config - nil lifts them
+func (vmm *Vmm) SetResources(resources *config.VmmResourcesConfig) error {
+	if resources != nil {
+		if err := ValidateResources(resources); err != nil {
+			return err
+		}
+	}
+	if vmm.instance == nil {
+		return errors.New("Unable to change the resources as instance isnt setup")
+	}
+	done, err := vmm.beginOp("update resources")
+	if err != nil {
+		return err
+	}
+	defer done()
+	if err := vmm.instance.UpdateResources(resources); err != nil {
+		return err
+	}
+	vmm.config.Resources = resources
+	err, _ = vutils.Config.SaveConfigToFile("", vmm.configPath, vmm.config)
+	return err
+}
+
+// GetResourcesModel returns the resource limits of the vmm and, while it is running, the values in effect in its
+// cgroup along with what it is using
+func (vmm *Vmm) GetResourcesModel() *models.VMResources {
+	res := &models.VMResources{
+		Limits:    resourceLimitsModel(vmm.config.Resources),
+		Effective: map[string]string{},
+	}
+	if vmm.instance == nil || vmm.Status() != "Running" {
+		return res
+	}
+	cg := newVmCgroup(vmm.id)
+	for _, file := range [][]string{{"cpu", "cpu.weight"}, {"cpu", "cpu.max"}, {"memory", "memory.max"}, {"io", "io.max"}, {"pids", "pids.max"}} {
+		if value, err := cg.read(file[0], file[1]); err == nil {
+			res.Effective[file[1]] = value
+		}
+	}
+	if value, err := cg.read("memory", "memory.current"); err == nil {
+		res.MemoryCurrent, _ = strconv.ParseInt(value, 10, 64)
+	}
+	if value, err := cg.read("pids", "pids.current"); err == nil {
+		res.PidsCurrent, _ = strconv.ParseInt(value, 10, 64)
+	}
+	if value, err := cg.read("cpu", "cpu.stat"); err == nil {
+		for _, line := range strings.Split(value, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "usage_usec" {
+				usage, _ := strconv.ParseFloat(fields[1], 64)
+				res.CPUUsageSeconds = usage / 1000000
+			}
+		}
+	}
+	return res
+}
+
+func resourceLimitsModel(resources *config.VmmResourcesConfig) *models.VMResourceLimits {
+	limits := &models.VMResourceLimits{IoMax: []*models.VMIOLimit{}}
+	if resources == nil {
+		return limits
+	}
+	limits.CPUWeight = resources.CPUWeight
+	limits.CPUMax = resources.CPUMax
+	limits.MemoryOverheadMib = resources.MemoryOverheadMiB
+	limits.PidsMax = resources.PidsMax
+	for _, limit := range resources.IOMax {
+		limits.IoMax = append(limits.IoMax, &models.VMIOLimit{
+			Device:    limit.Device,
+			ReadBps:   limit.ReadBps,
+			WriteBps:  limit.WriteBps,
+			ReadIops:  limit.ReadIOPS,
+			WriteIops: limit.WriteIOPS,
+		})
+	}
+	return limits
+}
+
+// ResourcesFromModel converts resource limits from the api to their config
+func ResourcesFromModel(limits *models.VMResourceLimits) *config.VmmResourcesConfig {
+	resources := &config.VmmResourcesConfig{
+		CPUWeight:         limits.CPUWeight,
+		CPUMax:            limits.CPUMax,
+		MemoryOverheadMiB: limits.MemoryOverheadMib,
+		PidsMax:           limits.PidsMax,
+	}
+	for _, limit := range limits.IoMax {
+		if limit == nil {
+			continue
+		}
+		resources.IOMax = append(resources.IOMax, &config.VmmIOLimit{
+			Device:    limit.Device,
+			ReadBps:   limit.ReadBps,
+			WriteBps:  limit.WriteBps,
+			ReadIOPS:  limit.ReadIops,
+			WriteIOPS: limit.WriteIops,
+		})
+	}
+	return resources
+}
@@ -0,0 +1,149 @@
+package vmm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/768bit/promethium/lib/config"
+)
+
+func TestFakeProcessResources(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	firecracker, procCleanup := fakeFirecrackerThreads(t, fcp.Pid(), int(fcp.cpus))
+	defer procCleanup()
+	fcp.SetResources(&config.VmmResourcesConfig{
+		CPUWeight:         200,
+		CPUMax:            1.5,
+		MemoryOverheadMiB: 64,
+		PidsMax:           50,
+		IOMax:             []*config.VmmIOLimit{{Device: "8:0", ReadBps: 1000}},
+	})
+	//the jailer that was waiting was started before the limits were set so they are applied once it has started
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	limited := map[string]string{
+		"cgroup.subtree_control":             "+cpu +memory +io +pids",
+		"firecracker/cgroup.subtree_control": "+cpu +memory +io +pids",
+		"firecracker/fake/cgroup.procs":      strconv.Itoa(firecracker),
+		"firecracker/fake/cpu.weight":        "200",
+		"firecracker/fake/cpu.max":           "150000 100000",
+		"firecracker/fake/memory.max":        strconv.Itoa((256 + 64) * 1024 * 1024),
+		"firecracker/fake/pids.max":          "50",
+		"firecracker/fake/io.max":            "8:0 rbps=1000 wbps=max riops=max wiops=max",
+	}
+	for file, want := range limited {
+		if got := readCgroupFile(t, file); got != want {
+			t.Errorf("expected %s to be %q, got %q", file, want, got)
+		}
+	}
+
+	//a jailer started with the limits puts firecracker in the cgroup with them before it execs it
+	if err := fcp.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the polling of the last run to stop", func() bool {
+		fcp.lock.Lock()
+		defer fcp.lock.Unlock()
+		return !fcp.isPolling
+	})
+	os.RemoveAll(filepath.Join(CgroupRoot, "firecracker"))
+	if err := fcp.Start(); err != nil {
+		t.Fatal(err)
+	}
+	spec := runner.latestSpec()
+	if spec.CgroupVersion != 2 || spec.ParentCgroup != "firecracker" {
+		t.Errorf("expected the jailer to be given the cgroup of the vm, got %+v", spec)
+	}
+	if got := strings.Join(spec.Cgroups, ","); got != "cpu.weight=200,cpu.max=150000 100000,memory.max=335544320,pids.max=50" {
+		t.Errorf("unexpected jailer cgroups %s", got)
+	}
+	if writes := runner.writesTo("firecracker/fake/memory.max"); len(writes) == 0 || writes[0] != limited["firecracker/fake/memory.max"] {
+		t.Errorf("expected the jailer to set the limits first, got %v", writes)
+	}
+
+	//limits that are lifted go back to unlimited, devices included
+	if err := fcp.UpdateResources(&config.VmmResourcesConfig{CPUWeight: 300}); err != nil {
+		t.Fatal(err)
+	}
+	for file, want := range map[string]string{
+		"firecracker/fake/cpu.weight": "300",
+		"firecracker/fake/cpu.max":    "max 100000",
+		"firecracker/fake/memory.max": "max",
+		"firecracker/fake/pids.max":   "max",
+		"firecracker/fake/io.max":     "8:0 rbps=max wbps=max riops=max wiops=max",
+	} {
+		if got := readCgroupFile(t, file); got != want {
+			t.Errorf("expected %s to be %q, got %q", file, want, got)
+		}
+	}
+	if err := fcp.UpdateResources(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readCgroupFile(t, "firecracker/fake/cpu.weight"); got != "100" {
+		t.Errorf("expected lifting the limits to set the default weight, got %q", got)
+	}
+}
+
+func TestFakeProcessResourcesFirstStart(t *testing.T) {
+	fcp, runner, cleanup := newLimitedFakeFireCrackerProcess(t, nil, nil, &config.VmmResourcesConfig{PidsMax: 50})
+	defer cleanup()
+	//the jailer started with the process already has the limits so firecracker is never run without them
+	if runner.startCount() != 1 {
+		t.Fatalf("expected firecracker to be started with the process, it was started %d times", runner.startCount())
+	}
+	spec := runner.latestSpec()
+	if spec.CgroupVersion != 2 || spec.ParentCgroup != "firecracker" {
+		t.Errorf("expected the first jailer to be given the cgroup of the vm, got %+v", spec)
+	}
+	if got := strings.Join(spec.Cgroups, ","); !strings.Contains(got, "pids.max=50") {
+		t.Errorf("expected the first jailer to set the limits, got %s", got)
+	}
+	if writes := runner.writesTo("firecracker/fake/pids.max"); len(writes) == 0 || writes[0] != "50" {
+		t.Errorf("expected the jailer to set the limits before firecracker runs, got %v", writes)
+	}
+	if fcp.currentResources().PidsMax != 50 {
+		t.Errorf("expected the limits to be kept, got %+v", fcp.currentResources())
+	}
+}
+
+func TestFakeProcessResourcesFailure(t *testing.T) {
+	fcp, runner, cleanup := newFakeFireCrackerProcess(t, nil, nil)
+	defer cleanup()
+	_, procCleanup := fakeFirecrackerThreads(t, fcp.Pid(), int(fcp.cpus))
+	defer procCleanup()
+	fcp.SetResources(&config.VmmResourcesConfig{PidsMax: 50})
+
+	//the limits cant be applied to a cgroup v1 hierarchy so the vm isnt left running without them
+	controllers := filepath.Join(CgroupRoot, "cgroup.controllers")
+	os.Remove(controllers)
+	err := fcp.Start()
+	if err == nil || !strings.Contains(err.Error(), ErrCgroupV2Required.Error()) {
+		t.Fatalf("expected the start to fail when the limits cant be applied, got %v", err)
+	}
+	if started, _ := fcp.runState(); started {
+		t.Error("expected the vm to not be left running")
+	}
+
+	//the jailer isnt started when the cgroup it puts firecracker in cant be made
+	os.MkdirAll(CgroupRoot, 0755)
+	if err := ioutil.WriteFile(controllers, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(CgroupRoot, "firecracker"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	jailers := runner.startCount()
+	err = fcp.Start()
+	if err == nil || !strings.Contains(err.Error(), "Unable to create the cgroup") {
+		t.Fatalf("expected the start to fail when the cgroup cant be made, got %v", err)
+	}
+	if runner.startCount() != jailers {
+		t.Errorf("expected the jailer to not be started, %d were", runner.startCount()-jailers)
+	}
+}
@@ -0,0 +1,71 @@
+package vmm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// runtimeStateName is the file kept next to the jail of a running vmm - it is what a restarted daemon uses to find
+// the firecracker it left running
+const runtimeStateName = "runtime.json"
+
+type runtimeState struct {
+	Pid        int       `json:"pid"`
+	SocketPath string    `json:"socketPath"`
+	Started    bool      `json:"started"`
+	StartedAt  time.Time `json:"startedAt"`
+	Paused     bool      `json:"paused"`
+}
+
+func readRuntimeState(path string) (*runtimeState, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &runtimeState{}
+	if err := json.Unmarshal(bs, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (fcp *FireCrackerProcess) runtimeStatePath() string {
+	return filepath.Join(ROOT_PATH, "firecracker", fcp.id, runtimeStateName)
+}
+
+// saveRuntimeState records the firecracker of the vmm - it is written to a temporary file first so a daemon that
+// dies part way through never leaves a state that cant be read
+func (fcp *FireCrackerProcess) saveRuntimeState() {
+	fcp.lock.Lock()
+	if fcp.jailerProc == nil {
+		fcp.lock.Unlock()
+		return
+	}
+	state := &runtimeState{
+		Pid:        fcp.jailerProc.Pid(),
+		SocketPath: fcp.socketPath,
+		Started:    fcp.isStarted,
+		StartedAt:  fcp.lastStartedAt,
+		Paused:     fcp.isPaused,
+	}
+	fcp.lock.Unlock()
+	bs, err := json.Marshal(state)
+	if err == nil {
+		path := fcp.runtimeStatePath()
+		if err = ioutil.WriteFile(path+".tmp", bs, 0600); err == nil {
+			err = os.Rename(path+".tmp", path)
+		}
+	}
+	if err != nil {
+		log.Warnf("Unable to save the runtime state of %s: %s. It will not survive a restart of the daemon.", fcp.id, err.Error())
+	}
+}
+
+func (fcp *FireCrackerProcess) removeRuntimeState() {
+	os.Remove(fcp.runtimeStatePath())
+}
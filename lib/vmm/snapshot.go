@@ -0,0 +1,439 @@
+package vmm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/promethium/lib/common"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/operations"
+	"github.com/768bit/vutils"
+	"github.com/go-openapi/strfmt"
+)
+
+const snapshotConfigExt = ".snapshot"
+
+// snapshot ids end up in paths so only ids that cant climb out of the snapshot folders are accepted
+var snapshotIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// snapshot metadata is kept next to the vmm config - the extension keeps it out of the instance config scan
+func (vmm *Vmm) snapshotConfigRoot() string {
+	return filepath.Join(vmm.mgr.instanceConfigRootPath, vmm.id+".snapshots")
+}
+
+func (vmm *Vmm) snapshotConfigPath(snapshotID string) string {
+	return filepath.Join(vmm.snapshotConfigRoot(), snapshotID+snapshotConfigExt)
+}
+
+// storageTarget is the storage target holding the root disk of the vmm - snapshots are written there too
+func (vmm *Vmm) storageTarget() (common.StorageDriver, error) {
+	for _, dsk := range vmm.config.Disks {
+		if dsk.IsRoot {
+			return vmm.mgr.Storage().GetStorageForURI(dsk.StorageURI)
+		}
+	}
+	if len(vmm.config.Disks) > 0 {
+		return vmm.mgr.Storage().GetStorageForURI(vmm.config.Disks[0].StorageURI)
+	}
+	return nil, errors.New("Unable to find a storage target for " + vmm.id)
+}
+
+func (vmm *Vmm) ListSnapshots() ([]*config.VmmSnapshotConfig, error) {
+	snapshots := []*config.VmmSnapshotConfig{}
+	if !vutils.Files.CheckPathExists(vmm.snapshotConfigRoot()) {
+		return snapshots, nil
+	}
+	for _, snapConf := range vutils.Files.GetFilesInDirWithExtension(vmm.snapshotConfigRoot(), snapshotConfigExt) {
+		snap, err := vmm.GetSnapshot(strings.TrimSuffix(snapConf, snapshotConfigExt))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+func (vmm *Vmm) GetSnapshot(snapshotID string) (*config.VmmSnapshotConfig, error) {
+	if !snapshotIDRegex.MatchString(snapshotID) {
+		return nil, errors.New("Invalid snapshot id: " + snapshotID)
+	}
+	snap := &config.VmmSnapshotConfig{}
+	if err := vutils.Config.LoadConfigFromFile(vmm.snapshotConfigPath(snapshotID), snap); err != nil {
+		return nil, errors.New("Unable to find snapshot with that id")
+	}
+	return snap, nil
+}
+
+// CreateSnapshot pauses the vmm, snapshots its memory and device state and copies its disks while nothing can
+// write to them so the disks match the memory, then resumes it if it was running
+func (vmm *Vmm) CreateSnapshot(op *operations.Operation, name string) (*config.VmmSnapshotConfig, error) {
+	if vmm.instance == nil {
+		return nil, errors.New("Unable to snapshot as instance isnt setup")
+	}
+	done, err := vmm.beginOp("snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	version, err := vmm.instance.Version()
+	if err != nil {
+		return nil, err
+	}
+	tstr, err := vmm.storageTarget()
+	if err != nil {
+		return nil, err
+	}
+	snapshotID, _ := vutils.UUID.MakeUUIDString()
+	snapshotPath, err := tstr.SnapshotPath(vmm.id, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	snapshotURI := tstr.GetURI() + "/snapshots/" + vmm.id + "/" + snapshotID + "/"
+	if name == "" {
+		name = vmm.config.Name + "-" + time.Now().Format("20060102150405")
+	}
+	snap := &config.VmmSnapshotConfig{
+		ID:                 snapshotID,
+		VmmID:              vmm.id,
+		Name:               name,
+		CreatedAt:          time.Now(),
+		FirecrackerVersion: version,
+		Memory:             vmm.config.Memory,
+		Cpus:               vmm.config.Cpus,
+		Kernel:             vmm.config.Kernel,
+		BootCmd:            vmm.config.BootCmd,
+		StateURI:           snapshotURI + snapshotStateFile,
+		MemoryURI:          snapshotURI + snapshotMemFile,
+		Disks:              []*config.VmmDiskConfig{},
+	}
+
+	op.SetProgress(0, "Snapshotting memory")
+	wasPaused := vmm.Status() == PAUSED_STATUS
+	if !wasPaused {
+		if err := vmm.instance.Pause(); err != nil {
+			tstr.DeleteSnapshot(vmm.id, snapshotID)
+			return nil, err
+		}
+	}
+	err = vmm.writeSnapshot(op, snap, snapshotPath, snapshotURI)
+	if !wasPaused {
+		if resumeErr := vmm.instance.Resume(); resumeErr != nil && err == nil {
+			err = resumeErr
+		}
+	}
+	if err != nil {
+		tstr.DeleteSnapshot(vmm.id, snapshotID)
+		return nil, err
+	}
+
+	vutils.Files.CreateDirIfNotExist(vmm.snapshotConfigRoot())
+	err, _ = vutils.Config.SaveConfigToFile("", vmm.snapshotConfigPath(snapshotID), snap)
+	if err != nil {
+		tstr.DeleteSnapshot(vmm.id, snapshotID)
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (vmm *Vmm) writeSnapshot(op *operations.Operation, snap *config.VmmSnapshotConfig, snapshotPath string, snapshotURI string) error {
+	err := vmm.instance.CreateSnapshot(filepath.Join(snapshotPath, snapshotStateFile), filepath.Join(snapshotPath, snapshotMemFile))
+	if err != nil {
+		return err
+	}
+	//the memory is done - the disks share the rest of the progress
+	op.SetProgress(30, "Copying disks")
+	step := diskStep(70, len(vmm.config.Disks))
+	for index, dsk := range vmm.config.Disks {
+		path, _, err := vmm.mgr.Storage().ResolveStorageURI(dsk.StorageURI)
+		if err != nil {
+			return err
+		}
+		diskName := fmt.Sprintf("disk%d.img", index)
+		from := 30 + index*step
+		if err := copyDisk(op, path, filepath.Join(snapshotPath, diskName), from, from+step); err != nil {
+			return err
+		}
+		snap.Disks = append(snap.Disks, &config.VmmDiskConfig{
+			IsRoot:     dsk.IsRoot,
+			StorageURI: snapshotURI + diskName,
+		})
+	}
+	snap.Size = dirSize(snapshotPath)
+	return nil
+}
+
+// diskStep is the share of the progress of an operation each of its disks takes when they share total
+func diskStep(total int, disks int) int {
+	if disks == 0 {
+		return total
+	}
+	return total / disks
+}
+
+// copyDisk copies a disk for an operation - the copy stops when the operation is cancelled
+func copyDisk(op *operations.Operation, src string, dest string, from int, to int) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, &operations.Reader{Op: op, Reader: in, Size: info.Size(), From: from, To: to}); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func (vmm *Vmm) DeleteSnapshot(snapshotID string) error {
+	done, err := vmm.beginOp("delete snapshot")
+	if err != nil {
+		return err
+	}
+	defer done()
+	snap, err := vmm.GetSnapshot(snapshotID)
+	if err != nil {
+		return err
+	}
+	tstr, err := vmm.mgr.Storage().GetStorageForURI(snap.StateURI)
+	if err != nil {
+		return err
+	}
+	if err := tstr.DeleteSnapshot(vmm.id, snap.ID); err != nil {
+		return err
+	}
+	if err := os.Remove(vmm.snapshotConfigPath(snap.ID)); err != nil {
+		return err
+	}
+	os.Remove(vmm.snapshotConfigRoot())
+	return nil
+}
+
+// checkSnapshot makes sure the snapshot can be loaded by the firecracker in use
+func (vmm *Vmm) checkSnapshot(snap *config.VmmSnapshotConfig) error {
+	version, err := vmm.instance.Version()
+	if err != nil {
+		return err
+	}
+	return config.CheckSnapshotCompatibility(snap.FirecrackerVersion, version)
+}
+
+func (vmm *Vmm) isRunning() bool {
+	switch vmm.Status() {
+	case "Running", "Starting", PAUSED_STATUS:
+		return true
+	}
+	return false
+}
+
+// RestoreSnapshot puts the disks of a stopped vmm back to how they were in the snapshot and resumes it from the
+// snapshotted memory - the copy of the disks is the progress of op and stops when it is cancelled, which leaves the
+// disks part way through so the restore has to be run again
+func (vmm *Vmm) RestoreSnapshot(op *operations.Operation, snapshotID string) error {
+	if vmm.instance == nil {
+		return errors.New("Unable to restore as instance isnt setup")
+	}
+	done, err := vmm.beginOp("restore")
+	if err != nil {
+		return err
+	}
+	defer done()
+	snap, err := vmm.GetSnapshot(snapshotID)
+	if err != nil {
+		return err
+	}
+	if err := vmm.checkSnapshot(snap); err != nil {
+		return err
+	}
+	if vmm.isRunning() {
+		return errors.New("VM must be stopped before a snapshot can be restored")
+	}
+	if len(snap.Disks) != len(vmm.config.Disks) {
+		return errors.New("The disks of the VM have changed since the snapshot was taken")
+	}
+	op.SetProgress(0, "Copying disks")
+	step := diskStep(90, len(snap.Disks))
+	for index, dsk := range snap.Disks {
+		src, _, err := vmm.mgr.Storage().ResolveStorageURI(dsk.StorageURI)
+		if err != nil {
+			return err
+		}
+		dest, _, err := vmm.mgr.Storage().ResolveStorageURI(vmm.config.Disks[index].StorageURI)
+		if err != nil {
+			return err
+		}
+		if err := copyDisk(op, src, dest, index*step, (index+1)*step); err != nil {
+			return err
+		}
+	}
+	op.SetProgress(90, "Loading snapshot")
+	return vmm.loadSnapshot(snap)
+}
+
+func (vmm *Vmm) loadSnapshot(snap *config.VmmSnapshotConfig) error {
+	statePath, _, err := vmm.mgr.Storage().ResolveStorageURI(snap.StateURI)
+	if err != nil {
+		return err
+	}
+	memPath, _, err := vmm.mgr.Storage().ResolveStorageURI(snap.MemoryURI)
+	if err != nil {
+		return err
+	}
+	return vmm.instance.LoadSnapshot(statePath, memPath)
+}
+
+// NewVmmFromSnapshot creates a new vmm with its own copy of the disks in a snapshot and starts it from the
+// snapshotted memory - the copy of the disks is the progress of op and stops when it is cancelled
+func (mgr *VmmManager) NewVmmFromSnapshot(op *operations.Operation, source *Vmm, snapshotID string, name string) (*Vmm, error) {
+	//the snapshot cant be deleted or restored over while it is being copied
+	done, err := source.beginOp("clone")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	snap, err := source.GetSnapshot(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if err := source.checkSnapshot(snap); err != nil {
+		return nil, err
+	}
+	tstr, err := mgr.Storage().GetStorageForURI(snap.StateURI)
+	if err != nil {
+		return nil, err
+	}
+
+	vmmId, _ := vutils.UUID.MakeUUIDString()
+	defer mgr.beginCreate(vmmId)()
+	//the disks copied so far are removed if the clone isnt created - including when the operation is cancelled
+	created := false
+	defer func() {
+		if !created {
+			tstr.DeleteDisks(vmmId)
+		}
+	}()
+	vmmConfig := &config.VmmConfig{
+		ID:        vmmId,
+		Name:      name,
+		Clustered: false,
+		Memory:    snap.Memory,
+		Cpus:      snap.Cpus,
+		Type:      config.FirecrackerVmm,
+		BootCmd:   snap.BootCmd,
+		Volumes:   []*config.VmmVolumeConfig{},
+		Network:   &config.VmmNetworkConfig{},
+		Disks:     []*config.VmmDiskConfig{},
+	}
+	if source.config.Vsock != nil {
+		//the guest keeps the CID it had in the snapshot until it reboots - the host only uses the unix socket so
+		//that doesnt get in the way of reaching it
+		vmmConfig.Vsock = &config.VmmVsockConfig{UDSPath: source.config.Vsock.UDSPath}
+	}
+
+	op.SetProgress(0, "Copying disks")
+	step := diskStep(80, len(snap.Disks))
+	for index, dsk := range snap.Disks {
+		src, _, err := mgr.Storage().ResolveStorageURI(dsk.StorageURI)
+		if err != nil {
+			return nil, err
+		}
+		diskName := fmt.Sprintf("disk%d.img", index)
+		if dsk.IsRoot {
+			diskName = "root.img"
+		}
+		dest, _, err := tstr.LookupPath("/disks/" + vmmId + "/" + diskName)
+		if err != nil {
+			return nil, err
+		}
+		if err := vutils.Files.CreateDirIfNotExist(filepath.Dir(dest)); err != nil {
+			return nil, err
+		}
+		if err := copyDisk(op, src, dest, index*step, (index+1)*step); err != nil {
+			return nil, err
+		}
+		disk, err := common.NewStorageDisk(vmmId, diskName, dest, tstr)
+		if err != nil {
+			return nil, err
+		}
+		vmmConfig.Disks = append(vmmConfig.Disks, disk.ToDiskConfig(dsk.IsRoot))
+	}
+
+	op.SetProgress(80, "Writing kernel")
+	kernelPath, _, err := mgr.Storage().ResolveStorageURI(snap.Kernel)
+	if err != nil {
+		return nil, err
+	}
+	kernFile, err := os.Open(kernelPath)
+	if err != nil {
+		return nil, err
+	}
+	defer kernFile.Close()
+	kernelDiskPath, err := tstr.WriteKernel(vmmId, kernFile)
+	if err != nil {
+		return nil, err
+	}
+	vmmConfig.Kernel = common.NewKernel(vmmId, kernelDiskPath, tstr).GetURI()
+
+	vmmConfigPath := filepath.Join(mgr.instanceConfigRootPath, vmmId+".json")
+
+	err, _ = vutils.Config.SaveConfigToFile("", vmmConfigPath, vmmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	created = true
+	vmm := newVmm(mgr, vmmId, vmmConfigPath, vmmConfig)
+	mgr.addInstance(vmm)
+
+	if _, err := vmm.init(vmmConfig); err != nil {
+		return vmm, err
+	}
+	op.SetProgress(90, "Loading snapshot")
+	return vmm, vmm.loadSnapshot(snap)
+}
+
+func (vmm *Vmm) GetSnapshotModel(snap *config.VmmSnapshotConfig) *models.VMSnapshot {
+	model := &models.VMSnapshot{
+		ID:                 strfmt.UUID4(snap.ID),
+		VMID:               strfmt.UUID4(snap.VmmID),
+		Name:               snap.Name,
+		CreatedAt:          strfmt.DateTime(snap.CreatedAt),
+		FirecrackerVersion: snap.FirecrackerVersion,
+		Memory:             snap.Memory,
+		Cpus:               snap.Cpus,
+		Size:               snap.Size,
+	}
+	if vmm.instance != nil {
+		model.Compatible = vmm.checkSnapshot(snap) == nil
+	}
+	return model
+}
+
+func dirSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
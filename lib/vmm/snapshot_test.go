@@ -0,0 +1,280 @@
+package vmm
+
+import (
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/networking"
+	"github.com/768bit/promethium/lib/operations"
+	"github.com/768bit/promethium/lib/privhelper"
+	"github.com/768bit/vutils"
+)
+
+const snapshotTestStorage = "test"
+
+// newSnapshotTestManager is a manager with a single firecracker vmm with a root disk in a local file storage
+// target - firecracker is faked by the runner and the firecracker binary only reports its version
+func newSnapshotTestManager(t *testing.T) (*VmmManager, *Vmm, *fakeRunner, func()) {
+	appRoot, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPath, runner := ROOT_PATH, privhelper.Default
+	fake := &fakeRunner{}
+	privhelper.Default = fake
+	restore := func() {
+		ROOT_PATH, privhelper.Default = rootPath, runner
+		os.RemoveAll(appRoot)
+	}
+	fail := func(err error) {
+		restore()
+		t.Fatal(err)
+	}
+
+	storageDir := filepath.Join(appRoot, "storage", snapshotTestStorage)
+	files := map[string]string{
+		filepath.Join(appRoot, "bin", "firecracker"):         "#!/bin/sh\necho Firecracker v0.21.0\n",
+		filepath.Join(appRoot, "bin", "jailer"):              "",
+		filepath.Join(storageDir, "kernels", "vm.elf"):       "kernel",
+		filepath.Join(storageDir, "disks", "vm", "root.img"): "disk as snapshotted",
+		filepath.Join(storageDir, "images", ".keep"):         "",
+		filepath.Join(storageDir, "snapshots", ".keep"):      "",
+		filepath.Join(appRoot, "instances", ".keep"):         "",
+	}
+	for path, contents := range files {
+		os.MkdirAll(filepath.Dir(path), 0750)
+		if err := ioutil.WriteFile(path, []byte(contents), 0750); err != nil {
+			fail(err)
+		}
+	}
+	cfg := &config.VmmConfig{
+		ID:     "vm",
+		Name:   "snapshotted",
+		Memory: 128,
+		Cpus:   1,
+		Type:   config.FirecrackerVmm,
+		Kernel: "local-file://" + snapshotTestStorage + "/kernels/vm.elf",
+		Disks: []*config.VmmDiskConfig{{
+			IsRoot:     true,
+			StorageURI: "local-file://" + snapshotTestStorage + "/disks/vm/root.img",
+		}},
+	}
+	if err, _ := vutils.Config.SaveConfigToFile("", filepath.Join(appRoot, "instances", "vm.json"), cfg); err != nil {
+		fail(err)
+	}
+
+	current, err := user.Current()
+	if err != nil {
+		fail(err)
+	}
+	group, err := user.LookupGroupId(current.Gid)
+	if err != nil {
+		fail(err)
+	}
+	mgr, err := NewVmmManager(&config.PromethiumDaemonConfig{
+		NodeID:   "test-node",
+		Clusters: []*config.ClusterConfig{},
+		Storage: []*config.StorageConfig{{
+			ID:     snapshotTestStorage,
+			Driver: "local-file",
+			Config: map[string]interface{}{"rootFolder": storageDir},
+		}},
+		Networks:  []*networking.NetworkConfig{},
+		AppRoot:   appRoot,
+		User:      current.Username,
+		Group:     group.Name,
+		JailUser:  current.Username,
+		JailGroup: group.Name,
+	})
+	if err != nil {
+		fail(err)
+	}
+	if err := mgr.Start(); err != nil {
+		fail(err)
+	}
+	vmm, err := mgr.Get("vm")
+	if err != nil {
+		mgr.Kill()
+		fail(err)
+	}
+	return mgr, vmm, fake, func() {
+		mgr.Kill()
+		restore()
+	}
+}
+
+func readDisk(t *testing.T, vmm *Vmm, uri string) string {
+	path, _, err := vmm.mgr.Storage().ResolveStorageURI(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents)
+}
+
+func hasRequest(fc *fakeFirecracker, request string) bool {
+	for _, seen := range fc.getRequests() {
+		if seen == request {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	mgr, vmm, runner, cleanup := newSnapshotTestManager(t)
+	defer cleanup()
+	if err := vmm.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the vm to be running", func() bool { return vmm.Status() == "Running" })
+
+	op := mgr.Operations().Start("snapshot-vm", vmm.ID(), "Snapshot VM", func(op *operations.Operation) (interface{}, error) {
+		return vmm.CreateSnapshot(op, "warm")
+	})
+	result, err := op.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap := result.(*config.VmmSnapshotConfig)
+	if !hasRequest(runner.latest(), "PUT /snapshot/create") {
+		t.Error("expected firecracker to be asked for the snapshot")
+	}
+	waitFor(t, "the vm to be resumed once it was snapshotted", func() bool { return vmm.Status() == "Running" })
+	if snap.FirecrackerVersion != "v0.21.0" || len(snap.Disks) != 1 {
+		t.Errorf("unexpected snapshot %+v", snap)
+	}
+	if snapshots, err := vmm.ListSnapshots(); err != nil || len(snapshots) != 1 || snapshots[0].ID != snap.ID {
+		t.Errorf("expected the snapshot to be listed, got %v %v", snapshots, err)
+	}
+
+	//the disk changes after the snapshot and is put back by the restore
+	rootURI := vmm.config.Disks[0].StorageURI
+	rootPath, _, _ := mgr.Storage().ResolveStorageURI(rootURI)
+	if err := ioutil.WriteFile(rootPath, []byte("disk after the snapshot"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := vmm.RestoreSnapshot(nil, snap.ID); err == nil {
+		t.Error("expected a running vm to not be restored")
+	}
+	if err := vmm.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	op = mgr.Operations().Start("restore-vm-snapshot", vmm.ID(), "Restore VM snapshot", func(op *operations.Operation) (interface{}, error) {
+		return nil, vmm.RestoreSnapshot(op, snap.ID)
+	})
+	if _, err := op.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if state := op.State(); state.Status != operations.StatusCompleted || state.Progress != 100 {
+		t.Errorf("expected the restore to complete, got %+v", state)
+	}
+	restored := runner.latest()
+	if !hasRequest(restored, "PUT /snapshot/load") {
+		t.Error("expected the restored vm to be loaded from the snapshot")
+	}
+	if restored.getState() != fakeRunning || vmm.Status() != "Running" {
+		t.Errorf("expected the restored vm to be resumed, firecracker is %q and the vm %q", restored.getState(), vmm.Status())
+	}
+	if disk := readDisk(t, vmm, rootURI); disk != "disk as snapshotted" {
+		t.Errorf("expected the disk to be restored, it is %q", disk)
+	}
+
+	//a clone gets its own copy of the disks in a folder of its own
+	op = mgr.Operations().Start("restore-vm-snapshot", vmm.ID(), "Clone VM snapshot", func(op *operations.Operation) (interface{}, error) {
+		return mgr.NewVmmFromSnapshot(op, vmm, snap.ID, "clone")
+	})
+	result, err = op.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := result.(*Vmm)
+	if clone.ID() == vmm.ID() || clone.Status() != "Running" {
+		t.Errorf("expected the clone to be a new running vm, it is %s %q", clone.ID(), clone.Status())
+	}
+	cloneURI := clone.config.Disks[0].StorageURI
+	if !strings.Contains(cloneURI, "/disks/"+clone.ID()+"/") {
+		t.Errorf("expected the clone disk to be in its own folder, it is %s", cloneURI)
+	}
+	if disk := readDisk(t, clone, cloneURI); disk != "disk as snapshotted" {
+		t.Errorf("expected the clone to have a copy of the snapshotted disk, it is %q", disk)
+	}
+
+	//a clone that fails once its disks are copied doesnt leave them behind
+	tstr, err := vmm.storageTarget()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diskIDs, _ := tstr.DiskIDs()
+	kernelPath, _, _ := mgr.Storage().ResolveStorageURI(snap.Kernel)
+	if err := os.Rename(kernelPath, kernelPath+".moved"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.NewVmmFromSnapshot(nil, vmm, snap.ID, "failed"); err == nil {
+		t.Error("expected a clone without a kernel to fail")
+	}
+	if err := os.Rename(kernelPath+".moved", kernelPath); err != nil {
+		t.Fatal(err)
+	}
+	if ids, _ := tstr.DiskIDs(); len(ids) != len(diskIDs) {
+		t.Errorf("expected the disks of the failed clone to be removed, have %v", ids)
+	}
+
+	//a cancelled clone stops copying the disks and isnt registered
+	vms := len(mgr.List(true))
+	op = mgr.Operations().Start("restore-vm-snapshot", vmm.ID(), "Clone VM snapshot", func(op *operations.Operation) (interface{}, error) {
+		op.Cancel()
+		return mgr.NewVmmFromSnapshot(op, vmm, snap.ID, "cancelled")
+	})
+	if _, err := op.Wait(); err == nil {
+		t.Error("expected a cancelled clone to fail")
+	}
+	if state := op.State(); state.Status != operations.StatusCancelled {
+		t.Errorf("expected the clone to be cancelled, got %s", state.Status)
+	}
+	if len(mgr.List(true)) != vms {
+		t.Error("expected a cancelled clone to not be registered")
+	}
+
+	if err := vmm.DeleteSnapshot(snap.ID); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, _ := vmm.ListSnapshots(); len(snapshots) != 0 {
+		t.Errorf("expected the snapshot to be deleted, got %v", snapshots)
+	}
+}
+
+func TestSnapshotIDValidation(t *testing.T) {
+	_, vmm, _, cleanup := newSnapshotTestManager(t)
+	defer cleanup()
+	//a snapshot config outside of the snapshot folder that a path in the id could reach
+	outside := filepath.Join(vmm.mgr.instanceConfigRootPath, "outside"+snapshotConfigExt)
+	if err, _ := vutils.Config.SaveConfigToFile("", outside, &config.VmmSnapshotConfig{ID: "outside"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, snapshotID := range []string{"", "../outside", "a/b", "a.b", ".."} {
+		if _, err := vmm.GetSnapshot(snapshotID); err == nil || !strings.Contains(err.Error(), "Invalid snapshot id") {
+			t.Errorf("expected %q to be refused, got %v", snapshotID, err)
+		}
+		if err := vmm.DeleteSnapshot(snapshotID); err == nil {
+			t.Errorf("expected deleting %q to be refused", snapshotID)
+		}
+		if err := vmm.RestoreSnapshot(nil, snapshotID); err == nil {
+			t.Errorf("expected restoring %q to be refused", snapshotID)
+		}
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("expected the config outside the snapshot folder to be left alone: %v", err)
+	}
+	if _, err := vmm.GetSnapshot("missing_snapshot-1"); err == nil || strings.Contains(err.Error(), "Invalid") {
+		t.Errorf("expected a valid id that doesnt exist to not be found, got %v", err)
+	}
+}
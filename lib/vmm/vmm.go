@@ -6,11 +6,16 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/768bit/promethium/api/models"
 	"github.com/768bit/promethium/lib/common"
 	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/promethium/lib/metrics"
+	"github.com/768bit/promethium/lib/operations"
 	"github.com/768bit/vutils"
+	"github.com/go-openapi/strfmt"
 )
 
 /*
@@ -26,8 +31,8 @@ They can be plain VMs... or OSv unikernel enabled applications
 //VMM consists of the process and other related items...
 //The process is loaded and dependencies are tracked..
 
-//when creating a new vmm we only care about
-func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, image string, size uint64, targetStorage string, primaryNetwork string, kernelImage string) (*Vmm, error) {
+// when creating a new vmm we only care about
+func (mgr *VmmManager) NewVmmFromImage(op *operations.Operation, name string, vcpus int64, mem int64, image string, size uint64, targetStorage string, primaryNetwork string, kernelImage string) (*Vmm, error) {
 
 	//the primary network selection consists of:
 	// bridge name
@@ -40,6 +45,8 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 
 	//get the image..
 	vmmId, _ := vutils.UUID.MakeUUIDString()
+	//the disks are written before the vmm is added so the doctor has to be told they arent left behind
+	defer mgr.beginCreate(vmmId)()
 	vmmConfig := &config.VmmConfig{
 		ID:        vmmId,
 		Name:      name,
@@ -49,6 +56,8 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 		Kernel:    "",
 		Volumes:   []*config.VmmVolumeConfig{},
 		Network:   &config.VmmNetworkConfig{},
+		//new vms get a vsock device so the host can reach services in the guest - the CID is allocated on init
+		Vsock: &config.VmmVsockConfig{},
 	}
 
 	img, err := mgr.Storage().GetImageByID(image)
@@ -71,6 +80,14 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 		println(err.Error())
 		return nil, err
 	}
+	//the disks written so far are removed if the vmm isnt created - including when the operation is cancelled
+	created := false
+	defer func() {
+		if !created {
+			tstr.DeleteDisks(vmmId)
+		}
+	}()
+	op.SetProgress(0, "Writing root disk")
 	pkgFile, ds, err := img.GetRootDiskReader()
 	if err != nil {
 		if pkgFile != nil {
@@ -83,7 +100,22 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 
 	vmmConfig.Type = config.VmmType(img.GetType())
 
-	rootDiskPath, err := tstr.WriteRootDisk(vmmId, ds, int64(size), false, true)
+	//the root disk is extracted from the compressed image so the progress is how far through the image file it is -
+	//the resize and conversion of the disk after it is extracted take the rest up to the kernel
+	var pkgSize int64
+	if info, err := pkgFile.Stat(); err == nil {
+		pkgSize = info.Size()
+	}
+	rootDiskPath, err := tstr.WriteRootDisk(op, vmmId, &operations.Reader{
+		Op:     op,
+		Reader: ds,
+		Size:   pkgSize,
+		To:     60,
+		Position: func() int64 {
+			position, _ := pkgFile.Seek(0, io.SeekCurrent)
+			return position
+		},
+	}, int64(size), false, true)
 	if err != nil {
 
 		println(err.Error())
@@ -100,6 +132,11 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 		rootDisk.ToDiskConfig(true),
 	}
 
+	op.SetProgress(80, "Writing kernel")
+	if err := op.Context().Err(); err != nil {
+		return nil, err
+	}
+
 	//does the image have a kernel? have we selected a different one?
 
 	if !img.HasKernel() && kernelImage == "" {
@@ -175,14 +212,43 @@ func (mgr *VmmManager) NewVmmFromImage(name string, vcpus int64, mem int64, imag
 		return nil, err
 	}
 
-	vmm := &Vmm{
-		mgr:        mgr,
-		id:         vmmId,
-		configPath: vmmConfigPath,
-		config:     vmmConfig,
+	created = true
+	op.SetProgress(95, "Setting up VM")
+
+	vmm := newVmm(mgr, vmmId, vmmConfigPath, vmmConfig)
+	mgr.addInstance(vmm)
+
+	return vmm.init(vmmConfig)
+}
+
+// NewMockVmm creates a mock vmm - it has no disks or kernel so it can be created without an image. The console
+// echoes what is typed into it.
+func (mgr *VmmManager) NewMockVmm(op *operations.Operation, name string, vcpus int64, mem int64) (*Vmm, error) {
+	vmmId, _ := vutils.UUID.MakeUUIDString()
+	vmmConfig := &config.VmmConfig{
+		ID:        vmmId,
+		Name:      name,
+		Clustered: false,
+		Memory:    mem,
+		Cpus:      vcpus,
+		Type:      config.MockVmm,
+		Volumes:   []*config.VmmVolumeConfig{},
+		Network:   &config.VmmNetworkConfig{},
+		Disks:     []*config.VmmDiskConfig{},
+		Mock: &config.VmmMockConfig{
+			ConsoleOutput: []string{"Booting mock VM " + name, name + " login:"},
+			Echo:          true,
+		},
+	}
+
+	op.SetProgress(95, "Setting up VM")
+	vmmConfigPath := filepath.Join(mgr.instanceConfigRootPath, vmmId+".json")
+	if err, _ := vutils.Config.SaveConfigToFile("", vmmConfigPath, vmmConfig); err != nil {
+		return nil, err
 	}
 
-	mgr.instances[vmmId] = vmm
+	vmm := newVmm(mgr, vmmId, vmmConfigPath, vmmConfig)
+	mgr.addInstance(vmm)
 
 	return vmm.init(vmmConfig)
 }
@@ -199,14 +265,8 @@ func (mgr *VmmManager) LoadVmm(vmmConfigPath string) (*Vmm, error) {
 		return nil, errors.New("Config is nil")
 	}
 
-	vmm := &Vmm{
-		mgr:        mgr,
-		id:         vmmConfig.ID,
-		configPath: vmmConfigPath,
-		config:     vmmConfig,
-	}
-
-	mgr.instances[vmmConfig.ID] = vmm
+	vmm := newVmm(mgr, vmmConfig.ID, vmmConfigPath, vmmConfig)
+	mgr.addInstance(vmm)
 
 	return vmm.init(vmmConfig)
 }
@@ -221,6 +281,25 @@ type Vmm struct {
 
 	fcInstancePath string
 	instance       common.VmmProcess
+	health         *HealthMonitor
+
+	proxyLock sync.Mutex
+	proxies   map[string]*VsockProxy
+
+	//guards the balloon target in the config - it is read by the metrics while it is changed by the api
+	balloonLock sync.RWMutex
+
+	ops *opLock
+}
+
+func newVmm(mgr *VmmManager, id string, configPath string, cfg *config.VmmConfig) *Vmm {
+	return &Vmm{
+		mgr:        mgr,
+		id:         id,
+		configPath: configPath,
+		config:     cfg,
+		ops:        newOpLock(),
+	}
 }
 
 func (vmm *Vmm) init(cfg *config.VmmConfig) (*Vmm, error) {
@@ -259,14 +338,69 @@ func (vmm *Vmm) init(cfg *config.VmmConfig) (*Vmm, error) {
 
 	}
 
+	restartPolicy := cfg.GetRestartPolicy()
+	if err := restartPolicy.Validate(); err != nil {
+		return vmm, err
+	}
+
+	if err := vmm.mgr.ensureJailUID(vmm); err != nil {
+		return vmm, err
+	}
+	if err := vmm.mgr.ensureVsockCID(vmm); err != nil {
+		return vmm, err
+	}
+
+	if cfg.Balloon != nil {
+		if err := cfg.Balloon.Validate(cfg.Memory); err != nil {
+			return vmm, err
+		}
+	}
+
+	if cfg.CPU != nil {
+		if err := ValidateCPU(cfg.CPU, cfg.Cpus); err != nil {
+			return vmm, err
+		}
+	}
+
+	if cfg.Resources != nil {
+		if err := ValidateResources(cfg.Resources); err != nil {
+			return vmm, err
+		}
+	}
+
+	if cfg.Mock != nil {
+		if err := cfg.Mock.Validate(); err != nil {
+			return vmm, err
+		}
+	}
+
+	health, err := NewHealthMonitor(vmm, cfg.HealthChecks)
+	if err != nil {
+		return vmm, err
+	}
+	vmm.health = health
+
 	switch cfg.Type {
 	case config.FirecrackerVmm:
 		fcp, err := NewFireCrackerProcessImg(vmm.id, vmm.config.Name, strings.TrimSpace(vmm.config.BootCmd), vmm.config.Cpus, vmm.config.Memory,
-			kernelPath, drvList, nil, vmm.config.AutoStart)
+			kernelPath, drvList, nil, vmm.config.AutoStart, restartPolicy, cfg.CPU, cfg.Resources, vmm.jailIdentity())
 		if err != nil {
 			return vmm, err
 		}
+		fcp.SetVsock(cfg.Vsock)
+		fcp.SetBalloon(cfg.Balloon)
+		if cfg.Vsock != nil {
+			fcp.SetShutdownRequest(vmm.agentShutdown)
+		}
+		fcp.SetOperationRunner(vmm.do)
 		vmm.instance = fcp
+		vmm.health.Start()
+		return vmm, nil
+	case config.MockVmm:
+		mp := NewMockProcess(vmm.id, cfg.Mock, restartPolicy)
+		mp.SetOperationRunner(vmm.do)
+		vmm.instance = mp
+		vmm.health.Start()
 		return vmm, nil
 	case config.OSvFirecrackerVmm:
 		//fcp, err := NewFireCrackerProcess(vmm.id, vmm.config.Name, vmm.config.BootCmd, vmm.config.EntryPoint, vmm.config.Cpus, vmm.config.Memory,
@@ -278,8 +412,51 @@ func (vmm *Vmm) init(cfg *config.VmmConfig) (*Vmm, error) {
 
 }
 
-func (vmm *Vmm) GetModel() string {
-	return vmm.config.Name
+func (vmm *Vmm) GetModel() *models.VM {
+	vm := &models.VM{
+		ID:            strfmt.UUID4(vmm.id),
+		Name:          vmm.config.Name,
+		Clustered:     vmm.config.Clustered,
+		ClusterID:     strfmt.UUID4(vmm.config.ClusterID),
+		Cpus:          vmm.config.Cpus,
+		Memory:        vmm.config.Memory,
+		Type:          string(vmm.config.Type),
+		BootCmd:       vmm.config.BootCmd,
+		EntryPoint:    vmm.config.EntryPoint,
+		AutoStart:     vmm.config.AutoStart,
+		RestartPolicy: string(vmm.config.GetRestartPolicy().Policy),
+		Disks:         []*models.VMDisk{},
+		Volumes:       []*models.VMVolume{},
+	}
+	if vmm.config.Vsock != nil {
+		vm.VsockCID = int64(vmm.config.Vsock.CID)
+	}
+	vm.BalloonTargetMib, vm.BalloonActualMib = vmm.BalloonMiB()
+	for _, dsk := range vmm.config.Disks {
+		vm.Disks = append(vm.Disks, &models.VMDisk{
+			IsRoot:     dsk.IsRoot,
+			StorageURI: dsk.StorageURI,
+		})
+	}
+	if vmm.instance != nil {
+		vm.Status = vmm.instance.GetStatus()
+		vm.CrashCount = vmm.instance.GetCrashCount()
+		vm.LastExitReason = vmm.instance.GetLastExitReason()
+	}
+	vm.Health, vm.HealthMessage = vmm.Health()
+	return vm
+}
+
+func (vmm *Vmm) GetListModel() *models.VMListItem {
+	item := &models.VMListItem{
+		ID:   strfmt.UUID4(vmm.id),
+		Name: vmm.config.Name,
+	}
+	if vmm.instance != nil {
+		item.Status = vmm.instance.GetStatus()
+	}
+	item.Health, _ = vmm.Health()
+	return item
 }
 
 func (vmm *Vmm) Name() string {
@@ -291,83 +468,174 @@ func (vmm *Vmm) ID() string {
 }
 
 func (vmm *Vmm) Start() error {
-	if vmm.instance == nil {
-		return errors.New("Unable to start as instance isnt setup")
-	} else {
-		err := vmm.instance.Start()
-		if err != nil {
+	return vmm.do("start", func() error {
+		if err := vmm.instance.Start(); err != nil {
 			return err
 		}
-
-		return nil
-	}
+		return vmm.setStoppedByUser(false)
+	})
 }
 
 func (vmm *Vmm) Stop() error {
-	if vmm.instance == nil {
-		return errors.New("Unable to stop as instance isnt setup")
-	} else {
-		err := vmm.instance.Stop()
-		if err != nil {
+	return vmm.do("stop", func() error {
+		if err := vmm.instance.Stop(); err != nil {
 			return err
 		}
-
-		return nil
-	}
+		//the proxies cant reach the guest once it is down so they arent left listening
+		vmm.closeVsockProxies()
+		return vmm.setStoppedByUser(true)
+	})
 }
 
 func (vmm *Vmm) Shutdown() error {
-	if vmm.instance == nil {
-		return errors.New("Unable to shutdown as instance isnt setup")
-	} else {
-		err := vmm.instance.Shutdown()
-		if err != nil {
+	return vmm.do("shutdown", func() error {
+		if err := vmm.instance.Shutdown(); err != nil {
 			return err
 		}
+		vmm.closeVsockProxies()
+		return vmm.setStoppedByUser(true)
+	})
+}
 
+// setStoppedByUser records whether the vmm was last stopped by the user - vmms stopped when the daemon exits arent
+// so an unless-stopped vmm comes back up with the daemon
+func (vmm *Vmm) setStoppedByUser(stopped bool) error {
+	if vmm.config.StoppedByUser == stopped {
 		return nil
 	}
+	vmm.config.StoppedByUser = stopped
+	err, _ := vutils.Config.SaveConfigToFile("", vmm.configPath, vmm.config)
+	return err
 }
 
 func (vmm *Vmm) Restart() error {
-	if vmm.instance == nil {
-		return errors.New("Unable to restart as instance isnt setup")
-	} else {
-		err := vmm.instance.Restart()
-		if err != nil {
-			return err
+	return vmm.do("restart", func() error {
+		return vmm.instance.Restart()
+	})
+}
+
+func (vmm *Vmm) Reset() error {
+	return vmm.do("reset", func() error {
+		return vmm.instance.Reset()
+	})
+}
+
+func (vmm *Vmm) Pause() error {
+	return vmm.do("pause", func() error {
+		if status := vmm.Status(); status != "Running" {
+			return &StateError{VmID: vmm.id, Operation: "pause", Status: status}
 		}
+		return vmm.instance.Pause()
+	})
+}
 
-		return nil
-	}
+func (vmm *Vmm) Resume() error {
+	return vmm.do("resume", func() error {
+		if status := vmm.Status(); status != PAUSED_STATUS {
+			return &StateError{VmID: vmm.id, Operation: "resume", Status: status}
+		}
+		return vmm.instance.Resume()
+	})
 }
 
-func (vmm *Vmm) Reset() error {
+// Metrics returns the metrics collected from firecracker - when flush is set firecracker is asked to write out
+// its current metrics first so the counters are up to date
+func (vmm *Vmm) Metrics(flush bool) (*metrics.VmmMetricsSnapshot, error) {
 	if vmm.instance == nil {
-		return errors.New("Unable to reset as instance isnt setup")
-	} else {
-		err := vmm.instance.Reset()
-		if err != nil {
-			return err
+		return nil, errors.New("Unable to get metrics as instance isnt setup")
+	}
+	if flush && vmm.Status() == "Running" {
+		samples := vmm.instance.Metrics().Samples
+		if err := vmm.instance.FlushMetrics(); err != nil {
+			return nil, err
+		}
+		//the flush is written to the fifo before firecracker responds but we read it asynchronously
+		deadline := time.Now().Add(time.Second)
+		for vmm.instance.Metrics().Samples == samples && time.Now().Before(deadline) {
+			time.Sleep(20 * time.Millisecond)
 		}
-
-		return nil
 	}
+	return vmm.instance.Metrics(), nil
 }
 
-func (vmm *Vmm) Console() (io.ReadCloser, io.ReadCloser, io.WriteCloser, error) {
+func (vmm *Vmm) GetMetricsModel(snap *metrics.VmmMetricsSnapshot) *models.VMMetrics {
+	model := &models.VMMetrics{
+		VMID:        strfmt.UUID4(vmm.id),
+		Samples:     snap.Samples,
+		ParseErrors: snap.ParseErrors,
+		Counters:    snap.Counters,
+		Gauges:      snap.Gauges,
+	}
+	if !snap.UpdatedAt.IsZero() {
+		model.UpdatedAt = strfmt.DateTime(snap.UpdatedAt)
+	}
+	return model
+}
 
-	return vmm.instance.Console()
+// Console attaches to the serial console of the vmm - any number of clients can be attached at once and each is
+// sent the recent output first
+func (vmm *Vmm) Console(readOnly bool) (io.ReadWriteCloser, error) {
+	if vmm.instance == nil {
+		return nil, errors.New("Unable to attach to console as instance isnt setup")
+	}
+	return vmm.instance.Console(readOnly)
 }
 
 func (vmm *Vmm) Status() string {
 	return vmm.instance.GetStatus()
 }
 
+// Health returns the combined health check status and the last failure message
+func (vmm *Vmm) Health() (string, string) {
+	return vmm.health.Status()
+}
+
+// waitReady waits for the vmm to be running and passing its health checks (if it has any) so vmms depending on it
+// can be started
+func (vmm *Vmm) waitReady(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status := vmm.Status()
+		health, _ := vmm.Health()
+		if status == "Running" && (health == HealthNone || health == HealthHealthy) {
+			return nil
+		} else if !vmm.isRunning() {
+			return fmt.Errorf("VMM %s it depends on is %s", vmm.Name(), status)
+		} else if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for VMM %s it depends on to be ready (%s, health %s)", vmm.Name(), status, health)
+		}
+		time.Sleep(dependencyPollInterval)
+	}
+}
+
 func (vmm *Vmm) Kill() error {
+	vmm.health.Stop()
+	vmm.closeVsockProxies()
+	defer vmm.waitOp("kill")()
 	return vmm.instance.Stop()
 }
 
 func (vmm *Vmm) WaitKill(timeout time.Duration) error {
+	vmm.health.Stop()
+	vmm.closeVsockProxies()
+	defer vmm.waitOp("shutdown")()
 	return vmm.instance.ShutdownTimeout(timeout)
 }
+
+// Detach leaves the instance running when the daemon exits
+func (vmm *Vmm) Detach() error {
+	vmm.health.Stop()
+	if vmm.instance == nil {
+		return nil
+	}
+	defer vmm.waitOp("detach")()
+	return vmm.instance.Detach()
+}
+
+// reattach picks up the instance left running by a previous run of the daemon
+func (vmm *Vmm) reattach() (bool, error) {
+	if vmm.instance == nil {
+		return false, nil
+	}
+	return vmm.instance.Reattach()
+}
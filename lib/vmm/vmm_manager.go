@@ -3,18 +3,21 @@ package vmm
 import (
 	"errors"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/768bit/promethium/api/models"
-	"github.com/768bit/promethium/lib/assets"
+	"github.com/768bit/promethium/lib/common"
 	"github.com/768bit/promethium/lib/config"
 	"github.com/768bit/promethium/lib/images"
 	"github.com/768bit/promethium/lib/networking"
+	"github.com/768bit/promethium/lib/operations"
+	"github.com/768bit/promethium/lib/recording"
 	"github.com/768bit/promethium/lib/storage"
 	"github.com/768bit/vutils"
 )
@@ -26,6 +29,14 @@ It will also load its global config so it can initialise itself...
 
 var ROOT_PATH string = ""
 
+// LOGS_CONFIG sets the rotation of the serial and firecracker logs kept for each instance
+var LOGS_CONFIG = (&config.PromethiumDaemonConfig{}).GetLogsConfig()
+
+// DependencyTimeout is how long a vmm waits on boot for the vmms it depends on to be running and healthy
+var DependencyTimeout = 2 * time.Minute
+
+var dependencyPollInterval = 250 * time.Millisecond
+
 func NewVmmManager(config *config.PromethiumDaemonConfig) (*VmmManager, error) {
 	log.Printf("Initialising VmmManager...")
 	vmmMgr := &VmmManager{
@@ -33,9 +44,12 @@ func NewVmmManager(config *config.PromethiumDaemonConfig) (*VmmManager, error) {
 		appRootPath:            config.AppRoot,
 		instances:              map[string]*Vmm{},
 		clusterInstances:       map[string]map[string]*Vmm{},
+		creating:               map[string]bool{},
 		instanceConfigRootPath: filepath.Join(config.AppRoot, "instances"),
+		operations:             operations.NewManager(operations.DefaultRetention),
 	}
 	ROOT_PATH = config.AppRoot
+	LOGS_CONFIG = config.GetLogsConfig()
 	if err := vmmMgr.init(); err != nil {
 		return nil, err
 	}
@@ -53,16 +67,31 @@ type VmmManager struct {
 	storageRootPath    string
 	cacheRootPath      string
 
+	//lock guards instances, clusterInstances, creating, startOrder, the storage manager and exiting - allocLock is held
+	//while a vmm is given a jail uid and vsock CID so two vmms cant be given the same one
+	lock             sync.RWMutex
+	allocLock        sync.Mutex
 	instances        map[string]*Vmm
 	clusterInstances map[string]map[string]*Vmm
-
-	networks *networking.Manager
+	//the ids of vmms whose disks are being written - they have no config until they are created
+	creating   map[string]bool
+	startOrder []*Vmm
+	//set once the daemon starts to exit - requests tracks the api requests in flight so they finish before the
+	//manager is torn down
+	exiting  bool
+	requests sync.WaitGroup
+
+	networks   *networking.Manager
+	recordings *recording.Store
+	operations *operations.Manager
 
 	runGroup  sync.WaitGroup
 	stopGroup sync.WaitGroup
 	killGroup sync.WaitGroup
 	uid       int
 	gid       int
+	jailUID   int
+	jailGID   int
 }
 
 func (vmmMgr *VmmManager) init() error {
@@ -79,6 +108,10 @@ func (vmmMgr *VmmManager) init() error {
 	vmmMgr.uid = uid
 	vmmMgr.gid = gid
 
+	if vmmMgr.jailUID, vmmMgr.jailGID, err = resolveJailIdentity(vmmMgr.config); err != nil {
+		return err
+	}
+
 	images.StartQemuNbd(uid, gid)
 
 	if err := vmmMgr.createFolders(); err != nil {
@@ -103,65 +136,155 @@ func (vmmMgr *VmmManager) Start() error {
 	if storageMgr, err := storage.NewStorageManager(vmmMgr.appRootPath, vmmMgr.config.Storage, vmmMgr.uid, vmmMgr.gid); err != nil {
 		return err
 	} else {
+		vmmMgr.lock.Lock()
 		vmmMgr.storageManager = storageMgr
+		vmmMgr.lock.Unlock()
 	}
 	if err := vmmMgr.scanInstanceConfigs(); err != nil {
 		return err
 	}
+	vmmMgr.doctorOnStart()
+	go vmmMgr.startInstances()
 	log.Printf("VmmManager Started...")
 	return nil
 }
 
 func (vmmMgr *VmmManager) scanInstanceConfigs() error {
 	log.Printf("Scanning Instance Configs...")
-	configMap := map[string]bool{}
 	for _, instanceConf := range vutils.Files.GetFilesInDirWithExtension(vmmMgr.instanceConfigRootPath, ".json") {
 		log.Printf("Loading Vmm Config: %s", instanceConf)
-		if vmm, err := vmmMgr.LoadVmm(filepath.Join(vmmMgr.instanceConfigRootPath, instanceConf)); err != nil {
+		if _, err := vmmMgr.LoadVmm(filepath.Join(vmmMgr.instanceConfigRootPath, instanceConf)); err != nil {
 			println("Error loading VMM: " + err.Error())
 			return err
-		} else {
-			configMap[vmm.id] = true
 		}
 	}
-	return vmmMgr.scanInstances(configMap)
+	vmmMgr.resolveStartOrder()
+	return vmmMgr.scanInstances()
+}
+
+// resolveStartOrder works out the order instances are brought up in at boot (and torn down in reverse) - instances
+// whose dependencies cant be resolved are left out so they arent started on boot
+func (vmmMgr *VmmManager) resolveStartOrder() {
+	vmmMgr.lock.Lock()
+	defer vmmMgr.lock.Unlock()
+	cfgs := []*config.VmmConfig{}
+	for _, vmm := range vmmMgr.instances {
+		cfgs = append(cfgs, vmm.config)
+	}
+	ordered, unresolved := config.ResolveStartOrder(cfgs)
+	for id, err := range unresolved {
+		log.Printf("Not starting VMM %s on boot: %s", id, err.Error())
+	}
+	vmmMgr.startOrder = make([]*Vmm, len(ordered))
+	for index, cfg := range ordered {
+		vmmMgr.startOrder[index] = vmmMgr.instances[cfg.ID]
+	}
 }
 
-func (vmmMgr *VmmManager) scanInstances(configMap map[string]bool) error {
-	//check that the instance exists in the configs... will also mean any orphans are tracked too...
-	//
+// startInstances brings up the instances that should start on boot in dependency order - any dependency of an
+// instance being started is started too even if it isnt set to start on boot itself
+func (vmmMgr *VmmManager) startInstances() {
+	startOrder := vmmMgr.startOrderList()
+	cfgs := make([]*config.VmmConfig, len(startOrder))
+	for index, vmm := range startOrder {
+		cfgs[index] = vmm.config
+	}
+	needed := map[string]bool{}
+	for index := len(cfgs) - 1; index >= 0; index-- {
+		cfg := cfgs[index]
+		if cfg.GetRestartPolicy().StartOnBoot(cfg.AutoStart, cfg.StoppedByUser) {
+			needed[cfg.ID] = true
+		}
+		if needed[cfg.ID] {
+			for _, dep := range config.ResolveDependencies(cfg, cfgs) {
+				needed[dep.ID] = true
+			}
+		}
+	}
+	byID := map[string]*Vmm{}
+	for _, vmm := range startOrder {
+		byID[vmm.id] = vmm
+	}
+	failed := map[string]bool{}
+	for _, vmm := range startOrder {
+		if !needed[vmm.id] {
+			continue
+		}
+		//the dependencies come first in the start order so they have been started (or failed) by now
+		var depErr error
+		for _, dep := range config.ResolveDependencies(vmm.config, cfgs) {
+			if failed[dep.ID] {
+				depErr = fmt.Errorf("VMM %s it depends on didnt start", dep.Name)
+			} else {
+				depErr = byID[dep.ID].waitReady(DependencyTimeout)
+			}
+			if depErr != nil {
+				break
+			}
+		}
+		if depErr != nil {
+			log.Printf("Not starting VMM %s on boot: %s", vmm.ID(), depErr.Error())
+			failed[vmm.id] = true
+			continue
+		}
+		if vmm.isRunning() {
+			log.Printf("VMM is still running: %s (%s)", vmm.Name(), vmm.ID())
+			continue
+		}
+		log.Printf("Starting VMM on boot: %s (%s)", vmm.Name(), vmm.ID())
+		if err := vmm.Start(); err != nil {
+			log.Printf("Error starting VMM %s on boot: %s", vmm.ID(), err.Error())
+			failed[vmm.id] = true
+			continue
+		}
+		if vmm.config.StartDelay > 0 {
+			time.Sleep(time.Duration(vmm.config.StartDelay) * time.Second)
+		}
+	}
+}
+
+func (vmmMgr *VmmManager) scanInstances() error {
 	log.Printf("Scanning Instances...")
+	instanceDirs, _, _, err := vmmMgr.classifyInstanceDirs()
+	if err != nil {
+		return err
+	}
+	//instances left running by a previous run of the daemon are picked up rather than started again
+	for _, dir := range instanceDirs {
+		vmm, _ := vmmMgr.Get(filepath.Base(dir))
+		if reattached, err := vmm.reattach(); err != nil {
+			log.Printf("Error setting up VMM %s: %s", vmm.ID(), err.Error())
+		} else if reattached {
+			log.Printf("Reattached to running VMM: %s (%s)", vmm.Name(), vmm.ID())
+		}
+	}
+	return nil
+}
+
+// classifyInstanceDirs sorts the folders in the firecracker root in to those of instances, those of cluster
+// instances and orphans that have no config
+func (vmmMgr *VmmManager) classifyInstanceDirs() ([]string, []string, []string, error) {
 	instanceDirs := []string{}
 	clusterInstanceDirs := []string{}
 	orphanedInstanceDirs := []string{}
-	err := filepath.Walk(vmmMgr.fcInstanceRootPath, func(path string, f os.FileInfo, _ error) error {
-
-		if f.IsDir() {
-
-			//check if this directory is valid...
-			if _, ok := vmmMgr.instances[f.Name()]; !ok {
-				foundItem := false
-				for _, clusterInstances := range vmmMgr.clusterInstances {
-					if _, ok := clusterInstances[f.Name()]; ok {
-						foundItem = true
-						delete(configMap, f.Name())
-						clusterInstanceDirs = append(clusterInstanceDirs, filepath.Join(vmmMgr.fcInstanceRootPath, f.Name()))
-						break
-					}
-				}
-				if !foundItem {
-					//is an orphaned item..
-					orphanedInstanceDirs = append(orphanedInstanceDirs, filepath.Join(vmmMgr.fcInstanceRootPath, f.Name()))
-				}
-			} else {
-				delete(configMap, f.Name())
-				instanceDirs = append(instanceDirs, filepath.Join(vmmMgr.fcInstanceRootPath, f.Name()))
-			}
-
+	infos, err := ioutil.ReadDir(vmmMgr.fcInstanceRootPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, f := range infos {
+		if !f.IsDir() {
+			continue
 		}
-		return nil
-	})
-	return err
+		dir := filepath.Join(vmmMgr.fcInstanceRootPath, f.Name())
+		if _, err := vmmMgr.Get(f.Name()); err == nil {
+			instanceDirs = append(instanceDirs, dir)
+		} else if vmmMgr.knownInstance(f.Name()) {
+			clusterInstanceDirs = append(clusterInstanceDirs, dir)
+		} else {
+			orphanedInstanceDirs = append(orphanedInstanceDirs, dir)
+		}
+	}
+	return instanceDirs, clusterInstanceDirs, orphanedInstanceDirs, nil
 }
 
 func (vmmMgr *VmmManager) createFolders() error {
@@ -170,32 +293,6 @@ func (vmmMgr *VmmManager) createFolders() error {
 	vmmMgr.storageRootPath = filepath.Join(vmmMgr.appRootPath, "storage")
 	vmmMgr.instanceConfigRootPath = filepath.Join(vmmMgr.appRootPath, "instances")
 	vmmMgr.fcInstanceRootPath = filepath.Join(vmmMgr.appRootPath, "firecracker")
-	binPath := filepath.Join(vmmMgr.appRootPath, "bin")
-	vutils.Files.CreateDirIfNotExist(binPath)
-	fcBinary, err := assets.FireCrackerAssets.Open("firecracker")
-	if err == nil {
-		of, err := os.Create(filepath.Join(binPath, "firecracker"))
-		of.Chmod(0700)
-		if err != nil {
-			println(err.Error())
-		} else {
-			io.Copy(of, fcBinary)
-			of.Close()
-		}
-		fcBinary.Close()
-	}
-	jlrBinary, err := assets.FireCrackerAssets.Open("jailer")
-	if err == nil {
-		of, err := os.Create(filepath.Join(binPath, "jailer"))
-		of.Chmod(0700)
-		if err != nil {
-			println(err.Error())
-		} else {
-			io.Copy(of, jlrBinary)
-			of.Close()
-		}
-		jlrBinary.Close()
-	}
 	if err := vutils.Files.CreateDirIfNotExist(vmmMgr.instanceConfigRootPath); err != nil {
 		return err
 	} else if err := vutils.Files.CreateDirIfNotExist(vmmMgr.storageRootPath); err != nil {
@@ -206,7 +303,10 @@ func (vmmMgr *VmmManager) createFolders() error {
 		return err
 	} else if err := vutils.Files.CreateDirIfNotExist(vmmMgr.fcInstanceRootPath); err != nil {
 		return err
+	} else if recordings, err := recording.NewStore(filepath.Join(vmmMgr.appRootPath, "recordings")); err != nil {
+		return err
 	} else {
+		vmmMgr.recordings = recordings
 		//now chown everything!
 		// err = config.DoChown(vmmMgr.appRootPath, vmmMgr.uid, vmmMgr.gid, true)
 		// if err != nil {
@@ -234,75 +334,170 @@ func (vmmMgr *VmmManager) Wait() error {
 	return nil
 }
 
+// BeginRequest tracks a request to the api so the manager isnt torn down while it is served - it is false once the
+// daemon is exiting and the request shouldnt be served. The func returned is called when the request is done.
+func (vmmMgr *VmmManager) BeginRequest() (func(), bool) {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	if vmmMgr.exiting {
+		return nil, false
+	}
+	vmmMgr.requests.Add(1)
+	return vmmMgr.requests.Done, true
+}
+
+// drain stops new requests and operations from being taken and waits up to timeout for the ones in flight to finish
+func (vmmMgr *VmmManager) drain(timeout time.Duration) {
+	vmmMgr.lock.Lock()
+	vmmMgr.exiting = true
+	vmmMgr.lock.Unlock()
+	vmmMgr.operations.CancelAll(timeout)
+	done := make(chan struct{})
+	go func() {
+		vmmMgr.requests.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Println("Timed out waiting on api requests to finish")
+	}
+}
+
 func (vmmMgr *VmmManager) Kill() error {
+	vmmMgr.drain(10 * time.Second)
 	//kill all instances IMMEDIATELY
-	for _, vmm := range vmmMgr.instances {
+	for _, vmm := range vmmMgr.allInstances() {
 		vmm.Kill()
 	}
-	for _, vmmColl := range vmmMgr.clusterInstances {
-		for _, vmm := range vmmColl {
-			vmm.Kill()
-		}
+	return vmmMgr.cleanupForExit()
+}
+
+// Detach leaves the instances running when the daemon exits so the next run of the daemon can pick them up
+func (vmmMgr *VmmManager) Detach() error {
+	vmmMgr.drain(10 * time.Second)
+	for _, vmm := range vmmMgr.allInstances() {
+		vmm.Detach()
 	}
 	return vmmMgr.cleanupForExit()
 }
 
 func (vmmMgr *VmmManager) WaitKill() error {
-	//kill all instances IMMEDIATELY
+	vmmMgr.drain(10 * time.Second)
+	//shut down instances with ordering in reverse start order - the rest can go in parallel
 	vmmMgr.killGroup = sync.WaitGroup{}
-	for _, vmm := range vmmMgr.instances {
+	ordered := []*Vmm{}
+	inOrder := map[string]bool{}
+	for _, vmm := range vmmMgr.startOrderList() {
+		if vmmMgr.hasStartOrdering(vmm) {
+			ordered = append(ordered, vmm)
+			inOrder[vmm.id] = true
+		}
+	}
+	waitKill := func(inVmm *Vmm) {
+		fmt.Printf("Killing VMM With Timeout: %s\n", inVmm.ID())
+		err := inVmm.WaitKill(30 * time.Second)
+		if err != nil {
+			fmt.Printf("Error waiting on shutdown: %s\n", err.Error())
+		} else {
+			fmt.Printf("VMM Killed With Timeout: %s\n", inVmm.ID())
+		}
+	}
+	vmmMgr.killGroup.Add(1)
+	go func() {
+		for index := len(ordered) - 1; index >= 0; index-- {
+			waitKill(ordered[index])
+		}
+		vmmMgr.killGroup.Done()
+	}()
+	for _, vmm := range vmmMgr.instanceList() {
+		if inOrder[vmm.id] {
+			continue
+		}
 		vmmMgr.killGroup.Add(1)
 		go func(inVmm *Vmm) {
-			fmt.Printf("Killing VMM With Timeout: %s\n", inVmm.ID())
-			err := inVmm.WaitKill(30 * time.Second)
-			if err != nil {
-				fmt.Printf("Error waiting on shutdown: %s\n", err.Error())
-			} else {
-				fmt.Printf("VMM Killed With Timeout: %s\n", inVmm.ID())
-			}
-
+			waitKill(inVmm)
 			vmmMgr.killGroup.Done()
 		}(vmm)
 	}
-	for _, vmmColl := range vmmMgr.clusterInstances {
-		for _, vmm := range vmmColl {
-			vmmMgr.killGroup.Add(1)
-			go func(inVmm *Vmm) {
-				inVmm.WaitKill(30 * time.Second)
-				vmmMgr.killGroup.Done()
-			}(vmm)
-		}
+	for _, vmm := range vmmMgr.clusterInstanceList() {
+		vmmMgr.killGroup.Add(1)
+		go func(inVmm *Vmm) {
+			inVmm.WaitKill(30 * time.Second)
+			vmmMgr.killGroup.Done()
+		}(vmm)
 	}
 	vmmMgr.killGroup.Wait()
 	log.Println("Waiting on shutdown completed")
 	return vmmMgr.cleanupForExit()
 }
 
+// hasStartOrdering is true when the instance has a start order, dependencies or is depended on
+func (vmmMgr *VmmManager) hasStartOrdering(vmm *Vmm) bool {
+	if vmm.config.StartOrder != 0 || len(vmm.config.DependsOn) > 0 {
+		return true
+	}
+	for _, other := range vmmMgr.instanceList() {
+		for _, dep := range other.config.DependsOn {
+			if dep == vmm.id || dep == vmm.config.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (vmmMgr *VmmManager) cleanupForExit() error {
-	vmmMgr.storageManager.Dispose()
+	vmmMgr.lock.Lock()
+	defer vmmMgr.lock.Unlock()
+	if vmmMgr.storageManager != nil {
+		vmmMgr.storageManager.Dispose()
+	}
 	log.Println("Cleanup complete")
 	return nil
 }
 
 func (vmmMgr *VmmManager) Storage() *storage.StorageManager {
-
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
 	return vmmMgr.storageManager
 }
 
-func (vmmMgr *VmmManager) Create(newVmConf *models.NewVM) (*Vmm, error) {
+// Recordings is where console sessions are recorded
+func (vmmMgr *VmmManager) Recordings() *recording.Store {
+	return vmmMgr.recordings
+}
+
+// Operations are the long running tasks of the daemon
+func (vmmMgr *VmmManager) Operations() *operations.Manager {
+	return vmmMgr.operations
+}
+
+// BuildImage runs the build of spec as an operation - the workspace of the build lives in the cache
+func (vmmMgr *VmmManager) BuildImage(spec *images.ImageBuildSpec) *operations.Operation {
+	return vmmMgr.operations.Start("build-image", spec.Name, "Build image "+spec.Name+" "+spec.Version, func(op *operations.Operation) (interface{}, error) {
+		return nil, spec.Build(op, filepath.Join(vmmMgr.cacheRootPath, "builds"))
+	})
+}
+
+func (vmmMgr *VmmManager) Create(op *operations.Operation, newVmConf *models.NewVM) (*Vmm, error) {
 	//need to create a templated VM..
 
 	//get storage back end
 
 	//get linux bridge
 
-	return vmmMgr.NewVmmFromImage(newVmConf.Name, newVmConf.Cpus, newVmConf.Memory, newVmConf.FromImage, uint64(newVmConf.RootDiskSize), newVmConf.StorageName, newVmConf.PrimaryNetworkID, newVmConf.KernelImage)
+	if config.VmmType(newVmConf.Type) == config.MockVmm {
+		return vmmMgr.NewMockVmm(op, newVmConf.Name, newVmConf.Cpus, newVmConf.Memory)
+	}
+
+	return vmmMgr.NewVmmFromImage(op, newVmConf.Name, newVmConf.Cpus, newVmConf.Memory, newVmConf.FromImage, uint64(newVmConf.RootDiskSize), newVmConf.StorageName, newVmConf.PrimaryNetworkID, newVmConf.KernelImage)
 
 }
 
 func (vmmMgr *VmmManager) List(showAll bool) []*Vmm {
 	instList := []*Vmm{}
-	for _, vmm := range vmmMgr.instances {
+	for _, vmm := range vmmMgr.instanceList() {
 		if showAll {
 			instList = append(instList, vmm)
 		} else {
@@ -312,17 +507,160 @@ func (vmmMgr *VmmManager) List(showAll bool) []*Vmm {
 			}
 		}
 	}
+	sort.Slice(instList, func(i, j int) bool {
+		return instList[i].Name() < instList[j].Name()
+	})
 	return instList
 }
 
 func (vmmMgr *VmmManager) Get(id string) (*Vmm, error) {
-	if v, ok := vmmMgr.instances[id]; !ok || v == nil {
+	vmmMgr.lock.RLock()
+	v, ok := vmmMgr.instances[id]
+	vmmMgr.lock.RUnlock()
+	if !ok || v == nil {
 		return nil, errors.New("Unable to find instance with that id")
 	} else {
 		return v, nil
 	}
 }
 
+// Delete removes a stopped vmm along with its disks, snapshots and logs
+func (vmmMgr *VmmManager) Delete(id string) error {
+	vmm, err := vmmMgr.Get(id)
+	if err != nil {
+		return err
+	}
+	done, err := vmm.beginOp("delete")
+	if err != nil {
+		return err
+	}
+	defer done()
+	if vmm.instance != nil {
+		if vmm.isRunning() {
+			return errors.New("VM must be stopped before it can be deleted")
+		}
+		//makes sure a restart of the restart policy cant bring it back
+		if err := vmm.instance.Stop(); err != nil {
+			return err
+		}
+	}
+	vmm.health.Stop()
+	vmm.closeVsockProxies()
+	if err := os.Remove(vmm.configPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	vmmMgr.removeInstance(vmm)
+	//the config is gone so anything left behind from here is found by the doctor
+	targets := map[string]common.StorageDriver{}
+	uris := []string{vmm.config.Kernel}
+	for _, dsk := range vmm.config.Disks {
+		uris = append(uris, dsk.StorageURI)
+	}
+	for _, uri := range uris {
+		if uri == "" {
+			continue
+		}
+		if target, err := vmmMgr.Storage().GetStorageForURI(uri); err == nil {
+			targets[target.GetURI()] = target
+		}
+	}
+	for _, target := range targets {
+		if err := target.DeleteDisks(id); err != nil {
+			log.Printf("Unable to delete the disks of %s: %s", id, err.Error())
+		}
+	}
+	if vmm.instance != nil {
+		for _, source := range []string{SerialLogSource, FirecrackerLogSource} {
+			if l := vmm.instance.Log(source); l != nil {
+				l.Close()
+			}
+		}
+	}
+	os.RemoveAll(vmm.snapshotConfigRoot())
+	return os.RemoveAll(filepath.Join(vmmMgr.fcInstanceRootPath, id))
+}
+
+// addInstance registers a vmm with the manager
+func (vmmMgr *VmmManager) addInstance(vmm *Vmm) {
+	vmmMgr.lock.Lock()
+	defer vmmMgr.lock.Unlock()
+	vmmMgr.instances[vmm.id] = vmm
+}
+
+// removeInstance unregisters a vmm from the manager
+func (vmmMgr *VmmManager) removeInstance(vmm *Vmm) {
+	vmmMgr.lock.Lock()
+	defer vmmMgr.lock.Unlock()
+	delete(vmmMgr.instances, vmm.id)
+	for index, ordered := range vmmMgr.startOrder {
+		if ordered == vmm {
+			vmmMgr.startOrder = append(vmmMgr.startOrder[:index], vmmMgr.startOrder[index+1:]...)
+			break
+		}
+	}
+}
+
+// instanceList returns the instances of this node - it is a copy so it can be used without holding the lock
+func (vmmMgr *VmmManager) instanceList() []*Vmm {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	list := make([]*Vmm, 0, len(vmmMgr.instances))
+	for _, vmm := range vmmMgr.instances {
+		list = append(list, vmm)
+	}
+	return list
+}
+
+func (vmmMgr *VmmManager) clusterInstanceList() []*Vmm {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	list := []*Vmm{}
+	for _, clusterInstances := range vmmMgr.clusterInstances {
+		for _, vmm := range clusterInstances {
+			list = append(list, vmm)
+		}
+	}
+	return list
+}
+
+func (vmmMgr *VmmManager) allInstances() []*Vmm {
+	return append(vmmMgr.instanceList(), vmmMgr.clusterInstanceList()...)
+}
+
+func (vmmMgr *VmmManager) startOrderList() []*Vmm {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	return append([]*Vmm{}, vmmMgr.startOrder...)
+}
+
+// beginCreate marks the vmm with id as being created until the func returned is called - its disks are written
+// before it has a config so this stops them being taken as left behind
+func (vmmMgr *VmmManager) beginCreate(id string) func() {
+	vmmMgr.lock.Lock()
+	vmmMgr.creating[id] = true
+	vmmMgr.lock.Unlock()
+	return func() {
+		vmmMgr.lock.Lock()
+		delete(vmmMgr.creating, id)
+		vmmMgr.lock.Unlock()
+	}
+}
+
+// knownInstance is whether there is a config for the vmm with id on this node or it is being created
+func (vmmMgr *VmmManager) knownInstance(id string) bool {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	if _, ok := vmmMgr.instances[id]; ok || vmmMgr.creating[id] {
+		return true
+	}
+	for _, clusterInstances := range vmmMgr.clusterInstances {
+		if _, ok := clusterInstances[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (vmmMgr *VmmManager) Update() error {
 	return nil
 }
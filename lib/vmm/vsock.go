@@ -0,0 +1,144 @@
+package vmm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/768bit/firecracker-go-sdk"
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/vutils"
+)
+
+var ErrVsockNotConfigured = errors.New("The VM doesnt have a vsock device")
+
+// DialHybridVsock connects to a guest vsock port through the unix socket firecracker exposes on the host.
+// Firecracker expects "CONNECT <port>\n" and replies with "OK <host port>\n" once the guest has accepted.
+func DialHybridVsock(udsPath string, port uint32, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", udsPath, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %d\n", port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	//read the ack a byte at a time so we dont swallow any data the guest sends straight after
+	line := []byte{}
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if b[0] == '\n' {
+			break
+		}
+		line = append(line, b[0])
+		if len(line) > 64 {
+			conn.Close()
+			return nil, errors.New("Invalid vsock handshake response")
+		}
+	}
+	if !strings.HasPrefix(string(line), "OK ") {
+		conn.Close()
+		return nil, errors.New("Vsock connect failed: " + string(line))
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// AllocateVsockCID returns the lowest guest CID that isnt in use
+func AllocateVsockCID(used map[uint32]bool) uint32 {
+	cid := config.MinVsockCID
+	for used[cid] {
+		cid++
+	}
+	return cid
+}
+
+// ensureVsockCID gives the vmm a CID that no other vmm on this host has - the config is saved when it changes
+func (vmmMgr *VmmManager) ensureVsockCID(vmm *Vmm) error {
+	if vmm.config.Vsock == nil {
+		return nil
+	}
+	vmmMgr.allocLock.Lock()
+	defer vmmMgr.allocLock.Unlock()
+	used := vmmMgr.usedVsockCIDs(vmm)
+	cid := vmm.config.Vsock.CID
+	if cid >= config.MinVsockCID && !used[cid] {
+		return nil
+	}
+	if cid != 0 {
+		log.Printf("Vsock CID %d of %s is already in use - allocating a new one", cid, vmm.id)
+	}
+	vmm.config.Vsock.CID = AllocateVsockCID(used)
+	err, _ := vutils.Config.SaveConfigToFile("", vmm.configPath, vmm.config)
+	return err
+}
+
+// usedVsockCIDs returns the CIDs of every vmm other than the one given - the configs are read under the manager
+// lock so a vmm being added or removed isnt missed
+func (vmmMgr *VmmManager) usedVsockCIDs(vmm *Vmm) map[uint32]bool {
+	vmmMgr.lock.RLock()
+	defer vmmMgr.lock.RUnlock()
+	used := map[uint32]bool{}
+	for _, other := range vmmMgr.instances {
+		if other == nil || other.id == vmm.id || other.config == nil || other.config.Vsock == nil {
+			continue
+		}
+		used[other.config.Vsock.CID] = true
+	}
+	return used
+}
+
+// vsockPath is where the vsock unix socket of the vmm is on the host
+func (vmm *Vmm) vsockPath() string {
+	udsPath := config.DefaultVsockUDSPath
+	if vmm.config != nil && vmm.config.Vsock != nil {
+		udsPath = vmm.config.Vsock.GetUDSPath()
+	}
+	return filepath.Join(ROOT_PATH, "firecracker", vmm.id, "root", udsPath)
+}
+
+// DialVsock connects to a port the guest is listening on over vsock
+func (vmm *Vmm) DialVsock(port uint32, timeout time.Duration) (net.Conn, error) {
+	if vmm.config == nil || vmm.config.Vsock == nil {
+		return nil, ErrVsockNotConfigured
+	}
+	return DialHybridVsock(vmm.vsockPath(), port, timeout)
+}
+
+// vsockHandler attaches the vsock device - the sdk handler predates hybrid vsock and cant set the unix socket path
+func (fcp *FireCrackerProcess) vsockHandler() firecracker.Handler {
+	return firecracker.Handler{
+		Name: firecracker.AddVsocksHandlerName,
+		Fn: func(ctx context.Context, m *firecracker.Machine) error {
+			if fcp.vsock == nil {
+				return nil
+			}
+			udsPath := fcp.vsock.GetUDSPath()
+			//firecracker wont start listening if the socket is left over from the last run
+			os.Remove(filepath.Join(fcp.chrootPath, udsPath))
+			if err := newFirecrackerAPI(fcp.socketPath).PutVsock(fcp.vsock.CID, "/"+udsPath); err != nil {
+				return err
+			}
+			//firecracker creates the socket as the jail user - the daemon has to own it to connect
+			return fcp.claimJailFiles(filepath.Join(fcp.chrootPath, udsPath))
+		},
+	}
+}
+
+// SetVsock gives the vm a vsock device the next time it starts - nil removes it
+func (fcp *FireCrackerProcess) SetVsock(vsock *config.VmmVsockConfig) {
+	fcp.vsock = vsock
+}
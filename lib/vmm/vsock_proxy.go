@@ -0,0 +1,193 @@
+package vmm
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/768bit/promethium/api/models"
+	"github.com/768bit/vutils"
+	"github.com/go-openapi/strfmt"
+)
+
+// how long to wait for the guest to accept a proxied connection
+const vsockProxyDialTimeout = 5 * time.Second
+
+// VsockProxy forwards connections made to a tcp address on the host to a port the guest listens on over vsock.
+// Proxies only last as long as the daemon - they arent saved with the vm
+type VsockProxy struct {
+	ID        string
+	GuestPort uint32
+
+	vmm      *Vmm
+	listener net.Listener
+	lock     sync.Mutex
+	conns    map[net.Conn]bool
+	closed   bool
+}
+
+// Address is the host address the proxy is listening on
+func (vp *VsockProxy) Address() string {
+	return vp.listener.Addr().String()
+}
+
+// Connections is how many connections are currently being proxied
+func (vp *VsockProxy) Connections() int {
+	vp.lock.Lock()
+	defer vp.lock.Unlock()
+	return len(vp.conns) / 2
+}
+
+// Close stops listening and drops every proxied connection
+func (vp *VsockProxy) Close() error {
+	vp.lock.Lock()
+	vp.closed = true
+	for conn := range vp.conns {
+		conn.Close()
+	}
+	vp.lock.Unlock()
+	return vp.listener.Close()
+}
+
+func (vp *VsockProxy) track(conn net.Conn, add bool) bool {
+	vp.lock.Lock()
+	defer vp.lock.Unlock()
+	if add {
+		if vp.closed {
+			return false
+		}
+		vp.conns[conn] = true
+	} else {
+		delete(vp.conns, conn)
+	}
+	return true
+}
+
+func (vp *VsockProxy) serve() {
+	for {
+		conn, err := vp.listener.Accept()
+		if err != nil {
+			return
+		}
+		go vp.handle(conn)
+	}
+}
+
+func (vp *VsockProxy) handle(hostConn net.Conn) {
+	defer hostConn.Close()
+	guestConn, err := vp.vmm.DialVsock(vp.GuestPort, vsockProxyDialTimeout)
+	if err != nil {
+		log.Printf("Vsock proxy %s unable to reach port %d of %s: %s", vp.ID, vp.GuestPort, vp.vmm.id, err.Error())
+		return
+	}
+	defer guestConn.Close()
+	if !vp.track(hostConn, true) || !vp.track(guestConn, true) {
+		return
+	}
+	defer vp.track(hostConn, false)
+	defer vp.track(guestConn, false)
+	done := make(chan struct{}, 2)
+	pipe := func(dst net.Conn, src net.Conn) {
+		io.Copy(dst, src)
+		//let the other side know nothing more is coming but leave it to finish sending
+		if closer, ok := dst.(interface{ CloseWrite() error }); ok {
+			closer.CloseWrite()
+		}
+		done <- struct{}{}
+	}
+	go pipe(guestConn, hostConn)
+	go pipe(hostConn, guestConn)
+	<-done
+	<-done
+}
+
+// AddVsockProxy starts proxying a host tcp port to a guest vsock port - a host port of 0 picks a free one
+func (vmm *Vmm) AddVsockProxy(bindAddress string, hostPort uint32, guestPort uint32) (*VsockProxy, error) {
+	if vmm.config.Vsock == nil {
+		return nil, ErrVsockNotConfigured
+	}
+	if guestPort == 0 {
+		return nil, errors.New("A guest port is required")
+	}
+	if bindAddress == "" {
+		//proxies give access to the inside of the guest so only the host can use them unless asked otherwise
+		bindAddress = "127.0.0.1"
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.FormatUint(uint64(hostPort), 10)))
+	if err != nil {
+		return nil, err
+	}
+	id, _ := vutils.UUID.MakeUUIDString()
+	proxy := &VsockProxy{
+		ID:        id,
+		GuestPort: guestPort,
+		vmm:       vmm,
+		listener:  listener,
+		conns:     map[net.Conn]bool{},
+	}
+	vmm.proxyLock.Lock()
+	if vmm.proxies == nil {
+		vmm.proxies = map[string]*VsockProxy{}
+	}
+	vmm.proxies[id] = proxy
+	vmm.proxyLock.Unlock()
+	go proxy.serve()
+	log.Printf("Proxying %s to vsock port %d of %s", proxy.Address(), guestPort, vmm.id)
+	return proxy, nil
+}
+
+// VsockProxies lists the proxies to the vm by host address
+func (vmm *Vmm) VsockProxies() []*VsockProxy {
+	vmm.proxyLock.Lock()
+	defer vmm.proxyLock.Unlock()
+	proxies := []*VsockProxy{}
+	for _, proxy := range vmm.proxies {
+		proxies = append(proxies, proxy)
+	}
+	sort.Slice(proxies, func(i, j int) bool {
+		return proxies[i].Address() < proxies[j].Address()
+	})
+	return proxies
+}
+
+// RemoveVsockProxy stops a proxy and drops its connections
+func (vmm *Vmm) RemoveVsockProxy(id string) error {
+	vmm.proxyLock.Lock()
+	proxy, ok := vmm.proxies[id]
+	delete(vmm.proxies, id)
+	vmm.proxyLock.Unlock()
+	if !ok {
+		return errors.New("Unable to find vsock proxy with id " + id)
+	}
+	return proxy.Close()
+}
+
+// closeVsockProxies stops every proxy to the vm
+func (vmm *Vmm) closeVsockProxies() {
+	vmm.proxyLock.Lock()
+	proxies := vmm.proxies
+	vmm.proxies = nil
+	vmm.proxyLock.Unlock()
+	for _, proxy := range proxies {
+		proxy.Close()
+	}
+}
+
+func (vmm *Vmm) GetVsockProxyModel(proxy *VsockProxy) *models.VsockProxy {
+	model := &models.VsockProxy{
+		ID:          strfmt.UUID4(proxy.ID),
+		VMID:        strfmt.UUID4(vmm.id),
+		GuestPort:   int64(proxy.GuestPort),
+		Connections: int64(proxy.Connections()),
+	}
+	if host, port, err := net.SplitHostPort(proxy.Address()); err == nil {
+		model.BindAddress = host
+		model.HostPort, _ = strconv.ParseInt(port, 10, 64)
+	}
+	return model
+}
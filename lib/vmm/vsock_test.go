@@ -0,0 +1,216 @@
+package vmm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/768bit/promethium/lib/config"
+	"github.com/768bit/vutils"
+)
+
+// addVsockVmm adds a vmm with a vsock device with the CID given to the manager
+func addVsockVmm(mgr *VmmManager, id string, cid uint32) (*Vmm, *fakeProcess) {
+	vmm, proc := addFakeVmm(mgr, id)
+	vmm.config.Vsock = &config.VmmVsockConfig{CID: cid}
+	return vmm, proc
+}
+
+func TestVsockCIDsAreUnique(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	first, _ := addVsockVmm(mgr, "first", 5)
+	if err := mgr.ensureVsockCID(first); err != nil {
+		t.Fatal(err)
+	}
+	clash, _ := addVsockVmm(mgr, "clash", 5)
+	reserved, _ := addVsockVmm(mgr, "reserved", 2)
+	for _, vmm := range []*Vmm{clash, reserved} {
+		if err := mgr.ensureVsockCID(vmm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if first.config.Vsock.CID != 5 {
+		t.Errorf("expected a free CID to be kept, it was changed to %d", first.config.Vsock.CID)
+	}
+	if clash.config.Vsock.CID != config.MinVsockCID {
+		t.Errorf("expected the clashing CID to be replaced with the lowest free one, got %d", clash.config.Vsock.CID)
+	}
+	if reserved.config.Vsock.CID != config.MinVsockCID+1 {
+		t.Errorf("expected the reserved CID to be replaced, got %d", reserved.config.Vsock.CID)
+	}
+	//the new CID is saved so it is kept when the daemon restarts
+	saved := &config.VmmConfig{}
+	if err := vutils.Config.LoadConfigFromFile(clash.configPath, saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Vsock == nil || saved.Vsock.CID != config.MinVsockCID {
+		t.Errorf("expected the new CID to be saved, got %+v", saved.Vsock)
+	}
+}
+
+func TestVsockCIDsAreUniqueWhenAllocatedTogether(t *testing.T) {
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	vmms := []*Vmm{}
+	for i := 0; i < 20; i++ {
+		vmm, _ := addVsockVmm(mgr, fmt.Sprintf("vm%d", i), 0)
+		vmms = append(vmms, vmm)
+	}
+	wg := sync.WaitGroup{}
+	errs := make(chan error, len(vmms))
+	for _, vmm := range vmms {
+		wg.Add(1)
+		go func(vmm *Vmm) {
+			defer wg.Done()
+			errs <- mgr.ensureVsockCID(vmm)
+		}(vmm)
+	}
+	//vmms coming and going while the CIDs are handed out
+	for i := 0; i < 20; i++ {
+		other, _ := addFakeVmm(mgr, fmt.Sprintf("other%d", i))
+		mgr.removeInstance(other)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	seen := map[uint32]string{}
+	for _, vmm := range vmms {
+		cid := vmm.config.Vsock.CID
+		if cid < config.MinVsockCID {
+			t.Errorf("%s was given the reserved CID %d", vmm.id, cid)
+		} else if other, ok := seen[cid]; ok {
+			t.Errorf("%s and %s were both given CID %d", vmm.id, other, cid)
+		}
+		seen[cid] = vmm.id
+	}
+}
+
+// serveHybridVsock listens on the vsock socket of the vmm like firecracker does - connections to the guest port
+// given are echoed back and any other port is refused
+func serveHybridVsock(t *testing.T, vmm *Vmm, guestPort uint32) net.Listener {
+	path := vmm.vsockPath()
+	os.MkdirAll(filepath.Dir(path), 0750)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				rdr := bufio.NewReader(conn)
+				line, err := rdr.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line != fmt.Sprintf("CONNECT %d\n", guestPort) {
+					return
+				}
+				fmt.Fprintf(conn, "OK 1073741824\n")
+				io.Copy(conn, rdr)
+			}(conn)
+		}
+	}()
+	return listener
+}
+
+func TestVsockProxy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vsock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	rootPath := ROOT_PATH
+	ROOT_PATH = dir
+	defer func() { ROOT_PATH = rootPath }()
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	vmm, _ := addVsockVmm(mgr, "vsock", config.MinVsockCID)
+	guest := serveHybridVsock(t, vmm, 1024)
+	defer guest.Close()
+
+	if _, err := vmm.AddVsockProxy("", 0, 0); err == nil {
+		t.Error("expected a proxy without a guest port to be refused")
+	}
+	proxy, err := vmm.AddVsockProxy("", 0, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(proxy.Address(), "127.0.0.1:") {
+		t.Errorf("expected the proxy to only listen on the host by default, it is on %s", proxy.Address())
+	}
+	conn, err := net.Dial("tcp", proxy.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Write([]byte("ping\n")); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || reply != "ping\n" {
+		t.Fatalf("expected the guest to echo through the proxy, got %q %v", reply, err)
+	}
+	if proxy.Connections() != 1 {
+		t.Errorf("expected the connection to be tracked, %d are", proxy.Connections())
+	}
+	if proxies := vmm.VsockProxies(); len(proxies) != 1 || proxies[0] != proxy {
+		t.Errorf("expected the proxy to be listed, got %v", proxies)
+	}
+
+	//stopping the vm closes its proxies and the connections through them
+	if err := vmm.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Error("expected the proxied connection to be closed when the vm stopped")
+	}
+	if _, err := net.DialTimeout("tcp", proxy.Address(), time.Second); err == nil {
+		t.Error("expected the proxy to stop listening when the vm stopped")
+	}
+	if proxies := vmm.VsockProxies(); len(proxies) != 0 {
+		t.Errorf("expected the proxies to be removed when the vm stopped, got %v", proxies)
+	}
+}
+
+func TestVsockProxyRemovedWithVm(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vsock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	rootPath := ROOT_PATH
+	ROOT_PATH = dir
+	defer func() { ROOT_PATH = rootPath }()
+	mgr, cleanup := newTestManager(t)
+	defer cleanup()
+	vmm, _ := addVsockVmm(mgr, "vsock", config.MinVsockCID)
+	proxy, err := vmm.AddVsockProxy("", 0, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Delete(vmm.id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := net.DialTimeout("tcp", proxy.Address(), time.Second); err == nil {
+		t.Error("expected the proxy to stop listening when the vm was deleted")
+	}
+}
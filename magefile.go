@@ -104,6 +104,21 @@ func Build() error {
 
 }
 
+// BuildAgent builds the guest agent as a static binary so it runs in any image
+func BuildAgent() error {
+	buildDir := filepath.Join(CWD, "build")
+	vutils.Files.CreateDirIfNotExist(buildDir)
+	agentBuildOut := filepath.Join(buildDir, "promethium-agent")
+	cmd := vutils.Exec.CreateAsyncCommand("go", false, "build", "-v", "-ldflags", "-w -s", "-o", agentBuildOut, "./cmd/agent")
+	err := cmd.BindToStdoutAndStdErr().SetWorkingDir(CWD).CopyEnv().AddEnv("CGO_ENABLED", "0").StartAndWait()
+	if err != nil {
+		fmt.Println(err)
+		return err
+	}
+	images.AgentBinaryPath = agentBuildOut
+	return nil
+}
+
 func packAssets() error {
 	fmt.Println("Packing Assets...")
 
@@ -195,6 +210,7 @@ func cleanPackedAssets() error {
 
 func BuildImages() error {
 	//build the base images based on the configuration map
+	mg.Deps(BuildAgent)
 
 	//using the directory structure - build the images...
 